@@ -26,15 +26,20 @@ import (
 	entv1beta1 "github.com/elastic/cloud-on-k8s/pkg/apis/enterprisesearch/v1beta1"
 	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
 	kbv1beta1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1beta1"
+	logstashv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/logstash/v1alpha1"
 	emsv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/maps/v1alpha1"
+	securityv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/security/v1alpha1"
+	policyv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/stackconfigpolicy/v1alpha1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/agent"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/apmserver"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/association"
 	associationctl "github.com/elastic/cloud-on-k8s/pkg/controller/association/controller"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/autoscaling"
+	esavalidation "github.com/elastic/cloud-on-k8s/pkg/controller/autoscaling/elasticsearch/validation"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/beat"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/certificates"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/container"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/networkpolicy"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/operator"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/reconciler"
 	controllerscheme "github.com/elastic/cloud-on-k8s/pkg/controller/common/scheme"
@@ -48,8 +53,10 @@ import (
 	"github.com/elastic/cloud-on-k8s/pkg/controller/kibana"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/license"
 	licensetrial "github.com/elastic/cloud-on-k8s/pkg/controller/license/trial"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/logstash"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/maps"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/remoteca"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/stackconfigpolicy"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/webhook"
 	"github.com/elastic/cloud-on-k8s/pkg/dev"
 	"github.com/elastic/cloud-on-k8s/pkg/dev/portforward"
@@ -256,6 +263,11 @@ func Command() *cobra.Command {
 		false,
 		"Use only UBI container images to deploy Elastic Stack applications. UBI images are only available from 7.10.0 onward.",
 	)
+	cmd.Flags().Bool(
+		operator.ManageNetworkPoliciesFlag,
+		false,
+		"Manage NetworkPolicies allowing the traffic between the Pods of the Elastic Stack applications and from the operator, unless disabled in the resource specification.",
+	)
 	cmd.Flags().Bool(
 		operator.ValidateStorageClassFlag,
 		true,
@@ -530,6 +542,17 @@ func startOperator(ctx context.Context) error {
 		return err
 	}
 
+	serverVersion, err := clientset.Discovery().ServerVersion()
+	if err != nil {
+		log.Error(err, "Failed to get Kubernetes server version")
+		return err
+	}
+	networkPoliciesSupported := networkpolicy.SupportedBy(*serverVersion)
+	if !networkPoliciesSupported {
+		log.Info("NetworkPolicies are not managed: the namespaces are not labelled with their name before Kubernetes 1.21",
+			"kubernetes_version", serverVersion.GitVersion)
+	}
+
 	log.Info("Setting up controllers")
 	var tracer *apm.Tracer
 	if viper.GetBool(operator.EnableTracingFlag) {
@@ -551,6 +574,8 @@ func startOperator(ctx context.Context) error {
 		MaxConcurrentReconciles:   viper.GetInt(operator.MaxConcurrentReconcilesFlag),
 		SetDefaultSecurityContext: viper.GetBool(operator.SetDefaultSecurityContextFlag),
 		ValidateStorageClass:      viper.GetBool(operator.ValidateStorageClassFlag),
+		ManageNetworkPolicies:     viper.GetBool(operator.ManageNetworkPoliciesFlag),
+		NetworkPoliciesSupported:  networkPoliciesSupported,
 		Tracer:                    tracer,
 	}
 
@@ -655,6 +680,8 @@ func registerControllers(mgr manager.Manager, params operator.Parameters, access
 		{name: "LicenseTrial", registerFunc: licensetrial.Add},
 		{name: "Agent", registerFunc: agent.Add},
 		{name: "Maps", registerFunc: maps.Add},
+		{name: "Logstash", registerFunc: logstash.Add},
+		{name: "StackConfigPolicy", registerFunc: stackconfigpolicy.Add},
 	}
 
 	for _, c := range controllers {
@@ -680,6 +707,7 @@ func registerControllers(mgr manager.Manager, params operator.Parameters, access
 		{name: "AGENT-KB", registerFunc: associationctl.AddAgentKibana},
 		{name: "AGENT-FS", registerFunc: associationctl.AddAgentFleetServer},
 		{name: "EMS-ES", registerFunc: associationctl.AddMapsES},
+		{name: "LOGSTASH-ES", registerFunc: associationctl.AddLogstashES},
 		{name: "ES-MONITORING", registerFunc: associationctl.AddEsMonitoring},
 		{name: "KB-MONITORING", registerFunc: associationctl.AddKbMonitoring},
 	}
@@ -718,6 +746,7 @@ func garbageCollectUsers(cfg *rest.Config, managedNamespaces []string) {
 		For(&beatv1beta1.BeatList{}, associationctl.BeatAssociationLabelNamespace, associationctl.BeatAssociationLabelName).
 		For(&agentv1alpha1.AgentList{}, associationctl.AgentAssociationLabelNamespace, associationctl.AgentAssociationLabelName).
 		For(&emsv1alpha1.ElasticMapsServerList{}, associationctl.MapsESAssociationLabelNamespace, associationctl.MapsESAssociationLabelName).
+		For(&logstashv1alpha1.LogstashList{}, associationctl.LogstashAssociationLabelNamespace, associationctl.LogstashAssociationLabelName).
 		DoGarbageCollection()
 	if err != nil {
 		log.Error(err, "user garbage collector failed")
@@ -727,13 +756,14 @@ func garbageCollectUsers(cfg *rest.Config, managedNamespaces []string) {
 
 func garbageCollectSoftOwnedSecrets(k8sClient k8s.Client) {
 	if err := reconciler.GarbageCollectAllSoftOwnedOrphanSecrets(k8sClient, map[string]client.Object{
-		esv1.Kind:          &esv1.Elasticsearch{},
-		apmv1.Kind:         &apmv1.ApmServer{},
-		kbv1.Kind:          &kbv1.Kibana{},
-		entv1.Kind:         &entv1.EnterpriseSearch{},
-		beatv1beta1.Kind:   &beatv1beta1.Beat{},
-		agentv1alpha1.Kind: &agentv1alpha1.Agent{},
-		emsv1alpha1.Kind:   &emsv1alpha1.ElasticMapsServer{},
+		esv1.Kind:             &esv1.Elasticsearch{},
+		apmv1.Kind:            &apmv1.ApmServer{},
+		kbv1.Kind:             &kbv1.Kibana{},
+		entv1.Kind:            &entv1.EnterpriseSearch{},
+		beatv1beta1.Kind:      &beatv1beta1.Beat{},
+		agentv1alpha1.Kind:    &agentv1alpha1.Agent{},
+		emsv1alpha1.Kind:      &emsv1alpha1.ElasticMapsServer{},
+		logstashv1alpha1.Kind: &logstashv1alpha1.Logstash{},
 	}); err != nil {
 		log.Error(err, "Orphan secrets garbage collection failed, will be attempted again at next operator restart.")
 		return
@@ -787,6 +817,10 @@ func setupWebhook(mgr manager.Manager, certRotation certificates.RotationParams,
 		&kbv1.Kibana{},
 		&kbv1beta1.Kibana{},
 		&emsv1alpha1.ElasticMapsServer{},
+		&logstashv1alpha1.Logstash{},
+		&policyv1alpha1.StackConfigPolicy{},
+		&securityv1alpha1.ElasticsearchUser{},
+		&securityv1alpha1.ElasticsearchRole{},
 	}
 	for _, obj := range webhookObjects {
 		if err := obj.SetupWebhookWithManager(mgr); err != nil {
@@ -795,8 +829,9 @@ func setupWebhook(mgr manager.Manager, certRotation certificates.RotationParams,
 		}
 	}
 
-	// esv1 validating webhook is wired up differently, in order to access the k8s client
+	// esv1 and autoscaling validating webhooks are wired up differently, in order to access the k8s client
 	esvalidation.RegisterWebhook(mgr, validateStorageClass)
+	esavalidation.RegisterWebhook(mgr)
 
 	// wait for the secret to be populated in the local filesystem before returning
 	interval := time.Second * 1
@@ -58,15 +58,21 @@ type AgentSpec struct {
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
 
 	// DaemonSet specifies the Agent should be deployed as a DaemonSet, and allows providing its spec.
-	// Cannot be used along with `deployment`.
+	// Cannot be used along with `deployment` or `statefulSet`.
 	// +kubebuilder:validation:Optional
 	DaemonSet *DaemonSetSpec `json:"daemonSet,omitempty"`
 
 	// Deployment specifies the Agent should be deployed as a Deployment, and allows providing its spec.
-	// Cannot be used along with `daemonSet`.
+	// Cannot be used along with `daemonSet` or `statefulSet`.
 	// +kubebuilder:validation:Optional
 	Deployment *DeploymentSpec `json:"deployment,omitempty"`
 
+	// StatefulSet specifies the Agent should be deployed as a StatefulSet, and allows providing its spec.
+	// The data directory of the Agent is stored in a persistent volume, which keeps the Fleet enrollment and the state
+	// of the integrations when Pods are rescheduled. Cannot be used along with `daemonSet` or `deployment`.
+	// +kubebuilder:validation:Optional
+	StatefulSet *StatefulSetSpec `json:"statefulSet,omitempty"`
+
 	// HTTP holds the HTTP layer configuration for the Agent in Fleet mode with Fleet Server enabled.
 	// +kubebuilder:validation:Optional
 	HTTP commonv1.HTTPConfig `json:"http,omitempty"`
@@ -81,6 +87,11 @@ type AgentSpec struct {
 	// +kubebuilder:validation:Optional
 	FleetServerEnabled bool `json:"fleetServerEnabled,omitempty"`
 
+	// NetworkPolicy controls the NetworkPolicies managed by the operator to allow the traffic to the Fleet Server Pods.
+	// Ignored unless `fleetServerEnabled` is set.
+	// +kubebuilder:validation:Optional
+	NetworkPolicy *commonv1.NetworkPolicySettings `json:"networkPolicy,omitempty"`
+
 	// KibanaRef is a reference to Kibana where Fleet should be set up and this Agent should be enrolled. Don't set
 	// unless `mode` is set to `fleet`.
 	// +kubebuilder:validation:Optional
@@ -90,6 +101,12 @@ type AgentSpec struct {
 	// Don't set unless `mode` is set to `fleet`.
 	// +kubebuilder:validation:Optional
 	FleetServerRef commonv1.ObjectSelector `json:"fleetServerRef,omitempty"`
+
+	// PolicyID is the ID of the Fleet agent policy this Agent should be enrolled with, for example an agent policy
+	// declared in the referenced Kibana. Defaults to the default agent policy, or to the default Fleet Server policy
+	// if `fleetServerEnabled` is set. Don't set unless `mode` is set to `fleet`. Requires `kibanaRef` to be set.
+	// +kubebuilder:validation:Optional
+	PolicyID string `json:"policyID,omitempty"`
 }
 
 type Output struct {
@@ -114,6 +131,23 @@ type DeploymentSpec struct {
 	Strategy appsv1.DeploymentStrategy `json:"strategy,omitempty"`
 }
 
+type StatefulSetSpec struct {
+	// +kubebuilder:pruning:PreserveUnknownFields
+	PodTemplate corev1.PodTemplateSpec `json:"podTemplate,omitempty"`
+	Replicas    *int32                 `json:"replicas,omitempty"`
+
+	// VolumeClaimTemplates is a list of persistent volume claims to be used by each Pod.
+	// A claim named `agent-data` is mounted as the Agent data directory. It defaults to a 1Gi claim using the default
+	// storage class if not specified. Other claims must be mounted through the PodTemplate.
+	// This field cannot be modified once the StatefulSet is created. The claims are deleted along with their Pods on
+	// scale down, when switching to a DaemonSet or a Deployment, and when the Agent is deleted.
+	// +kubebuilder:validation:Optional
+	VolumeClaimTemplates []corev1.PersistentVolumeClaim `json:"volumeClaimTemplates,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	UpdateStrategy appsv1.StatefulSetUpdateStrategy `json:"updateStrategy,omitempty"`
+}
+
 // AgentStatus defines the observed state of the Agent
 type AgentStatus struct {
 	// Version of the stack resource currently running. During version upgrades, multiple versions may run
@@ -232,6 +266,11 @@ func (a *Agent) ServiceAccountName() string {
 	return a.Spec.ServiceAccountName
 }
 
+// GetNetworkPolicySettings returns the NetworkPolicy settings of the Fleet Server.
+func (a *Agent) GetNetworkPolicySettings() *commonv1.NetworkPolicySettings {
+	return a.Spec.NetworkPolicy
+}
+
 // IsMarkedForDeletion returns true if the Agent is going to be deleted
 func (a *Agent) IsMarkedForDeletion() bool {
 	return !a.DeletionTimestamp.IsZero()
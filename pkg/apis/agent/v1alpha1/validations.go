@@ -29,10 +29,12 @@ var (
 		checkFleetServerOrFleetServerRef,
 		checkReferenceSetForMode,
 		checkSingleESRefInFleetMode,
+		checkPolicyID,
 	}
 
 	updateChecks = []func(old, curr *Agent) field.ErrorList{
 		checkNoDowngrade,
+		checkVolumeClaimTemplatesUnchanged,
 	}
 )
 
@@ -53,17 +55,34 @@ func checkSupportedVersion(a *Agent) field.ErrorList {
 }
 
 func checkAtMostOneDeploymentOption(a *Agent) field.ErrorList {
-	if a.Spec.DaemonSet != nil && a.Spec.Deployment != nil {
-		msg := "Specify either daemonSet or deployment, not both"
-		return field.ErrorList{
-			field.Forbidden(field.NewPath("spec").Child("daemonSet"), msg),
-			field.Forbidden(field.NewPath("spec").Child("deployment"), msg),
+	if deploymentOptionsCount(a) > 1 {
+		msg := "Specify only one of daemonSet, deployment or statefulSet"
+		var errs field.ErrorList
+		if a.Spec.DaemonSet != nil {
+			errs = append(errs, field.Forbidden(field.NewPath("spec").Child("daemonSet"), msg))
+		}
+		if a.Spec.Deployment != nil {
+			errs = append(errs, field.Forbidden(field.NewPath("spec").Child("deployment"), msg))
 		}
+		if a.Spec.StatefulSet != nil {
+			errs = append(errs, field.Forbidden(field.NewPath("spec").Child("statefulSet"), msg))
+		}
+		return errs
 	}
 
 	return nil
 }
 
+func deploymentOptionsCount(a *Agent) int {
+	count := 0
+	for _, specified := range []bool{a.Spec.DaemonSet != nil, a.Spec.Deployment != nil, a.Spec.StatefulSet != nil} {
+		if specified {
+			count++
+		}
+	}
+	return count
+}
+
 func checkAtMostOneDefaultESRef(a *Agent) field.ErrorList {
 	var found int
 	for _, o := range a.Spec.ElasticsearchRefs {
@@ -103,6 +122,22 @@ func checkNoDowngrade(prev, curr *Agent) field.ErrorList {
 	return commonv1.CheckNoDowngrade(prev.Spec.Version, curr.Spec.Version)
 }
 
+func checkVolumeClaimTemplatesUnchanged(prev, curr *Agent) field.ErrorList {
+	if prev.Spec.StatefulSet == nil || curr.Spec.StatefulSet == nil {
+		// the StatefulSet is created or replaced along with its volume claims
+		return nil
+	}
+	if !reflect.DeepEqual(prev.Spec.StatefulSet.VolumeClaimTemplates, curr.Spec.StatefulSet.VolumeClaimTemplates) {
+		return field.ErrorList{
+			field.Forbidden(
+				field.NewPath("spec").Child("statefulSet", "volumeClaimTemplates"),
+				fmt.Sprintf("volumeClaimTemplates cannot be modified, consider creating a new %s resource instead", Kind),
+			),
+		}
+	}
+	return nil
+}
+
 func checkSingleConfigSource(a *Agent) field.ErrorList {
 	if a.Spec.Config != nil && a.Spec.ConfigRef != nil {
 		msg := "Specify at most one of [`config`, `configRef`], not both"
@@ -116,9 +151,9 @@ func checkSingleConfigSource(a *Agent) field.ErrorList {
 }
 
 func checkSpec(a *Agent) field.ErrorList {
-	if (a.Spec.DaemonSet == nil && a.Spec.Deployment == nil) || (a.Spec.DaemonSet != nil && a.Spec.Deployment != nil) {
+	if deploymentOptionsCount(a) != 1 {
 		return field.ErrorList{
-			field.Invalid(field.NewPath("spec"), a.Spec, "either daemonset or deployment must be specified"),
+			field.Invalid(field.NewPath("spec"), a.Spec, "either daemonset, deployment or statefulset must be specified"),
 		}
 	}
 	return nil
@@ -227,3 +262,23 @@ func checkSingleESRefInFleetMode(a *Agent) field.ErrorList {
 	}
 	return nil
 }
+
+func checkPolicyID(a *Agent) field.ErrorList {
+	if a.Spec.PolicyID == "" {
+		return nil
+	}
+	if a.Spec.StandaloneModeEnabled() {
+		return field.ErrorList{field.Invalid(
+			field.NewPath("spec").Child("policyID"),
+			a.Spec.PolicyID,
+			"don't specify a policy ID, it can't be set in standalone mode",
+		)}
+	}
+	if !a.Spec.KibanaRef.IsDefined() {
+		return field.ErrorList{field.Required(
+			field.NewPath("spec").Child("kibanaRef"),
+			"specify a Kibana reference to enroll the Agent with the given policy ID",
+		)}
+	}
+	return nil
+}
@@ -9,6 +9,8 @@ import (
 
 	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func Test_checkSupportedVersion(t *testing.T) {
@@ -86,6 +88,15 @@ func Test_checkSpec(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "statefulset present only",
+			beat: Agent{
+				Spec: AgentSpec{
+					StatefulSet: &StatefulSetSpec{},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "neither present",
 			beat: Agent{
@@ -103,6 +114,16 @@ func Test_checkSpec(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "statefulset and deployment present",
+			beat: Agent{
+				Spec: AgentSpec{
+					Deployment:  &DeploymentSpec{},
+					StatefulSet: &StatefulSetSpec{},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -113,6 +134,56 @@ func Test_checkSpec(t *testing.T) {
 	}
 }
 
+func Test_checkAtMostOneDeploymentOption(t *testing.T) {
+	agent := Agent{Spec: AgentSpec{DaemonSet: &DaemonSetSpec{}, StatefulSet: &StatefulSetSpec{}}}
+	errs := checkAtMostOneDeploymentOption(&agent)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "spec.daemonSet", errs[0].Field)
+	assert.Equal(t, "spec.statefulSet", errs[1].Field)
+
+	agent.Spec.DaemonSet = nil
+	assert.Empty(t, checkAtMostOneDeploymentOption(&agent))
+}
+
+func Test_checkVolumeClaimTemplatesUnchanged(t *testing.T) {
+	claim := func(name string) []corev1.PersistentVolumeClaim {
+		return []corev1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: name}}}
+	}
+	tests := []struct {
+		name    string
+		prev    *StatefulSetSpec
+		curr    *StatefulSetSpec
+		wantErr bool
+	}{
+		{
+			name:    "unchanged",
+			prev:    &StatefulSetSpec{VolumeClaimTemplates: claim("agent-data")},
+			curr:    &StatefulSetSpec{VolumeClaimTemplates: claim("agent-data")},
+			wantErr: false,
+		},
+		{
+			name:    "changed",
+			prev:    &StatefulSetSpec{VolumeClaimTemplates: claim("agent-data")},
+			curr:    &StatefulSetSpec{VolumeClaimTemplates: claim("other")},
+			wantErr: true,
+		},
+		{
+			name:    "switching to a StatefulSet",
+			prev:    nil,
+			curr:    &StatefulSetSpec{VolumeClaimTemplates: claim("agent-data")},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev := Agent{Spec: AgentSpec{StatefulSet: tt.prev}}
+			curr := Agent{Spec: AgentSpec{StatefulSet: tt.curr}}
+			got := checkVolumeClaimTemplatesUnchanged(&prev, &curr)
+			assert.Equal(t, tt.wantErr, len(got) > 0)
+		})
+	}
+}
+
 func Test_checkAtMostOneDefaultESRef(t *testing.T) {
 	type args struct {
 		b *Agent
@@ -503,3 +574,53 @@ func Test_checkReferenceSetForMode(t *testing.T) {
 		})
 	}
 }
+
+func Test_checkPolicyID(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		a       *Agent
+		wantErr bool
+	}{
+		{
+			name:    "no policy ID: OK",
+			a:       &Agent{Spec: AgentSpec{Mode: AgentFleetMode}},
+			wantErr: false,
+		},
+		{
+			name: "fleet mode - policy ID with kibana ref: OK",
+			a: &Agent{
+				Spec: AgentSpec{
+					Mode:      AgentFleetMode,
+					PolicyID:  "eck-agent",
+					KibanaRef: commonv1.ObjectSelector{Name: "kibana"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "fleet mode - policy ID without kibana ref: NOK",
+			a: &Agent{
+				Spec: AgentSpec{
+					Mode:     AgentFleetMode,
+					PolicyID: "eck-agent",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "standalone mode - policy ID: NOK",
+			a: &Agent{
+				Spec: AgentSpec{
+					Mode:     AgentStandaloneMode,
+					PolicyID: "eck-agent",
+				},
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkPolicyID(tt.a)
+			assert.Equal(t, tt.wantErr, len(got) > 0)
+		})
+	}
+}
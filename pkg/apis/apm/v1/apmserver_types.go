@@ -32,6 +32,11 @@ type ApmServerSpec struct {
 	// Count of APM Server instances to deploy.
 	Count int32 `json:"count,omitempty"`
 
+	// Autoscaling configures a HorizontalPodAutoscaler managing the number of APM Server instances,
+	// in which case Count is ignored.
+	// +kubebuilder:validation:Optional
+	Autoscaling *commonv1.AutoscalingSpec `json:"autoscaling,omitempty"`
+
 	// Config holds the APM Server configuration. See: https://www.elastic.co/guide/en/apm/server/current/configuring-howto-apm-server.html
 	// +kubebuilder:pruning:PreserveUnknownFields
 	Config *commonv1.Config `json:"config,omitempty"`
@@ -51,6 +56,16 @@ type ApmServerSpec struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	PodTemplate corev1.PodTemplateSpec `json:"podTemplate,omitempty"`
 
+	// PodDisruptionBudget provides access to the default Pod disruption budget for the APM Server Pods.
+	// The default budget selects all the APM Server Pods and sets `maxUnavailable` to 1 when there is more than one Pod.
+	// To disable, set `PodDisruptionBudget` to the empty value (`{}` in YAML).
+	// +kubebuilder:validation:Optional
+	PodDisruptionBudget *commonv1.PodDisruptionBudgetTemplate `json:"podDisruptionBudget,omitempty"`
+
+	// NetworkPolicy controls the NetworkPolicies managed by the operator to allow the traffic to the APM Server Pods.
+	// +kubebuilder:validation:Optional
+	NetworkPolicy *commonv1.NetworkPolicySettings `json:"networkPolicy,omitempty"`
+
 	// SecureSettings is a list of references to Kubernetes secrets containing sensitive configuration options for APM Server.
 	SecureSettings []commonv1.SecretSource `json:"secureSettings,omitempty"`
 
@@ -120,6 +135,11 @@ func (as *ApmServer) ServiceAccountName() string {
 	return as.Spec.ServiceAccountName
 }
 
+// GetNetworkPolicySettings returns the NetworkPolicy settings of the APM Server.
+func (as *ApmServer) GetNetworkPolicySettings() *commonv1.NetworkPolicySettings {
+	return as.Spec.NetworkPolicy
+}
+
 // EffectiveVersion returns the version reported by APM server. For development builds APM server does not use the SNAPSHOT suffix.
 func (as *ApmServer) EffectiveVersion() string {
 	return strings.TrimSuffix(as.Spec.Version, "-SNAPSHOT")
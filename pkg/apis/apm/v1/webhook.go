@@ -31,6 +31,7 @@ var (
 		checkNameLength,
 		checkSupportedVersion,
 		checkAgentConfigurationMinVersion,
+		checkAutoscaling,
 	}
 
 	updateChecks = []func(old, curr *ApmServer) field.ErrorList{
@@ -131,3 +132,7 @@ func checkAgentConfigurationMinVersion(as *ApmServer) field.ErrorList {
 	}
 	return nil
 }
+
+func checkAutoscaling(as *ApmServer) field.ErrorList {
+	return commonv1.CheckAutoscaling(as.Spec.Autoscaling)
+}
@@ -60,7 +60,7 @@ func (in *ApmServer) DeepCopyInto(out *ApmServer) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	if in.esAssocConf != nil {
 		in, out := &in.esAssocConf, &out.esAssocConf
 		*out = new(commonv1.AssociationConf)
@@ -126,6 +126,11 @@ func (in *ApmServerList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApmServerSpec) DeepCopyInto(out *ApmServerSpec) {
 	*out = *in
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(commonv1.AutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Config != nil {
 		in, out := &in.Config, &out.Config
 		*out = (*in).DeepCopy()
@@ -134,6 +139,16 @@ func (in *ApmServerSpec) DeepCopyInto(out *ApmServerSpec) {
 	out.ElasticsearchRef = in.ElasticsearchRef
 	out.KibanaRef = in.KibanaRef
 	in.PodTemplate.DeepCopyInto(&out.PodTemplate)
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(commonv1.PodDisruptionBudgetTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(commonv1.NetworkPolicySettings)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SecureSettings != nil {
 		in, out := &in.SecureSettings, &out.SecureSettings
 		*out = make([]commonv1.SecretSource, len(*in))
@@ -156,7 +171,7 @@ func (in *ApmServerSpec) DeepCopy() *ApmServerSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApmServerStatus) DeepCopyInto(out *ApmServerStatus) {
 	*out = *in
-	out.DeploymentStatus = in.DeploymentStatus
+	in.DeploymentStatus.DeepCopyInto(&out.DeploymentStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApmServerStatus.
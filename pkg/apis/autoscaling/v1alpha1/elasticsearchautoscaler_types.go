@@ -0,0 +1,183 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package v1alpha1
+
+import (
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// Kind is inferred from the struct name using reflection in SchemeBuilder.Register()
+	// we duplicate it as a constant here for practical purposes.
+	Kind = "ElasticsearchAutoscaler"
+)
+
+// ElasticsearchAutoscalerSpec holds the specification of an Elasticsearch autoscaler resource.
+type ElasticsearchAutoscalerSpec struct {
+	// ElasticsearchRef is a reference to the Elasticsearch cluster, in the same namespace, which is managed by the autoscaler.
+	ElasticsearchRef ElasticsearchRef `json:"elasticsearchRef"`
+
+	// AutoscalingPolicySpecs holds the autoscaling policies and the resources limits of the NodeSets they apply to.
+	// +kubebuilder:validation:Optional
+	AutoscalingPolicySpecs esv1.AutoscalingPolicySpecs `json:"policies"`
+
+	// PollingPeriod is the period at which to synchronize and poll the Elasticsearch autoscaling API.
+	// Defaults to 60 seconds.
+	// +kubebuilder:validation:Optional
+	PollingPeriod *metav1.Duration `json:"pollingPeriod,omitempty"`
+}
+
+// ElasticsearchRef is a reference to an Elasticsearch cluster that exists in the same namespace.
+type ElasticsearchRef struct {
+	// Name is the name of the Elasticsearch resource to scale automatically.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name,omitempty"`
+}
+
+// ElasticsearchAutoscalerStatus defines the observed state of an Elasticsearch autoscaler.
+type ElasticsearchAutoscalerStatus struct {
+	// ObservedGeneration is the last observed generation by the controller.
+	// +kubebuilder:validation:Optional
+	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions holds the current service state of the autoscaling controller.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// AutoscalingPolicyStatuses is used to expose state messages to user or external system.
+	// +kubebuilder:validation:Optional
+	AutoscalingPolicyStatuses []AutoscalingPolicyStatus `json:"policies,omitempty"`
+}
+
+const (
+	// ActiveCondition is true if the autoscaler is managing the referenced Elasticsearch cluster.
+	ActiveCondition = "Active"
+	// HealthyCondition is true if the last reconciliation of the autoscaler completed without any error.
+	HealthyCondition = "Healthy"
+	// LimitedCondition is true if the resources computed by the autoscaler are limited by the ranges of at least one
+	// of the autoscaling policies.
+	LimitedCondition = "Limited"
+	// OnlineCondition is true if the Elasticsearch autoscaling API could be used during the last reconciliation.
+	// Otherwise the autoscaler only ensures that the resources are within the ranges of the autoscaling policies.
+	OnlineCondition = "Online"
+)
+
+// AutoscalingPolicyStatus holds the state of the resources managed by an autoscaling policy.
+type AutoscalingPolicyStatus struct {
+	// Name is the name of the autoscaling policy
+	Name string `json:"name"`
+	// NodeSetNodeCount holds the number of nodes for each nodeSet.
+	// +kubebuilder:validation:Optional
+	NodeSetNodeCount []NodeSetNodeCount `json:"nodeSets,omitempty"`
+	// ResourcesSpecification holds the resource values common to all the nodeSets managed by a same autoscaling policy.
+	// Only the resources managed by the autoscaling controller are saved in the Status.
+	// +kubebuilder:validation:Optional
+	ResourcesSpecification NodeResources `json:"resources,omitempty"`
+	// PolicyStates may contain various messages regarding the current state of this autoscaling policy.
+	// +kubebuilder:validation:Optional
+	PolicyStates []PolicyState `json:"state,omitempty"`
+	// LastModificationTime is the last time the resources have been updated, used by the cooldown algorithm.
+	// +kubebuilder:validation:Optional
+	LastModificationTime metav1.Time `json:"lastModificationTime,omitempty"`
+}
+
+// NodeSetNodeCount models the number of nodes expected in a given NodeSet.
+type NodeSetNodeCount struct {
+	// Name of the NodeSet.
+	Name string `json:"name"`
+	// NodeCount is the number of nodes, as computed by the autoscaler, expected in this NodeSet.
+	NodeCount int32 `json:"nodeCount"`
+}
+
+// NodeResources holds the resources to be applied to all the nodes of the NodeSets managed by an autoscaling policy.
+type NodeResources struct {
+	// +kubebuilder:validation:Optional
+	Limits corev1.ResourceList `json:"limits,omitempty"`
+	// +kubebuilder:validation:Optional
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+}
+
+// PolicyState holds the messages related to a given state of an autoscaling policy.
+type PolicyState struct {
+	// Type of the state, for example HorizontalScalingLimitReached.
+	Type string `json:"type"`
+	// Messages describing the state.
+	Messages []string `json:"messages"`
+}
+
+// +kubebuilder:object:root=true
+
+// ElasticsearchAutoscaler represents an ElasticsearchAutoscaler resource in a Kubernetes cluster.
+// +kubebuilder:resource:categories=elastic,shortName=esa
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="target",type="string",JSONPath=".spec.elasticsearchRef.name"
+// +kubebuilder:printcolumn:name="active",type="string",JSONPath=".status.conditions[?(@.type=='Active')].status"
+// +kubebuilder:printcolumn:name="healthy",type="string",JSONPath=".status.conditions[?(@.type=='Healthy')].status"
+// +kubebuilder:printcolumn:name="limited",type="string",JSONPath=".status.conditions[?(@.type=='Limited')].status"
+// +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:storageversion
+type ElasticsearchAutoscaler struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticsearchAutoscalerSpec   `json:"spec,omitempty"`
+	Status ElasticsearchAutoscalerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ElasticsearchAutoscalerList contains a list of Elasticsearch autoscalers.
+type ElasticsearchAutoscalerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticsearchAutoscaler `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticsearchAutoscaler{}, &ElasticsearchAutoscalerList{})
+}
+
+// ElasticsearchKey returns the namespaced name of the Elasticsearch cluster managed by the autoscaler.
+func (esa ElasticsearchAutoscaler) ElasticsearchKey() types.NamespacedName {
+	return types.NamespacedName{Namespace: esa.Namespace, Name: esa.Spec.ElasticsearchRef.Name}
+}
+
+// GetAutoscalingSpecification returns the autoscaling specification to be applied to the given Elasticsearch cluster.
+func (esa ElasticsearchAutoscaler) GetAutoscalingSpecification(es esv1.Elasticsearch) esv1.AutoscalingSpec {
+	return esv1.AutoscalingSpec{
+		AutoscalingPolicySpecs: esa.Spec.AutoscalingPolicySpecs,
+		PollingPeriod:          esa.Spec.PollingPeriod,
+		Elasticsearch:          es,
+	}
+}
+
+// SetCondition adds or updates a condition in the status of the autoscaler.
+// The last transition time is only updated if the status of the condition has changed.
+func (esa *ElasticsearchAutoscaler) SetCondition(conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&esa.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: esa.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// Managing returns the autoscalers from the list which manage the Elasticsearch cluster with the given name.
+func (l ElasticsearchAutoscalerList) Managing(esName string) []ElasticsearchAutoscaler {
+	var autoscalers []ElasticsearchAutoscaler
+	for _, esa := range l.Items {
+		if esa.Spec.ElasticsearchRef.Name == esName {
+			autoscalers = append(autoscalers, esa)
+		}
+	}
+	return autoscalers
+}
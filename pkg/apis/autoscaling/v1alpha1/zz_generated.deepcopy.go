@@ -0,0 +1,246 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingPolicyStatus) DeepCopyInto(out *AutoscalingPolicyStatus) {
+	*out = *in
+	if in.NodeSetNodeCount != nil {
+		in, out := &in.NodeSetNodeCount, &out.NodeSetNodeCount
+		*out = make([]NodeSetNodeCount, len(*in))
+		copy(*out, *in)
+	}
+	in.ResourcesSpecification.DeepCopyInto(&out.ResourcesSpecification)
+	if in.PolicyStates != nil {
+		in, out := &in.PolicyStates, &out.PolicyStates
+		*out = make([]PolicyState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.LastModificationTime.DeepCopyInto(&out.LastModificationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingPolicyStatus.
+func (in *AutoscalingPolicyStatus) DeepCopy() *AutoscalingPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchAutoscaler) DeepCopyInto(out *ElasticsearchAutoscaler) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchAutoscaler.
+func (in *ElasticsearchAutoscaler) DeepCopy() *ElasticsearchAutoscaler {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchAutoscaler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchAutoscaler) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchAutoscalerList) DeepCopyInto(out *ElasticsearchAutoscalerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticsearchAutoscaler, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchAutoscalerList.
+func (in *ElasticsearchAutoscalerList) DeepCopy() *ElasticsearchAutoscalerList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchAutoscalerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchAutoscalerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchAutoscalerSpec) DeepCopyInto(out *ElasticsearchAutoscalerSpec) {
+	*out = *in
+	out.ElasticsearchRef = in.ElasticsearchRef
+	if in.AutoscalingPolicySpecs != nil {
+		in, out := &in.AutoscalingPolicySpecs, &out.AutoscalingPolicySpecs
+		*out = make(v1.AutoscalingPolicySpecs, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PollingPeriod != nil {
+		in, out := &in.PollingPeriod, &out.PollingPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchAutoscalerSpec.
+func (in *ElasticsearchAutoscalerSpec) DeepCopy() *ElasticsearchAutoscalerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchAutoscalerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchAutoscalerStatus) DeepCopyInto(out *ElasticsearchAutoscalerStatus) {
+	*out = *in
+	if in.ObservedGeneration != nil {
+		in, out := &in.ObservedGeneration, &out.ObservedGeneration
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AutoscalingPolicyStatuses != nil {
+		in, out := &in.AutoscalingPolicyStatuses, &out.AutoscalingPolicyStatuses
+		*out = make([]AutoscalingPolicyStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchAutoscalerStatus.
+func (in *ElasticsearchAutoscalerStatus) DeepCopy() *ElasticsearchAutoscalerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchAutoscalerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchRef) DeepCopyInto(out *ElasticsearchRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRef.
+func (in *ElasticsearchRef) DeepCopy() *ElasticsearchRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeResources) DeepCopyInto(out *NodeResources) {
+	*out = *in
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeResources.
+func (in *NodeResources) DeepCopy() *NodeResources {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetNodeCount) DeepCopyInto(out *NodeSetNodeCount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetNodeCount.
+func (in *NodeSetNodeCount) DeepCopy() *NodeSetNodeCount {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetNodeCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyState) DeepCopyInto(out *PolicyState) {
+	*out = *in
+	if in.Messages != nil {
+		in, out := &in.Messages, &out.Messages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyState.
+func (in *PolicyState) DeepCopy() *PolicyState {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyState)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -7,6 +7,7 @@ package v1
 import (
 	"reflect"
 
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/api/policy/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -17,8 +18,9 @@ import (
 type DeploymentHealth string
 
 const (
-	GreenHealth DeploymentHealth = "green"
-	RedHealth   DeploymentHealth = "red"
+	GreenHealth  DeploymentHealth = "green"
+	YellowHealth DeploymentHealth = "yellow"
+	RedHealth    DeploymentHealth = "red"
 )
 
 // DeploymentStatus represents status information about a deployment.
@@ -35,6 +37,9 @@ type DeploymentStatus struct {
 	Version string `json:"version,omitempty"`
 	// Health of the deployment.
 	Health DeploymentHealth `json:"health,omitempty"`
+
+	// Autoscaling is the status of the HorizontalPodAutoscaler managing the number of replicas, if any.
+	Autoscaling *AutoscalingStatus `json:"autoscaling,omitempty"`
 }
 
 // IsDegraded returns true if the current status is worse than the previous.
@@ -114,12 +119,36 @@ type TLSOptions struct {
 	// - `tls.crt`: The certificate (or a chain).
 	// - `tls.key`: The private key to the first certificate in the certificate chain.
 	Certificate SecretRef `json:"certificate,omitempty"`
+
+	// Issuer is a reference to an external signer issuing the certificate instead of the operator.
+	// It is ignored if Certificate is specified.
+	Issuer *CertificateIssuer `json:"issuer,omitempty"`
 }
 
 // Enabled returns true when TLS is enabled based on this option struct.
 func (tls TLSOptions) Enabled() bool {
 	selfSigned := tls.SelfSignedCertificate
-	return selfSigned == nil || !selfSigned.Disabled || tls.Certificate.SecretName != ""
+	return selfSigned == nil || !selfSigned.Disabled || tls.Certificate.SecretName != "" || tls.Issuer != nil
+}
+
+// ExternalIssuer returns the external signer issuing the certificate, if any.
+func (tls TLSOptions) ExternalIssuer() *CertificateIssuer {
+	if tls.Certificate.SecretName != "" {
+		return nil
+	}
+	return tls.Issuer
+}
+
+// CertificateIssuer references an external signer issuing certificates through Kubernetes CertificateSigningRequests.
+type CertificateIssuer struct {
+	// SignerName is the name of the signer the CertificateSigningRequests are addressed to,
+	// for example `clusterissuers.cert-manager.io/corporate-pki`.
+	// +kubebuilder:validation:MinLength=1
+	SignerName string `json:"signerName"`
+	// CASecretName is the name of a Secret in the same namespace holding the PEM encoded certificate of the CA of the
+	// signer in its `ca.crt` entry. It is used to verify the issued certificates and distributed as the trusted CA.
+	// +kubebuilder:validation:MinLength=1
+	CASecretName string `json:"caSecretName"`
 }
 
 // SelfSignedCertificate holds configuration for the self-signed certificate generated by the operator.
@@ -170,6 +199,84 @@ func (p *PodDisruptionBudgetTemplate) IsDisabled() bool {
 	return reflect.DeepEqual(p, &PodDisruptionBudgetTemplate{})
 }
 
+// NetworkPolicySettings controls the NetworkPolicies managed by the operator for the Pods of a resource.
+type NetworkPolicySettings struct {
+	// Enabled makes the operator manage NetworkPolicies allowing the traffic to the Pods of the resource from the
+	// operator, from the other Pods of the resource and from the Pods of the associated resources.
+	// Defaults to the operator-wide manage-network-policies setting.
+	// +kubebuilder:validation:Optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// IsEnabled returns true if NetworkPolicies should be managed for the resource, falling back to the given
+// operator-wide default if not specified.
+func (n *NetworkPolicySettings) IsEnabled(operatorDefault bool) bool {
+	if n == nil || n.Enabled == nil {
+		return operatorDefault
+	}
+	return *n.Enabled
+}
+
+// NetworkPolicyHolder is implemented by resources whose Pods can be protected by NetworkPolicies managed by the operator.
+// +kubebuilder:object:generate=false
+type NetworkPolicyHolder interface {
+	GetNetworkPolicySettings() *NetworkPolicySettings
+}
+
+// AutoscalingSpec holds the specification of a HorizontalPodAutoscaler managing the number of replicas of a Deployment.
+// When set, the number of replicas is not reconciled from the count of the resource anymore.
+type AutoscalingSpec struct {
+	// MinReplicas is the lower limit for the number of replicas. Defaults to 1.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper limit for the number of replicas. It cannot be lower than MinReplicas.
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// TargetCPUUtilizationPercentage is the target average CPU utilization of the Pods, represented as a percentage of
+	// the requested CPU.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// TargetMemoryUtilizationPercentage is the target average memory utilization of the Pods, represented as a percentage
+	// of the requested memory.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+
+	// Metrics are additional metrics, such as custom or external metrics, used to compute the desired number of replicas.
+	// If no metric is specified at all, the HorizontalPodAutoscaler targets an average CPU utilization of 80%.
+	// +kubebuilder:validation:Optional
+	Metrics []autoscalingv2beta2.MetricSpec `json:"metrics,omitempty"`
+
+	// Behavior configures the scaling behavior of the HorizontalPodAutoscaler in both the up and down directions.
+	// +kubebuilder:validation:Optional
+	Behavior *autoscalingv2beta2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+}
+
+// GetMinReplicas returns the lower limit for the number of replicas, defaulting to 1.
+func (a AutoscalingSpec) GetMinReplicas() int32 {
+	if a.MinReplicas == nil {
+		return 1
+	}
+	return *a.MinReplicas
+}
+
+// AutoscalingStatus is the status of a HorizontalPodAutoscaler managing the number of replicas of a Deployment.
+type AutoscalingStatus struct {
+	// MinReplicas is the lower limit for the number of replicas.
+	MinReplicas int32 `json:"minReplicas"`
+	// MaxReplicas is the upper limit for the number of replicas.
+	MaxReplicas int32 `json:"maxReplicas"`
+	// CurrentReplicas is the current number of replicas as last seen by the HorizontalPodAutoscaler.
+	CurrentReplicas int32 `json:"currentReplicas"`
+	// DesiredReplicas is the desired number of replicas as last computed by the HorizontalPodAutoscaler.
+	DesiredReplicas int32 `json:"desiredReplicas"`
+}
+
 // SecretSource defines a data source based on a Kubernetes Secret.
 type SecretSource struct {
 	// SecretName is the name of the secret.
@@ -13,6 +13,7 @@ func TestTLSOptions_Enabled(t *testing.T) {
 	type fields struct {
 		SelfSignedCertificate *SelfSignedCertificate
 		Certificate           SecretRef
+		Issuer                *CertificateIssuer
 	}
 	tests := []struct {
 		name   string
@@ -41,6 +42,19 @@ func TestTLSOptions_Enabled(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "enabled: external issuer and self-signed disabled",
+			fields: fields{
+				SelfSignedCertificate: &SelfSignedCertificate{
+					Disabled: true,
+				},
+				Issuer: &CertificateIssuer{
+					SignerName:   "example.com/signer",
+					CASecretName: "signer-ca",
+				},
+			},
+			want: true,
+		},
 		{
 			name:   "enabled: by default",
 			fields: fields{},
@@ -63,6 +77,7 @@ func TestTLSOptions_Enabled(t *testing.T) {
 			tls := TLSOptions{
 				SelfSignedCertificate: tt.fields.SelfSignedCertificate,
 				Certificate:           tt.fields.Certificate,
+				Issuer:                tt.fields.Issuer,
 			}
 			if got := tls.Enabled(); got != tt.want {
 				t.Errorf("TLSOptions.Enabled() = %v, want %v", got, tt.want)
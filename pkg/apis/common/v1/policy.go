@@ -0,0 +1,28 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package v1
+
+// PoliciesStatus describes the StackConfigPolicies applied to a resource.
+type PoliciesStatus struct {
+	// Applied lists the names of the StackConfigPolicies applied to the resource, in order of precedence.
+	Applied []string `json:"applied,omitempty"`
+
+	// Conflicts lists the settings of the applied policies which conflict with the resource specification or with
+	// another policy.
+	Conflicts []PolicyConflict `json:"conflicts,omitempty"`
+}
+
+// PolicyConflict describes a setting of a StackConfigPolicy which conflicts with the resource specification or with
+// another policy.
+type PolicyConflict struct {
+	// Policy is the name of the StackConfigPolicy which declares the setting.
+	Policy string `json:"policy"`
+
+	// Setting is the name of the conflicting setting, or of the conflicting role mapping.
+	Setting string `json:"setting"`
+
+	// Message describes the conflict and how it has been resolved.
+	Message string `json:"message"`
+}
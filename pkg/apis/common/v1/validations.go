@@ -94,6 +94,22 @@ func CheckNoDowngrade(prev, curr string) field.ErrorList {
 	return nil
 }
 
+// CheckAutoscaling checks that the lower limit for the number of replicas of the given autoscaling specification does
+// not exceed the upper limit.
+func CheckAutoscaling(autoscaling *AutoscalingSpec) field.ErrorList {
+	if autoscaling == nil {
+		return nil
+	}
+	if autoscaling.GetMinReplicas() > autoscaling.MaxReplicas {
+		return field.ErrorList{field.Invalid(
+			field.NewPath("spec").Child("autoscaling", "maxReplicas"),
+			autoscaling.MaxReplicas,
+			"maxReplicas must be greater than or equal to minReplicas",
+		)}
+	}
+	return nil
+}
+
 func ParseVersion(ver string) (*version.Version, field.ErrorList) {
 	v, err := version.Parse(ver)
 	if err != nil {
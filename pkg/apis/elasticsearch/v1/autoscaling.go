@@ -17,6 +17,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// ElasticsearchAutoscalingSpecAnnotationName is the name of the annotation which was used to hold the autoscaling
+// specification before the introduction of the ElasticsearchAutoscaler resource.
+// Deprecated: the annotation is only read to convert it into an ElasticsearchAutoscaler resource.
 const ElasticsearchAutoscalingSpecAnnotationName = "elasticsearch.alpha.elastic.co/autoscaling-spec"
 
 var (
@@ -39,11 +42,9 @@ var (
 
 // DeciderSettings allow the user to tweak autoscaling deciders.
 // The map data structure complies with the <key,value> format expected by Elasticsearch.
-// +kubebuilder:object:generate=false
 type DeciderSettings map[string]string
 
 // AutoscalingPolicy models the Elasticsearch autoscaling API.
-// +kubebuilder:object:generate=false
 type AutoscalingPolicy struct {
 	// An autoscaling policy must target a unique set of roles.
 	Roles []string `json:"roles,omitempty"`
@@ -66,28 +67,25 @@ type AutoscalingSpec struct {
 	Elasticsearch Elasticsearch `json:"-"`
 }
 
-// +kubebuilder:object:generate=false
+// AutoscalingPolicySpecs is a list of autoscaling policies and their associated resources limits.
 type AutoscalingPolicySpecs []AutoscalingPolicySpec
 
 // NamedAutoscalingPolicy models an autoscaling policy as expected by the Elasticsearch policy API.
 // It is identified by a unique name provided by the user.
-// +kubebuilder:object:generate=false
 type NamedAutoscalingPolicy struct {
 	// Name identifies the autoscaling policy in the autoscaling specification.
 	Name string `json:"name,omitempty"`
 	// AutoscalingPolicy is the autoscaling policy as expected by the Elasticsearch API.
-	AutoscalingPolicy
+	AutoscalingPolicy `json:",inline"`
 }
 
 // AutoscalingPolicySpec holds a named autoscaling policy and the associated resources limits (cpu, memory, storage).
-// +kubebuilder:object:generate=false
 type AutoscalingPolicySpec struct {
-	NamedAutoscalingPolicy
+	NamedAutoscalingPolicy `json:",inline"`
 
 	AutoscalingResources `json:"resources"`
 }
 
-// +kubebuilder:object:generate=false
 // AutoscalingResources model the limits, submitted by the user, for the supported resources in an autoscaling policy.
 // Only the node count range is mandatory. For other resources, a limit range is required only
 // if the Elasticsearch autoscaling capacity API returns a requirement for a given resource.
@@ -104,14 +102,14 @@ type AutoscalingResources struct {
 }
 
 // QuantityRange models a resource limit range for resources which can be expressed with resource.Quantity.
-// +kubebuilder:object:generate=false
 type QuantityRange struct {
 	// Min represents the lower limit for the resources managed by the autoscaler.
 	Min resource.Quantity `json:"min"`
 	// Max represents the upper limit for the resources managed by the autoscaler.
 	Max resource.Quantity `json:"max"`
 	// RequestsToLimitsRatio allows to customize Kubernetes resource Limit based on the Request.
-	RequestsToLimitsRatio *float64 `json:"requestsToLimitsRatio"`
+	// +kubebuilder:validation:Optional
+	RequestsToLimitsRatio *float64 `json:"requestsToLimitsRatio,omitempty"`
 }
 
 // Enforce adjusts a proposed quantity to ensure it is within the quantity range.
@@ -146,7 +144,7 @@ func (ar AutoscalingResources) CPURequestsToLimitsRatio() float64 {
 	return *ar.CPURange.RequestsToLimitsRatio
 }
 
-// +kubebuilder:object:generate=false
+// CountRange is used to model the minimum and the maximum number of nodes over all the NodeSets managed by a same autoscaling policy.
 type CountRange struct {
 	// Min represents the minimum number of nodes in a tier.
 	Min int32 `json:"min"`
@@ -164,13 +162,14 @@ func (cr *CountRange) Enforce(count int32) int32 {
 	return count
 }
 
-// GetAutoscalingSpecification unmarshal autoscaling specifications from an Elasticsearch resource.
-func (es Elasticsearch) GetAutoscalingSpecification() (AutoscalingSpec, error) {
+// GetAutoscalingSpecificationFromAnnotation unmarshal the autoscaling specification from the deprecated annotation of an
+// Elasticsearch resource.
+func (es Elasticsearch) GetAutoscalingSpecificationFromAnnotation() (AutoscalingSpec, error) {
 	autoscalingSpec := AutoscalingSpec{}
-	if len(es.AutoscalingSpec()) == 0 {
+	if len(es.AutoscalingAnnotation()) == 0 {
 		return autoscalingSpec, nil
 	}
-	err := json.Unmarshal([]byte(es.AutoscalingSpec()), &autoscalingSpec)
+	err := json.Unmarshal([]byte(es.AutoscalingAnnotation()), &autoscalingSpec)
 	autoscalingSpec.Elasticsearch = es
 	return autoscalingSpec, err
 }
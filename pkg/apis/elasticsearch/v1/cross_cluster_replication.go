@@ -0,0 +1,100 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package v1
+
+// CrossClusterReplication holds the auto-follow patterns and the follower indices to declare in Elasticsearch, to
+// replicate indices of the remote clusters.
+type CrossClusterReplication struct {
+	// AutoFollowPatterns is a list of auto-follow patterns, which automatically create follower indices for the new
+	// indices of a remote cluster matching the patterns.
+	// +kubebuilder:validation:Optional
+	AutoFollowPatterns []AutoFollowPattern `json:"autoFollowPatterns,omitempty"`
+
+	// FollowerIndices is a list of follower indices replicating a leader index of a remote cluster.
+	// +kubebuilder:validation:Optional
+	FollowerIndices []FollowerIndex `json:"followerIndices,omitempty"`
+}
+
+// IsDefined returns true if at least one auto-follow pattern or follower index is declared.
+func (c CrossClusterReplication) IsDefined() bool {
+	return len(c.AutoFollowPatterns) > 0 || len(c.FollowerIndices) > 0
+}
+
+// AutoFollowPattern declares an auto-follow pattern.
+type AutoFollowPattern struct {
+	// Name of the auto-follow pattern. The name is expected to be unique for each auto-follow pattern.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// RemoteCluster is the name of the remote cluster containing the leader indices, as declared in the remote
+	// clusters of the specification.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	RemoteCluster string `json:"remoteCluster"`
+
+	// LeaderIndexPatterns is a list of index patterns matching the indices of the remote cluster to follow.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	LeaderIndexPatterns []string `json:"leaderIndexPatterns"`
+
+	// FollowIndexPattern is the name of the follower indices, where the `leader_index` placeholder enclosed in double
+	// curly braces is replaced with the name of the leader index. Defaults to the name of the leader index.
+	// +kubebuilder:validation:Optional
+	FollowIndexPattern string `json:"followIndexPattern,omitempty"`
+}
+
+// FollowerIndex declares a follower index.
+type FollowerIndex struct {
+	// Name of the follower index. The name is expected to be unique for each follower index.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// RemoteCluster is the name of the remote cluster containing the leader index, as declared in the remote clusters
+	// of the specification.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	RemoteCluster string `json:"remoteCluster"`
+
+	// LeaderIndex is the name of the index of the remote cluster to follow.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	LeaderIndex string `json:"leaderIndex"`
+
+	// Promote converts the follower index into a regular index which accepts writes, for example to fail over to this
+	// cluster when the remote cluster is unavailable. Replication is stopped and cannot be resumed.
+	// +kubebuilder:validation:Optional
+	Promote bool `json:"promote,omitempty"`
+}
+
+// FollowerIndexPhase is the phase of a follower index from the controller point of view.
+type FollowerIndexPhase string
+
+const (
+	// FollowerIndexFollowingPhase indicates that the follower index replicates its leader index.
+	FollowerIndexFollowingPhase FollowerIndexPhase = "Following"
+	// FollowerIndexPausedPhase indicates that the replication of the leader index is paused, either through the
+	// Elasticsearch API or because of an unrecoverable error.
+	FollowerIndexPausedPhase FollowerIndexPhase = "Paused"
+	// FollowerIndexPromotedPhase indicates that the follower index has been converted into a regular index.
+	FollowerIndexPromotedPhase FollowerIndexPhase = "Promoted"
+	// FollowerIndexFailedPhase indicates that the follower index could not be created or promoted.
+	FollowerIndexFailedPhase FollowerIndexPhase = "Failed"
+)
+
+// FollowerIndexStatus is the observed state of a follower index declared in the specification.
+type FollowerIndexStatus struct {
+	// Name of the follower index.
+	Name string `json:"name"`
+	// Phase of the follower index.
+	Phase FollowerIndexPhase `json:"phase,omitempty"`
+	// OperationsBehind is the number of operations of the leader index not yet replicated to the follower index,
+	// summed across all the shards.
+	OperationsBehind int64 `json:"operationsBehind,omitempty"`
+	// Message describes the error that prevented the follower index from being created or promoted, or the reason why
+	// the replication is paused, if any.
+	Message string `json:"message,omitempty"`
+}
@@ -5,6 +5,8 @@
 package v1
 
 import (
+	"strings"
+
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
 	"github.com/elastic/cloud-on-k8s/pkg/utils/stringsutil"
 	"github.com/elastic/go-ucfg"
@@ -43,6 +45,21 @@ const (
 // ClusterSettings is the cluster node in elasticsearch.yml.
 type ClusterSettings struct {
 	InitialMasterNodes []string `config:"initial_master_nodes"`
+	// ShardAwarenessAttributes can be set as a list, or as a comma-separated string.
+	ShardAwarenessAttributes []string `config:"routing.allocation.awareness.attributes"`
+}
+
+// AwarenessAttributes returns the names of the node attributes used for shard allocation awareness.
+func (c ClusterSettings) AwarenessAttributes() []string {
+	var attributes []string
+	for _, value := range c.ShardAwarenessAttributes {
+		for _, attribute := range strings.Split(value, ",") {
+			if attribute = strings.TrimSpace(attribute); attribute != "" {
+				attributes = append(attributes, attribute)
+			}
+		}
+	}
+	return attributes
 }
 
 // Node is the node section in elasticsearch.yml.
@@ -55,6 +72,9 @@ type Node struct {
 	RemoteClusterClient *bool    `config:"remote_cluster_client"` // available as of 7.7.0
 	Roles               []string `config:"roles"`                 // available as of 7.9.0, takes priority over the other fields if non-nil
 	VotingOnly          *bool    `config:"voting_only"`           // available as of 7.3.0
+
+	// Attributes are the custom node attributes, set with node.attr.<name>.
+	Attributes map[string]string `config:"attr"`
 }
 
 // HasRole returns true if the node has the given role.
@@ -406,6 +406,24 @@ func TestConfig_Unpack(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "awareness attributes",
+			args: &commonv1.Config{
+				Data: map[string]interface{}{
+					"node.attr.zone": "europe-west3-a",
+					"cluster.routing.allocation.awareness.attributes": "k8s_node_name,zone",
+				},
+			},
+			want: ElasticsearchSettings{
+				Node: &Node{
+					Attributes: map[string]string{"zone": "europe-west3-a"},
+				},
+				Cluster: ClusterSettings{
+					ShardAwarenessAttributes: []string{"k8s_node_name,zone"},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name:    "Unpack is nil safe",
 			args:    nil,
@@ -425,3 +443,9 @@ func TestConfig_Unpack(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterSettings_AwarenessAttributes(t *testing.T) {
+	require.Nil(t, ClusterSettings{}.AwarenessAttributes())
+	require.Equal(t, []string{"k8s_node_name", "zone"}, ClusterSettings{ShardAwarenessAttributes: []string{"k8s_node_name, zone"}}.AwarenessAttributes())
+	require.Equal(t, []string{"zone", "rack"}, ClusterSettings{ShardAwarenessAttributes: []string{"zone", "rack"}}.AwarenessAttributes())
+}
@@ -66,6 +66,10 @@ type ElasticsearchSpec struct {
 	// +kubebuilder:validation:Optional
 	PodDisruptionBudget *commonv1.PodDisruptionBudgetTemplate `json:"podDisruptionBudget,omitempty"`
 
+	// NetworkPolicy controls the NetworkPolicies managed by the operator to allow the traffic to the Elasticsearch Pods.
+	// +kubebuilder:validation:Optional
+	NetworkPolicy *commonv1.NetworkPolicySettings `json:"networkPolicy,omitempty"`
+
 	// Auth contains user authentication and authorization security settings for Elasticsearch.
 	// +kubebuilder:validation:Optional
 	Auth Auth `json:"auth,omitempty"`
@@ -95,6 +99,20 @@ type ElasticsearchSpec struct {
 	// Elasticsearch monitoring clusters running in the same Kubernetes cluster.
 	// +kubebuilder:validation:Optional
 	Monitoring Monitoring `json:"monitoring,omitempty"`
+
+	// Snapshots holds the snapshot repositories and the snapshot lifecycle management policies to declare in Elasticsearch.
+	// +kubebuilder:validation:Optional
+	Snapshots Snapshots `json:"snapshots,omitempty"`
+
+	// IndexManagement holds the index lifecycle management policies, the component and index templates and the ingest
+	// pipelines to declare in Elasticsearch.
+	// +kubebuilder:validation:Optional
+	IndexManagement IndexManagement `json:"indexManagement,omitempty"`
+
+	// CrossClusterReplication holds the auto-follow patterns and the follower indices replicating indices of the
+	// remote clusters declared in RemoteClusters.
+	// +kubebuilder:validation:Optional
+	CrossClusterReplication CrossClusterReplication `json:"crossClusterReplication,omitempty"`
 }
 
 type Monitoring struct {
@@ -150,12 +168,23 @@ type TransportTLSOptions struct {
 	// - `ca.crt`: The CA certificate in PEM format.
 	// - `ca.key`: The private key for the CA certificate in PEM format.
 	Certificate commonv1.SecretRef `json:"certificate,omitempty"`
+	// Issuer is a reference to an external signer issuing the node transport certificates instead of the operator.
+	// It is ignored if Certificate is specified.
+	Issuer *commonv1.CertificateIssuer `json:"issuer,omitempty"`
 }
 
 func (tto TransportTLSOptions) UserDefinedCA() bool {
 	return tto.Certificate.SecretName != ""
 }
 
+// ExternalIssuer returns the external signer issuing the node transport certificates, if any.
+func (tto TransportTLSOptions) ExternalIssuer() *commonv1.CertificateIssuer {
+	if tto.UserDefinedCA() {
+		return nil
+	}
+	return tto.Issuer
+}
+
 // RemoteCluster declares a remote Elasticsearch cluster connection.
 type RemoteCluster struct {
 	// Name is the name of the remote cluster as it is set in the Elasticsearch settings.
@@ -167,8 +196,79 @@ type RemoteCluster struct {
 	// ElasticsearchRef is a reference to an Elasticsearch cluster running within the same k8s cluster.
 	ElasticsearchRef commonv1.ObjectSelector `json:"elasticsearchRef,omitempty"`
 
-	// TODO: Allow the user to specify some options (transport.compress, transport.ping_schedule)
+	// External declares an Elasticsearch cluster running outside of the k8s cluster. It cannot be set along with
+	// ElasticsearchRef.
+	// +kubebuilder:validation:Optional
+	External *ExternalRemoteCluster `json:"external,omitempty"`
+
+	// Mode is the mode used to connect to the remote cluster. Possible values are sniff and proxy. Defaults to sniff.
+	// The proxy mode is only available from Elasticsearch 7.7.0.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=sniff;proxy
+	Mode RemoteClusterMode `json:"mode,omitempty"`
+
+	// ServerName is the server name sent in the TLS Server Name Indication extension when connecting to the remote
+	// cluster in proxy mode.
+	// +kubebuilder:validation:Optional
+	ServerName string `json:"serverName,omitempty"`
+
+	// Compress enables the compression of the requests sent to the remote cluster. Defaults to the transport.compress
+	// setting of the cluster.
+	// +kubebuilder:validation:Optional
+	Compress *bool `json:"compress,omitempty"`
+
+	// PingSchedule is the interval at which a ping is sent to the remote cluster to keep the connections alive, for
+	// example 30s. Defaults to the transport.ping_schedule setting of the cluster.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^(-1|[0-9]+(d|h|m|s|ms|micros|nanos))$`
+	PingSchedule string `json:"pingSchedule,omitempty"`
+
+	// SkipUnavailable skips the remote cluster in cross-cluster searches if none of its nodes are available.
+	// Defaults to false.
+	// +kubebuilder:validation:Optional
+	SkipUnavailable *bool `json:"skipUnavailable,omitempty"`
+
+	// NodeConnections is the number of gateway nodes to connect to in sniff mode, or the number of socket connections
+	// to open in proxy mode. Only available from Elasticsearch 7.7.0.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	NodeConnections *int32 `json:"nodeConnections,omitempty"`
+}
+
+// RemoteClusterMode is the mode used to connect to a remote cluster.
+type RemoteClusterMode string
+
+const (
+	// SniffRemoteClusterMode connects to the gateway nodes of the remote cluster discovered from the seed nodes.
+	SniffRemoteClusterMode RemoteClusterMode = "sniff"
+	// ProxyRemoteClusterMode connects to the remote cluster through a single address, usually a load balancer.
+	ProxyRemoteClusterMode RemoteClusterMode = "proxy"
+)
+
+// ExternalRemoteCluster declares an Elasticsearch cluster running outside of the k8s cluster.
+type ExternalRemoteCluster struct {
+	// Addresses are the transport addresses of the remote cluster, in the host:port format. They are the seed nodes in
+	// sniff mode. In proxy mode a single address is expected.
+	// +kubebuilder:validation:MinItems=1
+	Addresses []string `json:"addresses"`
 
+	// CASecretName is the name of a Secret in the same namespace holding the certificate of the CA of the transport
+	// layer of the remote cluster under ca.crt. The CA is added to the CAs trusted by the transport layer of this cluster.
+	// +kubebuilder:validation:Optional
+	CASecretName string `json:"caSecretName,omitempty"`
+}
+
+// ModeOrDefault returns the mode used to connect to the remote cluster, defaulting to sniff.
+func (r RemoteCluster) ModeOrDefault() RemoteClusterMode {
+	if r.Mode == "" {
+		return SniffRemoteClusterMode
+	}
+	return r.Mode
+}
+
+// IsDefined returns true if the remote cluster references a cluster running either in or outside of the k8s cluster.
+func (r RemoteCluster) IsDefined() bool {
+	return r.ElasticsearchRef.IsDefined() || r.External != nil
 }
 
 func (r RemoteCluster) ConfigHash() string {
@@ -285,6 +385,11 @@ type NodeSet struct {
 	// Items defined here take precedence over any default claims added by the operator with the same name.
 	// +kubebuilder:validation:Optional
 	VolumeClaimTemplates []corev1.PersistentVolumeClaim `json:"volumeClaimTemplates,omitempty"`
+
+	// UpdateStrategy specifies how rolling changes to the nodes of this NodeSet should be performed, in addition to
+	// the update strategy of the cluster.
+	// +kubebuilder:validation:Optional
+	UpdateStrategy *NodeSetUpdateStrategy `json:"updateStrategy,omitempty"`
 }
 
 // +kubebuilder:object:generate=false
@@ -312,6 +417,63 @@ func (n NodeSet) GetESContainerTemplate() *corev1.Container {
 type UpdateStrategy struct {
 	// ChangeBudget defines the constraints to consider when applying changes to the Elasticsearch cluster.
 	ChangeBudget ChangeBudget `json:"changeBudget,omitempty"`
+
+	// SnapshotBeforeUpgrade, if set, makes the operator take a snapshot of the cluster before the first Pod is
+	// restarted to run a different Elasticsearch version. The upgrade does not start until the snapshot succeeds.
+	// +kubebuilder:validation:Optional
+	SnapshotBeforeUpgrade *SnapshotBeforeUpgrade `json:"snapshotBeforeUpgrade,omitempty"`
+
+	// MaintenanceWindow, if set, restricts the restarts of the healthy nodes of the cluster during rolling changes to
+	// the periods of the window. It can be overridden for each NodeSet.
+	// +kubebuilder:validation:Optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+}
+
+// MaintenanceWindowFor returns the maintenance window of the given NodeSet, or nil if its nodes can be restarted at
+// any time.
+func (s UpdateStrategy) MaintenanceWindowFor(nodeSet NodeSet) *MaintenanceWindow {
+	if nodeSet.UpdateStrategy != nil && nodeSet.UpdateStrategy.MaintenanceWindow != nil {
+		return nodeSet.UpdateStrategy.MaintenanceWindow
+	}
+	return s.MaintenanceWindow
+}
+
+// NodeSetUpdateStrategy specifies how rolling changes to the nodes of a NodeSet should be performed.
+type NodeSetUpdateStrategy struct {
+	// ChangeBudget defines the constraints to consider when applying changes to the nodes of the NodeSet.
+	// The change budget of the cluster still applies.
+	// +kubebuilder:validation:Optional
+	ChangeBudget NodeSetChangeBudget `json:"changeBudget,omitempty"`
+
+	// MaintenanceWindow, if set, overrides the maintenance window of the cluster for the nodes of the NodeSet.
+	// +kubebuilder:validation:Optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+}
+
+// NodeSetChangeBudget defines the constraints to consider when applying changes to the nodes of a NodeSet.
+type NodeSetChangeBudget struct {
+	// MaxUnavailable is the maximum number of pods of the NodeSet that can be unavailable (not ready) during the update
+	// due to circumstances under the control of the operator. Setting a negative value will disable this restriction.
+	// Defaults to the restriction of the change budget of the cluster only.
+	MaxUnavailable *int32 `json:"maxUnavailable,omitempty"`
+}
+
+// GetMaxUnavailable returns the maximum number of unavailable pods of the NodeSet, or nil if unbounded.
+func (s *NodeSetUpdateStrategy) GetMaxUnavailable() *int32 {
+	if s == nil || s.ChangeBudget.MaxUnavailable == nil || *s.ChangeBudget.MaxUnavailable < 0 {
+		return nil
+	}
+	return s.ChangeBudget.MaxUnavailable
+}
+
+// MaintenanceWindow defines recurring periods of time during which healthy nodes can be restarted to apply changes.
+type MaintenanceWindow struct {
+	// Schedule is a cron expression defining the start of each period, evaluated in UTC. It has 5 fields: minute, hour,
+	// day of month, month and day of week, for example "0 22 * * 1-5" for every weekday at 10PM.
+	Schedule string `json:"schedule"`
+
+	// Duration of each period, for example "4h".
+	Duration metav1.Duration `json:"duration"`
 }
 
 // ChangeBudget defines the constraints to consider when applying changes to the Elasticsearch cluster.
@@ -416,6 +578,140 @@ type ElasticsearchStatus struct {
 	Phase   ElasticsearchOrchestrationPhase `json:"phase,omitempty"`
 
 	MonitoringAssociationsStatus commonv1.AssociationStatusMap `json:"monitoringAssociationStatus,omitempty"`
+
+	// SnapshotRepositories is the status of the snapshot repositories declared in the specification.
+	SnapshotRepositories []SnapshotRepositoryStatus `json:"snapshotRepositories,omitempty"`
+
+	// IndexManagement is the status of the index management objects declared in the specification.
+	IndexManagement []IndexManagementObjectStatus `json:"indexManagement,omitempty"`
+
+	// StackConfigPolicies describes the StackConfigPolicies applied to the cluster and the conflicts of their settings.
+	StackConfigPolicies *commonv1.PoliciesStatus `json:"stackConfigPolicies,omitempty"`
+
+	// UpgradeSnapshot is the status of the snapshot taken before the last version upgrade, if any.
+	UpgradeSnapshot *UpgradeSnapshotStatus `json:"upgradeSnapshot,omitempty"`
+
+	// DeprecationCheck is the result of the deprecation check performed before the last major version upgrade, if any.
+	DeprecationCheck *DeprecationCheckStatus `json:"deprecationCheck,omitempty"`
+
+	// DeferredChanges lists the NodeSets whose rolling changes are deferred until their next maintenance window.
+	DeferredChanges []DeferredChangesStatus `json:"deferredChanges,omitempty"`
+
+	// TransportCertificates describes the expiry and the upcoming rotations of the transport certificates.
+	TransportCertificates *TransportCertificatesStatus `json:"transportCertificates,omitempty"`
+
+	// RemoteClusters describes the connections to the remote clusters declared in the specification.
+	RemoteClusters []RemoteClusterStatus `json:"remoteClusters,omitempty"`
+
+	// FollowerIndices is the status of the cross-cluster replication follower indices declared in the specification.
+	FollowerIndices []FollowerIndexStatus `json:"followerIndices,omitempty"`
+
+	// NodeSets describes the nodes of each NodeSet declared in the specification.
+	NodeSets []NodeSetStatus `json:"nodeSets,omitempty"`
+
+	// Conditions holds the current service state of the Elasticsearch cluster.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// Condition types of an Elasticsearch cluster.
+const (
+	// ReconciliationCompleteCondition is true if the latest specification has been fully applied, without any error
+	// and without any pending change.
+	ReconciliationCompleteCondition = "ReconciliationComplete"
+	// RunningDesiredVersionCondition is true if all the nodes run the version of Elasticsearch declared in the specification.
+	RunningDesiredVersionCondition = "RunningDesiredVersion"
+	// ElasticsearchIsReachableCondition is true if the Elasticsearch API can be reached through the HTTP Service.
+	ElasticsearchIsReachableCondition = "ElasticsearchIsReachable"
+	// UpgradeBlockedCondition is true if some nodes cannot be restarted to apply pending changes, or if the nodes are
+	// held at the running version. Its message lists the checks which prevent the restart or the upgrade.
+	UpgradeBlockedCondition = "UpgradeBlocked"
+)
+
+// NodeSetStatus describes the nodes of a NodeSet.
+type NodeSetStatus struct {
+	// Name of the NodeSet.
+	Name string `json:"name"`
+	// DesiredNodes is the number of nodes declared in the specification.
+	DesiredNodes int32 `json:"desiredNodes"`
+	// ReadyNodes is the number of nodes whose Pod is ready.
+	ReadyNodes int32 `json:"readyNodes"`
+	// UpgradingNodes is the number of nodes whose Pod must be restarted to apply pending changes.
+	UpgradingNodes int32 `json:"upgradingNodes"`
+}
+
+// RemoteClusterStatus describes the connection to a remote cluster declared in the specification.
+type RemoteClusterStatus struct {
+	// Name of the remote cluster.
+	Name string `json:"name"`
+	// Mode is the mode used to connect to the remote cluster.
+	Mode RemoteClusterMode `json:"mode,omitempty"`
+	// Connected is true if at least one connection to the remote cluster is open.
+	Connected bool `json:"connected"`
+	// ConnectedNodes is the number of nodes of the remote cluster connected in sniff mode, or the number of open
+	// socket connections in proxy mode.
+	ConnectedNodes int32 `json:"connectedNodes"`
+}
+
+// TransportCertificatesStatus describes the expiry and the upcoming rotations of the transport certificates.
+type TransportCertificatesStatus struct {
+	// CAExpiration is the expiration time of the certificate of the transport CA.
+	CAExpiration metav1.Time `json:"caExpiration"`
+	// CANextRotation is the time at which the transport CA is rotated. It is not set if the CA is not managed by the
+	// operator.
+	CANextRotation *metav1.Time `json:"caNextRotation,omitempty"`
+	// NextRotation is the time at which the next node transport certificate is rotated.
+	NextRotation *metav1.Time `json:"nextRotation,omitempty"`
+	// ForcedRotation is the value of the annotation forcing the rotation of the transport certificates, once the
+	// certificates of all the nodes have been rotated.
+	ForcedRotation string `json:"forcedRotation,omitempty"`
+	// Nodes describes the transport certificate of each node.
+	Nodes []NodeTransportCertificateStatus `json:"nodes,omitempty"`
+}
+
+// NodeTransportCertificateStatus describes the transport certificate of a node.
+type NodeTransportCertificateStatus struct {
+	// Pod is the name of the Pod of the node.
+	Pod string `json:"pod"`
+	// Expiration is the expiration time of the certificate.
+	Expiration metav1.Time `json:"expiration"`
+	// NextRotation is the time at which the certificate is rotated.
+	NextRotation metav1.Time `json:"nextRotation"`
+}
+
+// DeferredChangesStatus describes the rolling changes of a NodeSet deferred until its next maintenance window.
+type DeferredChangesStatus struct {
+	// NodeSet is the name of the NodeSet.
+	NodeSet string `json:"nodeSet"`
+	// PendingPods is the number of Pods of the NodeSet waiting to be restarted to apply the changes.
+	PendingPods int32 `json:"pendingPods"`
+	// NextMaintenanceWindow is the start of the next maintenance window of the NodeSet. It is not set if the schedule
+	// of the window is not activated in the next 5 years.
+	NextMaintenanceWindow *metav1.Time `json:"nextMaintenanceWindow,omitempty"`
+}
+
+// DeprecationCheckPhase is the result of the deprecation check performed before a major version upgrade.
+type DeprecationCheckPhase string
+
+const (
+	// DeprecationCheckPassedPhase indicates that no critical deprecation issue was found, the upgrade can proceed.
+	DeprecationCheckPassedPhase DeprecationCheckPhase = "Passed"
+	// DeprecationCheckBlockedPhase indicates that critical deprecation issues block the upgrade.
+	DeprecationCheckBlockedPhase DeprecationCheckPhase = "Blocked"
+	// DeprecationCheckSkippedPhase indicates that the check has been skipped on user request.
+	DeprecationCheckSkippedPhase DeprecationCheckPhase = "Skipped"
+)
+
+// DeprecationCheckStatus describes the deprecation check performed before a major version upgrade.
+type DeprecationCheckStatus struct {
+	// Version is the Elasticsearch version the cluster is upgraded to once the check passes.
+	Version string `json:"version"`
+	// Phase is the result of the check.
+	Phase DeprecationCheckPhase `json:"phase"`
+	// CriticalIssues lists the critical deprecation issues reported by Elasticsearch which block the upgrade.
+	CriticalIssues []string `json:"criticalIssues,omitempty"`
 }
 
 type ZenDiscoveryStatus struct {
@@ -456,19 +752,30 @@ func (es *Elasticsearch) ServiceAccountName() string {
 	return es.Spec.ServiceAccountName
 }
 
-// IsAutoscalingDefined returns true if there is an autoscaling configuration in the annotations.
-func (es Elasticsearch) IsAutoscalingDefined() bool {
+// GetNetworkPolicySettings returns the NetworkPolicy settings of the Elasticsearch cluster.
+func (es *Elasticsearch) GetNetworkPolicySettings() *commonv1.NetworkPolicySettings {
+	return es.Spec.NetworkPolicy
+}
+
+// IsAutoscalingAnnotationSet returns true if there is an autoscaling configuration in the deprecated annotation.
+func (es Elasticsearch) IsAutoscalingAnnotationSet() bool {
 	_, ok := es.Annotations[ElasticsearchAutoscalingSpecAnnotationName]
 	return ok
 }
 
-// AutoscalingSpec returns the autoscaling spec in the Elasticsearch manifest.
-func (es Elasticsearch) AutoscalingSpec() string {
+// AutoscalingAnnotation returns the autoscaling spec held in the deprecated annotation of the Elasticsearch manifest.
+func (es Elasticsearch) AutoscalingAnnotation() string {
 	return es.Annotations[ElasticsearchAutoscalingSpecAnnotationName]
 }
 
+// SecureSettings returns the secure settings of the cluster, including the credentials of the snapshot repositories.
 func (es Elasticsearch) SecureSettings() []commonv1.SecretSource {
-	return es.Spec.SecureSettings
+	secureSettings := make([]commonv1.SecretSource, 0, len(es.Spec.SecureSettings))
+	secureSettings = append(secureSettings, es.Spec.SecureSettings...)
+	for _, repository := range es.Spec.Snapshots.Repositories {
+		secureSettings = append(secureSettings, repository.SecureSettings...)
+	}
+	return secureSettings
 }
 
 // -- associations
@@ -0,0 +1,83 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package v1
+
+import (
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+)
+
+// IndexManagement holds the index lifecycle management (ILM) policies, the component and index templates and the
+// ingest pipelines to declare in Elasticsearch.
+type IndexManagement struct {
+	// ILMPolicies is a list of index lifecycle management policies to create in Elasticsearch.
+	// The definition of each policy is the content of the `policy` object of the create or update policy API.
+	// +kubebuilder:validation:Optional
+	ILMPolicies []IndexManagementObject `json:"ilmPolicies,omitempty"`
+
+	// ComponentTemplates is a list of component templates to create in Elasticsearch.
+	// Component templates are available from Elasticsearch 7.8.0.
+	// +kubebuilder:validation:Optional
+	ComponentTemplates []IndexManagementObject `json:"componentTemplates,omitempty"`
+
+	// IndexTemplates is a list of composable index templates to create in Elasticsearch.
+	// Composable index templates are available from Elasticsearch 7.8.0.
+	// +kubebuilder:validation:Optional
+	IndexTemplates []IndexManagementObject `json:"indexTemplates,omitempty"`
+
+	// IngestPipelines is a list of ingest pipelines to create in Elasticsearch.
+	// +kubebuilder:validation:Optional
+	IngestPipelines []IndexManagementObject `json:"ingestPipelines,omitempty"`
+}
+
+// IsDefined returns true if at least one object is declared.
+func (im IndexManagement) IsDefined() bool {
+	return len(im.ILMPolicies) > 0 || len(im.ComponentTemplates) > 0 || len(im.IndexTemplates) > 0 || len(im.IngestPipelines) > 0
+}
+
+// IndexManagementObject declares a named object, applied to Elasticsearch as the body of the corresponding create or
+// update API.
+type IndexManagementObject struct {
+	// Name of the object. The name is expected to be unique for each type of object.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Definition of the object, as expected by the Elasticsearch API.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Definition *commonv1.Config `json:"definition"`
+}
+
+// IndexManagementObjectType is the type of an object declared in the index management specification.
+type IndexManagementObjectType string
+
+const (
+	ILMPolicyType         IndexManagementObjectType = "ILMPolicy"
+	ComponentTemplateType IndexManagementObjectType = "ComponentTemplate"
+	IndexTemplateType     IndexManagementObjectType = "IndexTemplate"
+	IngestPipelineType    IndexManagementObjectType = "IngestPipeline"
+)
+
+// IndexManagementObjectPhase is the phase of an index management object from the controller point of view.
+type IndexManagementObjectPhase string
+
+const (
+	// IndexManagementObjectReadyPhase indicates that the object exists in Elasticsearch as specified.
+	IndexManagementObjectReadyPhase IndexManagementObjectPhase = "Ready"
+	// IndexManagementObjectFailedPhase indicates that the object could not be applied to Elasticsearch.
+	IndexManagementObjectFailedPhase IndexManagementObjectPhase = "Failed"
+)
+
+// IndexManagementObjectStatus is the observed state of an index management object declared in the specification.
+type IndexManagementObjectStatus struct {
+	// Type of the object.
+	Type IndexManagementObjectType `json:"type"`
+	// Name of the object.
+	Name string `json:"name"`
+	// Phase of the object.
+	Phase IndexManagementObjectPhase `json:"phase,omitempty"`
+	// Message describes the error that prevented the object from being applied, if any.
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,161 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package v1
+
+import (
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+)
+
+// Snapshots holds the snapshot repositories and the snapshot lifecycle management (SLM) policies to declare in Elasticsearch.
+type Snapshots struct {
+	// Repositories is a list of snapshot repositories to register in Elasticsearch.
+	// +kubebuilder:validation:Optional
+	Repositories []SnapshotRepository `json:"repositories,omitempty"`
+
+	// Policies is a list of snapshot lifecycle management policies to create in Elasticsearch.
+	// Snapshot lifecycle management is available from Elasticsearch 7.4.0.
+	// +kubebuilder:validation:Optional
+	Policies []SnapshotLifecyclePolicy `json:"policies,omitempty"`
+}
+
+// IsDefined returns true if at least one snapshot repository or policy is declared.
+func (s Snapshots) IsDefined() bool {
+	return len(s.Repositories) > 0 || len(s.Policies) > 0
+}
+
+// SnapshotRepository declares a snapshot repository.
+type SnapshotRepository struct {
+	// Name of the snapshot repository. The name is expected to be unique for each repository.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Type of the snapshot repository, for example s3, gcs, azure or fs.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Type string `json:"type"`
+
+	// Settings holds the settings of the repository, specific to its type.
+	// See https://www.elastic.co/guide/en/elasticsearch/reference/current/snapshots-register-repository.html.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Settings *commonv1.Config `json:"settings,omitempty"`
+
+	// SecureSettings is a list of references to Kubernetes secrets containing the credentials of the repository client,
+	// for example `s3.client.default.access_key`. They are added to the Elasticsearch keystore along with the secure
+	// settings of the cluster.
+	// +kubebuilder:validation:Optional
+	SecureSettings []commonv1.SecretSource `json:"secureSettings,omitempty"`
+}
+
+// SnapshotLifecyclePolicy declares a snapshot lifecycle management policy.
+type SnapshotLifecyclePolicy struct {
+	// Name of the policy. The name is expected to be unique for each policy.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Schedule is the periodic or absolute schedule at which the policy creates snapshots, as a Cron expression.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// SnapshotName is the name automatically assigned to each snapshot created by the policy. Date math is supported.
+	// Defaults to `<{policy name}-{now/d}>`.
+	// +kubebuilder:validation:Optional
+	SnapshotName string `json:"snapshotName,omitempty"`
+
+	// Repository is the name of the repository used to store snapshots created by this policy.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Repository string `json:"repository"`
+
+	// Config holds the configuration of the snapshots created by the policy, for example `indices` or `include_global_state`.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Config *commonv1.Config `json:"config,omitempty"`
+
+	// Retention holds the retention rules used to retain and delete snapshots created by the policy.
+	// +kubebuilder:validation:Optional
+	Retention *SnapshotRetention `json:"retention,omitempty"`
+}
+
+// SnapshotNameOrDefault returns the snapshot name of the policy, or a default value derived from the policy name.
+func (p SnapshotLifecyclePolicy) SnapshotNameOrDefault() string {
+	if p.SnapshotName != "" {
+		return p.SnapshotName
+	}
+	return "<" + p.Name + "-{now/d}>"
+}
+
+// SnapshotRetention holds the retention rules of a snapshot lifecycle management policy.
+type SnapshotRetention struct {
+	// ExpireAfter is the time period after which a snapshot is considered expired and eligible for deletion, for example `30d`.
+	// +kubebuilder:validation:Optional
+	ExpireAfter string `json:"expireAfter,omitempty"`
+
+	// MinCount is the minimum number of snapshots to retain, even if the snapshots have expired.
+	// +kubebuilder:validation:Optional
+	MinCount *int32 `json:"minCount,omitempty"`
+
+	// MaxCount is the maximum number of snapshots to retain, even if the snapshots have not yet expired.
+	// +kubebuilder:validation:Optional
+	MaxCount *int32 `json:"maxCount,omitempty"`
+}
+
+// SnapshotRepositoryPhase is the phase of a snapshot repository from the controller point of view.
+type SnapshotRepositoryPhase string
+
+const (
+	// SnapshotRepositoryReadyPhase indicates that the repository is registered in Elasticsearch as specified.
+	SnapshotRepositoryReadyPhase SnapshotRepositoryPhase = "Ready"
+	// SnapshotRepositoryFailedPhase indicates that the repository could not be registered in Elasticsearch.
+	SnapshotRepositoryFailedPhase SnapshotRepositoryPhase = "Failed"
+)
+
+// SnapshotRepositoryStatus is the observed state of a snapshot repository declared in the specification.
+type SnapshotRepositoryStatus struct {
+	// Name of the snapshot repository.
+	Name string `json:"name"`
+	// Phase of the snapshot repository.
+	Phase SnapshotRepositoryPhase `json:"phase,omitempty"`
+	// Message describes the error that prevented the repository from being registered, if any.
+	Message string `json:"message,omitempty"`
+}
+
+// SnapshotBeforeUpgrade configures the snapshot taken before a version upgrade.
+type SnapshotBeforeUpgrade struct {
+	// Repository is the name of the snapshot repository used to store the snapshot. The repository must be registered
+	// in Elasticsearch, either through the Elasticsearch API or in the snapshots section of the specification.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Repository string `json:"repository"`
+}
+
+// UpgradeSnapshotPhase is the phase of the snapshot taken before a version upgrade.
+type UpgradeSnapshotPhase string
+
+const (
+	// UpgradeSnapshotInProgressPhase indicates that the snapshot is being taken, the upgrade is on hold.
+	UpgradeSnapshotInProgressPhase UpgradeSnapshotPhase = "InProgress"
+	// UpgradeSnapshotSucceededPhase indicates that the snapshot has been taken, the upgrade can proceed.
+	UpgradeSnapshotSucceededPhase UpgradeSnapshotPhase = "Succeeded"
+	// UpgradeSnapshotFailedPhase indicates that the snapshot failed, the upgrade is blocked.
+	UpgradeSnapshotFailedPhase UpgradeSnapshotPhase = "Failed"
+)
+
+// UpgradeSnapshotStatus is the observed state of the snapshot taken before a version upgrade.
+type UpgradeSnapshotStatus struct {
+	// Name of the snapshot.
+	Name string `json:"name"`
+	// Repository in which the snapshot is stored.
+	Repository string `json:"repository"`
+	// Version is the Elasticsearch version the cluster is upgraded to once the snapshot succeeds.
+	Version string `json:"version"`
+	// Phase of the snapshot.
+	Phase UpgradeSnapshotPhase `json:"phase,omitempty"`
+	// Message describes why the snapshot failed, if it did.
+	Message string `json:"message,omitempty"`
+}
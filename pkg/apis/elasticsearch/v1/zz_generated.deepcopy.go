@@ -12,6 +12,7 @@ package v1
 import (
 	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -41,6 +42,132 @@ func (in *Auth) DeepCopy() *Auth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoFollowPattern) DeepCopyInto(out *AutoFollowPattern) {
+	*out = *in
+	if in.LeaderIndexPatterns != nil {
+		in, out := &in.LeaderIndexPatterns, &out.LeaderIndexPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoFollowPattern.
+func (in *AutoFollowPattern) DeepCopy() *AutoFollowPattern {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoFollowPattern)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingPolicy) DeepCopyInto(out *AutoscalingPolicy) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Deciders != nil {
+		in, out := &in.Deciders, &out.Deciders
+		*out = make(map[string]DeciderSettings, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(DeciderSettings, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingPolicy.
+func (in *AutoscalingPolicy) DeepCopy() *AutoscalingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingPolicySpec) DeepCopyInto(out *AutoscalingPolicySpec) {
+	*out = *in
+	in.NamedAutoscalingPolicy.DeepCopyInto(&out.NamedAutoscalingPolicy)
+	in.AutoscalingResources.DeepCopyInto(&out.AutoscalingResources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingPolicySpec.
+func (in *AutoscalingPolicySpec) DeepCopy() *AutoscalingPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in AutoscalingPolicySpecs) DeepCopyInto(out *AutoscalingPolicySpecs) {
+	{
+		in := &in
+		*out = make(AutoscalingPolicySpecs, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingPolicySpecs.
+func (in AutoscalingPolicySpecs) DeepCopy() AutoscalingPolicySpecs {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingPolicySpecs)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingResources) DeepCopyInto(out *AutoscalingResources) {
+	*out = *in
+	if in.CPURange != nil {
+		in, out := &in.CPURange, &out.CPURange
+		*out = new(QuantityRange)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemoryRange != nil {
+		in, out := &in.MemoryRange, &out.MemoryRange
+		*out = new(QuantityRange)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageRange != nil {
+		in, out := &in.StorageRange, &out.StorageRange
+		*out = new(QuantityRange)
+		(*in).DeepCopyInto(*out)
+	}
+	out.NodeCountRange = in.NodeCountRange
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingResources.
+func (in *AutoscalingResources) DeepCopy() *AutoscalingResources {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChangeBudget) DeepCopyInto(out *ChangeBudget) {
 	*out = *in
@@ -74,6 +201,11 @@ func (in *ClusterSettings) DeepCopyInto(out *ClusterSettings) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ShardAwarenessAttributes != nil {
+		in, out := &in.ShardAwarenessAttributes, &out.ShardAwarenessAttributes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSettings.
@@ -86,6 +218,108 @@ func (in *ClusterSettings) DeepCopy() *ClusterSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CountRange) DeepCopyInto(out *CountRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CountRange.
+func (in *CountRange) DeepCopy() *CountRange {
+	if in == nil {
+		return nil
+	}
+	out := new(CountRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrossClusterReplication) DeepCopyInto(out *CrossClusterReplication) {
+	*out = *in
+	if in.AutoFollowPatterns != nil {
+		in, out := &in.AutoFollowPatterns, &out.AutoFollowPatterns
+		*out = make([]AutoFollowPattern, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FollowerIndices != nil {
+		in, out := &in.FollowerIndices, &out.FollowerIndices
+		*out = make([]FollowerIndex, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrossClusterReplication.
+func (in *CrossClusterReplication) DeepCopy() *CrossClusterReplication {
+	if in == nil {
+		return nil
+	}
+	out := new(CrossClusterReplication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in DeciderSettings) DeepCopyInto(out *DeciderSettings) {
+	{
+		in := &in
+		*out = make(DeciderSettings, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeciderSettings.
+func (in DeciderSettings) DeepCopy() DeciderSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(DeciderSettings)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeferredChangesStatus) DeepCopyInto(out *DeferredChangesStatus) {
+	*out = *in
+	if in.NextMaintenanceWindow != nil {
+		in, out := &in.NextMaintenanceWindow, &out.NextMaintenanceWindow
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeferredChangesStatus.
+func (in *DeferredChangesStatus) DeepCopy() *DeferredChangesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeferredChangesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeprecationCheckStatus) DeepCopyInto(out *DeprecationCheckStatus) {
+	*out = *in
+	if in.CriticalIssues != nil {
+		in, out := &in.CriticalIssues, &out.CriticalIssues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeprecationCheckStatus.
+func (in *DeprecationCheckStatus) DeepCopy() *DeprecationCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeprecationCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Elasticsearch) DeepCopyInto(out *Elasticsearch) {
 	*out = *in
@@ -191,6 +425,11 @@ func (in *ElasticsearchSpec) DeepCopyInto(out *ElasticsearchSpec) {
 		*out = new(commonv1.PodDisruptionBudgetTemplate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(commonv1.NetworkPolicySettings)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Auth.DeepCopyInto(&out.Auth)
 	if in.SecureSettings != nil {
 		in, out := &in.SecureSettings, &out.SecureSettings
@@ -202,9 +441,14 @@ func (in *ElasticsearchSpec) DeepCopyInto(out *ElasticsearchSpec) {
 	if in.RemoteClusters != nil {
 		in, out := &in.RemoteClusters, &out.RemoteClusters
 		*out = make([]RemoteCluster, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	in.Monitoring.DeepCopyInto(&out.Monitoring)
+	in.Snapshots.DeepCopyInto(&out.Snapshots)
+	in.IndexManagement.DeepCopyInto(&out.IndexManagement)
+	in.CrossClusterReplication.DeepCopyInto(&out.CrossClusterReplication)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSpec.
@@ -227,6 +471,65 @@ func (in *ElasticsearchStatus) DeepCopyInto(out *ElasticsearchStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.SnapshotRepositories != nil {
+		in, out := &in.SnapshotRepositories, &out.SnapshotRepositories
+		*out = make([]SnapshotRepositoryStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.IndexManagement != nil {
+		in, out := &in.IndexManagement, &out.IndexManagement
+		*out = make([]IndexManagementObjectStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.StackConfigPolicies != nil {
+		in, out := &in.StackConfigPolicies, &out.StackConfigPolicies
+		*out = new(commonv1.PoliciesStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeSnapshot != nil {
+		in, out := &in.UpgradeSnapshot, &out.UpgradeSnapshot
+		*out = new(UpgradeSnapshotStatus)
+		**out = **in
+	}
+	if in.DeprecationCheck != nil {
+		in, out := &in.DeprecationCheck, &out.DeprecationCheck
+		*out = new(DeprecationCheckStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeferredChanges != nil {
+		in, out := &in.DeferredChanges, &out.DeferredChanges
+		*out = make([]DeferredChangesStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TransportCertificates != nil {
+		in, out := &in.TransportCertificates, &out.TransportCertificates
+		*out = new(TransportCertificatesStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemoteClusters != nil {
+		in, out := &in.RemoteClusters, &out.RemoteClusters
+		*out = make([]RemoteClusterStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.FollowerIndices != nil {
+		in, out := &in.FollowerIndices, &out.FollowerIndices
+		*out = make([]FollowerIndexStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSets != nil {
+		in, out := &in.NodeSets, &out.NodeSets
+		*out = make([]NodeSetStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchStatus.
@@ -260,6 +563,26 @@ func (in *EsMonitoringAssociation) DeepCopy() *EsMonitoringAssociation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalRemoteCluster) DeepCopyInto(out *ExternalRemoteCluster) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalRemoteCluster.
+func (in *ExternalRemoteCluster) DeepCopy() *ExternalRemoteCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalRemoteCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FileRealmSource) DeepCopyInto(out *FileRealmSource) {
 	*out = *in
@@ -277,73 +600,212 @@ func (in *FileRealmSource) DeepCopy() *FileRealmSource {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogsMonitoring) DeepCopyInto(out *LogsMonitoring) {
+func (in *FollowerIndex) DeepCopyInto(out *FollowerIndex) {
 	*out = *in
-	if in.ElasticsearchRefs != nil {
-		in, out := &in.ElasticsearchRefs, &out.ElasticsearchRefs
-		*out = make([]commonv1.ObjectSelector, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FollowerIndex.
+func (in *FollowerIndex) DeepCopy() *FollowerIndex {
+	if in == nil {
+		return nil
 	}
+	out := new(FollowerIndex)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogsMonitoring.
-func (in *LogsMonitoring) DeepCopy() *LogsMonitoring {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FollowerIndexStatus) DeepCopyInto(out *FollowerIndexStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FollowerIndexStatus.
+func (in *FollowerIndexStatus) DeepCopy() *FollowerIndexStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(LogsMonitoring)
+	out := new(FollowerIndexStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MetricsMonitoring) DeepCopyInto(out *MetricsMonitoring) {
+func (in *IndexManagement) DeepCopyInto(out *IndexManagement) {
 	*out = *in
-	if in.ElasticsearchRefs != nil {
-		in, out := &in.ElasticsearchRefs, &out.ElasticsearchRefs
-		*out = make([]commonv1.ObjectSelector, len(*in))
-		copy(*out, *in)
+	if in.ILMPolicies != nil {
+		in, out := &in.ILMPolicies, &out.ILMPolicies
+		*out = make([]IndexManagementObject, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ComponentTemplates != nil {
+		in, out := &in.ComponentTemplates, &out.ComponentTemplates
+		*out = make([]IndexManagementObject, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IndexTemplates != nil {
+		in, out := &in.IndexTemplates, &out.IndexTemplates
+		*out = make([]IndexManagementObject, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IngestPipelines != nil {
+		in, out := &in.IngestPipelines, &out.IngestPipelines
+		*out = make([]IndexManagementObject, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsMonitoring.
-func (in *MetricsMonitoring) DeepCopy() *MetricsMonitoring {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexManagement.
+func (in *IndexManagement) DeepCopy() *IndexManagement {
 	if in == nil {
 		return nil
 	}
-	out := new(MetricsMonitoring)
+	out := new(IndexManagement)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Monitoring) DeepCopyInto(out *Monitoring) {
+func (in *IndexManagementObject) DeepCopyInto(out *IndexManagementObject) {
 	*out = *in
-	in.Metrics.DeepCopyInto(&out.Metrics)
-	in.Logs.DeepCopyInto(&out.Logs)
+	if in.Definition != nil {
+		in, out := &in.Definition, &out.Definition
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Monitoring.
-func (in *Monitoring) DeepCopy() *Monitoring {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexManagementObject.
+func (in *IndexManagementObject) DeepCopy() *IndexManagementObject {
 	if in == nil {
 		return nil
 	}
-	out := new(Monitoring)
+	out := new(IndexManagementObject)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Node) DeepCopyInto(out *Node) {
+func (in *IndexManagementObjectStatus) DeepCopyInto(out *IndexManagementObjectStatus) {
 	*out = *in
-	if in.Master != nil {
-		in, out := &in.Master, &out.Master
-		*out = new(bool)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexManagementObjectStatus.
+func (in *IndexManagementObjectStatus) DeepCopy() *IndexManagementObjectStatus {
+	if in == nil {
+		return nil
 	}
-	if in.Data != nil {
-		in, out := &in.Data, &out.Data
-		*out = new(bool)
+	out := new(IndexManagementObjectStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogsMonitoring) DeepCopyInto(out *LogsMonitoring) {
+	*out = *in
+	if in.ElasticsearchRefs != nil {
+		in, out := &in.ElasticsearchRefs, &out.ElasticsearchRefs
+		*out = make([]commonv1.ObjectSelector, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogsMonitoring.
+func (in *LogsMonitoring) DeepCopy() *LogsMonitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(LogsMonitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsMonitoring) DeepCopyInto(out *MetricsMonitoring) {
+	*out = *in
+	if in.ElasticsearchRefs != nil {
+		in, out := &in.ElasticsearchRefs, &out.ElasticsearchRefs
+		*out = make([]commonv1.ObjectSelector, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsMonitoring.
+func (in *MetricsMonitoring) DeepCopy() *MetricsMonitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsMonitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Monitoring) DeepCopyInto(out *Monitoring) {
+	*out = *in
+	in.Metrics.DeepCopyInto(&out.Metrics)
+	in.Logs.DeepCopyInto(&out.Logs)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Monitoring.
+func (in *Monitoring) DeepCopy() *Monitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(Monitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamedAutoscalingPolicy) DeepCopyInto(out *NamedAutoscalingPolicy) {
+	*out = *in
+	in.AutoscalingPolicy.DeepCopyInto(&out.AutoscalingPolicy)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedAutoscalingPolicy.
+func (in *NamedAutoscalingPolicy) DeepCopy() *NamedAutoscalingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NamedAutoscalingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Node) DeepCopyInto(out *Node) {
+	*out = *in
+	if in.Master != nil {
+		in, out := &in.Master, &out.Master
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = new(bool)
 		**out = **in
 	}
 	if in.Ingest != nil {
@@ -376,6 +838,13 @@ func (in *Node) DeepCopyInto(out *Node) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Node.
@@ -403,6 +872,11 @@ func (in *NodeSet) DeepCopyInto(out *NodeSet) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(NodeSetUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSet.
@@ -415,10 +889,125 @@ func (in *NodeSet) DeepCopy() *NodeSet {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetChangeBudget) DeepCopyInto(out *NodeSetChangeBudget) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetChangeBudget.
+func (in *NodeSetChangeBudget) DeepCopy() *NodeSetChangeBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetChangeBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetStatus) DeepCopyInto(out *NodeSetStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetStatus.
+func (in *NodeSetStatus) DeepCopy() *NodeSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetUpdateStrategy) DeepCopyInto(out *NodeSetUpdateStrategy) {
+	*out = *in
+	in.ChangeBudget.DeepCopyInto(&out.ChangeBudget)
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetUpdateStrategy.
+func (in *NodeSetUpdateStrategy) DeepCopy() *NodeSetUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeTransportCertificateStatus) DeepCopyInto(out *NodeTransportCertificateStatus) {
+	*out = *in
+	in.Expiration.DeepCopyInto(&out.Expiration)
+	in.NextRotation.DeepCopyInto(&out.NextRotation)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTransportCertificateStatus.
+func (in *NodeTransportCertificateStatus) DeepCopy() *NodeTransportCertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeTransportCertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuantityRange) DeepCopyInto(out *QuantityRange) {
+	*out = *in
+	out.Min = in.Min.DeepCopy()
+	out.Max = in.Max.DeepCopy()
+	if in.RequestsToLimitsRatio != nil {
+		in, out := &in.RequestsToLimitsRatio, &out.RequestsToLimitsRatio
+		*out = new(float64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuantityRange.
+func (in *QuantityRange) DeepCopy() *QuantityRange {
+	if in == nil {
+		return nil
+	}
+	out := new(QuantityRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoteCluster) DeepCopyInto(out *RemoteCluster) {
 	*out = *in
 	out.ElasticsearchRef = in.ElasticsearchRef
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ExternalRemoteCluster)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Compress != nil {
+		in, out := &in.Compress, &out.Compress
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SkipUnavailable != nil {
+		in, out := &in.SkipUnavailable, &out.SkipUnavailable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NodeConnections != nil {
+		in, out := &in.NodeConnections, &out.NodeConnections
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteCluster.
@@ -431,6 +1020,21 @@ func (in *RemoteCluster) DeepCopy() *RemoteCluster {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteClusterStatus) DeepCopyInto(out *RemoteClusterStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterStatus.
+func (in *RemoteClusterStatus) DeepCopy() *RemoteClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoleSource) DeepCopyInto(out *RoleSource) {
 	*out = *in
@@ -447,6 +1051,171 @@ func (in *RoleSource) DeepCopy() *RoleSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotBeforeUpgrade) DeepCopyInto(out *SnapshotBeforeUpgrade) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotBeforeUpgrade.
+func (in *SnapshotBeforeUpgrade) DeepCopy() *SnapshotBeforeUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotBeforeUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotLifecyclePolicy) DeepCopyInto(out *SnapshotLifecyclePolicy) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = (*in).DeepCopy()
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(SnapshotRetention)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotLifecyclePolicy.
+func (in *SnapshotLifecyclePolicy) DeepCopy() *SnapshotLifecyclePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotLifecyclePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotRepository) DeepCopyInto(out *SnapshotRepository) {
+	*out = *in
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = (*in).DeepCopy()
+	}
+	if in.SecureSettings != nil {
+		in, out := &in.SecureSettings, &out.SecureSettings
+		*out = make([]commonv1.SecretSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRepository.
+func (in *SnapshotRepository) DeepCopy() *SnapshotRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotRepositoryStatus) DeepCopyInto(out *SnapshotRepositoryStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRepositoryStatus.
+func (in *SnapshotRepositoryStatus) DeepCopy() *SnapshotRepositoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotRepositoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotRetention) DeepCopyInto(out *SnapshotRetention) {
+	*out = *in
+	if in.MinCount != nil {
+		in, out := &in.MinCount, &out.MinCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxCount != nil {
+		in, out := &in.MaxCount, &out.MaxCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRetention.
+func (in *SnapshotRetention) DeepCopy() *SnapshotRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Snapshots) DeepCopyInto(out *Snapshots) {
+	*out = *in
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]SnapshotRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]SnapshotLifecyclePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Snapshots.
+func (in *Snapshots) DeepCopy() *Snapshots {
+	if in == nil {
+		return nil
+	}
+	out := new(Snapshots)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransportCertificatesStatus) DeepCopyInto(out *TransportCertificatesStatus) {
+	*out = *in
+	in.CAExpiration.DeepCopyInto(&out.CAExpiration)
+	if in.CANextRotation != nil {
+		in, out := &in.CANextRotation, &out.CANextRotation
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRotation != nil {
+		in, out := &in.NextRotation, &out.NextRotation
+		*out = (*in).DeepCopy()
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodeTransportCertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportCertificatesStatus.
+func (in *TransportCertificatesStatus) DeepCopy() *TransportCertificatesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TransportCertificatesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransportConfig) DeepCopyInto(out *TransportConfig) {
 	*out = *in
@@ -473,6 +1242,11 @@ func (in *TransportTLSOptions) DeepCopyInto(out *TransportTLSOptions) {
 		copy(*out, *in)
 	}
 	out.Certificate = in.Certificate
+	if in.Issuer != nil {
+		in, out := &in.Issuer, &out.Issuer
+		*out = new(commonv1.CertificateIssuer)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportTLSOptions.
@@ -489,6 +1263,16 @@ func (in *TransportTLSOptions) DeepCopy() *TransportTLSOptions {
 func (in *UpdateStrategy) DeepCopyInto(out *UpdateStrategy) {
 	*out = *in
 	in.ChangeBudget.DeepCopyInto(&out.ChangeBudget)
+	if in.SnapshotBeforeUpgrade != nil {
+		in, out := &in.SnapshotBeforeUpgrade, &out.SnapshotBeforeUpgrade
+		*out = new(SnapshotBeforeUpgrade)
+		**out = **in
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateStrategy.
@@ -501,6 +1285,21 @@ func (in *UpdateStrategy) DeepCopy() *UpdateStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeSnapshotStatus) DeepCopyInto(out *UpgradeSnapshotStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeSnapshotStatus.
+func (in *UpgradeSnapshotStatus) DeepCopy() *UpgradeSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ZenDiscoveryStatus) DeepCopyInto(out *ZenDiscoveryStatus) {
 	*out = *in
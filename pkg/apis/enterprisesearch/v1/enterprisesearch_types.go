@@ -34,6 +34,11 @@ type EnterpriseSearchSpec struct {
 	// Count of Enterprise Search instances to deploy.
 	Count int32 `json:"count,omitempty"`
 
+	// Autoscaling configures a HorizontalPodAutoscaler managing the number of Enterprise Search instances,
+	// in which case Count is ignored.
+	// +kubebuilder:validation:Optional
+	Autoscaling *commonv1.AutoscalingSpec `json:"autoscaling,omitempty"`
+
 	// Config holds the Enterprise Search configuration.
 	// +kubebuilder:pruning:PreserveUnknownFields
 	Config *commonv1.Config `json:"config,omitempty"`
@@ -55,6 +60,16 @@ type EnterpriseSearchSpec struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	PodTemplate corev1.PodTemplateSpec `json:"podTemplate,omitempty"`
 
+	// PodDisruptionBudget provides access to the default Pod disruption budget for the Enterprise Search Pods.
+	// The default budget selects all the Enterprise Search Pods and sets `maxUnavailable` to 1 when there is more than one Pod.
+	// To disable, set `PodDisruptionBudget` to the empty value (`{}` in YAML).
+	// +kubebuilder:validation:Optional
+	PodDisruptionBudget *commonv1.PodDisruptionBudgetTemplate `json:"podDisruptionBudget,omitempty"`
+
+	// NetworkPolicy controls the NetworkPolicies managed by the operator to allow the traffic to the Enterprise Search Pods.
+	// +kubebuilder:validation:Optional
+	NetworkPolicy *commonv1.NetworkPolicySettings `json:"networkPolicy,omitempty"`
+
 	// ServiceAccountName is used to check access from the current resource to a resource (eg. Elasticsearch) in a different namespace.
 	// Can only be used if ECK is enforcing RBAC on references.
 	// +optional
@@ -79,6 +94,11 @@ func (ent *EnterpriseSearch) ServiceAccountName() string {
 	return ent.Spec.ServiceAccountName
 }
 
+// GetNetworkPolicySettings returns the NetworkPolicy settings of the Enterprise Search instance.
+func (ent *EnterpriseSearch) GetNetworkPolicySettings() *commonv1.NetworkPolicySettings {
+	return ent.Spec.NetworkPolicy
+}
+
 func (ent *EnterpriseSearch) Associated() commonv1.Associated {
 	if ent != nil {
 		return ent
@@ -26,6 +26,7 @@ var (
 		checkNoUnknownFields,
 		checkNameLength,
 		checkSupportedVersion,
+		checkAutoscaling,
 	}
 
 	updateChecks = []func(old, curr *EnterpriseSearch) field.ErrorList{
@@ -104,3 +105,7 @@ func checkSupportedVersion(ent *EnterpriseSearch) field.ErrorList {
 func checkNoDowngrade(prev, curr *EnterpriseSearch) field.ErrorList {
 	return commonv1.CheckNoDowngrade(prev.Spec.Version, curr.Spec.Version)
 }
+
+func checkAutoscaling(ent *EnterpriseSearch) field.ErrorList {
+	return commonv1.CheckAutoscaling(ent.Spec.Autoscaling)
+}
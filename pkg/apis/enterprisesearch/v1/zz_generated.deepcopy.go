@@ -20,7 +20,7 @@ func (in *EnterpriseSearch) DeepCopyInto(out *EnterpriseSearch) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	if in.assocConf != nil {
 		in, out := &in.assocConf, &out.assocConf
 		*out = new(commonv1.AssociationConf)
@@ -81,6 +81,11 @@ func (in *EnterpriseSearchList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnterpriseSearchSpec) DeepCopyInto(out *EnterpriseSearchSpec) {
 	*out = *in
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(commonv1.AutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Config != nil {
 		in, out := &in.Config, &out.Config
 		*out = (*in).DeepCopy()
@@ -93,6 +98,16 @@ func (in *EnterpriseSearchSpec) DeepCopyInto(out *EnterpriseSearchSpec) {
 	in.HTTP.DeepCopyInto(&out.HTTP)
 	out.ElasticsearchRef = in.ElasticsearchRef
 	in.PodTemplate.DeepCopyInto(&out.PodTemplate)
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(commonv1.PodDisruptionBudgetTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(commonv1.NetworkPolicySettings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnterpriseSearchSpec.
@@ -108,7 +123,7 @@ func (in *EnterpriseSearchSpec) DeepCopy() *EnterpriseSearchSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnterpriseSearchStatus) DeepCopyInto(out *EnterpriseSearchStatus) {
 	*out = *in
-	out.DeploymentStatus = in.DeploymentStatus
+	in.DeploymentStatus.DeepCopyInto(&out.DeploymentStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnterpriseSearchStatus.
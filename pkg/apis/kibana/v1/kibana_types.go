@@ -70,6 +70,20 @@ type KibanaSpec struct {
 	// Count of Kibana instances to deploy.
 	Count int32 `json:"count,omitempty"`
 
+	// Autoscaling configures a HorizontalPodAutoscaler managing the number of Kibana instances,
+	// in which case Count is ignored.
+	// +kubebuilder:validation:Optional
+	Autoscaling *commonv1.AutoscalingSpec `json:"autoscaling,omitempty"`
+
+	// UpgradeStrategy controls how Kibana instances are upgraded to a new version. Recreate, the default, stops all
+	// the instances before starting the new version. Rolling first starts a single instance of the new version, waits
+	// for it to migrate the saved objects, then replaces the remaining instances through a rolling update.
+	// Rolling requires ElasticsearchRef to be set and Kibana 7.12.0 or above. It falls back to Recreate otherwise, or if
+	// the migration of the saved objects fails.
+	// +kubebuilder:validation:Enum=Recreate;Rolling
+	// +kubebuilder:validation:Optional
+	UpgradeStrategy UpgradeStrategyType `json:"upgradeStrategy,omitempty"`
+
 	// ElasticsearchRef is a reference to an Elasticsearch cluster running in the same Kubernetes cluster.
 	ElasticsearchRef commonv1.ObjectSelector `json:"elasticsearchRef,omitempty"`
 
@@ -89,6 +103,16 @@ type KibanaSpec struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	PodTemplate corev1.PodTemplateSpec `json:"podTemplate,omitempty"`
 
+	// PodDisruptionBudget provides access to the default Pod disruption budget for the Kibana Pods.
+	// The default budget selects all the Kibana Pods and sets `maxUnavailable` to 1 when there is more than one Pod.
+	// To disable, set `PodDisruptionBudget` to the empty value (`{}` in YAML).
+	// +kubebuilder:validation:Optional
+	PodDisruptionBudget *commonv1.PodDisruptionBudgetTemplate `json:"podDisruptionBudget,omitempty"`
+
+	// NetworkPolicy controls the NetworkPolicies managed by the operator to allow the traffic to the Kibana Pods.
+	// +kubebuilder:validation:Optional
+	NetworkPolicy *commonv1.NetworkPolicySettings `json:"networkPolicy,omitempty"`
+
 	// SecureSettings is a list of references to Kubernetes secrets containing sensitive configuration options for Kibana.
 	SecureSettings []commonv1.SecretSource `json:"secureSettings,omitempty"`
 
@@ -103,6 +127,121 @@ type KibanaSpec struct {
 	// Elasticsearch monitoring clusters running in the same Kubernetes cluster.
 	// +kubebuilder:validation:Optional
 	Monitoring Monitoring `json:"monitoring,omitempty"`
+
+	// Spaces holds the Kibana spaces created and updated by the operator through the Kibana API.
+	// Spaces removed from this list are left untouched in Kibana, to preserve the saved objects they contain.
+	// Requires ElasticsearchRef to be set.
+	// +kubebuilder:validation:Optional
+	Spaces []Space `json:"spaces,omitempty"`
+
+	// Fleet holds the Fleet agent policies created and updated by the operator through the Kibana API.
+	// Requires ElasticsearchRef to be set.
+	// +kubebuilder:validation:Optional
+	Fleet *FleetSpec `json:"fleet,omitempty"`
+}
+
+// Space is a Kibana space. See https://www.elastic.co/guide/en/kibana/current/xpack-spaces.html.
+type Space struct {
+	// ID of the space, used in its URL.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9_-]+$`
+	ID string `json:"id"`
+
+	// Name of the space.
+	Name string `json:"name"`
+
+	// Description of the space.
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+
+	// Color of the space avatar, as a hex code.
+	// +kubebuilder:validation:Optional
+	Color string `json:"color,omitempty"`
+
+	// Initials displayed in the space avatar.
+	// +kubebuilder:validation:Optional
+	Initials string `json:"initials,omitempty"`
+
+	// DisabledFeatures is the list of Kibana features disabled in the space.
+	// +kubebuilder:validation:Optional
+	DisabledFeatures []string `json:"disabledFeatures,omitempty"`
+}
+
+// FleetSpec holds the Fleet resources managed by the operator.
+type FleetSpec struct {
+	// AgentPolicies is the list of agent policies managed by the operator. Agent policies previously created by the
+	// operator and removed from this list are deleted from Fleet.
+	// +kubebuilder:validation:Optional
+	AgentPolicies []AgentPolicy `json:"agentPolicies,omitempty"`
+}
+
+// AgentPolicy is a Fleet agent policy, referenced by Elastic Agents to enroll in Fleet.
+type AgentPolicy struct {
+	// ID of the agent policy, referenced by the policyID of the Agent resources.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_-]+$`
+	ID string `json:"id"`
+
+	// Name of the agent policy.
+	Name string `json:"name"`
+
+	// Namespace of the data streams the Agents using this policy write to. Defaults to `default`.
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Description of the agent policy.
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+
+	// MonitoringEnabled is the list of monitoring data collected from the Agents using this policy (`logs`, `metrics`).
+	// +kubebuilder:validation:Optional
+	MonitoringEnabled []string `json:"monitoringEnabled,omitempty"`
+
+	// IsDefaultFleetServer marks the policy as the default one for Fleet Server.
+	// +kubebuilder:validation:Optional
+	IsDefaultFleetServer bool `json:"isDefaultFleetServer,omitempty"`
+
+	// PackagePolicies is the list of package policies (integrations) added to the agent policy.
+	// +kubebuilder:validation:Optional
+	PackagePolicies []PackagePolicy `json:"packagePolicies,omitempty"`
+}
+
+// PackagePolicy is a Fleet package policy, the configuration of an integration in an agent policy.
+type PackagePolicy struct {
+	// ID of the package policy.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_-]+$`
+	ID string `json:"id"`
+
+	// Name of the package policy, unique in Fleet.
+	Name string `json:"name"`
+
+	// Package is the integration package configured by this policy. It is installed if needed.
+	Package PackageReference `json:"package"`
+
+	// Namespace of the data streams of the package policy. Defaults to the namespace of the agent policy.
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Description of the package policy.
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+
+	// Inputs holds the configuration of the package inputs, in the simplified format of the Fleet package policies API.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Inputs *commonv1.Config `json:"inputs,omitempty"`
+
+	// Vars holds the package level variables, in the simplified format of the Fleet package policies API.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Vars *commonv1.Config `json:"vars,omitempty"`
+}
+
+// PackageReference identifies a Fleet integration package.
+type PackageReference struct {
+	// Name of the package.
+	Name string `json:"name"`
+
+	// Version of the package.
+	Version string `json:"version"`
 }
 
 type Monitoring struct {
@@ -140,6 +279,78 @@ type KibanaStatus struct {
 	EnterpriseSearchAssociationStatus commonv1.AssociationStatus `json:"enterpriseSearchAssociationStatus,omitempty"`
 	// MonitoringAssociationStatus is the status of any auto-linking to monitoring Elasticsearch clusters.
 	MonitoringAssociationStatus commonv1.AssociationStatusMap `json:"monitoringAssociationStatus,omitempty"`
+	// StackConfigPolicies describes the StackConfigPolicies applied to this Kibana instance and the conflicts of their settings.
+	StackConfigPolicies *commonv1.PoliciesStatus `json:"stackConfigPolicies,omitempty"`
+	// ApplicationStatus is the status reported by the Kibana status API. It is only available if Kibana is associated
+	// with an Elasticsearch cluster.
+	ApplicationStatus *ApplicationStatus `json:"applicationStatus,omitempty"`
+	// Upgrade describes the progress of the version upgrade in progress, if any.
+	Upgrade *UpgradeStatus `json:"upgrade,omitempty"`
+}
+
+// UpgradeStrategyType is the strategy used to upgrade Kibana instances to a new version.
+type UpgradeStrategyType string
+
+const (
+	// RecreateUpgradeStrategy stops all the Kibana instances before starting the new version.
+	RecreateUpgradeStrategy UpgradeStrategyType = "Recreate"
+	// RollingUpgradeStrategy starts a single instance of the new version to migrate the saved objects before replacing
+	// the remaining instances through a rolling update.
+	RollingUpgradeStrategy UpgradeStrategyType = "Rolling"
+)
+
+// UpgradePhase is the phase of a Kibana version upgrade.
+type UpgradePhase string
+
+const (
+	// UpgradeMigratingSavedObjects means that a single instance of the new version is migrating the saved objects.
+	UpgradeMigratingSavedObjects UpgradePhase = "MigratingSavedObjects"
+	// UpgradeRollingOut means that the saved objects are migrated and the remaining instances are being replaced
+	// through a rolling update.
+	UpgradeRollingOut UpgradePhase = "RollingOut"
+	// UpgradeRecreating means that all the instances are stopped before starting the new version.
+	UpgradeRecreating UpgradePhase = "Recreating"
+)
+
+// UpgradeStatus describes the progress of a Kibana version upgrade.
+type UpgradeStatus struct {
+	// TargetVersion is the version Kibana is upgraded to.
+	TargetVersion string `json:"targetVersion"`
+
+	// Phase is the current phase of the upgrade.
+	Phase UpgradePhase `json:"phase"`
+
+	// Message gives details about the phase, such as the reason for falling back to recreating all the instances.
+	Message string `json:"message,omitempty"`
+}
+
+// ApplicationStatusLevel is the overall level of a Kibana instance as reported by its status API.
+type ApplicationStatusLevel string
+
+const (
+	// ApplicationAvailable means that Kibana is fully functional.
+	ApplicationAvailable ApplicationStatusLevel = "available"
+	// ApplicationDegraded means that some features of Kibana may not be working.
+	ApplicationDegraded ApplicationStatusLevel = "degraded"
+	// ApplicationUnavailable means that Kibana is not functional, or that its status cannot be retrieved.
+	ApplicationUnavailable ApplicationStatusLevel = "unavailable"
+	// ApplicationCritical means that Kibana is not functional and requires an intervention.
+	ApplicationCritical ApplicationStatusLevel = "critical"
+	// ApplicationUnknown means that the operator cannot call the Kibana status API, for example because the
+	// association of Kibana with Elasticsearch is not configured yet.
+	ApplicationUnknown ApplicationStatusLevel = "unknown"
+)
+
+// ApplicationStatus is the status of Kibana as reported by its status API.
+type ApplicationStatus struct {
+	// Level is the overall level of Kibana: available, degraded, unavailable, critical or unknown.
+	Level ApplicationStatusLevel `json:"level,omitempty"`
+
+	// Summary describes the overall level.
+	Summary string `json:"summary,omitempty"`
+
+	// DegradedPlugins lists the plugins which are not available.
+	DegradedPlugins []string `json:"degradedPlugins,omitempty"`
 }
 
 // IsMarkedForDeletion returns true if the Kibana is going to be deleted
@@ -155,6 +366,11 @@ func (k *Kibana) ServiceAccountName() string {
 	return k.Spec.ServiceAccountName
 }
 
+// GetNetworkPolicySettings returns the NetworkPolicy settings of the Kibana instance.
+func (k *Kibana) GetNetworkPolicySettings() *commonv1.NetworkPolicySettings {
+	return k.Spec.NetworkPolicy
+}
+
 // -- associations
 
 var _ commonv1.Associated = &Kibana{}
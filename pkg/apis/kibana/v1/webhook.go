@@ -20,7 +20,16 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
+const (
+	managedObjectsRequireEsRefMsg = "Kibana must be associated with an Elasticsearch cluster to manage spaces or Fleet policies"
+	fleetPoliciesUnsupportedMsg   = "Fleet policies are only supported for Kibana version 8.0.0 and above"
+)
+
 var (
+	// fleetPoliciesMinVersion is the minimum version of Kibana supporting the management of Fleet policies by the
+	// operator, which relies on agent and package policies created with a given ID.
+	fleetPoliciesMinVersion = version.From(8, 0, 0)
+
 	groupKind     = schema.GroupKind{Group: GroupVersion.Group, Kind: Kind}
 	validationLog = ulog.Log.WithName("kibana-v1-validation")
 
@@ -29,6 +38,9 @@ var (
 		checkNameLength,
 		checkSupportedVersion,
 		checkMonitoring,
+		checkAutoscaling,
+		checkSpaces,
+		checkFleet,
 	}
 
 	updateChecks = []func(old, curr *Kibana) field.ErrorList{
@@ -117,3 +129,63 @@ func checkMonitoring(k *Kibana) field.ErrorList {
 	}
 	return errs
 }
+
+func checkAutoscaling(k *Kibana) field.ErrorList {
+	return commonv1.CheckAutoscaling(k.Spec.Autoscaling)
+}
+
+func checkSpaces(k *Kibana) field.ErrorList {
+	if len(k.Spec.Spaces) == 0 {
+		return nil
+	}
+	var errs field.ErrorList
+	if !k.Spec.ElasticsearchRef.IsDefined() {
+		errs = append(errs, field.Required(field.NewPath("spec").Child("elasticsearchRef"), managedObjectsRequireEsRefMsg))
+	}
+	ids := make(map[string]struct{}, len(k.Spec.Spaces))
+	for i, space := range k.Spec.Spaces {
+		if _, exists := ids[space.ID]; exists {
+			errs = append(errs, field.Duplicate(field.NewPath("spec").Child("spaces").Index(i).Child("id"), space.ID))
+		}
+		ids[space.ID] = struct{}{}
+	}
+	return errs
+}
+
+func checkFleet(k *Kibana) field.ErrorList {
+	if k.Spec.Fleet == nil || len(k.Spec.Fleet.AgentPolicies) == 0 {
+		return nil
+	}
+	var errs field.ErrorList
+	fleetPath := field.NewPath("spec").Child("fleet")
+	if !k.Spec.ElasticsearchRef.IsDefined() {
+		errs = append(errs, field.Required(field.NewPath("spec").Child("elasticsearchRef"), managedObjectsRequireEsRefMsg))
+	}
+	if v, err := version.Parse(k.Spec.Version); err == nil && !v.GTE(fleetPoliciesMinVersion) {
+		errs = append(errs, field.Forbidden(fleetPath, fleetPoliciesUnsupportedMsg))
+	}
+
+	policyIDs := map[string]struct{}{}
+	packagePolicyIDs := map[string]struct{}{}
+	packagePolicyNames := map[string]struct{}{}
+	for i, policy := range k.Spec.Fleet.AgentPolicies {
+		policyPath := fleetPath.Child("agentPolicies").Index(i)
+		if _, exists := policyIDs[policy.ID]; exists {
+			errs = append(errs, field.Duplicate(policyPath.Child("id"), policy.ID))
+		}
+		policyIDs[policy.ID] = struct{}{}
+		for j, packagePolicy := range policy.PackagePolicies {
+			packagePolicyPath := policyPath.Child("packagePolicies").Index(j)
+			if _, exists := packagePolicyIDs[packagePolicy.ID]; exists {
+				errs = append(errs, field.Duplicate(packagePolicyPath.Child("id"), packagePolicy.ID))
+			}
+			packagePolicyIDs[packagePolicy.ID] = struct{}{}
+			// package policy names are unique across all agent policies in Fleet
+			if _, exists := packagePolicyNames[packagePolicy.Name]; exists {
+				errs = append(errs, field.Duplicate(packagePolicyPath.Child("name"), packagePolicy.Name))
+			}
+			packagePolicyNames[packagePolicy.Name] = struct{}{}
+		}
+	}
+	return errs
+}
@@ -9,7 +9,9 @@ import (
 	"strings"
 	"testing"
 
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
 	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/pointer"
 	"github.com/elastic/cloud-on-k8s/pkg/utils/test"
 	"github.com/stretchr/testify/require"
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
@@ -97,6 +99,99 @@ func TestWebhook(t *testing.T) {
 				`spec.version: Invalid value: "300.1.2": Unsupported version: version 300.1.2 is higher than the highest supported version`,
 			),
 		},
+		{
+			Name:      "invalid-autoscaling",
+			Operation: admissionv1beta1.Create,
+			Object: func(t *testing.T, uid string) []byte {
+				t.Helper()
+				k := mkKibana(uid)
+				k.Spec.Autoscaling = &commonv1.AutoscalingSpec{MinReplicas: pointer.Int32(3), MaxReplicas: 2}
+				return serialize(t, k)
+			},
+			Check: test.ValidationWebhookFailed(
+				`spec.autoscaling.maxReplicas: Invalid value: 2: maxReplicas must be greater than or equal to minReplicas`,
+			),
+		},
+		{
+			Name:      "valid-spaces-and-fleet",
+			Operation: admissionv1beta1.Create,
+			Object: func(t *testing.T, uid string) []byte {
+				t.Helper()
+				k := mkKibana(uid)
+				k.Spec.Version = "8.1.0"
+				k.Spec.ElasticsearchRef = commonv1.ObjectSelector{Name: "es"}
+				k.Spec.Spaces = []kbv1.Space{{ID: "dev", Name: "Dev"}, {ID: "prod", Name: "Prod"}}
+				k.Spec.Fleet = &kbv1.FleetSpec{AgentPolicies: []kbv1.AgentPolicy{
+					{ID: "fleet-server", Name: "Fleet Server", PackagePolicies: []kbv1.PackagePolicy{
+						{ID: "fleet-server-1", Name: "fleet-server-1", Package: kbv1.PackageReference{Name: "fleet_server", Version: "1.1.1"}},
+					}},
+					{ID: "agents", Name: "Agents", PackagePolicies: []kbv1.PackagePolicy{
+						{ID: "system-1", Name: "system-1", Package: kbv1.PackageReference{Name: "system", Version: "1.13.0"}},
+					}},
+				}}
+				return serialize(t, k)
+			},
+			Check: test.ValidationWebhookSucceeded,
+		},
+		{
+			Name:      "spaces-without-elasticsearch-ref",
+			Operation: admissionv1beta1.Create,
+			Object: func(t *testing.T, uid string) []byte {
+				t.Helper()
+				k := mkKibana(uid)
+				k.Spec.Spaces = []kbv1.Space{{ID: "dev", Name: "Dev"}}
+				return serialize(t, k)
+			},
+			Check: test.ValidationWebhookFailed(
+				`spec.elasticsearchRef: Required value: Kibana must be associated with an Elasticsearch cluster to manage spaces or Fleet policies`,
+			),
+		},
+		{
+			Name:      "duplicate-space-id",
+			Operation: admissionv1beta1.Create,
+			Object: func(t *testing.T, uid string) []byte {
+				t.Helper()
+				k := mkKibana(uid)
+				k.Spec.ElasticsearchRef = commonv1.ObjectSelector{Name: "es"}
+				k.Spec.Spaces = []kbv1.Space{{ID: "dev", Name: "Dev"}, {ID: "dev", Name: "Dev 2"}}
+				return serialize(t, k)
+			},
+			Check: test.ValidationWebhookFailed(
+				`spec.spaces\[1\].id: Duplicate value: "dev"`,
+			),
+		},
+		{
+			Name:      "fleet-unsupported-version",
+			Operation: admissionv1beta1.Create,
+			Object: func(t *testing.T, uid string) []byte {
+				t.Helper()
+				k := mkKibana(uid)
+				k.Spec.ElasticsearchRef = commonv1.ObjectSelector{Name: "es"}
+				k.Spec.Fleet = &kbv1.FleetSpec{AgentPolicies: []kbv1.AgentPolicy{{ID: "agents", Name: "Agents"}}}
+				return serialize(t, k)
+			},
+			Check: test.ValidationWebhookFailed(
+				`spec.fleet: Forbidden: Fleet policies are only supported for Kibana version 8.0.0 and above`,
+			),
+		},
+		{
+			Name:      "duplicate-package-policy-name",
+			Operation: admissionv1beta1.Create,
+			Object: func(t *testing.T, uid string) []byte {
+				t.Helper()
+				k := mkKibana(uid)
+				k.Spec.Version = "8.1.0"
+				k.Spec.ElasticsearchRef = commonv1.ObjectSelector{Name: "es"}
+				k.Spec.Fleet = &kbv1.FleetSpec{AgentPolicies: []kbv1.AgentPolicy{
+					{ID: "a", Name: "A", PackagePolicies: []kbv1.PackagePolicy{{ID: "system-a", Name: "system"}}},
+					{ID: "b", Name: "B", PackagePolicies: []kbv1.PackagePolicy{{ID: "system-b", Name: "system"}}},
+				}}
+				return serialize(t, k)
+			},
+			Check: test.ValidationWebhookFailed(
+				`spec.fleet.agentPolicies\[1\].packagePolicies\[0\].name: Duplicate value: "system"`,
+			),
+		},
 		{
 			Name:      "update-valid",
 			Operation: admissionv1beta1.Update,
@@ -15,6 +15,75 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentPolicy) DeepCopyInto(out *AgentPolicy) {
+	*out = *in
+	if in.MonitoringEnabled != nil {
+		in, out := &in.MonitoringEnabled, &out.MonitoringEnabled
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PackagePolicies != nil {
+		in, out := &in.PackagePolicies, &out.PackagePolicies
+		*out = make([]PackagePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentPolicy.
+func (in *AgentPolicy) DeepCopy() *AgentPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationStatus) DeepCopyInto(out *ApplicationStatus) {
+	*out = *in
+	if in.DegradedPlugins != nil {
+		in, out := &in.DegradedPlugins, &out.DegradedPlugins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationStatus.
+func (in *ApplicationStatus) DeepCopy() *ApplicationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetSpec) DeepCopyInto(out *FleetSpec) {
+	*out = *in
+	if in.AgentPolicies != nil {
+		in, out := &in.AgentPolicies, &out.AgentPolicies
+		*out = make([]AgentPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetSpec.
+func (in *FleetSpec) DeepCopy() *FleetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KbMonitoringAssociation) DeepCopyInto(out *KbMonitoringAssociation) {
 	*out = *in
@@ -155,6 +224,11 @@ func (in *KibanaList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KibanaSpec) DeepCopyInto(out *KibanaSpec) {
 	*out = *in
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(commonv1.AutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	out.ElasticsearchRef = in.ElasticsearchRef
 	out.EnterpriseSearchRef = in.EnterpriseSearchRef
 	if in.Config != nil {
@@ -163,6 +237,16 @@ func (in *KibanaSpec) DeepCopyInto(out *KibanaSpec) {
 	}
 	in.HTTP.DeepCopyInto(&out.HTTP)
 	in.PodTemplate.DeepCopyInto(&out.PodTemplate)
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(commonv1.PodDisruptionBudgetTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(commonv1.NetworkPolicySettings)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SecureSettings != nil {
 		in, out := &in.SecureSettings, &out.SecureSettings
 		*out = make([]commonv1.SecretSource, len(*in))
@@ -171,6 +255,18 @@ func (in *KibanaSpec) DeepCopyInto(out *KibanaSpec) {
 		}
 	}
 	in.Monitoring.DeepCopyInto(&out.Monitoring)
+	if in.Spaces != nil {
+		in, out := &in.Spaces, &out.Spaces
+		*out = make([]Space, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Fleet != nil {
+		in, out := &in.Fleet, &out.Fleet
+		*out = new(FleetSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaSpec.
@@ -186,7 +282,7 @@ func (in *KibanaSpec) DeepCopy() *KibanaSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KibanaStatus) DeepCopyInto(out *KibanaStatus) {
 	*out = *in
-	out.DeploymentStatus = in.DeploymentStatus
+	in.DeploymentStatus.DeepCopyInto(&out.DeploymentStatus)
 	if in.MonitoringAssociationStatus != nil {
 		in, out := &in.MonitoringAssociationStatus, &out.MonitoringAssociationStatus
 		*out = make(commonv1.AssociationStatusMap, len(*in))
@@ -194,6 +290,21 @@ func (in *KibanaStatus) DeepCopyInto(out *KibanaStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.StackConfigPolicies != nil {
+		in, out := &in.StackConfigPolicies, &out.StackConfigPolicies
+		*out = new(commonv1.PoliciesStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApplicationStatus != nil {
+		in, out := &in.ApplicationStatus, &out.ApplicationStatus
+		*out = new(ApplicationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(UpgradeStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaStatus.
@@ -262,3 +373,77 @@ func (in *Monitoring) DeepCopy() *Monitoring {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackagePolicy) DeepCopyInto(out *PackagePolicy) {
+	*out = *in
+	out.Package = in.Package
+	if in.Inputs != nil {
+		in, out := &in.Inputs, &out.Inputs
+		*out = (*in).DeepCopy()
+	}
+	if in.Vars != nil {
+		in, out := &in.Vars, &out.Vars
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackagePolicy.
+func (in *PackagePolicy) DeepCopy() *PackagePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PackagePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageReference) DeepCopyInto(out *PackageReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageReference.
+func (in *PackageReference) DeepCopy() *PackageReference {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Space) DeepCopyInto(out *Space) {
+	*out = *in
+	if in.DisabledFeatures != nil {
+		in, out := &in.DisabledFeatures, &out.DisabledFeatures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Space.
+func (in *Space) DeepCopy() *Space {
+	if in == nil {
+		return nil
+	}
+	out := new(Space)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStatus) DeepCopyInto(out *UpgradeStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStatus.
+func (in *UpgradeStatus) DeepCopy() *UpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,282 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+)
+
+const (
+	// Kind is inferred from the struct name using reflection in SchemeBuilder.Register()
+	// we duplicate it as a constant here for practical purposes.
+	Kind = "Logstash"
+)
+
+// LogstashSpec defines the desired state of Logstash
+type LogstashSpec struct {
+	// Version of the Logstash.
+	Version string `json:"version"`
+
+	// Count of Logstash instances to deploy.
+	// +kubebuilder:validation:Optional
+	Count int32 `json:"count,omitempty"`
+
+	// Image is the Logstash Docker image to deploy. Version has to match the Logstash in the image.
+	// +kubebuilder:validation:Optional
+	Image string `json:"image,omitempty"`
+
+	// ElasticsearchRefs are references to Elasticsearch clusters running in the same Kubernetes cluster.
+	// The connection settings of each cluster are exposed to the Logstash pipelines through environment variables
+	// prefixed with the uppercased `clusterName` of the reference, for example `${MY_CLUSTER_ES_HOSTS}`.
+	// +kubebuilder:validation:Optional
+	ElasticsearchRefs []ElasticsearchCluster `json:"elasticsearchRefs,omitempty"`
+
+	// Config holds the Logstash configuration (logstash.yml). At most one of [`Config`, `ConfigRef`] can be specified.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Config *commonv1.Config `json:"config,omitempty"`
+
+	// ConfigRef contains a reference to an existing Kubernetes Secret holding the Logstash configuration.
+	// Logstash settings must be specified as yaml, under a single "logstash.yml" entry. At most one of [`Config`, `ConfigRef`]
+	// can be specified.
+	// +kubebuilder:validation:Optional
+	ConfigRef *commonv1.ConfigSource `json:"configRef,omitempty"`
+
+	// Pipelines holds the Logstash pipelines definitions (pipelines.yml). At most one of [`Pipelines`, `PipelinesRef`]
+	// can be specified.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Pipelines []commonv1.Config `json:"pipelines,omitempty"`
+
+	// PipelinesRef contains a reference to an existing Kubernetes Secret holding the Logstash pipelines definitions.
+	// Pipelines must be specified as yaml, under a single "pipelines.yml" entry. At most one of [`Pipelines`, `PipelinesRef`]
+	// can be specified.
+	// +kubebuilder:validation:Optional
+	PipelinesRef *commonv1.ConfigSource `json:"pipelinesRef,omitempty"`
+
+	// VolumeClaimTemplates is a list of persistent volume claims to be used by each Pod.
+	// A claim named `logstash-data` is mounted as the Logstash data directory, which holds persistent queues and
+	// dead letter queues. Other claims must be mounted through the PodTemplate.
+	// This field cannot be modified once the resource is created.
+	// +kubebuilder:validation:Optional
+	VolumeClaimTemplates []corev1.PersistentVolumeClaim `json:"volumeClaimTemplates,omitempty"`
+
+	// SecureSettings is a list of references to Kubernetes Secrets containing sensitive configuration options for Logstash.
+	// Secrets data can be then referenced in the Logstash config and pipelines using the Secret's keys or as specified
+	// in `Entries` field of each SecureSetting.
+	// +kubebuilder:validation:Optional
+	SecureSettings []commonv1.SecretSource `json:"secureSettings,omitempty"`
+
+	// ServiceAccountName is used to check access from the current resource to an Elasticsearch resource in a different namespace.
+	// Can only be used if ECK is enforcing RBAC on references.
+	// +kubebuilder:validation:Optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// PodTemplate provides customisation options for the Logstash pods.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	PodTemplate corev1.PodTemplateSpec `json:"podTemplate,omitempty"`
+
+	// UpdateStrategy is a StatefulSetUpdateStrategy. The default type is "RollingUpdate".
+	// +kubebuilder:validation:Optional
+	UpdateStrategy appsv1.StatefulSetUpdateStrategy `json:"updateStrategy,omitempty"`
+}
+
+// ElasticsearchCluster is a reference to an Elasticsearch cluster, named so that its connection settings can be
+// referenced from the Logstash pipelines.
+type ElasticsearchCluster struct {
+	commonv1.ObjectSelector `json:",omitempty,inline"`
+
+	// ClusterName is an alias for the cluster, used as a prefix of the environment variables holding the cluster
+	// connection settings. It must only contain letters, digits and underscores.
+	// +kubebuilder:validation:Required
+	ClusterName string `json:"clusterName,omitempty"`
+}
+
+// LogstashStatus defines the observed state of Logstash
+type LogstashStatus struct {
+	// Version of the stack resource currently running. During version upgrades, multiple versions may run
+	// in parallel: this value specifies the lowest version currently running.
+	Version string `json:"version,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	ExpectedNodes int32 `json:"expectedNodes,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	AvailableNodes int32 `json:"availableNodes,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Health LogstashHealth `json:"health,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	ElasticsearchAssociationsStatus commonv1.AssociationStatusMap `json:"elasticsearchAssociationsStatus,omitempty"`
+}
+
+type LogstashHealth string
+
+const (
+	// LogstashRedHealth means that the health is neither yellow nor green.
+	LogstashRedHealth LogstashHealth = "red"
+
+	// LogstashYellowHealth means that:
+	// 1) at least one Pod is Ready, and
+	// 2) associations are not configured, or configured and established
+	LogstashYellowHealth LogstashHealth = "yellow"
+
+	// LogstashGreenHealth means that:
+	// 1) all Pods are Ready, and
+	// 2) associations are not configured, or configured and established
+	LogstashGreenHealth LogstashHealth = "green"
+)
+
+// +kubebuilder:object:root=true
+
+// Logstash is the Schema for the Logstashes API.
+// +kubebuilder:resource:categories=elastic,shortName=ls
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="health",type="string",JSONPath=".status.health"
+// +kubebuilder:printcolumn:name="available",type="integer",JSONPath=".status.availableNodes",description="Available nodes"
+// +kubebuilder:printcolumn:name="expected",type="integer",JSONPath=".status.expectedNodes",description="Expected nodes"
+// +kubebuilder:printcolumn:name="version",type="string",JSONPath=".status.version",description="Logstash version"
+// +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:storageversion
+type Logstash struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec         LogstashSpec                                      `json:"spec,omitempty"`
+	Status       LogstashStatus                                    `json:"status,omitempty"`
+	esAssocConfs map[types.NamespacedName]commonv1.AssociationConf `json:"-"`
+}
+
+var _ commonv1.Associated = &Logstash{}
+
+func (l *Logstash) GetAssociations() []commonv1.Association {
+	associations := make([]commonv1.Association, 0, len(l.Spec.ElasticsearchRefs))
+	for _, ref := range l.Spec.ElasticsearchRefs {
+		associations = append(associations, &LogstashESAssociation{
+			Logstash: l,
+			ref:      ref.WithDefaultNamespace(l.Namespace).NamespacedName(),
+		})
+	}
+	return associations
+}
+
+func (l *Logstash) ServiceAccountName() string {
+	return l.Spec.ServiceAccountName
+}
+
+// IsMarkedForDeletion returns true if the Logstash is going to be deleted
+func (l *Logstash) IsMarkedForDeletion() bool {
+	return !l.DeletionTimestamp.IsZero()
+}
+
+func (l *Logstash) AssociationStatusMap(typ commonv1.AssociationType) commonv1.AssociationStatusMap {
+	if typ == commonv1.ElasticsearchAssociationType {
+		return l.Status.ElasticsearchAssociationsStatus
+	}
+	return commonv1.AssociationStatusMap{}
+}
+
+func (l *Logstash) SetAssociationStatusMap(typ commonv1.AssociationType, status commonv1.AssociationStatusMap) error {
+	if typ != commonv1.ElasticsearchAssociationType {
+		return fmt.Errorf("association type %s not known", typ)
+	}
+	l.Status.ElasticsearchAssociationsStatus = status
+	return nil
+}
+
+func (l *Logstash) SecureSettings() []commonv1.SecretSource {
+	return l.Spec.SecureSettings
+}
+
+// ElasticsearchCluster returns the Elasticsearch cluster reference matching the given association, if any.
+func (l *Logstash) ElasticsearchCluster(assoc commonv1.Association) (ElasticsearchCluster, bool) {
+	ref := assoc.AssociationRef().NamespacedName()
+	for _, cluster := range l.Spec.ElasticsearchRefs {
+		if cluster.WithDefaultNamespace(l.Namespace).NamespacedName() == ref {
+			return cluster, true
+		}
+	}
+	return ElasticsearchCluster{}, false
+}
+
+type LogstashESAssociation struct {
+	*Logstash
+	// ref is the namespaced name of the Elasticsearch used in Association
+	ref types.NamespacedName
+}
+
+var _ commonv1.Association = &LogstashESAssociation{}
+
+func (lea *LogstashESAssociation) AssociationID() string {
+	return fmt.Sprintf("%s-%s", lea.ref.Namespace, lea.ref.Name)
+}
+
+func (lea *LogstashESAssociation) Associated() commonv1.Associated {
+	if lea == nil {
+		return nil
+	}
+	if lea.Logstash == nil {
+		lea.Logstash = &Logstash{}
+	}
+	return lea.Logstash
+}
+
+func (lea *LogstashESAssociation) AssociationType() commonv1.AssociationType {
+	return commonv1.ElasticsearchAssociationType
+}
+
+func (lea *LogstashESAssociation) AssociationRef() commonv1.ObjectSelector {
+	return commonv1.ObjectSelector{
+		Name:      lea.ref.Name,
+		Namespace: lea.ref.Namespace,
+	}
+}
+
+func (lea *LogstashESAssociation) AssociationConfAnnotationName() string {
+	return commonv1.ElasticsearchConfigAnnotationName(lea.ref)
+}
+
+func (lea *LogstashESAssociation) AssociationConf() *commonv1.AssociationConf {
+	if lea.esAssocConfs == nil {
+		return nil
+	}
+	assocConf, found := lea.esAssocConfs[lea.ref]
+	if !found {
+		return nil
+	}
+
+	return &assocConf
+}
+
+func (lea *LogstashESAssociation) SetAssociationConf(conf *commonv1.AssociationConf) {
+	if lea.esAssocConfs == nil {
+		lea.esAssocConfs = make(map[types.NamespacedName]commonv1.AssociationConf)
+	}
+	if conf != nil {
+		lea.esAssocConfs[lea.ref] = *conf
+	}
+}
+
+// +kubebuilder:object:root=true
+
+// LogstashList contains a list of Logstashes
+type LogstashList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Logstash `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Logstash{}, &LogstashList{})
+}
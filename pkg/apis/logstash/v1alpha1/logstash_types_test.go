@@ -0,0 +1,74 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+)
+
+func TestLogstashESAssociation_AssociationConfAnnotationName(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		ref  types.NamespacedName
+		want string
+	}{
+		{
+			name: "average length names",
+			ref:  types.NamespacedName{Namespace: "namespace1", Name: "elasticsearch1"},
+			want: "association.k8s.elastic.co/es-conf-XUQW524DHLS7APSORQVR76XZV6TVXVG623SITM7UUARH6",
+		},
+		{
+			name: "max length namespace and name (63 and 36 respectively)",
+			ref: types.NamespacedName{
+				Namespace: "longnamespacelongnamespacelongnamespacelongnamespacelongnamespa",
+				Name:      "elasticsearch1elasticsearch1elastics"},
+			want: "association.k8s.elastic.co/es-conf-UT4MHW5EYE7CSF2BR4NBSAM4JHY3NEFSDPOCBXQPIZ6BK",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			lea := LogstashESAssociation{ref: tt.ref}
+			got := lea.AssociationConfAnnotationName()
+
+			require.Equal(t, tt.want, got)
+			tokens := strings.Split(got, "/")
+			require.Equal(t, 2, len(tokens))
+			require.LessOrEqual(t, len(tokens[0]), 253)
+			require.LessOrEqual(t, len(tokens[1]), 63)
+		})
+	}
+}
+
+func TestLogstash_ElasticsearchCluster(t *testing.T) {
+	ls := Logstash{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ls"},
+		Spec: LogstashSpec{
+			ElasticsearchRefs: []ElasticsearchCluster{
+				{ObjectSelector: commonv1.ObjectSelector{Name: "es1"}, ClusterName: "prod"},
+				{ObjectSelector: commonv1.ObjectSelector{Name: "es2", Namespace: "other"}, ClusterName: "qa"},
+			},
+		},
+	}
+
+	associations := ls.GetAssociations()
+	require.Len(t, associations, 2)
+
+	cluster, found := ls.ElasticsearchCluster(associations[0])
+	require.True(t, found)
+	require.Equal(t, "prod", cluster.ClusterName)
+
+	cluster, found = ls.ElasticsearchCluster(associations[1])
+	require.True(t, found)
+	require.Equal(t, "qa", cluster.ClusterName)
+
+	_, found = ls.ElasticsearchCluster(&LogstashESAssociation{Logstash: &ls, ref: types.NamespacedName{Namespace: "ns", Name: "es3"}})
+	require.False(t, found)
+}
@@ -0,0 +1,105 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var (
+	clusterNameRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+
+	defaultChecks = []func(*Logstash) field.ErrorList{
+		checkNoUnknownFields,
+		checkNameLength,
+		checkSupportedVersion,
+		checkSingleConfigSource,
+		checkSinglePipelinesSource,
+		checkESRefsNamed,
+	}
+
+	updateChecks = []func(old, curr *Logstash) field.ErrorList{
+		checkNoDowngrade,
+		checkVolumeClaimTemplatesUnchanged,
+	}
+)
+
+func checkNoUnknownFields(l *Logstash) field.ErrorList {
+	return commonv1.NoUnknownFields(l, l.ObjectMeta)
+}
+
+func checkNameLength(l *Logstash) field.ErrorList {
+	return commonv1.CheckNameLength(l)
+}
+
+func checkSupportedVersion(l *Logstash) field.ErrorList {
+	return commonv1.CheckSupportedStackVersion(l.Spec.Version, version.SupportedLogstashVersions)
+}
+
+func checkSingleConfigSource(l *Logstash) field.ErrorList {
+	if l.Spec.Config != nil && l.Spec.ConfigRef != nil {
+		msg := "Specify at most one of [`config`, `configRef`], not both"
+		return field.ErrorList{
+			field.Forbidden(field.NewPath("spec").Child("config"), msg),
+			field.Forbidden(field.NewPath("spec").Child("configRef"), msg),
+		}
+	}
+
+	return nil
+}
+
+func checkSinglePipelinesSource(l *Logstash) field.ErrorList {
+	if len(l.Spec.Pipelines) > 0 && l.Spec.PipelinesRef != nil {
+		msg := "Specify at most one of [`pipelines`, `pipelinesRef`], not both"
+		return field.ErrorList{
+			field.Forbidden(field.NewPath("spec").Child("pipelines"), msg),
+			field.Forbidden(field.NewPath("spec").Child("pipelinesRef"), msg),
+		}
+	}
+
+	return nil
+}
+
+func checkESRefsNamed(l *Logstash) field.ErrorList {
+	var errs field.ErrorList
+	seen := make(map[string]struct{}, len(l.Spec.ElasticsearchRefs))
+	for i, ref := range l.Spec.ElasticsearchRefs {
+		path := field.NewPath("spec").Child("elasticsearchRefs").Index(i).Child("clusterName")
+		switch {
+		case ref.ClusterName == "":
+			errs = append(errs, field.Required(path, "clusterName is used to reference the cluster in pipelines"))
+		case !clusterNameRegexp.MatchString(ref.ClusterName):
+			errs = append(errs, field.Invalid(path, ref.ClusterName, "clusterName must start with a letter and only contain letters, digits and underscores"))
+		default:
+			if _, exists := seen[ref.ClusterName]; exists {
+				errs = append(errs, field.Duplicate(path, ref.ClusterName))
+			}
+			seen[ref.ClusterName] = struct{}{}
+		}
+	}
+	return errs
+}
+
+func checkNoDowngrade(prev, curr *Logstash) field.ErrorList {
+	return commonv1.CheckNoDowngrade(prev.Spec.Version, curr.Spec.Version)
+}
+
+func checkVolumeClaimTemplatesUnchanged(prev, curr *Logstash) field.ErrorList {
+	if !reflect.DeepEqual(prev.Spec.VolumeClaimTemplates, curr.Spec.VolumeClaimTemplates) {
+		return field.ErrorList{
+			field.Forbidden(
+				field.NewPath("spec").Child("volumeClaimTemplates"),
+				fmt.Sprintf("volumeClaimTemplates cannot be modified, consider creating a new %s resource instead", Kind),
+			),
+		}
+	}
+	return nil
+}
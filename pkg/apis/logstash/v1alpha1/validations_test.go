@@ -0,0 +1,172 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_checkSupportedVersion(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		version string
+		wantErr bool
+	}{
+		{
+			name:    "below min supported: NOK",
+			version: "7.15.2",
+			wantErr: true,
+		},
+		{
+			name:    "within supported: OK",
+			version: "7.16.0",
+			wantErr: false,
+		},
+		{
+			name:    "above max supported: NOK",
+			version: "9.0.0",
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			l := Logstash{Spec: LogstashSpec{Version: tt.version}}
+			got := checkSupportedVersion(&l)
+			assert.Equal(t, tt.wantErr, len(got) > 0)
+		})
+	}
+}
+
+func Test_checkSingleSources(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		spec    LogstashSpec
+		wantErr bool
+	}{
+		{
+			name: "no config nor pipelines",
+			spec: LogstashSpec{},
+		},
+		{
+			name: "config and pipelines",
+			spec: LogstashSpec{
+				Config:    &commonv1.Config{Data: map[string]interface{}{"log.level": "debug"}},
+				Pipelines: []commonv1.Config{{Data: map[string]interface{}{"pipeline.id": "main"}}},
+			},
+		},
+		{
+			name: "configRef and pipelinesRef",
+			spec: LogstashSpec{
+				ConfigRef:    &commonv1.ConfigSource{SecretRef: commonv1.SecretRef{SecretName: "config"}},
+				PipelinesRef: &commonv1.ConfigSource{SecretRef: commonv1.SecretRef{SecretName: "pipelines"}},
+			},
+		},
+		{
+			name: "config and configRef",
+			spec: LogstashSpec{
+				Config:    &commonv1.Config{Data: map[string]interface{}{"log.level": "debug"}},
+				ConfigRef: &commonv1.ConfigSource{SecretRef: commonv1.SecretRef{SecretName: "config"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "pipelines and pipelinesRef",
+			spec: LogstashSpec{
+				Pipelines:    []commonv1.Config{{Data: map[string]interface{}{"pipeline.id": "main"}}},
+				PipelinesRef: &commonv1.ConfigSource{SecretRef: commonv1.SecretRef{SecretName: "pipelines"}},
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			l := Logstash{Spec: tt.spec}
+			got := append(checkSingleConfigSource(&l), checkSinglePipelinesSource(&l)...)
+			assert.Equal(t, tt.wantErr, len(got) > 0)
+		})
+	}
+}
+
+func Test_checkESRefsNamed(t *testing.T) {
+	ref := func(name, clusterName string) ElasticsearchCluster {
+		return ElasticsearchCluster{ObjectSelector: commonv1.ObjectSelector{Name: name}, ClusterName: clusterName}
+	}
+	for _, tt := range []struct {
+		name    string
+		refs    []ElasticsearchCluster
+		wantErr bool
+	}{
+		{
+			name: "no refs",
+		},
+		{
+			name: "named refs",
+			refs: []ElasticsearchCluster{ref("es1", "production"), ref("es2", "monitoring_2")},
+		},
+		{
+			name:    "missing clusterName",
+			refs:    []ElasticsearchCluster{ref("es1", "")},
+			wantErr: true,
+		},
+		{
+			name:    "invalid clusterName",
+			refs:    []ElasticsearchCluster{ref("es1", "my-cluster")},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate clusterName",
+			refs:    []ElasticsearchCluster{ref("es1", "production"), ref("es2", "production")},
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			l := Logstash{Spec: LogstashSpec{ElasticsearchRefs: tt.refs}}
+			got := checkESRefsNamed(&l)
+			assert.Equal(t, tt.wantErr, len(got) > 0)
+		})
+	}
+}
+
+func Test_checkVolumeClaimTemplatesUnchanged(t *testing.T) {
+	claim := func(name string) corev1.PersistentVolumeClaim {
+		return corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	for _, tt := range []struct {
+		name    string
+		prev    []corev1.PersistentVolumeClaim
+		curr    []corev1.PersistentVolumeClaim
+		wantErr bool
+	}{
+		{
+			name: "no claims",
+		},
+		{
+			name: "same claims",
+			prev: []corev1.PersistentVolumeClaim{claim("logstash-data")},
+			curr: []corev1.PersistentVolumeClaim{claim("logstash-data")},
+		},
+		{
+			name:    "claim added",
+			curr:    []corev1.PersistentVolumeClaim{claim("logstash-data")},
+			wantErr: true,
+		},
+		{
+			name:    "claim renamed",
+			prev:    []corev1.PersistentVolumeClaim{claim("logstash-data")},
+			curr:    []corev1.PersistentVolumeClaim{claim("queue")},
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			prev := Logstash{Spec: LogstashSpec{VolumeClaimTemplates: tt.prev}}
+			curr := Logstash{Spec: LogstashSpec{VolumeClaimTemplates: tt.curr}}
+			got := checkVolumeClaimTemplatesUnchanged(&prev, &curr)
+			assert.Equal(t, tt.wantErr, len(got) > 0)
+		})
+	}
+}
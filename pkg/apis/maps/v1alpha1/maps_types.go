@@ -30,6 +30,11 @@ type MapsSpec struct {
 	// Count of Elastic Maps Server instances to deploy.
 	Count int32 `json:"count,omitempty"`
 
+	// Autoscaling configures a HorizontalPodAutoscaler managing the number of Elastic Maps Server instances,
+	// in which case Count is ignored.
+	// +kubebuilder:validation:Optional
+	Autoscaling *commonv1.AutoscalingSpec `json:"autoscaling,omitempty"`
+
 	// ElasticsearchRef is a reference to an Elasticsearch cluster running in the same Kubernetes cluster.
 	ElasticsearchRef commonv1.ObjectSelector `json:"elasticsearchRef,omitempty"`
 
@@ -50,6 +55,16 @@ type MapsSpec struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	PodTemplate corev1.PodTemplateSpec `json:"podTemplate,omitempty"`
 
+	// PodDisruptionBudget provides access to the default Pod disruption budget for the Elastic Maps Server Pods.
+	// The default budget selects all the Elastic Maps Server Pods and sets `maxUnavailable` to 1 when there is more than one Pod.
+	// To disable, set `PodDisruptionBudget` to the empty value (`{}` in YAML).
+	// +kubebuilder:validation:Optional
+	PodDisruptionBudget *commonv1.PodDisruptionBudgetTemplate `json:"podDisruptionBudget,omitempty"`
+
+	// NetworkPolicy controls the NetworkPolicies managed by the operator to allow the traffic to the Elastic Maps Server Pods.
+	// +kubebuilder:validation:Optional
+	NetworkPolicy *commonv1.NetworkPolicySettings `json:"networkPolicy,omitempty"`
+
 	// ServiceAccountName is used to check access from the current resource to a resource (eg. Elasticsearch) in a different namespace.
 	// Can only be used if ECK is enforcing RBAC on references.
 	// +optional
@@ -87,6 +102,11 @@ func (m *ElasticMapsServer) ServiceAccountName() string {
 	return m.Spec.ServiceAccountName
 }
 
+// GetNetworkPolicySettings returns the NetworkPolicy settings of the Elastic Maps Server.
+func (m *ElasticMapsServer) GetNetworkPolicySettings() *commonv1.NetworkPolicySettings {
+	return m.Spec.NetworkPolicy
+}
+
 func (m *ElasticMapsServer) AssociationConf() *commonv1.AssociationConf {
 	return m.assocConf
 }
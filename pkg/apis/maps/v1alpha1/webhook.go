@@ -24,6 +24,7 @@ var (
 		checkNoUnknownFields,
 		checkNameLength,
 		checkSupportedVersion,
+		checkAutoscaling,
 	}
 )
 
@@ -79,3 +80,7 @@ func checkNameLength(k *ElasticMapsServer) field.ErrorList {
 func checkSupportedVersion(k *ElasticMapsServer) field.ErrorList {
 	return commonv1.CheckSupportedStackVersion(k.Spec.Version, version.SupportedMapsVersions)
 }
+
+func checkAutoscaling(k *ElasticMapsServer) field.ErrorList {
+	return commonv1.CheckAutoscaling(k.Spec.Autoscaling)
+}
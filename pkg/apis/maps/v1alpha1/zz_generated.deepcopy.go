@@ -20,7 +20,7 @@ func (in *ElasticMapsServer) DeepCopyInto(out *ElasticMapsServer) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	if in.assocConf != nil {
 		in, out := &in.assocConf, &out.assocConf
 		*out = new(v1.AssociationConf)
@@ -81,6 +81,11 @@ func (in *ElasticMapsServerList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MapsSpec) DeepCopyInto(out *MapsSpec) {
 	*out = *in
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(v1.AutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	out.ElasticsearchRef = in.ElasticsearchRef
 	if in.Config != nil {
 		in, out := &in.Config, &out.Config
@@ -93,6 +98,16 @@ func (in *MapsSpec) DeepCopyInto(out *MapsSpec) {
 	}
 	in.HTTP.DeepCopyInto(&out.HTTP)
 	in.PodTemplate.DeepCopyInto(&out.PodTemplate)
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(v1.PodDisruptionBudgetTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(v1.NetworkPolicySettings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MapsSpec.
@@ -108,7 +123,7 @@ func (in *MapsSpec) DeepCopy() *MapsSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MapsStatus) DeepCopyInto(out *MapsStatus) {
 	*out = *in
-	out.DeploymentStatus = in.DeploymentStatus
+	in.DeploymentStatus.DeepCopyInto(&out.DeploymentStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MapsStatus.
@@ -0,0 +1,34 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package v1alpha1
+
+// ElasticsearchRef is a reference to an Elasticsearch cluster that exists in the same namespace.
+type ElasticsearchRef struct {
+	// Name is the name of the Elasticsearch resource.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// Phase is the phase of a user or a role from the controller point of view.
+type Phase string
+
+const (
+	// ReadyPhase indicates that the user or the role exists in Elasticsearch as declared.
+	ReadyPhase Phase = "Ready"
+	// FailedPhase indicates that the user or the role could not be applied in Elasticsearch.
+	FailedPhase Phase = "Failed"
+)
+
+// NativeRealmStatus defines the observed state of a user or a role of the native realm.
+type NativeRealmStatus struct {
+	// Phase of the user or the role.
+	Phase Phase `json:"phase,omitempty"`
+
+	// Message describes the error that prevented the user or the role from being applied, if any.
+	Message string `json:"message,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed for this resource.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
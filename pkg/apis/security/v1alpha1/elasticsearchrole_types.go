@@ -0,0 +1,77 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+)
+
+const (
+	// RoleKind is inferred from the struct name using reflection in SchemeBuilder.Register()
+	// we duplicate it as a constant here for practical purposes.
+	RoleKind = "ElasticsearchRole"
+)
+
+// ElasticsearchRoleSpec defines a role of the native realm of an Elasticsearch cluster.
+type ElasticsearchRoleSpec struct {
+	// ElasticsearchRef is a reference to the Elasticsearch cluster, in the same namespace, in which the role is created.
+	ElasticsearchRef ElasticsearchRef `json:"elasticsearchRef"`
+
+	// RoleName is the name of the role in Elasticsearch. Defaults to the name of the ElasticsearchRole resource.
+	// +kubebuilder:validation:Optional
+	RoleName string `json:"roleName,omitempty"`
+
+	// Definition is the body of the request used to create the role, as documented in
+	// https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-put-role.html.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Definition *commonv1.Config `json:"definition,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ElasticsearchRole represents a role of the native realm of an Elasticsearch cluster, managed through the
+// Elasticsearch security API.
+// +kubebuilder:resource:categories=elastic,shortName=esrole
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="elasticsearch",type="string",JSONPath=".spec.elasticsearchRef.name"
+// +kubebuilder:printcolumn:name="phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:storageversion
+type ElasticsearchRole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticsearchRoleSpec `json:"spec,omitempty"`
+	Status NativeRealmStatus     `json:"status,omitempty"`
+}
+
+// ElasticsearchKey returns the namespaced name of the Elasticsearch cluster in which the role is created.
+func (r ElasticsearchRole) ElasticsearchKey() types.NamespacedName {
+	return types.NamespacedName{Namespace: r.Namespace, Name: r.Spec.ElasticsearchRef.Name}
+}
+
+// RoleName returns the name of the role in Elasticsearch.
+func (r ElasticsearchRole) RoleName() string {
+	if r.Spec.RoleName != "" {
+		return r.Spec.RoleName
+	}
+	return r.Name
+}
+
+// +kubebuilder:object:root=true
+
+// ElasticsearchRoleList contains a list of ElasticsearchRole resources.
+type ElasticsearchRoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticsearchRole `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticsearchRole{}, &ElasticsearchRoleList{})
+}
@@ -0,0 +1,123 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+)
+
+const (
+	// UserKind is inferred from the struct name using reflection in SchemeBuilder.Register()
+	// we duplicate it as a constant here for practical purposes.
+	UserKind = "ElasticsearchUser"
+
+	// DefaultPasswordKey is the key of the password in the Secret referenced by a user, if not specified.
+	DefaultPasswordKey = "password"
+)
+
+// ElasticsearchUserSpec defines a user of the native realm of an Elasticsearch cluster.
+type ElasticsearchUserSpec struct {
+	// ElasticsearchRef is a reference to the Elasticsearch cluster, in the same namespace, in which the user is created.
+	ElasticsearchRef ElasticsearchRef `json:"elasticsearchRef"`
+
+	// Username is the name of the user in Elasticsearch. Defaults to the name of the ElasticsearchUser resource.
+	// +kubebuilder:validation:Optional
+	Username string `json:"username,omitempty"`
+
+	// PasswordSecretRef is a reference to the Secret, in the same namespace, which holds the password of the user.
+	PasswordSecretRef PasswordSecretRef `json:"passwordSecretRef"`
+
+	// Roles is the list of the roles granted to the user.
+	// +kubebuilder:validation:Optional
+	Roles []string `json:"roles,omitempty"`
+
+	// FullName is the full name of the user.
+	// +kubebuilder:validation:Optional
+	FullName string `json:"fullName,omitempty"`
+
+	// Email is the email address of the user.
+	// +kubebuilder:validation:Optional
+	Email string `json:"email,omitempty"`
+
+	// Metadata holds arbitrary metadata about the user.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Metadata *commonv1.Config `json:"metadata,omitempty"`
+
+	// Enabled specifies whether the user can authenticate. Defaults to true.
+	// +kubebuilder:validation:Optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// PasswordSecretRef is a reference to a key of a Secret that exists in the same namespace.
+type PasswordSecretRef struct {
+	// SecretName is the name of the Secret.
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName"`
+
+	// Key is the key of the password in the Secret. Defaults to "password".
+	// +kubebuilder:validation:Optional
+	Key string `json:"key,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ElasticsearchUser represents a user of the native realm of an Elasticsearch cluster, managed through the
+// Elasticsearch security API.
+// +kubebuilder:resource:categories=elastic,shortName=esuser
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="elasticsearch",type="string",JSONPath=".spec.elasticsearchRef.name"
+// +kubebuilder:printcolumn:name="phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:storageversion
+type ElasticsearchUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticsearchUserSpec `json:"spec,omitempty"`
+	Status NativeRealmStatus     `json:"status,omitempty"`
+}
+
+// ElasticsearchKey returns the namespaced name of the Elasticsearch cluster in which the user is created.
+func (u ElasticsearchUser) ElasticsearchKey() types.NamespacedName {
+	return types.NamespacedName{Namespace: u.Namespace, Name: u.Spec.ElasticsearchRef.Name}
+}
+
+// Username returns the name of the user in Elasticsearch.
+func (u ElasticsearchUser) Username() string {
+	if u.Spec.Username != "" {
+		return u.Spec.Username
+	}
+	return u.Name
+}
+
+// PasswordKey returns the key of the password in the Secret referenced by the user.
+func (u ElasticsearchUser) PasswordKey() string {
+	if u.Spec.PasswordSecretRef.Key != "" {
+		return u.Spec.PasswordSecretRef.Key
+	}
+	return DefaultPasswordKey
+}
+
+// IsEnabled returns true if the user can authenticate.
+func (u ElasticsearchUser) IsEnabled() bool {
+	return u.Spec.Enabled == nil || *u.Spec.Enabled
+}
+
+// +kubebuilder:object:root=true
+
+// ElasticsearchUserList contains a list of ElasticsearchUser resources.
+type ElasticsearchUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticsearchUser `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticsearchUser{}, &ElasticsearchUserList{})
+}
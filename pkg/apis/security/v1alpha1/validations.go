@@ -0,0 +1,83 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package v1alpha1
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+)
+
+const (
+	reservedUsernameMsg = "Username is reserved for the built-in users of Elasticsearch or the operator"
+	roleMissingDefMsg   = "definition is required"
+	// operatorUsersPrefix is the prefix of the names of the users created by the operator in the file realm.
+	operatorUsersPrefix = "elastic-internal"
+)
+
+// reservedUsernames are the built-in users of Elasticsearch, which cannot be managed through the native realm.
+var reservedUsernames = map[string]struct{}{
+	"elastic":                {},
+	"kibana":                 {},
+	"kibana_system":          {},
+	"logstash_system":        {},
+	"beats_system":           {},
+	"apm_system":             {},
+	"remote_monitoring_user": {},
+}
+
+var userChecks = []func(*ElasticsearchUser) field.ErrorList{
+	checkUserNoUnknownFields,
+	checkUserNameLength,
+	checkUsername,
+	checkPasswordSecretRef,
+}
+
+var roleChecks = []func(*ElasticsearchRole) field.ErrorList{
+	checkRoleNoUnknownFields,
+	checkRoleNameLength,
+	checkRoleDefinition,
+}
+
+func checkUserNoUnknownFields(u *ElasticsearchUser) field.ErrorList {
+	return commonv1.NoUnknownFields(u, u.ObjectMeta)
+}
+
+func checkUserNameLength(u *ElasticsearchUser) field.ErrorList {
+	return commonv1.CheckNameLength(u)
+}
+
+// checkUsername ensures that the user does not override a built-in user of Elasticsearch or a user of the operator.
+func checkUsername(u *ElasticsearchUser) field.ErrorList {
+	username := u.Username()
+	if _, reserved := reservedUsernames[username]; reserved || strings.HasPrefix(username, operatorUsersPrefix) {
+		return field.ErrorList{field.Forbidden(field.NewPath("spec").Child("username"), reservedUsernameMsg)}
+	}
+	return nil
+}
+
+func checkPasswordSecretRef(u *ElasticsearchUser) field.ErrorList {
+	if u.Spec.PasswordSecretRef.SecretName == "" {
+		return field.ErrorList{field.Required(field.NewPath("spec").Child("passwordSecretRef").Child("secretName"), "")}
+	}
+	return nil
+}
+
+func checkRoleNoUnknownFields(r *ElasticsearchRole) field.ErrorList {
+	return commonv1.NoUnknownFields(r, r.ObjectMeta)
+}
+
+func checkRoleNameLength(r *ElasticsearchRole) field.ErrorList {
+	return commonv1.CheckNameLength(r)
+}
+
+func checkRoleDefinition(r *ElasticsearchRole) field.ErrorList {
+	if r.Spec.Definition == nil || len(r.Spec.Definition.Data) == 0 {
+		return field.ErrorList{field.Required(field.NewPath("spec").Child("definition"), roleMissingDefMsg)}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+)
+
+func TestElasticsearchUser_validate(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		resource   string
+		spec       ElasticsearchUserSpec
+		wantErrors []string
+	}{
+		{
+			name:     "valid user",
+			resource: "jdoe",
+			spec: ElasticsearchUserSpec{
+				ElasticsearchRef:  ElasticsearchRef{Name: "es"},
+				PasswordSecretRef: PasswordSecretRef{SecretName: "jdoe-password"},
+				Roles:             []string{"viewer"},
+			},
+		},
+		{
+			name:     "missing password Secret",
+			resource: "jdoe",
+			spec: ElasticsearchUserSpec{
+				ElasticsearchRef: ElasticsearchRef{Name: "es"},
+			},
+			wantErrors: []string{"spec.passwordSecretRef.secretName"},
+		},
+		{
+			name:     "built-in user",
+			resource: "elastic",
+			spec: ElasticsearchUserSpec{
+				ElasticsearchRef:  ElasticsearchRef{Name: "es"},
+				PasswordSecretRef: PasswordSecretRef{SecretName: "elastic-password"},
+			},
+			wantErrors: []string{"spec.username"},
+		},
+		{
+			name:     "user of the operator",
+			resource: "probe",
+			spec: ElasticsearchUserSpec{
+				ElasticsearchRef:  ElasticsearchRef{Name: "es"},
+				Username:          "elastic-internal-probe",
+				PasswordSecretRef: PasswordSecretRef{SecretName: "probe-password"},
+			},
+			wantErrors: []string{"spec.username"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			u := ElasticsearchUser{ObjectMeta: metav1.ObjectMeta{Name: tt.resource, Namespace: "ns"}, Spec: tt.spec}
+			err := u.validate()
+			if len(tt.wantErrors) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			for _, want := range tt.wantErrors {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestElasticsearchRole_validate(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		spec       ElasticsearchRoleSpec
+		wantErrors []string
+	}{
+		{
+			name: "valid role",
+			spec: ElasticsearchRoleSpec{
+				ElasticsearchRef: ElasticsearchRef{Name: "es"},
+				Definition:       &commonv1.Config{Data: map[string]interface{}{"cluster": []interface{}{"monitor"}}},
+			},
+		},
+		{
+			name: "missing definition",
+			spec: ElasticsearchRoleSpec{
+				ElasticsearchRef: ElasticsearchRef{Name: "es"},
+				Definition:       &commonv1.Config{},
+			},
+			wantErrors: []string{"spec.definition"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := ElasticsearchRole{ObjectMeta: metav1.ObjectMeta{Name: "viewer", Namespace: "ns"}, Spec: tt.spec}
+			err := r.validate()
+			if len(tt.wantErrors) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			for _, want := range tt.wantErrors {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
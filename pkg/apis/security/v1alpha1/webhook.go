@@ -0,0 +1,99 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package v1alpha1
+
+import (
+	ulog "github.com/elastic/cloud-on-k8s/pkg/utils/log"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var (
+	userGroupKind = schema.GroupKind{Group: GroupVersion.Group, Kind: UserKind}
+	roleGroupKind = schema.GroupKind{Group: GroupVersion.Group, Kind: RoleKind}
+	validationLog = ulog.Log.WithName("security-v1alpha1-validation")
+)
+
+// +kubebuilder:webhook:path=/validate-security-k8s-elastic-co-v1alpha1-elasticsearchuser,mutating=false,failurePolicy=ignore,groups=security.k8s.elastic.co,resources=elasticsearchusers,verbs=create;update,versions=v1alpha1,name=elastic-esuser-validation-v1alpha1.k8s.elastic.co,sideEffects=None,admissionReviewVersions=v1;v1beta1,matchPolicy=Exact
+
+var _ webhook.Validator = &ElasticsearchUser{}
+
+func (u *ElasticsearchUser) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(u).
+		Complete()
+}
+
+func (u *ElasticsearchUser) ValidateCreate() error {
+	validationLog.V(1).Info("Validate create", "name", u.Name)
+	return u.validate()
+}
+
+func (u *ElasticsearchUser) ValidateDelete() error {
+	validationLog.V(1).Info("Validate delete", "name", u.Name)
+	return nil
+}
+
+func (u *ElasticsearchUser) ValidateUpdate(_ runtime.Object) error {
+	validationLog.V(1).Info("Validate update", "name", u.Name)
+	return u.validate()
+}
+
+func (u *ElasticsearchUser) validate() error {
+	var errors field.ErrorList
+	for _, check := range userChecks {
+		if err := check(u); err != nil {
+			errors = append(errors, err...)
+		}
+	}
+
+	if len(errors) > 0 {
+		return apierrors.NewInvalid(userGroupKind, u.Name, errors)
+	}
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-security-k8s-elastic-co-v1alpha1-elasticsearchrole,mutating=false,failurePolicy=ignore,groups=security.k8s.elastic.co,resources=elasticsearchroles,verbs=create;update,versions=v1alpha1,name=elastic-esrole-validation-v1alpha1.k8s.elastic.co,sideEffects=None,admissionReviewVersions=v1;v1beta1,matchPolicy=Exact
+
+var _ webhook.Validator = &ElasticsearchRole{}
+
+func (r *ElasticsearchRole) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+func (r *ElasticsearchRole) ValidateCreate() error {
+	validationLog.V(1).Info("Validate create", "name", r.Name)
+	return r.validate()
+}
+
+func (r *ElasticsearchRole) ValidateDelete() error {
+	validationLog.V(1).Info("Validate delete", "name", r.Name)
+	return nil
+}
+
+func (r *ElasticsearchRole) ValidateUpdate(_ runtime.Object) error {
+	validationLog.V(1).Info("Validate update", "name", r.Name)
+	return r.validate()
+}
+
+func (r *ElasticsearchRole) validate() error {
+	var errors field.ErrorList
+	for _, check := range roleChecks {
+		if err := check(r); err != nil {
+			errors = append(errors, err...)
+		}
+	}
+
+	if len(errors) > 0 {
+		return apierrors.NewInvalid(roleGroupKind, r.Name, errors)
+	}
+	return nil
+}
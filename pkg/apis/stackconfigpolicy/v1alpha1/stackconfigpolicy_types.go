@@ -0,0 +1,140 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+)
+
+const (
+	// Kind is inferred from the struct name using reflection in SchemeBuilder.Register()
+	// we duplicate it as a constant here for practical purposes.
+	Kind = "StackConfigPolicy"
+)
+
+// StackConfigPolicySpec defines the settings enforced on the Elasticsearch and Kibana resources selected by the policy.
+type StackConfigPolicySpec struct {
+	// ResourceSelector is a label selector for the Elasticsearch and Kibana resources the policy applies to.
+	// Policies created in the operator namespace select resources in all the namespaces managed by the operator.
+	// An empty selector selects all the resources.
+	// +kubebuilder:validation:Optional
+	ResourceSelector metav1.LabelSelector `json:"resourceSelector,omitempty"`
+
+	// Elasticsearch holds the settings enforced on the selected Elasticsearch clusters.
+	// +kubebuilder:validation:Optional
+	Elasticsearch ElasticsearchConfigPolicySpec `json:"elasticsearch,omitempty"`
+
+	// Kibana holds the settings enforced on the selected Kibana instances.
+	// +kubebuilder:validation:Optional
+	Kibana KibanaConfigPolicySpec `json:"kibana,omitempty"`
+}
+
+// ElasticsearchConfigPolicySpec holds the settings enforced on Elasticsearch clusters.
+type ElasticsearchConfigPolicySpec struct {
+	// Config holds the Elasticsearch configuration settings (elasticsearch.yml) enforced on all the nodes of the
+	// selected clusters. They take precedence over the configuration of the node sets.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Config *commonv1.Config `json:"config,omitempty"`
+
+	// RoleMappings holds the role mappings created in the selected clusters through the Elasticsearch security API.
+	// +kubebuilder:validation:Optional
+	RoleMappings []RoleMapping `json:"roleMappings,omitempty"`
+}
+
+// RoleMapping is a role mapping managed through the Elasticsearch security API.
+type RoleMapping struct {
+	// Name is the name of the role mapping.
+	Name string `json:"name"`
+
+	// Definition is the body of the request used to create the role mapping, as documented in
+	// https://www.elastic.co/guide/en/elasticsearch/reference/current/security-api-put-role-mapping.html.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Definition *commonv1.Config `json:"definition,omitempty"`
+}
+
+// KibanaConfigPolicySpec holds the settings enforced on Kibana instances.
+type KibanaConfigPolicySpec struct {
+	// Config holds the Kibana configuration settings (kibana.yml) enforced on the selected Kibana instances.
+	// They take precedence over the configuration of the Kibana resources.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Config *commonv1.Config `json:"config,omitempty"`
+}
+
+// PolicyPhase is the phase of a StackConfigPolicy.
+type PolicyPhase string
+
+const (
+	// ReadyPhase indicates that the policy is applied to all the selected resources without conflict.
+	ReadyPhase PolicyPhase = "Ready"
+	// ConflictPhase indicates that some settings of the policy conflict with the specification of a selected resource
+	// or with another policy.
+	ConflictPhase PolicyPhase = "Conflict"
+	// InvalidPhase indicates that the policy cannot be applied, because its resource selector is invalid or because it
+	// is not in the operator namespace.
+	InvalidPhase PolicyPhase = "Invalid"
+)
+
+// StackConfigPolicyStatus defines the observed state of a StackConfigPolicy.
+type StackConfigPolicyStatus struct {
+	// Phase of the policy.
+	Phase PolicyPhase `json:"phase,omitempty"`
+
+	// Resources is the number of resources selected by the policy.
+	Resources int `json:"resources"`
+
+	// Conflicts lists the settings of the policy which conflict with the specification of a selected resource or with
+	// another policy.
+	Conflicts []ResourcePolicyConflict `json:"conflicts,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed for this StackConfigPolicy.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// ResourcePolicyConflict describes a setting of a StackConfigPolicy which conflicts with the specification of a
+// selected resource or with another policy.
+type ResourcePolicyConflict struct {
+	// Kind of the resource.
+	Kind string `json:"kind"`
+	// Namespace of the resource.
+	Namespace string `json:"namespace"`
+	// Name of the resource.
+	Name string `json:"name"`
+
+	commonv1.PolicyConflict `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+
+// StackConfigPolicy represents a set of settings enforced on Elasticsearch and Kibana resources selected by label.
+// +kubebuilder:resource:categories=elastic,shortName=scp
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="resources",type="integer",JSONPath=".status.resources",description="Resources selected by the policy"
+// +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:storageversion
+type StackConfigPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   StackConfigPolicySpec   `json:"spec,omitempty"`
+	Status StackConfigPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// StackConfigPolicyList contains a list of StackConfigPolicy resources.
+type StackConfigPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StackConfigPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&StackConfigPolicy{}, &StackConfigPolicyList{})
+}
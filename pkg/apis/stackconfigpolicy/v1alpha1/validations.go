@@ -0,0 +1,91 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/settings"
+)
+
+const (
+	cfgInvalidMsg            = "Configuration invalid"
+	unsupportedConfigErrMsg  = "Configuration setting is reserved for internal use. User-configured use is unsupported"
+	roleMappingMissingDefMsg = "definition is required"
+)
+
+var defaultChecks = []func(*StackConfigPolicy) field.ErrorList{
+	checkNoUnknownFields,
+	checkNameLength,
+	checkResourceSelector,
+	checkElasticsearchConfig,
+	checkRoleMappings,
+	checkKibanaConfig,
+}
+
+func checkNoUnknownFields(p *StackConfigPolicy) field.ErrorList {
+	return commonv1.NoUnknownFields(p, p.ObjectMeta)
+}
+
+func checkNameLength(p *StackConfigPolicy) field.ErrorList {
+	return commonv1.CheckNameLength(p)
+}
+
+func checkResourceSelector(p *StackConfigPolicy) field.ErrorList {
+	if _, err := metav1.LabelSelectorAsSelector(&p.Spec.ResourceSelector); err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("spec").Child("resourceSelector"), p.Spec.ResourceSelector, err.Error())}
+	}
+	return nil
+}
+
+// checkElasticsearchConfig ensures that the enforced Elasticsearch configuration can be parsed and does not contain any
+// setting managed by the operator. Unlike the node sets configuration, such settings are rejected since they would be
+// enforced on all the selected clusters.
+func checkElasticsearchConfig(p *StackConfigPolicy) field.ErrorList {
+	if p.Spec.Elasticsearch.Config == nil {
+		return nil
+	}
+	path := field.NewPath("spec").Child("elasticsearch").Child("config")
+	config, err := settings.NewCanonicalConfigFrom(p.Spec.Elasticsearch.Config.Data)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, p.Spec.Elasticsearch.Config, cfgInvalidMsg)}
+	}
+	var errs field.ErrorList
+	for _, setting := range config.HasKeys(esv1.UnsupportedSettings) {
+		errs = append(errs, field.Forbidden(path.Child(setting), unsupportedConfigErrMsg))
+	}
+	return errs
+}
+
+func checkRoleMappings(p *StackConfigPolicy) field.ErrorList {
+	var errs field.ErrorList
+	seen := make(map[string]struct{}, len(p.Spec.Elasticsearch.RoleMappings))
+	for i, roleMapping := range p.Spec.Elasticsearch.RoleMappings {
+		path := field.NewPath("spec").Child("elasticsearch").Child("roleMappings").Index(i)
+		if roleMapping.Name == "" {
+			errs = append(errs, field.Required(path.Child("name"), ""))
+		} else if _, exists := seen[roleMapping.Name]; exists {
+			errs = append(errs, field.Duplicate(path.Child("name"), roleMapping.Name))
+		}
+		seen[roleMapping.Name] = struct{}{}
+		if roleMapping.Definition == nil || len(roleMapping.Definition.Data) == 0 {
+			errs = append(errs, field.Required(path.Child("definition"), roleMappingMissingDefMsg))
+		}
+	}
+	return errs
+}
+
+func checkKibanaConfig(p *StackConfigPolicy) field.ErrorList {
+	if p.Spec.Kibana.Config == nil {
+		return nil
+	}
+	if _, err := settings.NewCanonicalConfigFrom(p.Spec.Kibana.Config.Data); err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("spec").Child("kibana").Child("config"), p.Spec.Kibana.Config, cfgInvalidMsg)}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+)
+
+func TestStackConfigPolicy_validate(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		spec       StackConfigPolicySpec
+		wantErrors []string
+	}{
+		{
+			name: "empty policy",
+			spec: StackConfigPolicySpec{},
+		},
+		{
+			name: "valid policy",
+			spec: StackConfigPolicySpec{
+				ResourceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				Elasticsearch: ElasticsearchConfigPolicySpec{
+					Config: &commonv1.Config{Data: map[string]interface{}{"indices.memory.index_buffer_size": "20%"}},
+					RoleMappings: []RoleMapping{
+						{Name: "admins", Definition: &commonv1.Config{Data: map[string]interface{}{"roles": []interface{}{"superuser"}}}},
+					},
+				},
+				Kibana: KibanaConfigPolicySpec{
+					Config: &commonv1.Config{Data: map[string]interface{}{"xpack.reporting.enabled": false}},
+				},
+			},
+		},
+		{
+			name: "invalid resource selector",
+			spec: StackConfigPolicySpec{
+				ResourceSelector: metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "env", Operator: "Unknown"},
+				}},
+			},
+			wantErrors: []string{"spec.resourceSelector"},
+		},
+		{
+			name: "unsupported Elasticsearch settings",
+			spec: StackConfigPolicySpec{
+				Elasticsearch: ElasticsearchConfigPolicySpec{
+					Config: &commonv1.Config{Data: map[string]interface{}{"cluster.name": "prod", "network.host": "0.0.0.0"}},
+				},
+			},
+			wantErrors: []string{"spec.elasticsearch.config.cluster.name", "spec.elasticsearch.config.network.host"},
+		},
+		{
+			name: "invalid role mappings",
+			spec: StackConfigPolicySpec{
+				Elasticsearch: ElasticsearchConfigPolicySpec{
+					RoleMappings: []RoleMapping{
+						{Name: "admins", Definition: &commonv1.Config{Data: map[string]interface{}{"roles": []interface{}{"superuser"}}}},
+						{Name: "admins", Definition: &commonv1.Config{Data: map[string]interface{}{"roles": []interface{}{"viewer"}}}},
+						{Definition: &commonv1.Config{Data: map[string]interface{}{"roles": []interface{}{"viewer"}}}},
+						{Name: "viewers"},
+					},
+				},
+			},
+			wantErrors: []string{
+				"spec.elasticsearch.roleMappings[1].name",
+				"spec.elasticsearch.roleMappings[2].name",
+				"spec.elasticsearch.roleMappings[3].definition",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p := StackConfigPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "elastic-system"}, Spec: tt.spec}
+			err := p.validate()
+			if len(tt.wantErrors) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			for _, want := range tt.wantErrors {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package v1alpha1
+
+import (
+	ulog "github.com/elastic/cloud-on-k8s/pkg/utils/log"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var (
+	groupKind     = schema.GroupKind{Group: GroupVersion.Group, Kind: Kind}
+	validationLog = ulog.Log.WithName("stackconfigpolicy-v1alpha1-validation")
+)
+
+// +kubebuilder:webhook:path=/validate-stackconfigpolicy-k8s-elastic-co-v1alpha1-stackconfigpolicy,mutating=false,failurePolicy=ignore,groups=stackconfigpolicy.k8s.elastic.co,resources=stackconfigpolicies,verbs=create;update,versions=v1alpha1,name=elastic-scp-validation-v1alpha1.k8s.elastic.co,sideEffects=None,admissionReviewVersions=v1;v1beta1,matchPolicy=Exact
+
+var _ webhook.Validator = &StackConfigPolicy{}
+
+func (p *StackConfigPolicy) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(p).
+		Complete()
+}
+
+func (p *StackConfigPolicy) ValidateCreate() error {
+	validationLog.V(1).Info("Validate create", "name", p.Name)
+	return p.validate()
+}
+
+func (p *StackConfigPolicy) ValidateDelete() error {
+	validationLog.V(1).Info("Validate delete", "name", p.Name)
+	return nil
+}
+
+func (p *StackConfigPolicy) ValidateUpdate(_ runtime.Object) error {
+	validationLog.V(1).Info("Validate update", "name", p.Name)
+	return p.validate()
+}
+
+func (p *StackConfigPolicy) validate() error {
+	var errors field.ErrorList
+	for _, dc := range defaultChecks {
+		if err := dc(p); err != nil {
+			errors = append(errors, err...)
+		}
+	}
+
+	if len(errors) > 0 {
+		return apierrors.NewInvalid(groupKind, p.Name, errors)
+	}
+	return nil
+}
@@ -53,7 +53,12 @@ func reconcileConfig(params Params, configHash hash.Hash) *reconciler.Results {
 	}
 
 	if params.Agent.Spec.FleetModeEnabled() {
-		fleetSetupCfgBytes, err := buildFleetSetupConfig(params.Agent, params.Client)
+		enrollmentToken, err := fleetEnrollmentToken(params)
+		if err != nil {
+			return results.WithError(err)
+		}
+
+		fleetSetupCfgBytes, err := buildFleetSetupConfig(params.Agent, params.Client, enrollmentToken)
 		if err != nil {
 			return results.WithError(err)
 		}
@@ -161,7 +166,7 @@ func getUserConfig(params Params) (*settings.CanonicalConfig, error) {
 	return common.ParseConfigRef(params, &params.Agent, params.Agent.Spec.ConfigRef, ConfigFileName)
 }
 
-func buildFleetSetupConfig(agent agentv1alpha1.Agent, client k8s.Client) ([]byte, error) {
+func buildFleetSetupConfig(agent agentv1alpha1.Agent, client k8s.Client, enrollmentToken string) ([]byte, error) {
 	cfgMap := map[string]interface{}{}
 
 	for _, cfgPart := range []struct {
@@ -181,6 +186,16 @@ func buildFleetSetupConfig(agent agentv1alpha1.Agent, client k8s.Client) ([]byte
 		}
 	}
 
+	if enrollmentToken != "" {
+		// enroll with the token of the agent policy referenced by the Agent, rather than the default one
+		fleetCfg, ok := cfgMap[FleetSetupFleetKey].(map[string]interface{})
+		if !ok {
+			fleetCfg = map[string]interface{}{}
+			cfgMap[FleetSetupFleetKey] = fleetCfg
+		}
+		fleetCfg["enrollment_token"] = enrollmentToken
+	}
+
 	cfg, err := settings.NewCanonicalConfigFrom(cfgMap)
 	if err != nil {
 		return nil, err
@@ -260,6 +275,10 @@ func buildFleetSetupFleetServerConfig(agent agentv1alpha1.Agent, client k8s.Clie
 		"cert_key": path.Join(FleetCertsMountPath, certificates.KeyFileName),
 	}
 
+	if agent.Spec.PolicyID != "" {
+		fleetServerCfg["policy_id"] = agent.Spec.PolicyID
+	}
+
 	esExpected := len(agent.Spec.ElasticsearchRefs) > 0 && agent.Spec.ElasticsearchRefs[0].IsDefined()
 	if esExpected {
 		esConnectionSettings, err := extractConnectionSettings(agent, client, commonv1.ElasticsearchAssociationType)
@@ -367,6 +367,23 @@ func TestBuildFleetSetupFleetServerConfig(t *testing.T) {
 			},
 			client: nil,
 		},
+		{
+			name: "fleet server enabled, policy ID",
+			agent: agentv1alpha1.Agent{
+				Spec: agentv1alpha1.AgentSpec{
+					FleetServerEnabled: true,
+					PolicyID:           "eck-fleet-server",
+				},
+			},
+			wantErr: false,
+			wantCfg: map[string]interface{}{
+				"enable":    true,
+				"cert":      path.Join(FleetCertsMountPath, certificates.CertFileName),
+				"cert_key":  path.Join(FleetCertsMountPath, certificates.KeyFileName),
+				"policy_id": "eck-fleet-server",
+			},
+			client: nil,
+		},
 		{
 			name:    "fleet server enabled, elasticsearch ref, no elasticsearch ca",
 			agent:   agentWithoutCa,
@@ -426,6 +443,21 @@ func TestBuildFleetSetupFleetServerConfig(t *testing.T) {
 	}
 }
 
+func TestBuildFleetSetupConfig_EnrollmentToken(t *testing.T) {
+	agent := agentv1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "ns"},
+		Spec:       agentv1alpha1.AgentSpec{Mode: agentv1alpha1.AgentFleetMode},
+	}
+
+	cfgBytes, err := buildFleetSetupConfig(agent, k8s.NewFakeClient(), "")
+	require.NoError(t, err)
+	require.NotContains(t, string(cfgBytes), "enrollment_token")
+
+	cfgBytes, err = buildFleetSetupConfig(agent, k8s.NewFakeClient(), "token")
+	require.NoError(t, err)
+	require.Contains(t, string(cfgBytes), "enrollment_token: token")
+}
+
 func TestExtractConnectionSettings(t *testing.T) {
 	agentWithoutCa := agentv1alpha1.Agent{
 		ObjectMeta: metav1.ObjectMeta{
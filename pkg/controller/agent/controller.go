@@ -81,8 +81,16 @@ func addWatches(c controller.Controller, r *ReconcileAgent) error {
 		return err
 	}
 
+	// Watch StatefulSets
+	if err := c.Watch(&source.Kind{Type: &appsv1.StatefulSet{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &agentv1alpha1.Agent{},
+	}); err != nil {
+		return err
+	}
+
 	// Watch Pods, to ensure `status.version` is correctly reconciled on any change.
-	// Watching Deployments or DaemonSets only may lead to missing some events.
+	// Watching Deployments, DaemonSets or StatefulSets only may lead to missing some events.
 	if err := watches.WatchPods(c, NameLabelName); err != nil {
 		return err
 	}
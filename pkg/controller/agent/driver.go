@@ -63,6 +63,10 @@ func (p *Params) GetPodTemplate() corev1.PodTemplateSpec {
 		return p.Agent.Spec.DaemonSet.PodTemplate
 	}
 
+	if p.Agent.Spec.StatefulSet != nil {
+		return p.Agent.Spec.StatefulSet.PodTemplate
+	}
+
 	return p.Agent.Spec.Deployment.PodTemplate
 }
 
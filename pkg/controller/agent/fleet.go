@@ -0,0 +1,78 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package agent
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/association"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/certificates"
+	kbclient "github.com/elastic/cloud-on-k8s/pkg/controller/kibana/client"
+)
+
+// fleetEnrollmentToken returns the enrollment token of the agent policy referenced by the Agent, retrieved from the
+// associated Kibana. It is empty if the Agent does not reference any policy, or if it runs Fleet Server which is
+// enrolled with the policy ID directly.
+func fleetEnrollmentToken(params Params) (string, error) {
+	spec := params.Agent.Spec
+	if !spec.FleetModeEnabled() || spec.PolicyID == "" || spec.FleetServerEnabled {
+		return "", nil
+	}
+
+	kbClient, err := newKibanaClient(params)
+	if err != nil {
+		return "", err
+	}
+	defer kbClient.Close()
+
+	keys, err := kbClient.GetEnrollmentAPIKeys(params.Context, spec.PolicyID)
+	if err != nil {
+		return "", err
+	}
+	for _, key := range keys {
+		if key.Active {
+			return key.APIKey, nil
+		}
+	}
+	return "", fmt.Errorf("no active enrollment token found for agent policy %s", spec.PolicyID)
+}
+
+// newKibanaClient returns a client to call the API of the Kibana associated with the Agent, using the credentials of
+// the association.
+func newKibanaClient(params Params) (kbclient.Client, error) {
+	assoc, err := association.SingleAssociationOfType(params.Agent.GetAssociations(), commonv1.KibanaAssociationType)
+	if err != nil {
+		return nil, err
+	}
+	if assoc == nil || !assoc.AssociationConf().IsConfigured() {
+		return nil, fmt.Errorf("association with Kibana %s is not configured yet", params.Agent.Spec.KibanaRef.Name)
+	}
+
+	var caCerts []*x509.Certificate
+	if assoc.AssociationConf().GetCACertProvided() {
+		var caSecret corev1.Secret
+		key := types.NamespacedName{Namespace: params.Agent.Namespace, Name: assoc.AssociationConf().GetCASecretName()}
+		if err := params.Client.Get(context.Background(), key, &caSecret); err != nil {
+			return nil, err
+		}
+		if caCerts, err = certificates.ParsePEMCerts(caSecret.Data[CAFileName]); err != nil {
+			return nil, err
+		}
+	}
+
+	return kbclient.NewKibanaClientForAssociation(
+		params.Client,
+		params.OperatorParams.Dialer,
+		assoc,
+		assoc.AssociationConf().GetURL(),
+		caCerts,
+	)
+}
@@ -11,6 +11,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	agentv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/agent/v1alpha1"
@@ -44,6 +45,7 @@ const (
 	DataVolumeName            = "agent-data"
 	DataMountHostPathTemplate = "/var/lib/%s/%s/agent-data"
 	DataMountPath             = "/usr/share/data"
+	FleetDataMountPath        = "/usr/share/elastic-agent/state"
 
 	// ConfigChecksumLabel is a label used to store Agent config checksum.
 	ConfigChecksumLabel = "agent.k8s.elastic.co/config-checksum"
@@ -53,6 +55,22 @@ const (
 )
 
 var (
+	// DefaultDataVolumeClaim is the default volume claim template for the Agent data directory when running as a
+	// StatefulSet.
+	DefaultDataVolumeClaim = corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: DataVolumeName,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("1Gi"),
+				},
+			},
+		},
+	}
+
 	defaultResources = corev1.ResourceRequirements{
 		Limits: map[corev1.ResourceName]resource.Quantity{
 			corev1.ResourceMemory: resource.MustParse("350Mi"),
@@ -103,8 +121,12 @@ func buildPodTemplate(params Params, fleetCerts *certificates.CertificatesSecret
 		builder = builder.
 			WithResources(defaultResources).
 			WithArgs("-e", "-c", path.Join(ConfigMountPath, ConfigFileName))
+	}
 
-		// volume with agent data path
+	// volume with agent data path, provided by a volume claim template when running as a StatefulSet
+	if spec.StatefulSet != nil {
+		builder = builder.WithVolumeMounts(corev1.VolumeMount{Name: DataVolumeName, MountPath: dataMountPath(*spec)})
+	} else if spec.StandaloneModeEnabled() {
 		vols = append(vols, createDataVolume(params))
 	}
 
@@ -314,6 +336,15 @@ func createDataVolume(params Params) volume.VolumeLike {
 		corev1.HostPathDirectoryOrCreate)
 }
 
+// dataMountPath returns the path of the Agent data directory: the state directory of the Agent in Fleet mode, or the
+// path configured in the standalone Agent configuration.
+func dataMountPath(spec agentv1alpha1.AgentSpec) string {
+	if spec.FleetModeEnabled() {
+		return FleetDataMountPath
+	}
+	return DataMountPath
+}
+
 func certificatesDir(association commonv1.Association) string {
 	ref := association.AssociationRef()
 	return fmt.Sprintf(
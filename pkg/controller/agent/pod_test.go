@@ -550,6 +550,12 @@ func Test_writeEsAssocToConfigHash(t *testing.T) {
 	}
 }
 
+func Test_dataMountPath(t *testing.T) {
+	require.Equal(t, DataMountPath, dataMountPath(agentv1alpha1.AgentSpec{}))
+	require.Equal(t, DataMountPath, dataMountPath(agentv1alpha1.AgentSpec{Mode: agentv1alpha1.AgentStandaloneMode}))
+	require.Equal(t, FleetDataMountPath, dataMountPath(agentv1alpha1.AgentSpec{Mode: agentv1alpha1.AgentFleetMode}))
+}
+
 func generateBuilder() *defaults.PodTemplateBuilder {
 	return defaults.NewPodTemplateBuilder(corev1.PodTemplateSpec{}, "agent")
 }
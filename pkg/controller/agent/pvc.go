@@ -0,0 +1,94 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	agentv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/agent/v1alpha1"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/pointer"
+)
+
+// reconcilePVCs takes care of the PersistentVolumeClaims created by the StatefulSet controller from the volume claim
+// templates, which are not deleted along with the Pods:
+// - the Agent is set as owner of the claims in use, so that they are garbage collected when the Agent is deleted
+// - the claims of Pods which are not expected anymore, after a scale down or a switch to a DaemonSet or a Deployment,
+// are deleted once their Pod is gone.
+func reconcilePVCs(params Params) error {
+	c, agent := params.Client, params.Agent
+	// the claims are labelled with the selector of the StatefulSet
+	var pvcs corev1.PersistentVolumeClaimList
+	if err := c.List(context.Background(), &pvcs, client.InNamespace(agent.Namespace), client.MatchingLabels(NewLabels(agent))); err != nil {
+		return err
+	}
+	if len(pvcs.Items) == 0 {
+		return nil
+	}
+	pods, err := k8s.PodsMatchingLabels(c, agent.Namespace, NewLabels(agent))
+	if err != nil {
+		return err
+	}
+
+	expected := expectedPVCNames(agent)
+	for i := range pvcs.Items {
+		pvc := &pvcs.Items[i]
+		if _, isExpected := expected[pvc.Name]; isExpected {
+			if k8s.HasOwner(pvc, &agent) {
+				continue
+			}
+			if err := controllerutil.SetOwnerReference(&agent, pvc, scheme.Scheme); err != nil {
+				return err
+			}
+			if err := c.Update(context.Background(), pvc); err != nil {
+				return fmt.Errorf("while setting the owner of PVC %s: %w", pvc.Name, err)
+			}
+			continue
+		}
+		if usedByPod(*pvc, pods) {
+			// wait for the Pod to be deleted
+			continue
+		}
+		params.Logger().Info("Deleting PVC", "namespace", pvc.Namespace, "agent_name", agent.Name, "pvc_name", pvc.Name)
+		if err := c.Delete(context.Background(), pvc); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// expectedPVCNames returns the names of the claims created by the StatefulSet controller for the expected Pods.
+func expectedPVCNames(agent agentv1alpha1.Agent) map[string]struct{} {
+	names := make(map[string]struct{})
+	if agent.Spec.StatefulSet == nil {
+		return names
+	}
+	replicas := pointer.Int32OrDefault(agent.Spec.StatefulSet.Replicas, int32(1))
+	for _, claim := range volumeClaimTemplates(agent.Spec.StatefulSet) {
+		for ordinal := int32(0); ordinal < replicas; ordinal++ {
+			names[fmt.Sprintf("%s-%s-%d", claim.Name, Name(agent.Name), ordinal)] = struct{}{}
+		}
+	}
+	return names
+}
+
+// usedByPod returns true if the given claim was created for one of the given Pods: its name is the name of the claim
+// template followed by the name of the Pod.
+func usedByPod(pvc corev1.PersistentVolumeClaim, pods []corev1.Pod) bool {
+	for _, pod := range pods {
+		if strings.HasSuffix(pvc.Name, "-"+pod.Name) {
+			return true
+		}
+	}
+	return false
+}
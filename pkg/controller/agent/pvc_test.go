@@ -0,0 +1,113 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package agent
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+
+	agentv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/agent/v1alpha1"
+	controllerscheme "github.com/elastic/cloud-on-k8s/pkg/controller/common/scheme"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+func Test_reconcilePVCs(t *testing.T) {
+	controllerscheme.SetupScheme()
+	statefulSetAgent := func(replicas int32) agentv1alpha1.Agent {
+		return agentv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "agent", UID: "uid"},
+			Spec:       agentv1alpha1.AgentSpec{StatefulSet: &agentv1alpha1.StatefulSetSpec{Replicas: pointer.Int32(replicas)}},
+		}
+	}
+	daemonSetAgent := agentv1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "agent", UID: "uid"},
+		Spec:       agentv1alpha1.AgentSpec{DaemonSet: &agentv1alpha1.DaemonSetSpec{}},
+	}
+	pvc := func(name string, labels map[string]string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, Labels: labels}}
+	}
+	pod := func(name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, Labels: NewLabels(daemonSetAgent)}}
+	}
+	agentLabels := NewLabels(daemonSetAgent)
+	otherLabels := map[string]string{NameLabelName: "other"}
+
+	tests := []struct {
+		name      string
+		agent     agentv1alpha1.Agent
+		objects   []runtime.Object
+		wantPVCs  []string
+		wantOwned []string
+	}{
+		{
+			name:  "claims in use are owned by the Agent",
+			agent: statefulSetAgent(2),
+			objects: []runtime.Object{
+				pvc("agent-data-agent-agent-0", agentLabels), pvc("agent-data-agent-agent-1", agentLabels),
+				pvc("agent-data-other-agent-0", otherLabels),
+			},
+			wantPVCs:  []string{"agent-data-agent-agent-0", "agent-data-agent-agent-1", "agent-data-other-agent-0"},
+			wantOwned: []string{"agent-data-agent-agent-0", "agent-data-agent-agent-1"},
+		},
+		{
+			name:  "scale down: the claim of the removed Pod is deleted",
+			agent: statefulSetAgent(1),
+			objects: []runtime.Object{
+				pvc("agent-data-agent-agent-0", agentLabels), pvc("agent-data-agent-agent-1", agentLabels),
+				pvc("agent-data-other-agent-1", otherLabels),
+			},
+			wantPVCs:  []string{"agent-data-agent-agent-0", "agent-data-other-agent-1"},
+			wantOwned: []string{"agent-data-agent-agent-0"},
+		},
+		{
+			name:  "scale down: the claim is kept until the Pod is deleted",
+			agent: statefulSetAgent(1),
+			objects: []runtime.Object{
+				pvc("agent-data-agent-agent-0", agentLabels), pvc("agent-data-agent-agent-1", agentLabels),
+				pod("agent-agent-1"),
+			},
+			wantPVCs:  []string{"agent-data-agent-agent-0", "agent-data-agent-agent-1"},
+			wantOwned: []string{"agent-data-agent-agent-0"},
+		},
+		{
+			name:  "switch to a DaemonSet: all the claims are deleted",
+			agent: daemonSetAgent,
+			objects: []runtime.Object{
+				pvc("agent-data-agent-agent-0", agentLabels), pvc("custom-agent-agent-0", agentLabels),
+			},
+			wantPVCs: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := k8s.NewFakeClient(append(tt.objects, &tt.agent)...)
+			require.NoError(t, reconcilePVCs(Params{Context: context.Background(), Client: c, Agent: tt.agent}))
+
+			var pvcs corev1.PersistentVolumeClaimList
+			require.NoError(t, c.List(context.Background(), &pvcs))
+			names := []string{}
+			owned := []string{}
+			for i := range pvcs.Items {
+				names = append(names, pvcs.Items[i].Name)
+				if k8s.HasOwner(&pvcs.Items[i], &tt.agent) {
+					owned = append(owned, pvcs.Items[i].Name)
+				}
+			}
+			sort.Strings(names)
+			require.Equal(t, tt.wantPVCs, names)
+			if tt.wantOwned == nil {
+				tt.wantOwned = []string{}
+			}
+			require.Equal(t, tt.wantOwned, owned)
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/daemonset"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/deployment"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/reconciler"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/statefulset"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
 	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
 	"github.com/elastic/cloud-on-k8s/pkg/utils/pointer"
@@ -19,7 +20,6 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -33,25 +33,14 @@ func reconcilePodVehicle(params Params, podTemplate corev1.PodTemplateSpec) *rec
 	spec := params.Agent.Spec
 	name := Name(params.Agent.Name)
 
-	var toDelete client.Object
 	var reconciliationFunc func(params ReconciliationParams) (int32, int32, error)
 	switch {
 	case spec.DaemonSet != nil:
 		reconciliationFunc = reconcileDaemonSet
-		toDelete = &v1.Deployment{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      name,
-				Namespace: params.Agent.Namespace,
-			},
-		}
 	case spec.Deployment != nil:
 		reconciliationFunc = reconcileDeployment
-		toDelete = &v1.DaemonSet{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      name,
-				Namespace: params.Agent.Namespace,
-			},
-		}
+	case spec.StatefulSet != nil:
+		reconciliationFunc = reconcileStatefulSet
 	}
 
 	ready, desired, err := reconciliationFunc(ReconciliationParams{
@@ -64,15 +53,28 @@ func reconcilePodVehicle(params Params, podTemplate corev1.PodTemplateSpec) *rec
 		return results.WithError(err)
 	}
 
-	// clean up the other one
-	if err := params.Client.Get(context.Background(), types.NamespacedName{
-		Namespace: params.Agent.Namespace,
-		Name:      name,
-	}, toDelete); err == nil {
-		results.WithError(params.Client.Delete(context.Background(), toDelete))
-	} else if !apierrors.IsNotFound(err) {
-		results.WithError(err)
+	// clean up the other ones
+	meta := metav1.ObjectMeta{Name: name, Namespace: params.Agent.Namespace}
+	var toDelete []client.Object
+	if spec.DaemonSet == nil {
+		toDelete = append(toDelete, &v1.DaemonSet{ObjectMeta: meta})
+	}
+	if spec.Deployment == nil {
+		toDelete = append(toDelete, &v1.Deployment{ObjectMeta: meta})
+	}
+	if spec.StatefulSet == nil {
+		toDelete = append(toDelete, &v1.StatefulSet{ObjectMeta: meta})
 	}
+	for _, obj := range toDelete {
+		if err := params.Client.Get(context.Background(), k8s.ExtractNamespacedName(obj), obj); err == nil {
+			results.WithError(params.Client.Delete(context.Background(), obj))
+		} else if !apierrors.IsNotFound(err) {
+			results.WithError(err)
+		}
+	}
+
+	// delete the claims of the StatefulSet Pods which are not expected anymore
+	results.WithError(reconcilePVCs(params))
 
 	err = updateStatus(params, ready, desired)
 	if err != nil && apierrors.IsConflict(err) {
@@ -127,6 +129,54 @@ func reconcileDaemonSet(rp ReconciliationParams) (int32, int32, error) {
 	return reconciled.Status.NumberReady, reconciled.Status.DesiredNumberScheduled, nil
 }
 
+func reconcileStatefulSet(rp ReconciliationParams) (int32, int32, error) {
+	ss := statefulset.New(statefulset.Params{
+		Name:                 Name(rp.agent.Name),
+		Namespace:            rp.agent.Namespace,
+		ServiceName:          statefulSetServiceName(rp.agent),
+		Selector:             NewLabels(rp.agent),
+		Labels:               NewLabels(rp.agent),
+		PodTemplateSpec:      rp.podTemplate,
+		VolumeClaimTemplates: volumeClaimTemplates(rp.agent.Spec.StatefulSet),
+		Replicas:             pointer.Int32OrDefault(rp.agent.Spec.StatefulSet.Replicas, int32(1)),
+		// Agents do not depend on each other, they can be created and deleted in parallel
+		PodManagementPolicy: v1.ParallelPodManagement,
+		UpdateStrategy:      rp.agent.Spec.StatefulSet.UpdateStrategy,
+	})
+	if err := controllerutil.SetControllerReference(&rp.agent, &ss, scheme.Scheme); err != nil {
+		return 0, 0, err
+	}
+
+	reconciled, err := statefulset.Reconcile(rp.client, ss, &rp.agent)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return reconciled.Status.ReadyReplicas, reconciled.Status.Replicas, nil
+}
+
+// statefulSetServiceName returns the name of the Service governing the StatefulSet: the Fleet Server Service if Fleet
+// Server is enabled, none otherwise as other Agents are not meant to be reached.
+func statefulSetServiceName(agent agentv1alpha1.Agent) string {
+	if agent.Spec.FleetServerEnabled {
+		return HTTPServiceName(agent.Name)
+	}
+	return ""
+}
+
+// volumeClaimTemplates returns the volume claim templates of the StatefulSet, including the default claim for the
+// data directory if not specified by the user.
+func volumeClaimTemplates(spec *agentv1alpha1.StatefulSetSpec) []corev1.PersistentVolumeClaim {
+	claims := make([]corev1.PersistentVolumeClaim, 0, len(spec.VolumeClaimTemplates)+1)
+	claims = append(claims, spec.VolumeClaimTemplates...)
+	for _, claim := range claims {
+		if claim.Name == DataVolumeName {
+			return claims
+		}
+	}
+	return append(claims, *DefaultDataVolumeClaim.DeepCopy())
+}
+
 type ReconciliationParams struct {
 	client      k8s.Client
 	agent       agentv1alpha1.Agent
@@ -0,0 +1,53 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package agent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	agentv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/agent/v1alpha1"
+)
+
+func Test_volumeClaimTemplates(t *testing.T) {
+	otherClaim := corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "other"}}
+	customDataClaim := corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: DataVolumeName},
+		Spec:       corev1.PersistentVolumeClaimSpec{StorageClassName: pointerToString("fast")},
+	}
+	tests := []struct {
+		name string
+		spec agentv1alpha1.StatefulSetSpec
+		want []corev1.PersistentVolumeClaim
+	}{
+		{
+			name: "default data claim",
+			spec: agentv1alpha1.StatefulSetSpec{},
+			want: []corev1.PersistentVolumeClaim{DefaultDataVolumeClaim},
+		},
+		{
+			name: "default data claim appended to other claims",
+			spec: agentv1alpha1.StatefulSetSpec{VolumeClaimTemplates: []corev1.PersistentVolumeClaim{otherClaim}},
+			want: []corev1.PersistentVolumeClaim{otherClaim, DefaultDataVolumeClaim},
+		},
+		{
+			name: "user-provided data claim",
+			spec: agentv1alpha1.StatefulSetSpec{VolumeClaimTemplates: []corev1.PersistentVolumeClaim{customDataClaim, otherClaim}},
+			want: []corev1.PersistentVolumeClaim{customDataClaim, otherClaim},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, volumeClaimTemplates(&tt.spec))
+		})
+	}
+}
+
+func pointerToString(s string) *string {
+	return &s
+}
@@ -29,7 +29,10 @@ import (
 	ulog "github.com/elastic/cloud-on-k8s/pkg/utils/log"
 	"go.elastic.co/apm"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -111,6 +114,30 @@ func addWatches(c controller.Controller, r *ReconcileApmServer) error {
 		return err
 	}
 
+	// Watch PodDisruptionBudgets
+	if err := c.Watch(&source.Kind{Type: &policyv1beta1.PodDisruptionBudget{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &apmv1.ApmServer{},
+	}); err != nil {
+		return err
+	}
+
+	// Watch NetworkPolicies
+	if err := c.Watch(&source.Kind{Type: &networkingv1.NetworkPolicy{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &apmv1.ApmServer{},
+	}); err != nil {
+		return err
+	}
+
+	// Watch HorizontalPodAutoscalers, to report their status
+	if err := c.Watch(&source.Kind{Type: &autoscalingv2beta2.HorizontalPodAutoscaler{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &apmv1.ApmServer{},
+	}); err != nil {
+		return err
+	}
+
 	// Watch Pods, to ensure `status.version` and version upgrades are correctly reconciled on any change.
 	// Watching Deployments only may lead to missing some events.
 	if err := watches.WatchPods(c, ApmServerNameLabelName); err != nil {
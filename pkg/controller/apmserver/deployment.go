@@ -13,12 +13,16 @@ import (
 	"go.elastic.co/apm"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	apmv1 "github.com/elastic/cloud-on-k8s/pkg/apis/apm/v1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/certificates"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/deployment"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/hpa"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/keystore"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/networkpolicy"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/pdb"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/pod"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/volume"
@@ -75,11 +79,44 @@ func (r *ReconcileApmServer) reconcileApmServerDeployment(
 		return state, err
 	}
 
+	autoscalingStatus, err := hpa.Reconcile(r.K8sClient(), hpa.Params{
+		Owner:      as,
+		Spec:       as.Spec.Autoscaling,
+		Deployment: params.Name,
+		Labels:     params.Labels,
+	})
+	if err != nil {
+		return state, err
+	}
+
+	err = pdb.Reconcile(r.K8sClient(), pdb.Params{
+		Owner:    as,
+		Template: as.Spec.PodDisruptionBudget,
+		Name:     PodDisruptionBudget(as.Name),
+		Selector: params.Selector,
+		Replicas: hpa.MaxReplicas(as.Spec.Autoscaling, as.Spec.Count),
+	})
+	if err != nil {
+		return state, err
+	}
+
+	err = networkpolicy.Reconcile(r.K8sClient(), networkpolicy.Params{
+		Owner:       as,
+		Enabled:     r.NetworkPoliciesEnabled(as.GetNetworkPolicySettings()),
+		Name:        networkpolicy.Name(Namer, as.Name),
+		Labels:      params.Selector,
+		PodSelector: params.Selector,
+		Ingress:     []networkingv1.NetworkPolicyIngressRule{networkpolicy.FromOperator(r.OperatorNamespace, HTTPPort)},
+	})
+	if err != nil {
+		return state, err
+	}
+
 	pods, err := k8s.PodsMatchingLabels(r.K8sClient(), as.Namespace, map[string]string{ApmServerNameLabelName: as.Name})
 	if err != nil {
 		return state, err
 	}
-	if err := state.UpdateApmServerState(result, pods, tokenSecret); err != nil {
+	if err := state.UpdateApmServerState(result, autoscalingStatus, pods, tokenSecret); err != nil {
 		return state, err
 	}
 	return state, nil
@@ -162,5 +199,6 @@ func (r *ReconcileApmServer) deploymentParams(
 		Labels:          NewLabels(as.Name),
 		PodTemplateSpec: podSpec,
 		Strategy:        appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType},
+		Autoscaled:      as.Spec.Autoscaling != nil,
 	}, nil
 }
@@ -13,6 +13,7 @@ const (
 	httpServiceSuffix = "http"
 	configSuffix      = "config"
 	deploymentSuffix  = "server"
+	pdbSuffix         = "default"
 )
 
 // Namer configured with the defaults for resources related to an APM resource.
@@ -33,3 +34,7 @@ func Deployment(apmName string) string {
 func Config(apmName string) string {
 	return Namer.Suffix(apmName, configSuffix)
 }
+
+func PodDisruptionBudget(apmName string) string {
+	return Namer.Suffix(apmName, pdbSuffix)
+}
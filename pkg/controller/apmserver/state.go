@@ -10,6 +10,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	apmv1 "github.com/elastic/cloud-on-k8s/pkg/apis/apm/v1"
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common"
 )
 
@@ -29,12 +30,18 @@ func NewState(request reconcile.Request, as *apmv1.ApmServer) State {
 	return State{Request: request, ApmServer: as, originalApmServer: as.DeepCopy()}
 }
 
-// UpdateApmServerState updates the ApmServer status based on the given deployment.
-func (s State) UpdateApmServerState(deployment v1.Deployment, pods []corev1.Pod, apmServerSecret corev1.Secret) error {
+// UpdateApmServerState updates the ApmServer status based on the given deployment and its autoscaling status.
+func (s State) UpdateApmServerState(
+	deployment v1.Deployment,
+	autoscalingStatus *commonv1.AutoscalingStatus,
+	pods []corev1.Pod,
+	apmServerSecret corev1.Secret,
+) error {
 	deploymentStatus, err := common.DeploymentStatus(s.ApmServer.Status.DeploymentStatus, deployment, pods, APMVersionLabelName)
 	if err != nil {
 		return err
 	}
+	deploymentStatus.Autoscaling = autoscalingStatus
 	s.ApmServer.Status.DeploymentStatus = deploymentStatus
 	s.ApmServer.Status.SecretTokenSecretName = apmServerSecret.Name
 	return nil
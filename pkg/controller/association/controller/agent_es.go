@@ -61,5 +61,6 @@ func AddAgentES(mgr manager.Manager, accessReviewer rbac.AccessReviewer, params
 				return "superuser", nil
 			},
 		},
+		NetworkPolicyCreation: toElasticsearch(agentPods),
 	})
 }
@@ -45,5 +45,6 @@ func AddAgentKibana(mgr manager.Manager, accessReviewer rbac.AccessReviewer, par
 				return "superuser", nil
 			},
 		},
+		NetworkPolicyCreation: toKibana(agentPods),
 	})
 }
@@ -64,6 +64,7 @@ func AddApmES(mgr manager.Manager, accessReviewer rbac.AccessReviewer, params op
 			UserSecretSuffix: "apm-user",
 			ESUserRole:       getAPMElasticsearchRoles,
 		},
+		NetworkPolicyCreation: toElasticsearch(apmServerPods),
 	})
 }
 
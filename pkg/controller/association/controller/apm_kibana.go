@@ -50,6 +50,7 @@ func AddApmKibana(mgr manager.Manager, accessReviewer rbac.AccessReviewer, param
 				return user.ApmAgentUserRole, nil
 			},
 		},
+		NetworkPolicyCreation: toKibana(apmServerPods),
 	})
 }
 
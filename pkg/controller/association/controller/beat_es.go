@@ -66,6 +66,7 @@ func AddBeatES(mgr manager.Manager, accessReviewer rbac.AccessReviewer, params o
 			UserSecretSuffix: "beat-user",
 			ESUserRole:       getBeatRoles,
 		},
+		NetworkPolicyCreation: toElasticsearch(beatPods),
 	})
 }
 
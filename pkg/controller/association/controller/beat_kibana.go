@@ -49,6 +49,7 @@ func AddBeatKibana(mgr manager.Manager, accessReviewer rbac.AccessReviewer, para
 			UserSecretSuffix: "beat-kb-user",
 			ESUserRole:       getBeatKibanaRoles,
 		},
+		NetworkPolicyCreation: toKibana(beatPods),
 	})
 }
 
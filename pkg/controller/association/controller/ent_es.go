@@ -62,5 +62,6 @@ func AddEntES(mgr manager.Manager, accessReviewer rbac.AccessReviewer, params op
 				return esuser.SuperUserBuiltinRole, nil
 			},
 		},
+		NetworkPolicyCreation: toElasticsearch(entPods),
 	})
 }
@@ -64,5 +64,6 @@ func AddEsMonitoring(mgr manager.Manager, accessReviewer rbac.AccessReviewer, pa
 				return user.StackMonitoringUserRole, nil
 			},
 		},
+		NetworkPolicyCreation: toElasticsearch(elasticsearchPods),
 	})
 }
@@ -53,5 +53,6 @@ func AddKbMonitoring(mgr manager.Manager, accessReviewer rbac.AccessReviewer, pa
 				return user.StackMonitoringUserRole, nil
 			},
 		},
+		NetworkPolicyCreation: toElasticsearch(kibanaPods),
 	})
 }
@@ -41,6 +41,7 @@ func AddKibanaEnt(mgr manager.Manager, accessReviewer rbac.AccessReviewer, param
 		AssociationResourceNameLabelName:      entctl.EnterpriseSearchNameLabelName,
 		AssociationResourceNamespaceLabelName: entctl.EnterpriseSearchNamespaceLabelName,
 		ElasticsearchUserCreation:             nil, // no dedicated ES user required for Kibana->Ent connection
+		NetworkPolicyCreation:                 toEnterpriseSearch(kibanaPods),
 	})
 }
 
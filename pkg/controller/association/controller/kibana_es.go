@@ -65,6 +65,7 @@ func AddKibanaES(mgr manager.Manager, accessReviewer rbac.AccessReviewer, params
 				return KibanaSystemUserBuiltinRole, nil
 			},
 		},
+		NetworkPolicyCreation: toElasticsearch(kibanaPods),
 	})
 }
 
@@ -64,5 +64,6 @@ func AddMapsES(mgr manager.Manager, accessReviewer rbac.AccessReviewer, params o
 				return MapsSystemUserBuiltinRole, nil
 			},
 		},
+		NetworkPolicyCreation: toElasticsearch(mapsPods),
 	})
 }
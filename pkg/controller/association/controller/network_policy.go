@@ -0,0 +1,77 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/elastic/cloud-on-k8s/pkg/controller/agent"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/apmserver"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/association"
+	beatcommon "github.com/elastic/cloud-on-k8s/pkg/controller/beat/common"
+	eslabel "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/label"
+	esnetwork "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/network"
+	entctl "github.com/elastic/cloud-on-k8s/pkg/controller/enterprisesearch"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/kibana"
+	kbnetwork "github.com/elastic/cloud-on-k8s/pkg/controller/kibana/network"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/logstash"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/maps"
+)
+
+type podLabelsFunc func(resource types.NamespacedName) map[string]string
+
+// podsNamed returns a function building labels which select the Pods of a resource with the given name label.
+func podsNamed(nameLabel string) podLabelsFunc {
+	return func(resource types.NamespacedName) map[string]string {
+		return map[string]string{nameLabel: resource.Name}
+	}
+}
+
+var (
+	agentPods         = podsNamed(agent.NameLabelName)
+	apmServerPods     = podsNamed(apmserver.ApmServerNameLabelName)
+	beatPods          = podsNamed(beatcommon.NameLabelName)
+	elasticsearchPods = podsNamed(eslabel.ClusterNameLabelName)
+	entPods           = podsNamed(entctl.EnterpriseSearchNameLabelName)
+	kibanaPods        = podsNamed(kibana.KibanaNameLabelName)
+	logstashPods      = podsNamed(logstash.NameLabelName)
+	mapsPods          = podsNamed(maps.NameLabelName)
+)
+
+// toElasticsearch allows the associated Pods to connect to the HTTP port of the referenced Elasticsearch cluster.
+func toElasticsearch(associatedPods podLabelsFunc) *association.NetworkPolicyCreation {
+	return &association.NetworkPolicyCreation{
+		AssociatedPodLabels: associatedPods,
+		ReferencedPodLabels: elasticsearchPods,
+		ReferencedPort:      esnetwork.HTTPPort,
+	}
+}
+
+// toKibana allows the associated Pods to connect to the HTTP port of the referenced Kibana.
+func toKibana(associatedPods podLabelsFunc) *association.NetworkPolicyCreation {
+	return &association.NetworkPolicyCreation{
+		AssociatedPodLabels: associatedPods,
+		ReferencedPodLabels: kibanaPods,
+		ReferencedPort:      kbnetwork.HTTPPort,
+	}
+}
+
+// toEnterpriseSearch allows the associated Pods to connect to the HTTP port of the referenced Enterprise Search.
+func toEnterpriseSearch(associatedPods podLabelsFunc) *association.NetworkPolicyCreation {
+	return &association.NetworkPolicyCreation{
+		AssociatedPodLabels: associatedPods,
+		ReferencedPodLabels: entPods,
+		ReferencedPort:      entctl.HTTPPort,
+	}
+}
+
+// toFleetServer allows the associated Pods to connect to the referenced Fleet Server.
+func toFleetServer(associatedPods podLabelsFunc) *association.NetworkPolicyCreation {
+	return &association.NetworkPolicyCreation{
+		AssociatedPodLabels: associatedPods,
+		ReferencedPodLabels: agentPods,
+		ReferencedPort:      agent.FleetServerPort,
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package association
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/metrics"
+)
+
+var reportedStatuses = []commonv1.AssociationStatus{
+	commonv1.AssociationPending,
+	commonv1.AssociationEstablished,
+	commonv1.AssociationFailed,
+}
+
+func (r *Reconciler) statusLabels(associated types.NamespacedName, status commonv1.AssociationStatus) prometheus.Labels {
+	return prometheus.Labels{
+		metrics.NamespaceLabel:   associated.Namespace,
+		metrics.NameLabel:        associated.Name,
+		metrics.AssociationLabel: r.AssociationName,
+		metrics.StatusLabel:      string(status),
+	}
+}
+
+// reportStatuses reports the number of associations of the given resource in each status.
+func (r *Reconciler) reportStatuses(associated types.NamespacedName, statuses commonv1.AssociationStatusMap) {
+	counts := make(map[commonv1.AssociationStatus]int)
+	for _, status := range statuses {
+		counts[status]++
+	}
+	for _, status := range reportedStatuses {
+		metrics.AssociationStatusGauge.With(r.statusLabels(associated, status)).Set(float64(counts[status]))
+	}
+}
+
+// deleteStatusMetrics removes the association metrics of the given resource.
+func (r *Reconciler) deleteStatusMetrics(associated types.NamespacedName) {
+	for _, status := range reportedStatuses {
+		metrics.AssociationStatusGauge.Delete(r.statusLabels(associated, status))
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package association
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/metrics"
+)
+
+func TestReconciler_reportStatuses(t *testing.T) {
+	r := Reconciler{AssociationInfo: AssociationInfo{AssociationName: "agent-es"}}
+	agent := types.NamespacedName{Namespace: "ns", Name: "agent"}
+	count := func(status commonv1.AssociationStatus) float64 {
+		return testutil.ToFloat64(metrics.AssociationStatusGauge.With(r.statusLabels(agent, status)))
+	}
+
+	r.reportStatuses(agent, commonv1.AssociationStatusMap{
+		"ns/es1": commonv1.AssociationEstablished,
+		"ns/es2": commonv1.AssociationEstablished,
+		"ns/es3": commonv1.AssociationPending,
+	})
+	require.Equal(t, float64(1), count(commonv1.AssociationPending))
+	require.Equal(t, float64(2), count(commonv1.AssociationEstablished))
+	require.Equal(t, float64(0), count(commonv1.AssociationFailed))
+
+	r.deleteStatusMetrics(agent)
+	for _, status := range reportedStatuses {
+		require.False(t, metrics.AssociationStatusGauge.Delete(r.statusLabels(agent, status)))
+	}
+}
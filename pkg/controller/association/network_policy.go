@@ -0,0 +1,69 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package association
+
+import (
+	"context"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/networkpolicy"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+// NetworkPolicyCreation specifies settings to allow the traffic of the association with a NetworkPolicy, created in the
+// namespace of the referenced resource if NetworkPolicies are enabled for it.
+type NetworkPolicyCreation struct {
+	// AssociatedPodLabels returns labels selecting the Pods of the associated resource connecting to the referenced
+	// resource (eg. the Kibana Pods for a Kibana to Elasticsearch association).
+	AssociatedPodLabels func(associated types.NamespacedName) map[string]string
+	// ReferencedPodLabels returns labels selecting the Pods of the referenced resource.
+	ReferencedPodLabels func(referenced types.NamespacedName) map[string]string
+	// ReferencedPort is the port of the referenced Pods the associated Pods connect to.
+	ReferencedPort int32
+}
+
+// networkPolicyName returns the name of the NetworkPolicy created in the namespace of the referenced resource to allow
+// the traffic of the given association.
+func networkPolicyName(association commonv1.Association, associationName string) string {
+	nameTemplate := association.GetNamespace() + "-" + association.GetName() + "-" + associationName + "%s-network-policy"
+	return commonv1.FormatNameWithID(nameTemplate, association.AssociationID())
+}
+
+// reconcileNetworkPolicy reconciles the NetworkPolicy allowing the associated Pods to connect to the referenced Pods.
+// It is owned by the referenced resource and only exists if NetworkPolicies are enabled for it.
+func (r *Reconciler) reconcileNetworkPolicy(association commonv1.Association) error {
+	if r.NetworkPolicyCreation == nil {
+		return nil
+	}
+
+	referencedKey := association.AssociationRef().NamespacedName()
+	referenced := r.ReferencedObjTemplate()
+	if err := r.Get(context.Background(), referencedKey, referenced); err != nil {
+		return err
+	}
+	enabled := false
+	if holder, ok := referenced.(commonv1.NetworkPolicyHolder); ok {
+		enabled = r.NetworkPoliciesEnabled(holder.GetNetworkPolicySettings())
+	}
+
+	associatedKey := k8s.ExtractNamespacedName(association)
+	return networkpolicy.Reconcile(r.Client, networkpolicy.Params{
+		Owner:       referenced,
+		Enabled:     enabled,
+		Name:        networkPolicyName(association, r.AssociationName),
+		Labels:      r.AssociationResourceLabels(associatedKey, referencedKey),
+		PodSelector: r.NetworkPolicyCreation.ReferencedPodLabels(referencedKey),
+		Ingress: []networkingv1.NetworkPolicyIngressRule{
+			networkpolicy.FromPods(
+				associatedKey.Namespace,
+				r.NetworkPolicyCreation.AssociatedPodLabels(associatedKey),
+				r.NetworkPolicyCreation.ReferencedPort,
+			),
+		},
+	})
+}
@@ -0,0 +1,144 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package association
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+var kbNetworkPolicyKey = types.NamespacedName{Namespace: esNamespace, Name: "kbns-kbname-kb-es-network-policy"}
+
+func networkPolicyTestReconciler(manageNetworkPolicies bool, runtimeObjs ...runtime.Object) Reconciler {
+	r := testReconciler(runtimeObjs...)
+	r.NetworkPolicyCreation = &NetworkPolicyCreation{
+		AssociatedPodLabels: func(associated types.NamespacedName) map[string]string {
+			return map[string]string{"kibana.k8s.elastic.co/name": associated.Name}
+		},
+		ReferencedPodLabels: func(referenced types.NamespacedName) map[string]string {
+			return map[string]string{"elasticsearch.k8s.elastic.co/cluster-name": referenced.Name}
+		},
+		ReferencedPort: 9200,
+	}
+	r.ManageNetworkPolicies = manageNetworkPolicies
+	r.NetworkPoliciesSupported = true
+	return r
+}
+
+func esWithNetworkPolicy(enabled *bool) *esv1.Elasticsearch {
+	es := sampleES.DeepCopy()
+	if enabled != nil {
+		es.Spec.NetworkPolicy = &commonv1.NetworkPolicySettings{Enabled: enabled}
+	}
+	return es
+}
+
+func TestReconciler_reconcileNetworkPolicy(t *testing.T) {
+	varFalse := false
+	tests := []struct {
+		name                  string
+		manageNetworkPolicies bool
+		unsupported           bool
+		es                    *esv1.Elasticsearch
+		existing              bool
+		wantExists            bool
+	}{
+		{
+			name:       "no NetworkPolicy by default",
+			es:         esWithNetworkPolicy(nil),
+			wantExists: false,
+		},
+		{
+			name:                  "NetworkPolicy enabled operator-wide",
+			manageNetworkPolicies: true,
+			es:                    esWithNetworkPolicy(nil),
+			wantExists:            true,
+		},
+		{
+			name:       "NetworkPolicy enabled for the referenced resource",
+			es:         esWithNetworkPolicy(&varTrue),
+			wantExists: true,
+		},
+		{
+			name:                  "NetworkPolicy disabled for the referenced resource",
+			manageNetworkPolicies: true,
+			es:                    esWithNetworkPolicy(&varFalse),
+			existing:              true,
+			wantExists:            false,
+		},
+		{
+			name:                  "namespaces not labelled with their name by the Kubernetes version",
+			manageNetworkPolicies: true,
+			unsupported:           true,
+			es:                    esWithNetworkPolicy(&varTrue),
+			existing:              true,
+			wantExists:            false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs := []runtime.Object{tt.es}
+			if tt.existing {
+				existing := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{
+					Namespace: kbNetworkPolicyKey.Namespace,
+					Name:      kbNetworkPolicyKey.Name,
+				}}
+				require.NoError(t, controllerutil.SetControllerReference(tt.es, existing, k8s.Scheme()))
+				objs = append(objs, existing)
+			}
+			r := networkPolicyTestReconciler(tt.manageNetworkPolicies, objs...)
+			r.NetworkPoliciesSupported = !tt.unsupported
+			kb := sampleKibanaWithESRef()
+
+			require.NoError(t, r.reconcileNetworkPolicy(kb.EsAssociation()))
+
+			var policy networkingv1.NetworkPolicy
+			err := r.Get(context.Background(), kbNetworkPolicyKey, &policy)
+			if !tt.wantExists {
+				require.True(t, apierrors.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, metav1.IsControlledBy(&policy, tt.es))
+			require.Equal(t, "kbname", policy.Labels["kibanaassociation.k8s.elastic.co/name"])
+			require.Equal(t, map[string]string{"elasticsearch.k8s.elastic.co/cluster-name": "esname"}, policy.Spec.PodSelector.MatchLabels)
+			require.Len(t, policy.Spec.Ingress, 1)
+			require.Equal(t, map[string]string{"kibana.k8s.elastic.co/name": "kbname"}, policy.Spec.Ingress[0].From[0].PodSelector.MatchLabels)
+			require.Equal(t, map[string]string{"kubernetes.io/metadata.name": kibanaNamespace}, policy.Spec.Ingress[0].From[0].NamespaceSelector.MatchLabels)
+			require.Equal(t, 9200, policy.Spec.Ingress[0].Ports[0].Port.IntValue())
+		})
+	}
+}
+
+func TestReconciler_Reconcile_DeletesOrphanedNetworkPolicy(t *testing.T) {
+	// setup Kibana with no ES ref, and the NetworkPolicy of a previous association that should be garbage collected
+	kb := sampleKibanaNoEsRef()
+	policy := networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{
+		Namespace: kbNetworkPolicyKey.Namespace,
+		Name:      kbNetworkPolicyKey.Name,
+		Labels: kbAssociationInfo.AssociationResourceLabels(
+			k8s.ExtractNamespacedName(&kb),
+			k8s.ExtractNamespacedName(&sampleES),
+		),
+	}}
+	r := networkPolicyTestReconciler(true, &kb, &policy)
+	_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: k8s.ExtractNamespacedName(&kb)})
+	require.NoError(t, err)
+	err = r.Get(context.Background(), kbNetworkPolicyKey, &networkingv1.NetworkPolicy{})
+	require.True(t, apierrors.IsNotFound(err))
+}
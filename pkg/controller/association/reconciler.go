@@ -26,6 +26,7 @@ import (
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/annotation"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/events"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/name"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/networkpolicy"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/operator"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/reconciler"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
@@ -82,6 +83,10 @@ type AssociationInfo struct {
 	// ElasticsearchUserCreation specifies settings to create an Elasticsearch user as part of the association.
 	// May be nil if no user creation is required.
 	ElasticsearchUserCreation *ElasticsearchUserCreation
+
+	// NetworkPolicyCreation specifies settings to allow the traffic of the association with a NetworkPolicy.
+	// May be nil if the referenced resource does not support NetworkPolicies.
+	NetworkPolicyCreation *NetworkPolicyCreation
 }
 
 type ElasticsearchUserCreation struct {
@@ -214,6 +219,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 
 		newStatusMap[association.AssociationRef().NamespacedName().String()] = newStatus
 	}
+	r.reportStatuses(associatedKey, newStatusMap)
 
 	// we want to attempt a status update even in the presence of errors
 	if err := r.updateStatus(ctx, associated, newStatusMap); err != nil {
@@ -235,6 +241,10 @@ func (r *Reconciler) reconcileAssociation(ctx context.Context, association commo
 		return commonv1.AssociationPending, RemoveAssociationConf(r.Client, association)
 	}
 
+	if err := r.reconcileNetworkPolicy(association); err != nil {
+		return commonv1.AssociationPending, err
+	}
+
 	associationRef := association.AssociationRef()
 	assocLabels := r.AssociationResourceLabels(k8s.ExtractNamespacedName(association.Associated()), association.AssociationRef().NamespacedName())
 
@@ -367,6 +377,15 @@ func (r *Reconciler) Unbind(association commonv1.Association) error {
 		)); err != nil {
 		return err
 	}
+	// Stop allowing the traffic to the referenced resource
+	if err := networkpolicy.DeleteMatching(
+		r.Client,
+		r.AssociationResourceLabels(
+			k8s.ExtractNamespacedName(association),
+			association.AssociationRef().NamespacedName(),
+		)); err != nil {
+		return err
+	}
 	// Also remove the association configuration
 	return RemoveAssociationConf(r.Client, association)
 }
@@ -441,6 +460,8 @@ func (r *Reconciler) onDelete(ctx context.Context, associated types.NamespacedNa
 	// remove watches
 	r.removeWatches(associated)
 
+	r.deleteStatusMetrics(associated)
+
 	// delete user Secret in the Elasticsearch namespace
 	if err := deleteOrphanedResources(ctx, r.Client, r.AssociationInfo, associated, nil); err != nil {
 		r.log(associated).Error(err, "Error while trying to delete orphaned resources. Continuing.")
@@ -9,6 +9,7 @@ import (
 
 	"go.elastic.co/apm"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -43,7 +44,7 @@ func deleteOrphanedResources(
 	for _, secret := range secrets.Items {
 		secret := secret
 		for _, association := range associations {
-			if isSecretForAssociation(info, secret, association) {
+			if isForAssociation(info, &secret, association) {
 				goto nextSecret
 			}
 		}
@@ -57,21 +58,44 @@ func deleteOrphanedResources(
 	nextSecret:
 	}
 
+	// List all the NetworkPolicies allowing the traffic of an association
+	var policies networkingv1.NetworkPolicyList
+	if err := c.List(context.Background(), &policies, associatedLabels); err != nil {
+		return err
+	}
+
+	for _, policy := range policies.Items {
+		policy := policy
+		for _, association := range associations {
+			if isForAssociation(info, &policy, association) {
+				goto nextPolicy
+			}
+		}
+
+		log.Info("Deleting network policy", "namespace", policy.Namespace, "network_policy_name", policy.Name, "associated_name", associated.Name)
+		if err := c.Delete(context.Background(), &policy); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+
+	nextPolicy:
+	}
+
 	return nil
 }
 
-func isSecretForAssociation(info AssociationInfo, secret corev1.Secret, association commonv1.Association) bool {
+// isForAssociation returns true if the given resource has been created for the given association, based on its labels.
+func isForAssociation(info AssociationInfo, obj client.Object, association commonv1.Association) bool {
 	ref := association.AssociationRef()
 
 	// grab name from label (eg. elasticsearch.k8s.elastic.co/cluster-name=elasticsearch1 or kibana.k8s.elastic.co/name=kibana1)
-	resourceName, ok := secret.Labels[info.AssociationResourceNameLabelName]
+	resourceName, ok := obj.GetLabels()[info.AssociationResourceNameLabelName]
 	if !ok || resourceName != ref.Name {
 		// name points to a resource not involved in this `association`
 		return false
 	}
 
 	// grab namespace from label (eg. elasticsearch.k8s.elastic.co/cluster-namespace=default or kibana.k8s.elastic.co/namespace=default)
-	resourceNamespace, ok := secret.Labels[info.AssociationResourceNamespaceLabelName]
+	resourceNamespace, ok := obj.GetLabels()[info.AssociationResourceNamespaceLabelName]
 	if !ok || resourceNamespace != ref.Namespace {
 		// namespace points to a resource not involved in this `association`
 		return false
@@ -5,12 +5,21 @@
 package autoscaling
 
 import (
+	"context"
+
+	autoscalingv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/autoscaling/v1alpha1"
 	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/autoscaling/elasticsearch"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/operator"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	ulog "github.com/elastic/cloud-on-k8s/pkg/utils/log"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
@@ -18,14 +27,56 @@ const (
 	controllerName = "elasticsearch-autoscaling"
 )
 
+var log = ulog.Log.WithName(controllerName)
+
 // Add creates a new Elasticsearch autoscaling controller and adds it to the Manager with default RBAC.
-// The Manager will set fields on the Controller and Start it when the Manager is Started.
+// A second controller, which converts the deprecated autoscaling annotations into ElasticsearchAutoscaler resources,
+// is also added to the Manager.
+// The Manager will set fields on the Controllers and Start them when the Manager is Started.
 func Add(mgr manager.Manager, p operator.Parameters) error {
 	r := elasticsearch.NewReconciler(mgr, p)
 	c, err := common.NewController(mgr, controllerName, r, p)
 	if err != nil {
 		return err
 	}
-	// Watch for changes on Elasticsearch clusters.
-	return c.Watch(&source.Kind{Type: &esv1.Elasticsearch{}}, &handler.EnqueueRequestForObject{})
+	// Watch for changes on ElasticsearchAutoscaler resources.
+	if err := c.Watch(&source.Kind{Type: &autoscalingv1alpha1.ElasticsearchAutoscaler{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+	// Watch for changes on the Elasticsearch clusters managed by an autoscaler.
+	if err := c.Watch(&source.Kind{Type: &esv1.Elasticsearch{}}, handler.EnqueueRequestsFromMapFunc(autoscalersForElasticsearch(mgr.GetClient()))); err != nil {
+		return err
+	}
+
+	migration, err := common.NewController(mgr, elasticsearch.MigrationControllerName, elasticsearch.NewMigrationReconciler(mgr, p), p)
+	if err != nil {
+		return err
+	}
+	// Watch for Elasticsearch clusters which still hold the deprecated autoscaling annotation.
+	return migration.Watch(
+		&source.Kind{Type: &esv1.Elasticsearch{}},
+		&handler.EnqueueRequestForObject{},
+		predicate.NewPredicateFuncs(func(object client.Object) bool {
+			es, ok := object.(*esv1.Elasticsearch)
+			return ok && es.IsAutoscalingAnnotationSet()
+		}),
+	)
+}
+
+// autoscalersForElasticsearch returns a function which maps an Elasticsearch cluster to the autoscalers managing it.
+func autoscalersForElasticsearch(c k8s.Client) handler.MapFunc {
+	return func(object client.Object) []reconcile.Request {
+		var autoscalers autoscalingv1alpha1.ElasticsearchAutoscalerList
+		if err := c.List(context.Background(), &autoscalers, client.InNamespace(object.GetNamespace())); err != nil {
+			log.Error(err, "dropping watch event due to error in handler",
+				"namespace", object.GetNamespace(), "es_name", object.GetName())
+			return nil
+		}
+		managing := autoscalers.Managing(object.GetName())
+		requests := make([]reconcile.Request, 0, len(managing))
+		for _, esa := range managing {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: esa.Namespace, Name: esa.Name}})
+		}
+		return requests
+	}
 }
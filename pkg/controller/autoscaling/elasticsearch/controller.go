@@ -9,8 +9,10 @@ import (
 	"fmt"
 	"time"
 
+	autoscalingv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/autoscaling/v1alpha1"
 	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/autoscaling/elasticsearch/status"
+	esavalidation "github.com/elastic/cloud-on-k8s/pkg/controller/autoscaling/elasticsearch/validation"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/annotation"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/certificates"
@@ -28,6 +30,9 @@ import (
 	logconf "github.com/elastic/cloud-on-k8s/pkg/utils/log"
 	"github.com/elastic/cloud-on-k8s/pkg/utils/net"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -40,6 +45,15 @@ const (
 	controllerName = "elasticsearch-autoscaling"
 
 	enterpriseFeaturesDisabledMsg = "Autoscaling is an enterprise feature. Enterprise features are disabled"
+
+	// Reasons used in the conditions of the autoscaler status.
+	reasonReconciled             = "Reconciled"
+	reasonReconciliationError    = "ReconciliationError"
+	reasonElasticsearchNotFound  = "ElasticsearchNotFound"
+	reasonElasticsearchUnmanaged = "ElasticsearchUnmanaged"
+	reasonOnline                 = "AutoscalingAPIAvailable"
+	reasonOffline                = "AutoscalingAPIUnavailable"
+	reasonLimited                = "ScalingLimitReached"
 )
 
 // licenseCheckRequeue is the default duration used to retry a licence check if the cluster is supposed to be managed by
@@ -49,9 +63,9 @@ var licenseCheckRequeue = reconcile.Result{
 	RequeueAfter: 60 * time.Second,
 }
 
-// ReconcileElasticsearch reconciles autoscaling policies and Elasticsearch resources specifications based on
+// ReconcileElasticsearchAutoscaler reconciles autoscaling policies and Elasticsearch resources specifications based on
 // Elasticsearch autoscaling API response.
-type ReconcileElasticsearch struct {
+type ReconcileElasticsearchAutoscaler struct {
 	k8s.Client
 	operator.Parameters
 	esClientProvider EsClientProvider
@@ -63,9 +77,9 @@ type ReconcileElasticsearch struct {
 }
 
 // NewReconciler returns a new reconcile.Reconciler
-func NewReconciler(mgr manager.Manager, params operator.Parameters) *ReconcileElasticsearch {
+func NewReconciler(mgr manager.Manager, params operator.Parameters) *ReconcileElasticsearchAutoscaler {
 	c := mgr.GetClient()
-	return &ReconcileElasticsearch{
+	return &ReconcileElasticsearchAutoscaler{
 		Client:           c,
 		Parameters:       params,
 		esClientProvider: newElasticsearchClient,
@@ -77,22 +91,47 @@ func NewReconciler(mgr manager.Manager, params operator.Parameters) *ReconcileEl
 // Reconcile updates the ResourceRequirements and PersistentVolumeClaim fields for each elasticsearch container in a
 // NodeSet managed by an autoscaling policy. ResourceRequirements are updated according to the response of the Elasticsearch
 // _autoscaling/capacity API and given the constraints provided by the user in the autoscaling specification.
-func (r *ReconcileElasticsearch) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	ctx = common.NewReconciliationContext(ctx, &r.iteration, r.Tracer, controllerName, "es_name", request)
+func (r *ReconcileElasticsearchAutoscaler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	ctx = common.NewReconciliationContext(ctx, &r.iteration, r.Tracer, controllerName, "esa_name", request)
 	defer common.LogReconciliationRunNoSideEffects(logconf.FromContext(ctx))()
 	defer tracing.EndContextTransaction(ctx)
 
-	// Fetch the Elasticsearch instance
-	var es esv1.Elasticsearch
-	requeue, err := r.fetchElasticsearch(ctx, request, &es)
+	// Fetch the ElasticsearchAutoscaler instance
+	var esa autoscalingv1alpha1.ElasticsearchAutoscaler
+	requeue, err := r.fetchElasticsearchAutoscaler(ctx, request, &esa)
 	if err != nil || requeue {
 		return reconcile.Result{}, tracing.CaptureError(ctx, err)
 	}
 
-	if !es.IsAutoscalingDefined() {
+	if common.IsUnmanaged(&esa) {
+		logconf.FromContext(ctx).Info(
+			"Object is currently not managed by this controller. Skipping reconciliation",
+			"namespace", esa.Namespace, "esa_name", esa.Name,
+		)
 		return reconcile.Result{}, nil
 	}
 
+	currentStatus := *esa.Status.DeepCopy()
+	results := reconciler.NewResult(ctx)
+	result, err := r.reconcileAutoscaler(ctx, &esa)
+	if err != nil {
+		esa.SetCondition(autoscalingv1alpha1.HealthyCondition, metav1.ConditionFalse, reasonReconciliationError, err.Error())
+	} else {
+		esa.SetCondition(autoscalingv1alpha1.HealthyCondition, metav1.ConditionTrue, reasonReconciled, "")
+	}
+	return results.
+		WithResult(result).
+		WithError(err).
+		WithError(r.updateStatus(esa, currentStatus)).
+		Aggregate()
+}
+
+// reconcileAutoscaler reconciles the Elasticsearch cluster referenced by the autoscaler. The conditions in the status
+// of the autoscaler are updated accordingly, it is up to the caller to save them.
+func (r *ReconcileElasticsearchAutoscaler) reconcileAutoscaler(
+	ctx context.Context,
+	esa *autoscalingv1alpha1.ElasticsearchAutoscaler,
+) (reconcile.Result, error) {
 	log := logconf.FromContext(ctx)
 
 	enabled, err := r.licenseChecker.EnterpriseFeaturesEnabled()
@@ -101,77 +140,121 @@ func (r *ReconcileElasticsearch) Reconcile(ctx context.Context, request reconcil
 	}
 	if !enabled {
 		log.Info(enterpriseFeaturesDisabledMsg)
-		r.recorder.Eventf(&es, corev1.EventTypeWarning, license.EventInvalidLicense, enterpriseFeaturesDisabledMsg)
+		r.recorder.Eventf(esa, corev1.EventTypeWarning, license.EventInvalidLicense, enterpriseFeaturesDisabledMsg)
+		esa.SetCondition(autoscalingv1alpha1.ActiveCondition, metav1.ConditionFalse, license.EventInvalidLicense, enterpriseFeaturesDisabledMsg)
 		// We still schedule a reconciliation in case a valid license is applied later
 		return licenseCheckRequeue, nil
 	}
 
+	// Fetch the Elasticsearch cluster managed by the autoscaler
+	var es esv1.Elasticsearch
+	if err := r.Get(context.Background(), esa.ElasticsearchKey(), &es); err != nil {
+		if apierrors.IsNotFound(err) {
+			// The Elasticsearch cluster is watched, we will be notified when it is created.
+			esa.SetCondition(autoscalingv1alpha1.ActiveCondition, metav1.ConditionFalse, reasonElasticsearchNotFound,
+				fmt.Sprintf("Elasticsearch %s not found", esa.Spec.ElasticsearchRef.Name))
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
 	if common.IsUnmanaged(&es) {
-		log.Info("Object is currently not managed by this controller. Skipping reconciliation", "namespace", es.Namespace, "es_name", es.Name)
+		log.Info("Elasticsearch cluster is currently not managed by this controller. Skipping reconciliation", "namespace", es.Namespace, "es_name", es.Name)
+		esa.SetCondition(autoscalingv1alpha1.ActiveCondition, metav1.ConditionFalse, reasonElasticsearchUnmanaged,
+			fmt.Sprintf("Elasticsearch %s is not managed by the operator", es.Name))
 		return reconcile.Result{}, nil
 	}
 
 	compat, err := annotation.CheckCompatibility(&es, r.OperatorInfo.BuildInfo.Version)
 	if err != nil {
-		k8s.EmitErrorEvent(r.recorder, err, &es, events.EventCompatCheckError, "Error during compatibility check: %v", err)
-		return reconcile.Result{}, tracing.CaptureError(ctx, err)
+		k8s.EmitErrorEvent(r.recorder, err, esa, events.EventCompatCheckError, "Error during compatibility check: %v", err)
+		return reconcile.Result{}, err
 	}
 
 	if !compat {
-		// this resource is not able to be reconciled by this version of the controller, so we will skip it and not requeue
+		// the Elasticsearch cluster is not able to be reconciled by this version of the controller, so we will skip it and not requeue
 		return reconcile.Result{}, nil
 	}
 
-	// Get resource policies from the Elasticsearch spec
-	autoscalingSpecification, err := es.GetAutoscalingSpecification()
-	if err != nil {
-		return reconcile.Result{}, tracing.CaptureError(ctx, err)
+	if es.IsAutoscalingAnnotationSet() {
+		// The deprecated annotation must be converted first, it would otherwise override the autoscaler.
+		log.Info("Waiting for the autoscaling annotation to be migrated", "namespace", es.Namespace, "es_name", es.Name)
+		return reconcile.Result{Requeue: true}, nil
 	}
 
 	// Validate Elasticsearch and Autoscaling spec
-	if err := validation.ValidateElasticsearch(es); err != nil {
+	if err := validation.ValidateElasticsearch(r.Client, es); err != nil {
 		log.Error(
 			err,
 			"Elasticsearch manifest validation failed",
 			"namespace", es.Namespace,
 			"es_name", es.Name,
 		)
-		return reconcile.Result{}, tracing.CaptureError(ctx, err)
+		return reconcile.Result{}, err
 	}
-
-	// Build status from annotation or existing resources
-	autoscalingStatus, err := status.From(es)
-	if err != nil {
-		return reconcile.Result{}, tracing.CaptureError(ctx, err)
+	if err := esavalidation.ValidateElasticsearchAutoscaler(r.Client, *esa); err != nil {
+		log.Error(
+			err,
+			"ElasticsearchAutoscaler manifest validation failed",
+			"namespace", esa.Namespace,
+			"esa_name", esa.Name,
+		)
+		return reconcile.Result{}, err
 	}
 
+	esa.SetCondition(autoscalingv1alpha1.ActiveCondition, metav1.ConditionTrue, reasonReconciled,
+		fmt.Sprintf("Elasticsearch %s is managed by the autoscaler", es.Name))
+
+	// Get resource policies from the autoscaler
+	autoscalingSpecification := esa.GetAutoscalingSpecification(es)
+
+	// Build status from the autoscaler or existing resources
+	autoscalingStatus := status.From(*esa)
+
 	if len(autoscalingSpecification.AutoscalingPolicySpecs) == 0 && len(autoscalingStatus.AutoscalingPolicyStatuses) == 0 {
-		// This cluster is not managed by the autoscaler
+		// No autoscaling policy yet
 		return reconcile.Result{}, nil
 	}
 
 	// Get autoscaling policies and the associated node sets.
 	autoscaledNodeSets, nodeSetErr := autoscalingSpecification.GetAutoscaledNodeSets()
 	if nodeSetErr != nil {
-		return reconcile.Result{}, tracing.CaptureError(ctx, nodeSetErr)
+		return reconcile.Result{}, nodeSetErr
 	}
 	log.V(1).Info("Autoscaling policies and node sets", "policies", autoscaledNodeSets.Names())
 
 	// Import existing resources in the current Status if the cluster is managed by some autoscaling policies but
-	// the status annotation does not exist.
+	// the status does not exist.
 	if err := autoscalingStatus.ImportExistingResources(log, r.Client, autoscalingSpecification, autoscaledNodeSets); err != nil {
-		return reconcile.Result{}, tracing.CaptureError(ctx, err)
+		return reconcile.Result{}, err
 	}
 
 	// Call the main function
-	current, err := r.reconcileInternal(ctx, autoscalingStatus, autoscaledNodeSets, autoscalingSpecification, es)
+	current, err := r.reconcileInternal(ctx, esa, autoscalingStatus, autoscaledNodeSets, autoscalingSpecification)
 	if err != nil {
-		return reconcile.Result{}, tracing.CaptureError(ctx, err)
+		return reconcile.Result{}, err
 	}
 	results := &reconciler.Results{}
 	return results.WithResult(defaultResult(autoscalingSpecification)).WithResult(current).Aggregate()
 }
 
+// updateStatus updates the status of the autoscaler if it has been modified during the reconciliation.
+func (r *ReconcileElasticsearchAutoscaler) updateStatus(
+	esa autoscalingv1alpha1.ElasticsearchAutoscaler,
+	currentStatus autoscalingv1alpha1.ElasticsearchAutoscalerStatus,
+) error {
+	esa.Status.ObservedGeneration = &esa.Generation
+	if apiequality.Semantic.DeepEqual(esa.Status, currentStatus) {
+		return nil
+	}
+	err := common.UpdateStatus(r.Client, &esa)
+	if err != nil && apierrors.IsConflict(err) {
+		// The autoscaler has been updated in the meantime, the status will be updated during the next reconciliation.
+		return nil
+	}
+	return err
+}
+
 func defaultResult(autoscalingSpecification esv1.AutoscalingSpec) reconcile.Result {
 	return reconcile.Result{
 		Requeue:      true,
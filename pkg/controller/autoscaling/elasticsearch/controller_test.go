@@ -15,9 +15,11 @@ import (
 	"time"
 
 	"github.com/elastic/cloud-on-k8s/pkg/about"
+	autoscalingv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/autoscaling/v1alpha1"
 	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/autoscaling/elasticsearch/resources"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/autoscaling/elasticsearch/status"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/annotation"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/license"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/operator"
 	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
@@ -28,7 +30,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -219,14 +223,16 @@ func TestReconcile(t *testing.T) {
 				if err := yaml.Unmarshal(bytes, &es); err != nil {
 					t.Fatalf("yaml.Unmarshal error = %v, wantErr %v", err, tt.wantErr)
 				}
+				// The samples still rely on the deprecated annotations, convert them into an autoscaler.
+				esa := autoscalerFromAnnotations(t, &es)
 				if tt.args.isOnline {
-					k8sClient = k8s.NewFakeClient(es.DeepCopy(), fakeService, fakeEndpoints)
+					k8sClient = k8s.NewFakeClient(es.DeepCopy(), esa.DeepCopy(), fakeService, fakeEndpoints)
 				} else {
-					k8sClient = k8s.NewFakeClient(es.DeepCopy())
+					k8sClient = k8s.NewFakeClient(es.DeepCopy(), esa.DeepCopy())
 				}
 			}
 
-			r := &ReconcileElasticsearch{
+			r := &ReconcileElasticsearchAutoscaler{
 				Client:           k8sClient,
 				esClientProvider: tt.fields.EsClient.newFakeElasticsearchClient,
 				Parameters: operator.Parameters{
@@ -250,12 +256,14 @@ func TestReconcile(t *testing.T) {
 				return
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("ReconcileElasticsearch.reconcileInternal() = %v, want %v", got, tt.want)
+				t.Errorf("ReconcileElasticsearchAutoscaler.reconcileInternal() = %v, want %v", got, tt.want)
 			}
 			if tt.args.esManifest != "" {
-				// Get back Elasticsearch from the API Server.
+				// Get back Elasticsearch and the autoscaler from the API Server.
 				updatedElasticsearch := esv1.Elasticsearch{}
 				require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Namespace: "testns", Name: "testes"}, &updatedElasticsearch))
+				updatedAutoscaler := autoscalingv1alpha1.ElasticsearchAutoscaler{}
+				require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Namespace: "testns", Name: "testes"}, &updatedAutoscaler))
 				// Read expected the expected Elasticsearch resource.
 				expectedElasticsearch := esv1.Elasticsearch{}
 				bytes, err := ioutil.ReadFile(filepath.Join("testdata", tt.args.esManifest, "elasticsearch-expected.yml"))
@@ -263,14 +271,18 @@ func TestReconcile(t *testing.T) {
 				require.NoError(t, yaml.Unmarshal(bytes, &expectedElasticsearch))
 				assert.Equal(t, updatedElasticsearch.Spec, expectedElasticsearch.Spec, "Updated Elasticsearch spec. is not the expected one")
 				// Check that the autoscaling spec is still the expected one.
+				expectedAutoscalingSpec, err := expectedElasticsearch.GetAutoscalingSpecificationFromAnnotation()
+				require.NoError(t, err)
 				assert.Equal(
 					t,
-					updatedElasticsearch.Annotations[esv1.ElasticsearchAutoscalingSpecAnnotationName],
-					expectedElasticsearch.Annotations[esv1.ElasticsearchAutoscalingSpecAnnotationName],
+					updatedAutoscaler.Spec.AutoscalingPolicySpecs,
+					expectedAutoscalingSpec.AutoscalingPolicySpecs,
 					"Autoscaling specification is not the expected one",
 				)
 				// Compare the statuses.
-				statusesEqual(t, updatedElasticsearch, expectedElasticsearch)
+				expectedStatus, err := status.FromAnnotation(expectedElasticsearch)
+				require.NoError(t, err)
+				statusesEqual(t, status.From(updatedAutoscaler), expectedStatus)
 				// Check event raised
 				gotEvents := fetchEvents(tt.fields.recorder)
 				require.ElementsMatch(t, tt.wantEvents, gotEvents)
@@ -279,12 +291,110 @@ func TestReconcile(t *testing.T) {
 	}
 }
 
-func statusesEqual(t *testing.T, got, want esv1.Elasticsearch) {
+// autoscalerFromAnnotations builds an autoscaler from the deprecated autoscaling annotations of the sample files.
+// The annotations are removed from the given Elasticsearch resource.
+func autoscalerFromAnnotations(t *testing.T, es *esv1.Elasticsearch) autoscalingv1alpha1.ElasticsearchAutoscaler {
 	t.Helper()
-	gotStatus, err := status.From(got)
+	autoscalingSpec, err := es.GetAutoscalingSpecificationFromAnnotation()
 	require.NoError(t, err)
-	wantStatus, err := status.From(want)
+	autoscalingStatus, err := status.FromAnnotation(*es)
 	require.NoError(t, err)
+	delete(es.Annotations, esv1.ElasticsearchAutoscalingSpecAnnotationName)
+	delete(es.Annotations, status.ElasticsearchAutoscalingStatusAnnotationName)
+	return autoscalingv1alpha1.ElasticsearchAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Namespace: es.Namespace, Name: es.Name},
+		Spec: autoscalingv1alpha1.ElasticsearchAutoscalerSpec{
+			ElasticsearchRef:       autoscalingv1alpha1.ElasticsearchRef{Name: es.Name},
+			AutoscalingPolicySpecs: autoscalingSpec.AutoscalingPolicySpecs,
+			PollingPeriod:          autoscalingSpec.PollingPeriod,
+		},
+		Status: autoscalingv1alpha1.ElasticsearchAutoscalerStatus{
+			AutoscalingPolicyStatuses: autoscalingStatus.ToAutoscalingPolicyStatuses(),
+		},
+	}
+}
+
+func TestReconcile_Conditions(t *testing.T) {
+	esa := autoscalingv1alpha1.ElasticsearchAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "esa"},
+		Spec: autoscalingv1alpha1.ElasticsearchAutoscalerSpec{
+			ElasticsearchRef: autoscalingv1alpha1.ElasticsearchRef{Name: "testes"},
+		},
+	}
+	annotatedES := esv1.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "testns",
+			Name:      "testes",
+			Annotations: map[string]string{
+				annotation.ControllerVersionAnnotation:          "1.5.0",
+				esv1.ElasticsearchAutoscalingSpecAnnotationName: "{}",
+			},
+		},
+		Spec: esv1.ElasticsearchSpec{Version: "7.11.0"},
+	}
+	tests := []struct {
+		name           string
+		objects        []runtime.Object
+		licenseChecker license.Checker
+		want           reconcile.Result
+		wantActive     metav1.ConditionStatus
+		wantReason     string
+	}{
+		{
+			name:           "Elasticsearch cluster does not exist",
+			objects:        []runtime.Object{esa.DeepCopy()},
+			licenseChecker: &fakeLicenceChecker{},
+			want:           reconcile.Result{},
+			wantActive:     metav1.ConditionFalse,
+			wantReason:     reasonElasticsearchNotFound,
+		},
+		{
+			name:           "Enterprise features are disabled",
+			objects:        []runtime.Object{esa.DeepCopy()},
+			licenseChecker: license.MockChecker{MissingLicense: true},
+			want:           licenseCheckRequeue,
+			wantActive:     metav1.ConditionFalse,
+			wantReason:     license.EventInvalidLicense,
+		},
+		{
+			name:           "Autoscaling annotation has not been migrated yet",
+			objects:        []runtime.Object{esa.DeepCopy(), annotatedES.DeepCopy()},
+			licenseChecker: &fakeLicenceChecker{},
+			want:           reconcile.Result{Requeue: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := k8s.NewFakeClient(tt.objects...)
+			r := &ReconcileElasticsearchAutoscaler{
+				Client:         k8sClient,
+				Parameters:     operator.Parameters{OperatorInfo: about.OperatorInfo{BuildInfo: about.BuildInfo{Version: "1.5.0"}}},
+				recorder:       record.NewFakeRecorder(1000),
+				licenseChecker: tt.licenseChecker,
+			}
+			got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: k8s.ExtractNamespacedName(&esa)})
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+
+			var updated autoscalingv1alpha1.ElasticsearchAutoscaler
+			require.NoError(t, k8sClient.Get(context.Background(), k8s.ExtractNamespacedName(&esa), &updated))
+			healthy := meta.FindStatusCondition(updated.Status.Conditions, autoscalingv1alpha1.HealthyCondition)
+			require.NotNil(t, healthy)
+			require.Equal(t, metav1.ConditionTrue, healthy.Status)
+			active := meta.FindStatusCondition(updated.Status.Conditions, autoscalingv1alpha1.ActiveCondition)
+			if tt.wantActive == "" {
+				require.Nil(t, active)
+				return
+			}
+			require.NotNil(t, active)
+			require.Equal(t, tt.wantActive, active.Status)
+			require.Equal(t, tt.wantReason, active.Reason)
+		})
+	}
+}
+
+func statusesEqual(t *testing.T, gotStatus, wantStatus status.Status) {
+	t.Helper()
 	require.Equal(t, len(gotStatus.AutoscalingPolicyStatuses), len(wantStatus.AutoscalingPolicyStatuses))
 	for _, wantPolicyStatus := range wantStatus.AutoscalingPolicyStatuses {
 		gotPolicyStatus := getPolicyStatus(gotStatus.AutoscalingPolicyStatuses, wantPolicyStatus.Name)
@@ -10,6 +10,7 @@ import (
 	"sort"
 	"strings"
 
+	autoscalingv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/autoscaling/v1alpha1"
 	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/autoscaling/elasticsearch/autoscaler"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/autoscaling/elasticsearch/resources"
@@ -21,22 +22,23 @@ import (
 	"github.com/go-logr/logr"
 	"go.elastic.co/apm"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
-func (r *ReconcileElasticsearch) reconcileInternal(
+func (r *ReconcileElasticsearchAutoscaler) reconcileInternal(
 	ctx context.Context,
+	esa *autoscalingv1alpha1.ElasticsearchAutoscaler,
 	autoscalingStatus status.Status,
 	autoscaledNodeSets esv1.AutoscaledNodeSets,
 	autoscalingSpec esv1.AutoscalingSpec,
-	es esv1.Elasticsearch,
 ) (reconcile.Result, error) {
 	defer tracing.Span(&ctx)()
 	results := &reconciler.Results{}
 	log := logconf.FromContext(ctx)
 	statusBuilder := newStatusBuilder(log, autoscalingSpec)
 
-	if esReachable, err := r.isElasticsearchReachable(ctx, es); !esReachable || err != nil {
+	if esReachable, err := r.isElasticsearchReachable(ctx, autoscalingSpec.Elasticsearch); !esReachable || err != nil {
 		// Elasticsearch is not reachable, or we got an error while checking Elasticsearch availability, follow up with an offline reconciliation.
 		if err != nil {
 			log.V(1).Info(
@@ -44,20 +46,55 @@ func (r *ReconcileElasticsearch) reconcileInternal(
 				"error.message", err.Error(),
 			)
 		}
-		return r.doOfflineReconciliation(ctx, statusBuilder, autoscalingStatus, autoscaledNodeSets, autoscalingSpec, results)
+		esa.SetCondition(autoscalingv1alpha1.OnlineCondition, metav1.ConditionFalse, reasonOffline, "Elasticsearch is not available")
+		return r.doOfflineReconciliation(ctx, esa, statusBuilder, autoscalingStatus, autoscaledNodeSets, autoscalingSpec, results)
 	}
 
 	// Cluster is expected to be online and reachable, attempt a call to the autoscaling API.
 	// If an error occurs we still attempt an offline reconciliation to enforce limits set by the user.
-	result, err := r.attemptOnlineReconciliation(ctx, statusBuilder, autoscalingStatus, autoscaledNodeSets, autoscalingSpec, results)
+	result, err := r.attemptOnlineReconciliation(ctx, esa, statusBuilder, autoscalingStatus, autoscaledNodeSets, autoscalingSpec, results)
 	if err != nil {
 		log.Error(tracing.CaptureError(ctx, err), "autoscaling online reconciliation failed")
+		esa.SetCondition(autoscalingv1alpha1.OnlineCondition, metav1.ConditionFalse, reasonOffline, err.Error())
 		// Attempt an offline reconciliation
-		if _, err := r.doOfflineReconciliation(ctx, statusBuilder, autoscalingStatus, autoscaledNodeSets, autoscalingSpec, results); err != nil {
+		if _, err := r.doOfflineReconciliation(ctx, esa, statusBuilder, autoscalingStatus, autoscaledNodeSets, autoscalingSpec, results); err != nil {
 			log.Error(tracing.CaptureError(ctx, err), "autoscaling offline reconciliation failed")
 		}
+		return result, err
 	}
-	return result, err
+	esa.SetCondition(autoscalingv1alpha1.OnlineCondition, metav1.ConditionTrue, reasonOnline, "")
+	return result, nil
+}
+
+// updateLimitedCondition sets the Limited condition of the autoscaler according to the states of the autoscaling policies.
+func updateLimitedCondition(esa *autoscalingv1alpha1.ElasticsearchAutoscaler, autoscalingStatus status.Status) {
+	var limitedPolicies []string
+	for _, policyStatus := range autoscalingStatus.AutoscalingPolicyStatuses {
+		for _, policyState := range policyStatus.PolicyStates {
+			if policyState.Type == status.VerticalScalingLimitReached || policyState.Type == status.HorizontalScalingLimitReached {
+				limitedPolicies = append(limitedPolicies, policyStatus.Name)
+				break
+			}
+		}
+	}
+	if len(limitedPolicies) == 0 {
+		esa.SetCondition(autoscalingv1alpha1.LimitedCondition, metav1.ConditionFalse, reasonReconciled, "")
+		return
+	}
+	sort.Strings(limitedPolicies)
+	esa.SetCondition(autoscalingv1alpha1.LimitedCondition, metav1.ConditionTrue, reasonLimited,
+		fmt.Sprintf("Limits reached for policies: %s", strings.Join(limitedPolicies, ",")))
+}
+
+// updateAutoscalingStatus updates the policy statuses and the Limited condition of the autoscaler.
+func updateAutoscalingStatus(
+	esa *autoscalingv1alpha1.ElasticsearchAutoscaler,
+	statusBuilder *status.AutoscalingStatusBuilder,
+	nextClusterResources resources.ClusterResources,
+	currentAutoscalingStatus status.Status,
+) {
+	status.UpdateAutoscalingStatus(esa, statusBuilder, nextClusterResources, currentAutoscalingStatus)
+	updateLimitedCondition(esa, statusBuilder.Build())
 }
 
 // newStatusBuilder creates a new status builder and initializes it with overlapping policies.
@@ -90,7 +127,7 @@ func newStatusBuilder(log logr.Logger, autoscalingSpec esv1.AutoscalingSpec) *st
 }
 
 // Check if the Service is available.
-func (r *ReconcileElasticsearch) isElasticsearchReachable(ctx context.Context, es esv1.Elasticsearch) (bool, error) {
+func (r *ReconcileElasticsearchAutoscaler) isElasticsearchReachable(ctx context.Context, es esv1.Elasticsearch) (bool, error) {
 	defer tracing.Span(&ctx)()
 	externalService, err := services.GetExternalService(r.Client, es)
 	if apierrors.IsNotFound(err) {
@@ -107,8 +144,9 @@ func (r *ReconcileElasticsearch) isElasticsearchReachable(ctx context.Context, e
 }
 
 // attemptOnlineReconciliation attempts an online autoscaling reconciliation with a call to the Elasticsearch autoscaling API.
-func (r *ReconcileElasticsearch) attemptOnlineReconciliation(
+func (r *ReconcileElasticsearchAutoscaler) attemptOnlineReconciliation(
 	ctx context.Context,
+	esa *autoscalingv1alpha1.ElasticsearchAutoscaler,
 	statusBuilder *status.AutoscalingStatusBuilder,
 	currentAutoscalingStatus status.Status,
 	autoscaledNodeSets esv1.AutoscaledNodeSets,
@@ -199,12 +237,13 @@ func (r *ReconcileElasticsearch) attemptOnlineReconciliation(
 	}
 
 	// Emit the K8S events
-	status.EmitEvents(autoscalingSpec.Elasticsearch, r.recorder, statusBuilder.Build())
+	status.EmitEvents(*esa, r.recorder, statusBuilder.Build())
 
 	// Update the Elasticsearch resource with the calculated resources.
-	if err := reconcileElasticsearch(log, &autoscalingSpec.Elasticsearch, statusBuilder, nextClusterResources, currentAutoscalingStatus); err != nil {
+	if err := reconcileElasticsearch(log, &autoscalingSpec.Elasticsearch, nextClusterResources); err != nil {
 		return reconcile.Result{}, tracing.CaptureError(ctx, err)
 	}
+	updateAutoscalingStatus(esa, statusBuilder, nextClusterResources, currentAutoscalingStatus)
 
 	if results.HasError() {
 		return results.Aggregate()
@@ -221,8 +260,9 @@ func (r *ReconcileElasticsearch) attemptOnlineReconciliation(
 }
 
 // doOfflineReconciliation runs an autoscaling reconciliation if the autoscaling API is not ready (yet).
-func (r *ReconcileElasticsearch) doOfflineReconciliation(
+func (r *ReconcileElasticsearchAutoscaler) doOfflineReconciliation(
 	ctx context.Context,
+	esa *autoscalingv1alpha1.ElasticsearchAutoscaler,
 	statusBuilder *status.AutoscalingStatusBuilder,
 	currentAutoscalingStatus status.Status,
 	autoscaledNodeSets esv1.AutoscaledNodeSets,
@@ -245,12 +285,13 @@ func (r *ReconcileElasticsearch) doOfflineReconciliation(
 	}
 
 	// Emit the K8S events
-	status.EmitEvents(autoscalingSpec.Elasticsearch, r.recorder, statusBuilder.Build())
+	status.EmitEvents(*esa, r.recorder, statusBuilder.Build())
 
 	// Update the Elasticsearch manifest
-	if err := reconcileElasticsearch(log, &autoscalingSpec.Elasticsearch, statusBuilder, clusterNodeSetsResources, currentAutoscalingStatus); err != nil {
+	if err := reconcileElasticsearch(log, &autoscalingSpec.Elasticsearch, clusterNodeSetsResources); err != nil {
 		return reconcile.Result{}, tracing.CaptureError(ctx, err)
 	}
+	updateAutoscalingStatus(esa, statusBuilder, clusterNodeSetsResources, currentAutoscalingStatus)
 
 	// Apply the updated Elasticsearch manifest
 	if err := r.Client.Update(context.Background(), &autoscalingSpec.Elasticsearch); err != nil {
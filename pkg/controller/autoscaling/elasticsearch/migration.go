@@ -0,0 +1,132 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+
+	autoscalingv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/autoscaling/v1alpha1"
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/autoscaling/elasticsearch/status"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/events"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/operator"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	logconf "github.com/elastic/cloud-on-k8s/pkg/utils/log"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	MigrationControllerName = "elasticsearch-autoscaling-migration"
+)
+
+// ReconcileAutoscalingAnnotation converts the deprecated autoscaling annotations of an Elasticsearch resource into an
+// ElasticsearchAutoscaler resource.
+type ReconcileAutoscalingAnnotation struct {
+	k8s.Client
+	operator.Parameters
+	recorder record.EventRecorder
+
+	// iteration is the number of times this controller has run its Reconcile method
+	iteration uint64
+}
+
+// NewMigrationReconciler returns a new reconcile.Reconciler which migrates the autoscaling annotations.
+func NewMigrationReconciler(mgr manager.Manager, params operator.Parameters) *ReconcileAutoscalingAnnotation {
+	return &ReconcileAutoscalingAnnotation{
+		Client:     mgr.GetClient(),
+		Parameters: params,
+		recorder:   mgr.GetEventRecorderFor(MigrationControllerName),
+	}
+}
+
+// Reconcile creates or updates an ElasticsearchAutoscaler, named after the Elasticsearch cluster, from the deprecated
+// autoscaling specification and status annotations. The annotations are then removed from the Elasticsearch resource.
+func (r *ReconcileAutoscalingAnnotation) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	ctx = common.NewReconciliationContext(ctx, &r.iteration, r.Tracer, MigrationControllerName, "es_name", request)
+	defer common.LogReconciliationRunNoSideEffects(logconf.FromContext(ctx))()
+	defer tracing.EndContextTransaction(ctx)
+
+	var es esv1.Elasticsearch
+	if err := r.Get(context.Background(), request.NamespacedName, &es); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, tracing.CaptureError(ctx, err)
+	}
+
+	if !es.IsAutoscalingAnnotationSet() || common.IsUnmanaged(&es) || es.IsMarkedForDeletion() {
+		return reconcile.Result{}, nil
+	}
+
+	if err := migrateAutoscalingAnnotations(r.Client, es); err != nil {
+		k8s.EmitErrorEvent(r.recorder, err, &es, events.EventReasonUnexpected, "Error while migrating the autoscaling annotation: %v", err)
+		return reconcile.Result{}, tracing.CaptureError(ctx, err)
+	}
+
+	logconf.FromContext(ctx).Info("Autoscaling annotation migrated", "namespace", es.Namespace, "es_name", es.Name, "esa_name", es.Name)
+	r.recorder.Eventf(&es, corev1.EventTypeNormal, events.EventReasonUpgraded,
+		"Autoscaling annotation migrated to ElasticsearchAutoscaler %s", es.Name)
+	return reconcile.Result{}, nil
+}
+
+// migrateAutoscalingAnnotations creates or updates the ElasticsearchAutoscaler from the annotations of the given
+// Elasticsearch resource, then removes the annotations.
+// The status annotation is only migrated if the autoscaler does not have a status yet.
+func migrateAutoscalingAnnotations(c k8s.Client, es esv1.Elasticsearch) error {
+	autoscalingSpec, err := es.GetAutoscalingSpecificationFromAnnotation()
+	if err != nil {
+		return fmt.Errorf("while parsing autoscaling specification: %w", err)
+	}
+	autoscalingStatus, err := status.FromAnnotation(es)
+	if err != nil {
+		return fmt.Errorf("while parsing autoscaling status: %w", err)
+	}
+
+	var esa autoscalingv1alpha1.ElasticsearchAutoscaler
+	err = c.Get(context.Background(), types.NamespacedName{Namespace: es.Namespace, Name: es.Name}, &esa)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+	if exists && esa.Spec.ElasticsearchRef.Name != es.Name {
+		return fmt.Errorf("ElasticsearchAutoscaler %s/%s already exists and manages another cluster", esa.Namespace, esa.Name)
+	}
+
+	esa.Namespace = es.Namespace
+	esa.Name = es.Name
+	esa.Spec = autoscalingv1alpha1.ElasticsearchAutoscalerSpec{
+		ElasticsearchRef:       autoscalingv1alpha1.ElasticsearchRef{Name: es.Name},
+		AutoscalingPolicySpecs: autoscalingSpec.AutoscalingPolicySpecs,
+		PollingPeriod:          autoscalingSpec.PollingPeriod,
+	}
+	if exists {
+		err = c.Update(context.Background(), &esa)
+	} else {
+		err = c.Create(context.Background(), &esa)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(esa.Status.AutoscalingPolicyStatuses) == 0 && len(autoscalingStatus.AutoscalingPolicyStatuses) > 0 {
+		esa.Status.AutoscalingPolicyStatuses = autoscalingStatus.ToAutoscalingPolicyStatuses()
+		if err := common.UpdateStatus(c, &esa); err != nil {
+			return err
+		}
+	}
+
+	// The Elasticsearch cluster is now managed through the autoscaler, remove the annotations.
+	delete(es.Annotations, esv1.ElasticsearchAutoscalingSpecAnnotationName)
+	delete(es.Annotations, status.ElasticsearchAutoscalingStatusAnnotationName)
+	return c.Update(context.Background(), &es)
+}
@@ -0,0 +1,144 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package elasticsearch
+
+import (
+	"context"
+	"testing"
+
+	autoscalingv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/autoscaling/v1alpha1"
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/autoscaling/elasticsearch/status"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	autoscalingSpecAnnotation = `{
+	"pollingPeriod": "42s",
+	"policies": [{
+		"name": "data",
+		"roles": ["data"],
+		"resources": {
+			"nodeCount": {"min": 1, "max": 3},
+			"memory": {"min": "2Gi", "max": "8Gi"}
+		}
+	}]
+}`
+	autoscalingStatusAnnotation = `{
+	"policies": [{
+		"name": "data",
+		"nodeSets": [{"name": "data", "nodeCount": 2}],
+		"resources": {"requests": {"memory": "4Gi"}},
+		"lastModificationTime": "2021-10-01T10:00:00Z"
+	}]
+}`
+)
+
+func TestReconcileAutoscalingAnnotation(t *testing.T) {
+	esKey := types.NamespacedName{Namespace: "ns", Name: "es"}
+	annotatedES := func() *esv1.Elasticsearch {
+		return &esv1.Elasticsearch{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: esKey.Namespace,
+				Name:      esKey.Name,
+				Annotations: map[string]string{
+					esv1.ElasticsearchAutoscalingSpecAnnotationName:     autoscalingSpecAnnotation,
+					status.ElasticsearchAutoscalingStatusAnnotationName: autoscalingStatusAnnotation,
+					"other": "annotation",
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name            string
+		objects         []runtime.Object
+		wantErr         bool
+		wantAnnotations map[string]string
+		wantStatus      []autoscalingv1alpha1.AutoscalingPolicyStatus
+	}{
+		{
+			name:            "Create the autoscaler and migrate the status",
+			objects:         []runtime.Object{annotatedES()},
+			wantAnnotations: map[string]string{"other": "annotation"},
+			wantStatus: []autoscalingv1alpha1.AutoscalingPolicyStatus{{
+				Name:             "data",
+				NodeSetNodeCount: []autoscalingv1alpha1.NodeSetNodeCount{{Name: "data", NodeCount: 2}},
+			}},
+		},
+		{
+			name: "Update the existing autoscaler but keep its status",
+			objects: []runtime.Object{
+				annotatedES(),
+				&autoscalingv1alpha1.ElasticsearchAutoscaler{
+					ObjectMeta: metav1.ObjectMeta{Namespace: esKey.Namespace, Name: esKey.Name},
+					Spec: autoscalingv1alpha1.ElasticsearchAutoscalerSpec{
+						ElasticsearchRef: autoscalingv1alpha1.ElasticsearchRef{Name: esKey.Name},
+					},
+					Status: autoscalingv1alpha1.ElasticsearchAutoscalerStatus{
+						AutoscalingPolicyStatuses: []autoscalingv1alpha1.AutoscalingPolicyStatus{{
+							Name:             "data",
+							NodeSetNodeCount: []autoscalingv1alpha1.NodeSetNodeCount{{Name: "data", NodeCount: 3}},
+						}},
+					},
+				},
+			},
+			wantAnnotations: map[string]string{"other": "annotation"},
+			wantStatus: []autoscalingv1alpha1.AutoscalingPolicyStatus{{
+				Name:             "data",
+				NodeSetNodeCount: []autoscalingv1alpha1.NodeSetNodeCount{{Name: "data", NodeCount: 3}},
+			}},
+		},
+		{
+			name: "An autoscaler with the same name manages another cluster",
+			objects: []runtime.Object{
+				annotatedES(),
+				&autoscalingv1alpha1.ElasticsearchAutoscaler{
+					ObjectMeta: metav1.ObjectMeta{Namespace: esKey.Namespace, Name: esKey.Name},
+					Spec: autoscalingv1alpha1.ElasticsearchAutoscalerSpec{
+						ElasticsearchRef: autoscalingv1alpha1.ElasticsearchRef{Name: "another-es"},
+					},
+				},
+			},
+			wantErr:         true,
+			wantAnnotations: annotatedES().Annotations,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := k8s.NewFakeClient(tt.objects...)
+			r := &ReconcileAutoscalingAnnotation{
+				Client:   k8sClient,
+				recorder: record.NewFakeRecorder(100),
+			}
+			_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: esKey})
+			require.Equal(t, tt.wantErr, err != nil, "unexpected error: %v", err)
+
+			var es esv1.Elasticsearch
+			require.NoError(t, k8sClient.Get(context.Background(), esKey, &es))
+			require.Equal(t, tt.wantAnnotations, es.Annotations)
+			if tt.wantErr {
+				return
+			}
+
+			var esa autoscalingv1alpha1.ElasticsearchAutoscaler
+			require.NoError(t, k8sClient.Get(context.Background(), esKey, &esa))
+			require.Equal(t, esKey.Name, esa.Spec.ElasticsearchRef.Name)
+			require.Equal(t, "42s", esa.Spec.PollingPeriod.Duration.String())
+			require.Len(t, esa.Spec.AutoscalingPolicySpecs, 1)
+			require.Equal(t, "data", esa.Spec.AutoscalingPolicySpecs[0].Name)
+			require.Len(t, esa.Status.AutoscalingPolicyStatuses, len(tt.wantStatus))
+			for i := range tt.wantStatus {
+				require.Equal(t, tt.wantStatus[i].Name, esa.Status.AutoscalingPolicyStatuses[i].Name)
+				require.Equal(t, tt.wantStatus[i].NodeSetNodeCount, esa.Status.AutoscalingPolicyStatuses[i].NodeSetNodeCount)
+			}
+		})
+	}
+}
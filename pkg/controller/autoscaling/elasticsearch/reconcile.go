@@ -8,9 +8,9 @@ import (
 	"context"
 	"fmt"
 
+	autoscalingv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/autoscaling/v1alpha1"
 	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/autoscaling/elasticsearch/resources"
-	"github.com/elastic/cloud-on-k8s/pkg/controller/autoscaling/elasticsearch/status"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/validation"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/volume"
@@ -25,13 +25,11 @@ import (
 )
 
 // reconcileElasticsearch updates the resources in the NodeSets of an Elasticsearch spec according to the NodeSetsResources
-// computed by the autoscaling algorithm. It also updates the autoscaling status annotation.
+// computed by the autoscaling algorithm.
 func reconcileElasticsearch(
 	log logr.Logger,
 	es *esv1.Elasticsearch,
-	statusBuilder *status.AutoscalingStatusBuilder,
 	nextClusterResources resources.ClusterResources,
-	currentAutoscalingStatus status.Status,
 ) error {
 	nextResourcesByNodeSet := nextClusterResources.ByNodeSet()
 	for i := range es.Spec.NodeSets {
@@ -76,9 +74,7 @@ func reconcileElasticsearch(
 			log.V(1).Info("Updating nodeset with resources", "nodeset", name, "resources", nextClusterResources)
 		}
 	}
-
-	// Update autoscaling status
-	return status.UpdateAutoscalingStatus(es, statusBuilder, nextClusterResources, currentAutoscalingStatus)
+	return nil
 }
 
 func newVolumeClaimTemplate(storageQuantity resource.Quantity, nodeSet esv1.NodeSet) ([]corev1.PersistentVolumeClaim, error) {
@@ -107,15 +103,15 @@ func newVolumeClaimTemplate(storageQuantity resource.Quantity, nodeSet esv1.Node
 	return []corev1.PersistentVolumeClaim{*volumeClaimTemplate}, nil
 }
 
-func (r *ReconcileElasticsearch) fetchElasticsearch(
+func (r *ReconcileElasticsearchAutoscaler) fetchElasticsearchAutoscaler(
 	ctx context.Context,
 	request reconcile.Request,
-	es *esv1.Elasticsearch,
+	esa *autoscalingv1alpha1.ElasticsearchAutoscaler,
 ) (bool, error) {
-	span, _ := apm.StartSpan(ctx, "fetch_elasticsearch", tracing.SpanTypeApp)
+	span, _ := apm.StartSpan(ctx, "fetch_elasticsearch_autoscaler", tracing.SpanTypeApp)
 	defer span.End()
 
-	err := r.Get(context.Background(), request.NamespacedName, es)
+	err := r.Get(context.Background(), request.NamespacedName, esa)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			return true, nil
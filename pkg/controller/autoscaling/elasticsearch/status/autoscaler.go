@@ -0,0 +1,33 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package status
+
+import (
+	"context"
+	"fmt"
+
+	autoscalingv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/autoscaling/v1alpha1"
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetElasticsearchAutoscaler returns the ElasticsearchAutoscaler which manages the given Elasticsearch cluster, or nil if
+// the cluster is not managed by an autoscaler. An error is returned if the cluster is managed by more than one autoscaler.
+func GetElasticsearchAutoscaler(c k8s.Client, es esv1.Elasticsearch) (*autoscalingv1alpha1.ElasticsearchAutoscaler, error) {
+	var autoscalers autoscalingv1alpha1.ElasticsearchAutoscalerList
+	if err := c.List(context.Background(), &autoscalers, client.InNamespace(es.Namespace)); err != nil {
+		return nil, err
+	}
+	managing := autoscalers.Managing(es.Name)
+	switch len(managing) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &managing[0], nil
+	default:
+		return nil, fmt.Errorf("cluster %s/%s is managed by %d autoscalers", es.Namespace, es.Name, len(managing))
+	}
+}
@@ -7,24 +7,24 @@ package status
 import (
 	"strings"
 
-	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	autoscalingv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/autoscaling/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/record"
 )
 
 // EmitEvents emits a selected type of event on the Kubernetes cluster event channel.
-func EmitEvents(elasticsearch esv1.Elasticsearch, recorder record.EventRecorder, status Status) {
+func EmitEvents(esa autoscalingv1alpha1.ElasticsearchAutoscaler, recorder record.EventRecorder, status Status) {
 	for _, status := range status.AutoscalingPolicyStatuses {
-		emitEventForAutoscalingPolicy(elasticsearch, recorder, status)
+		emitEventForAutoscalingPolicy(esa, recorder, status)
 	}
 }
 
-func emitEventForAutoscalingPolicy(elasticsearch esv1.Elasticsearch, recorder record.EventRecorder, status AutoscalingPolicyStatus) {
+func emitEventForAutoscalingPolicy(esa autoscalingv1alpha1.ElasticsearchAutoscaler, recorder record.EventRecorder, status AutoscalingPolicyStatus) {
 	for _, event := range status.PolicyStates {
 		//nolint:exhaustive
 		switch event.Type {
 		case VerticalScalingLimitReached, HorizontalScalingLimitReached, MemoryRequired, StorageRequired, UnexpectedNodeStorageCapacity:
-			recorder.Event(&elasticsearch, corev1.EventTypeWarning, string(event.Type), strings.Join(event.Messages, ". "))
+			recorder.Event(&esa, corev1.EventTypeWarning, string(event.Type), strings.Join(event.Messages, ". "))
 		}
 	}
 }
@@ -6,13 +6,18 @@ package status
 
 import (
 	"encoding/json"
+	"sort"
 
+	autoscalingv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/autoscaling/v1alpha1"
 	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/autoscaling/elasticsearch/resources"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
+	// ElasticsearchAutoscalingStatusAnnotationName is the name of the annotation which was used to hold the autoscaling
+	// status before the introduction of the ElasticsearchAutoscaler resource.
+	// Deprecated: the annotation is only read to convert it into the status of an ElasticsearchAutoscaler resource.
 	ElasticsearchAutoscalingStatusAnnotationName = "elasticsearch.alpha.elastic.co/autoscaling-status"
 
 	EmptyResponse                  AutoscalingEventType = "EmptyResponse"
@@ -165,7 +170,8 @@ func (psb *AutoscalingStatusBuilder) Build() Status {
 	}
 }
 
-func From(es esv1.Elasticsearch) (Status, error) {
+// FromAnnotation reads the autoscaling status from the deprecated annotation of an Elasticsearch resource.
+func FromAnnotation(es esv1.Elasticsearch) (Status, error) {
 	status := Status{}
 	if es.Annotations == nil {
 		return status, nil
@@ -178,12 +184,83 @@ func From(es esv1.Elasticsearch) (Status, error) {
 	return status, err
 }
 
+// From reads the autoscaling status from the status of an ElasticsearchAutoscaler resource.
+func From(esa autoscalingv1alpha1.ElasticsearchAutoscaler) Status {
+	status := Status{}
+	for _, policyStatus := range esa.Status.AutoscalingPolicyStatuses {
+		nodeSetNodeCount := make(resources.NodeSetNodeCountList, len(policyStatus.NodeSetNodeCount))
+		for i, nodeSet := range policyStatus.NodeSetNodeCount {
+			nodeSetNodeCount[i] = resources.NodeSetNodeCount{Name: nodeSet.Name, NodeCount: nodeSet.NodeCount}
+		}
+		policyStates := make([]PolicyState, len(policyStatus.PolicyStates))
+		for i, policyState := range policyStatus.PolicyStates {
+			policyStates[i] = PolicyState{Type: AutoscalingEventType(policyState.Type), Messages: policyState.Messages}
+		}
+		status.AutoscalingPolicyStatuses = append(status.AutoscalingPolicyStatuses, AutoscalingPolicyStatus{
+			Name:             policyStatus.Name,
+			NodeSetNodeCount: nodeSetNodeCount,
+			ResourcesSpecification: resources.NodeResources{
+				Limits:   policyStatus.ResourcesSpecification.Limits,
+				Requests: policyStatus.ResourcesSpecification.Requests,
+			},
+			PolicyStates:         policyStates,
+			LastModificationTime: policyStatus.LastModificationTime,
+		})
+	}
+	return status
+}
+
+// ToAutoscalingPolicyStatuses converts the status into the policy statuses of an ElasticsearchAutoscaler resource.
+// Policies and states are sorted to get a stable representation of the status.
+func (s Status) ToAutoscalingPolicyStatuses() []autoscalingv1alpha1.AutoscalingPolicyStatus {
+	if len(s.AutoscalingPolicyStatuses) == 0 {
+		return nil
+	}
+	policyStatuses := make([]autoscalingv1alpha1.AutoscalingPolicyStatus, len(s.AutoscalingPolicyStatuses))
+	for i, policyStatus := range s.AutoscalingPolicyStatuses {
+		var nodeSetNodeCount []autoscalingv1alpha1.NodeSetNodeCount
+		for _, nodeSet := range policyStatus.NodeSetNodeCount {
+			nodeSetNodeCount = append(nodeSetNodeCount, autoscalingv1alpha1.NodeSetNodeCount{Name: nodeSet.Name, NodeCount: nodeSet.NodeCount})
+		}
+		var policyStates []autoscalingv1alpha1.PolicyState
+		for _, policyState := range policyStatus.PolicyStates {
+			policyStates = append(policyStates, autoscalingv1alpha1.PolicyState{Type: string(policyState.Type), Messages: policyState.Messages})
+		}
+		sort.SliceStable(policyStates, func(i, j int) bool { return policyStates[i].Type < policyStates[j].Type })
+		policyStatuses[i] = autoscalingv1alpha1.AutoscalingPolicyStatus{
+			Name:             policyStatus.Name,
+			NodeSetNodeCount: nodeSetNodeCount,
+			ResourcesSpecification: autoscalingv1alpha1.NodeResources{
+				Limits:   policyStatus.ResourcesSpecification.Limits,
+				Requests: policyStatus.ResourcesSpecification.Requests,
+			},
+			PolicyStates:         policyStates,
+			LastModificationTime: policyStatus.LastModificationTime,
+		}
+	}
+	sort.SliceStable(policyStatuses, func(i, j int) bool { return policyStatuses[i].Name < policyStatuses[j].Name })
+	return policyStatuses
+}
+
+// HasEvent returns true if at least one of the autoscaling policies is in a state of the given type.
+func (s Status) HasEvent(eventType AutoscalingEventType) bool {
+	for _, policyStatus := range s.AutoscalingPolicyStatuses {
+		for _, policyState := range policyStatus.PolicyStates {
+			if policyState.Type == eventType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// UpdateAutoscalingStatus updates the policy statuses of the autoscaler with the resources computed by the autoscaling algorithm.
 func UpdateAutoscalingStatus(
-	es *esv1.Elasticsearch,
+	esa *autoscalingv1alpha1.ElasticsearchAutoscaler,
 	statusBuilder *AutoscalingStatusBuilder,
 	nextClusterResources resources.ClusterResources,
 	currentAutoscalingStatus Status,
-) error {
+) {
 	// Update the timestamp on tiers resources
 	now := metav1.Now()
 	for _, nextNodeSetResources := range nextClusterResources {
@@ -202,15 +279,5 @@ func UpdateAutoscalingStatus(
 		}
 	}
 
-	// Create the annotation
-	if es.Annotations == nil {
-		es.Annotations = make(map[string]string)
-	}
-	status := statusBuilder.Build()
-	serializedStatus, err := json.Marshal(&status)
-	if err != nil {
-		return err
-	}
-	es.Annotations[ElasticsearchAutoscalingStatusAnnotationName] = string(serializedStatus)
-	return nil
+	esa.Status.AutoscalingPolicyStatuses = statusBuilder.Build().ToAutoscalingPolicyStatuses()
 }
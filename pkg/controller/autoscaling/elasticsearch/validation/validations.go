@@ -0,0 +1,90 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	autoscalingv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/autoscaling/v1alpha1"
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	esvalidation "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/validation"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	autoscalingVersionMsg = "autoscaling is not available in this version of Elasticsearch"
+	alreadyManagedMsg     = "Elasticsearch cluster is already managed by autoscaler %s"
+)
+
+var elasticsearchRefPath = field.NewPath("spec").Child("elasticsearchRef")
+
+// ValidateElasticsearchAutoscaler validates an ElasticsearchAutoscaler. The autoscaling policies are validated on their
+// own, and against the referenced Elasticsearch cluster if it exists.
+func ValidateElasticsearchAutoscaler(c k8s.Client, esa autoscalingv1alpha1.ElasticsearchAutoscaler) error {
+	errs := validateElasticsearchAutoscaler(c, esa)
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: autoscalingv1alpha1.GroupVersion.Group, Kind: autoscalingv1alpha1.Kind},
+			esa.Name,
+			errs,
+		)
+	}
+	return nil
+}
+
+func validateElasticsearchAutoscaler(c k8s.Client, esa autoscalingv1alpha1.ElasticsearchAutoscaler) field.ErrorList {
+	if len(esa.Spec.ElasticsearchRef.Name) == 0 {
+		return field.ErrorList{field.Required(elasticsearchRefPath.Child("name"), "name is mandatory")}
+	}
+
+	errs := esvalidation.ValidateAutoscalingPolicies(autoscalingSpecPath, esa.Spec.AutoscalingPolicySpecs)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	// An Elasticsearch cluster can only be managed by one autoscaler.
+	var autoscalers autoscalingv1alpha1.ElasticsearchAutoscalerList
+	if err := c.List(context.Background(), &autoscalers, client.InNamespace(esa.Namespace)); err != nil {
+		return field.ErrorList{field.InternalError(elasticsearchRefPath, err)}
+	}
+	for _, other := range autoscalers.Managing(esa.Spec.ElasticsearchRef.Name) {
+		if other.Name != esa.Name {
+			errs = append(errs, field.Forbidden(elasticsearchRefPath, fmt.Sprintf(alreadyManagedMsg, other.Name)))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	var es esv1.Elasticsearch
+	if err := c.Get(context.Background(), esa.ElasticsearchKey(), &es); err != nil {
+		if apierrors.IsNotFound(err) {
+			// The Elasticsearch cluster may be created later, it will be validated during the reconciliation.
+			return nil
+		}
+		return field.ErrorList{field.InternalError(elasticsearchRefPath, err)}
+	}
+
+	v, err := version.Parse(es.Spec.Version)
+	if err != nil {
+		return field.ErrorList{field.Invalid(elasticsearchRefPath, es.Spec.Version, err.Error())}
+	}
+	if !v.GTE(esvalidation.ElasticsearchMinAutoscalingVersion) {
+		return field.ErrorList{field.Invalid(elasticsearchRefPath, es.Spec.Version, autoscalingVersionMsg)}
+	}
+
+	return esvalidation.ValidateAutoscalingSpecification(autoscalingSpecPath, esa.GetAutoscalingSpecification(es))
+}
+
+// autoscalingSpecPath helps to compute the path used in validation error fields.
+func autoscalingSpecPath(index int, child string, moreChildren ...string) *field.Path {
+	return field.NewPath("spec").Child("policies").Index(index).Child(child, moreChildren...)
+}
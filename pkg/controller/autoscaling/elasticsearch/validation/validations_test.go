@@ -0,0 +1,114 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package validation
+
+import (
+	"testing"
+
+	autoscalingv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/autoscaling/v1alpha1"
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestValidateElasticsearchAutoscaler(t *testing.T) {
+	dataPolicy := esv1.AutoscalingPolicySpec{
+		NamedAutoscalingPolicy: esv1.NamedAutoscalingPolicy{
+			Name:              "data",
+			AutoscalingPolicy: esv1.AutoscalingPolicy{Roles: []string{"data"}},
+		},
+		AutoscalingResources: esv1.AutoscalingResources{
+			NodeCountRange: esv1.CountRange{Min: 1, Max: 3},
+			MemoryRange:    &esv1.QuantityRange{Min: resource.MustParse("2Gi"), Max: resource.MustParse("8Gi")},
+			StorageRange:   &esv1.QuantityRange{Min: resource.MustParse("5Gi"), Max: resource.MustParse("10Gi")},
+		},
+	}
+	newAutoscaler := func(name, esName string, policies ...esv1.AutoscalingPolicySpec) *autoscalingv1alpha1.ElasticsearchAutoscaler {
+		return &autoscalingv1alpha1.ElasticsearchAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+			Spec: autoscalingv1alpha1.ElasticsearchAutoscalerSpec{
+				ElasticsearchRef:       autoscalingv1alpha1.ElasticsearchRef{Name: esName},
+				AutoscalingPolicySpecs: policies,
+			},
+		}
+	}
+	newElasticsearch := func(version string, roles ...string) *esv1.Elasticsearch {
+		return &esv1.Elasticsearch{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "es"},
+			Spec: esv1.ElasticsearchSpec{
+				Version: version,
+				NodeSets: []esv1.NodeSet{{
+					Name:   "data",
+					Config: &commonv1.Config{Data: map[string]interface{}{"node.roles": roles}},
+				}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		esa           *autoscalingv1alpha1.ElasticsearchAutoscaler
+		objects       []runtime.Object
+		expectedError string
+	}{
+		{
+			name:          "Elasticsearch reference is mandatory",
+			esa:           newAutoscaler("esa", "", dataPolicy),
+			expectedError: "spec.elasticsearchRef.name: Required value",
+		},
+		{
+			name: "Invalid autoscaling policy",
+			esa: newAutoscaler("esa", "es", func() esv1.AutoscalingPolicySpec {
+				p := *dataPolicy.DeepCopy()
+				p.NodeCountRange = esv1.CountRange{Min: 3, Max: 1}
+				return p
+			}()),
+			expectedError: "spec.policies[0].resources.nodeCount.max: Invalid value: 1: max node count must be an integer greater or equal than the min node count",
+		},
+		{
+			name:    "Elasticsearch cluster does not exist yet",
+			esa:     newAutoscaler("esa", "es", dataPolicy),
+			objects: nil,
+		},
+		{
+			name:    "Happy path",
+			esa:     newAutoscaler("esa", "es", dataPolicy),
+			objects: []runtime.Object{newElasticsearch("7.11.0", "data")},
+		},
+		{
+			name:          "Elasticsearch cluster already managed by another autoscaler",
+			esa:           newAutoscaler("esa", "es", dataPolicy),
+			objects:       []runtime.Object{newAutoscaler("other", "es", dataPolicy)},
+			expectedError: "spec.elasticsearchRef: Forbidden: Elasticsearch cluster is already managed by autoscaler other",
+		},
+		{
+			name:          "Autoscaling is not supported",
+			esa:           newAutoscaler("esa", "es", dataPolicy),
+			objects:       []runtime.Object{newElasticsearch("7.10.0", "data")},
+			expectedError: "autoscaling is not available in this version of Elasticsearch",
+		},
+		{
+			name:          "Autoscaling policy does not manage any NodeSet",
+			esa:           newAutoscaler("esa", "es", dataPolicy),
+			objects:       []runtime.Object{newElasticsearch("7.11.0", "ml")},
+			expectedError: "spec.policies[0].roles: Invalid value: []string{\"data\"}: roles must be used in at least one nodeSet",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateElasticsearchAutoscaler(k8s.NewFakeClient(tt.objects...), *tt.esa)
+			if tt.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
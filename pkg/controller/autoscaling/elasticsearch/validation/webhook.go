@@ -0,0 +1,65 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package validation
+
+import (
+	"context"
+	"net/http"
+
+	autoscalingv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/autoscaling/v1alpha1"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	ulog "github.com/elastic/cloud-on-k8s/pkg/utils/log"
+	admissionv1 "k8s.io/api/admission/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/validate-autoscaling-k8s-elastic-co-v1alpha1-elasticsearchautoscaler,mutating=false,failurePolicy=ignore,groups=autoscaling.k8s.elastic.co,resources=elasticsearchautoscalers,verbs=create;update,versions=v1alpha1,name=elastic-esa-validation-v1alpha1.k8s.elastic.co,sideEffects=None,admissionReviewVersions=v1;v1beta1,matchPolicy=Exact
+
+const (
+	webhookPath = "/validate-autoscaling-k8s-elastic-co-v1alpha1-elasticsearchautoscaler"
+)
+
+var esalog = ulog.Log.WithName("esa-validation")
+
+// RegisterWebhook registers the ElasticsearchAutoscaler validating webhook. It needs the k8s client to validate the
+// autoscaling policies against the Elasticsearch cluster they apply to.
+func RegisterWebhook(mgr ctrl.Manager) {
+	wh := &validatingWebhook{
+		client: mgr.GetClient(),
+	}
+	esalog.Info("Registering ElasticsearchAutoscaler validating webhook", "path", webhookPath)
+	mgr.GetWebhookServer().Register(webhookPath, &webhook.Admission{Handler: wh})
+}
+
+type validatingWebhook struct {
+	client  k8s.Client
+	decoder *admission.Decoder
+}
+
+var _ admission.DecoderInjector = &validatingWebhook{}
+
+// InjectDecoder injects the decoder automatically.
+func (wh *validatingWebhook) InjectDecoder(d *admission.Decoder) error {
+	wh.decoder = d
+	return nil
+}
+
+func (wh *validatingWebhook) Handle(_ context.Context, req admission.Request) admission.Response {
+	esa := &autoscalingv1alpha1.ElasticsearchAutoscaler{}
+	if err := wh.decoder.DecodeRaw(req.Object, esa); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if req.Operation == admissionv1.Create || req.Operation == admissionv1.Update {
+		esalog.V(1).Info("validate", "operation", req.Operation, "namespace", esa.Namespace, "name", esa.Name)
+		if err := ValidateElasticsearchAutoscaler(wh.client, *esa); err != nil {
+			return admission.Denied(err.Error())
+		}
+	}
+
+	return admission.Allowed("")
+}
@@ -0,0 +1,105 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package annotation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/set"
+)
+
+// The functions below help to keep track, in the annotations of a resource, of the objects the operator manages
+// through the API of the Elastic Stack application, so that the objects which are not declared anymore can be deleted.
+
+// GetNames returns the set of names serialized as a comma-separated list in the given annotation.
+// If the annotation does not exist the set is empty but not nil.
+func GetNames(obj metav1.Object, annotation string) set.StringSet {
+	names := set.Make()
+	serialized, ok := obj.GetAnnotations()[annotation]
+	if !ok || strings.TrimSpace(serialized) == "" {
+		return names
+	}
+	for _, name := range strings.Split(serialized, ",") {
+		names.Add(name)
+	}
+	return names
+}
+
+// NamesValue returns the annotation value holding the given names as a sorted comma-separated list, or an empty
+// string if there is no name.
+func NamesValue(names set.StringSet) string {
+	sorted := names.AsSlice()
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+// GetJSON unmarshals the JSON value of the given annotation into v. v is left untouched if the annotation does not
+// exist.
+func GetJSON(obj metav1.Object, annotation string, v interface{}) error {
+	serialized, ok := obj.GetAnnotations()[annotation]
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal([]byte(serialized), v)
+}
+
+// JSONValue returns the annotation value holding the JSON serialization of the given map, or an empty string if the
+// map is empty.
+func JSONValue(m interface{}) (string, error) {
+	if reflect.ValueOf(m).Len() == 0 {
+		return "", nil
+	}
+	serialized, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	return string(serialized), nil
+}
+
+// PatchAnnotations sets the given annotations of the resource, an empty value removing the annotation, and patches the
+// resource if one of them has changed. A merge patch is used so that the update does not conflict with other changes
+// made to the resource during the same reconciliation. obj is updated in place with the patched resource, so that its
+// resource version is up-to-date for the following updates.
+func PatchAnnotations(ctx context.Context, c k8s.Client, obj ctrlclient.Object, values map[string]string) error {
+	original, ok := obj.DeepCopyObject().(ctrlclient.Object)
+	if !ok {
+		return fmt.Errorf("cannot copy %T", obj)
+	}
+	annotations := obj.GetAnnotations()
+	changed := false
+	for annotation, value := range values {
+		current, exists := annotations[annotation]
+		switch {
+		case value == "" && exists:
+			delete(annotations, annotation)
+		case value != "" && (!exists || current != value):
+			if annotations == nil {
+				annotations = make(map[string]string)
+			}
+			annotations[annotation] = value
+		default:
+			continue
+		}
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	obj.SetAnnotations(annotations)
+	if err := c.Patch(ctx, obj, ctrlclient.MergeFrom(original)); err != nil {
+		obj.SetAnnotations(original.GetAnnotations())
+		return err
+	}
+	return nil
+}
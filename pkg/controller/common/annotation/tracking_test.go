@@ -0,0 +1,70 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package annotation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/set"
+)
+
+func TestNames(t *testing.T) {
+	es := esv1.Elasticsearch{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"empty": "", "names": "b,a"}}}
+	require.Equal(t, set.Make(), GetNames(&es, "missing"))
+	require.Equal(t, set.Make(), GetNames(&es, "empty"))
+	require.Equal(t, set.Make("a", "b"), GetNames(&es, "names"))
+
+	require.Equal(t, "", NamesValue(set.Make()))
+	require.Equal(t, "a,b", NamesValue(set.Make("b", "a")))
+}
+
+func TestJSON(t *testing.T) {
+	es := esv1.Elasticsearch{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"invalid": "{", "applied": `{"a":"1"}`}}}
+	applied := make(map[string]string)
+	require.NoError(t, GetJSON(&es, "missing", &applied))
+	require.Empty(t, applied)
+	require.Error(t, GetJSON(&es, "invalid", &applied))
+	require.NoError(t, GetJSON(&es, "applied", &applied))
+	require.Equal(t, map[string]string{"a": "1"}, applied)
+
+	value, err := JSONValue(map[string]string{})
+	require.NoError(t, err)
+	require.Equal(t, "", value)
+	value, err = JSONValue(applied)
+	require.NoError(t, err)
+	require.Equal(t, `{"a":"1"}`, value)
+}
+
+func TestPatchAnnotations(t *testing.T) {
+	es := esv1.Elasticsearch{ObjectMeta: metav1.ObjectMeta{
+		Namespace:   "ns",
+		Name:        "es",
+		Annotations: map[string]string{"other": "value", "removed": "a"},
+	}}
+	c := k8s.NewFakeClient(&es)
+	key := types.NamespacedName{Namespace: "ns", Name: "es"}
+	initialVersion := es.ResourceVersion
+
+	// nothing changed: no patch
+	require.NoError(t, PatchAnnotations(context.Background(), c, &es, map[string]string{"removed": "a", "missing": ""}))
+	require.Equal(t, initialVersion, es.ResourceVersion)
+
+	// annotations are set or removed, the resource is updated in place
+	require.NoError(t, PatchAnnotations(context.Background(), c, &es, map[string]string{"removed": "", "added": "b"}))
+	require.NotEqual(t, initialVersion, es.ResourceVersion)
+	require.Equal(t, map[string]string{"other": "value", "added": "b"}, es.Annotations)
+
+	var stored esv1.Elasticsearch
+	require.NoError(t, c.Get(context.Background(), key, &stored))
+	require.Equal(t, stored.ResourceVersion, es.ResourceVersion)
+	require.Equal(t, map[string]string{"other": "value", "added": "b"}, stored.Annotations)
+}
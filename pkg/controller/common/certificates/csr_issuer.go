@@ -0,0 +1,206 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package certificates
+
+import (
+	"context"
+	"crypto"
+	cryptorand "crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/hash"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// csrIssuer issues certificates signed by an external signer, such as cert-manager or a signer backed by a corporate
+// PKI, through Kubernetes CertificateSigningRequests.
+// CertificateSigningRequests must be approved by an approver other than the operator before being signed. They are
+// deleted once the certificate is retrieved.
+type csrIssuer struct {
+	client     k8s.Client
+	signerName string
+	ca         *CA
+}
+
+var _ Issuer = &csrIssuer{}
+
+// NewCSRIssuer returns an Issuer requesting certificates to the given signer, whose CA certificate is the one of the
+// given CA.
+func NewCSRIssuer(c k8s.Client, signerName string, ca *CA) Issuer {
+	return &csrIssuer{client: c, signerName: signerName, ca: ca}
+}
+
+// RetrieveIssuerCA returns the CA of the given external issuer, from the Secret referenced by the issuer in the given
+// namespace. Its private key is not available.
+func RetrieveIssuerCA(c k8s.Client, namespace string, issuer commonv1.CertificateIssuer) (*CA, error) {
+	var secret corev1.Secret
+	nsn := types.NamespacedName{Namespace: namespace, Name: issuer.CASecretName}
+	if err := c.Get(context.Background(), nsn, &secret); err != nil {
+		return nil, err
+	}
+	caData, exists := secret.Data[CAFileName]
+	if !exists {
+		return nil, fmt.Errorf("no %s entry in the CA secret %s of the certificate issuer %s", CAFileName, nsn, issuer.SignerName)
+	}
+	certs, err := ParsePEMCerts(caData)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse the CA certificate of the certificate issuer %s: %w", issuer.SignerName, err)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no CA certificate found in the CA secret %s of the certificate issuer %s", nsn, issuer.SignerName)
+	}
+	return NewCA(nil, certs[0]), nil
+}
+
+func (i *csrIssuer) CA() *CA {
+	return i.ca
+}
+
+func (i *csrIssuer) CompatiblePrivateKey(secret *corev1.Secret, fileName string) crypto.Signer {
+	keyData, exists := secret.Data[fileName]
+	if !exists {
+		return nil
+	}
+	privateKey, err := ParsePEMPrivateKey(keyData)
+	if err != nil {
+		log.Error(err, "Unable to parse stored private key", "namespace", secret.Namespace, "secret_name", secret.Name, "cert_key_filename", fileName)
+		return nil
+	}
+	return privateKey
+}
+
+func (i *csrIssuer) NewPrivateKey() (crypto.Signer, error) {
+	return rsa.GenerateKey(cryptorand.Reader, 2048)
+}
+
+func (i *csrIssuer) Issue(ctx context.Context, request CertificateRequest) ([]byte, error) {
+	csrData, csrName, err := createCertificateSigningRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var csr certificatesv1.CertificateSigningRequest
+	err = i.client.Get(ctx, types.NamespacedName{Name: csrName}, &csr)
+	if apierrors.IsNotFound(err) {
+		return nil, i.createCSR(ctx, csrName, csrData, request)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, condition := range csr.Status.Conditions {
+		if condition.Type != certificatesv1.CertificateDenied && condition.Type != certificatesv1.CertificateFailed {
+			continue
+		}
+		if condition.Status == corev1.ConditionFalse {
+			continue
+		}
+		// delete the request so it can be retried
+		if err := i.deleteCSR(ctx, csr); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf(
+			"certificate signing request %s for %s/%s was %s: %s %s",
+			csrName, request.Owner.Namespace, request.Name, strings.ToLower(string(condition.Type)), condition.Reason, condition.Message,
+		)
+	}
+
+	if len(csr.Status.Certificate) == 0 {
+		log.V(1).Info("Certificate signing request not issued yet",
+			"namespace", request.Owner.Namespace, "owner_name", request.Owner.Name, "csr_name", csrName, "signer_name", i.signerName)
+		return nil, nil
+	}
+
+	certs, err := ParsePEMCerts(csr.Status.Certificate)
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) == 0 || !PrivateMatchesPublicKey(certs[0].PublicKey, request.PrivateKey) {
+		return nil, fmt.Errorf("certificate issued for the certificate signing request %s does not match the private key", csrName)
+	}
+	log.Info("Certificate issued", "namespace", request.Owner.Namespace, "owner_name", request.Owner.Name, "csr_name", csrName)
+	return csr.Status.Certificate, i.deleteCSR(ctx, csr)
+}
+
+func (i *csrIssuer) createCSR(ctx context.Context, name string, csrData []byte, request CertificateRequest) error {
+	csr := certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			Request:    csrData,
+			SignerName: i.signerName,
+			Usages:     certificateSigningRequestUsages(request.Template),
+		},
+	}
+	log.Info("Creating certificate signing request",
+		"namespace", request.Owner.Namespace, "owner_name", request.Owner.Name, "csr_name", name, "signer_name", i.signerName)
+	if err := i.client.Create(ctx, &csr); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+func (i *csrIssuer) deleteCSR(ctx context.Context, csr certificatesv1.CertificateSigningRequest) error {
+	if err := i.client.Delete(ctx, &csr); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// createCertificateSigningRequest returns the PEM encoded certificate signing request for the given request, along
+// with the name of the corresponding CertificateSigningRequest. The name depends on the subject, the SANs and the
+// public key of the certificate, so that a new CertificateSigningRequest is created when one of them changes.
+func createCertificateSigningRequest(request CertificateRequest) ([]byte, string, error) {
+	template := x509.CertificateRequest{
+		Subject:         request.Template.Subject,
+		DNSNames:        request.Template.DNSNames,
+		IPAddresses:     request.Template.IPAddresses,
+		ExtraExtensions: request.Template.ExtraExtensions,
+	}
+	csrDER, err := x509.CreateCertificateRequest(cryptorand.Reader, &template, request.PrivateKey)
+	if err != nil {
+		return nil, "", err
+	}
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	if err != nil {
+		return nil, "", err
+	}
+	// the signature may not be deterministic, only consider the content of the request
+	name := fmt.Sprintf("%s-%s-%s", request.Owner.Namespace, request.Name, hash.HashObject(csr.RawTBSCertificateRequest))
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER}), name, nil
+}
+
+// certificateSigningRequestUsages returns the usages of a CertificateSigningRequest matching the ones of the given
+// certificate template.
+func certificateSigningRequestUsages(template ValidatedCertificateTemplate) []certificatesv1.KeyUsage {
+	var usages []certificatesv1.KeyUsage
+	if template.KeyUsage&x509.KeyUsageDigitalSignature != 0 {
+		usages = append(usages, certificatesv1.UsageDigitalSignature)
+	}
+	if template.KeyUsage&x509.KeyUsageKeyEncipherment != 0 {
+		usages = append(usages, certificatesv1.UsageKeyEncipherment)
+	}
+	for _, usage := range template.ExtKeyUsage {
+		//nolint:exhaustive
+		switch usage {
+		case x509.ExtKeyUsageServerAuth:
+			usages = append(usages, certificatesv1.UsageServerAuth)
+		case x509.ExtKeyUsageClientAuth:
+			usages = append(usages, certificatesv1.UsageClientAuth)
+		}
+	}
+	return usages
+}
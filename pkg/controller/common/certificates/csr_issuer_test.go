@@ -0,0 +1,216 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package certificates
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/watches"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/stretchr/testify/require"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const testSignerName = "example.com/corporate-pki"
+
+// listCSRs returns the CertificateSigningRequests existing in the apiserver.
+func listCSRs(t *testing.T, c k8s.Client) []certificatesv1.CertificateSigningRequest {
+	t.Helper()
+	var csrs certificatesv1.CertificateSigningRequestList
+	require.NoError(t, c.List(context.Background(), &csrs))
+	return csrs.Items
+}
+
+// signCSR simulates the external signer by issuing the certificate requested by the given CertificateSigningRequest
+// with the given CA.
+func signCSR(t *testing.T, c k8s.Client, csr certificatesv1.CertificateSigningRequest, ca *CA) {
+	t.Helper()
+	block, _ := pem.Decode(csr.Spec.Request)
+	require.NotNil(t, block)
+	request, err := x509.ParseCertificateRequest(block.Bytes)
+	require.NoError(t, err)
+	certData, err := ca.CreateCertificate(ValidatedCertificateTemplate(x509.Certificate{
+		Subject:         request.Subject,
+		DNSNames:        request.DNSNames,
+		IPAddresses:     request.IPAddresses,
+		ExtraExtensions: request.Extensions,
+		PublicKey:       request.PublicKey,
+		NotBefore:       time.Now().Add(-10 * time.Minute),
+		NotAfter:        time.Now().Add(DefaultCertValidity),
+	}))
+	require.NoError(t, err)
+	csr.Status.Conditions = []certificatesv1.CertificateSigningRequestCondition{
+		{Type: certificatesv1.CertificateApproved, Status: corev1.ConditionTrue},
+	}
+	csr.Status.Certificate = EncodePEMCert(certData, ca.Cert.Raw)
+	require.NoError(t, c.Update(context.Background(), &csr))
+}
+
+func Test_csrIssuer_Issue(t *testing.T) {
+	c := k8s.NewFakeClient()
+	issuer := NewCSRIssuer(c, testSignerName, NewCA(nil, testCA.Cert))
+	template := createValidatedHTTPCertificateTemplate(
+		k8s.ExtractNamespacedName(&testES), esv1.ESNamer, testES.Spec.HTTP.TLS, nil, []corev1.Service{testSvc},
+		&x509.CertificateRequest{}, DefaultCertValidity,
+	)
+	request := CertificateRequest{
+		Owner:      k8s.ExtractNamespacedName(&testES),
+		Name:       "test-es-name-es-http-certs-internal",
+		Template:   *template,
+		PrivateKey: testRSAPrivateKey,
+	}
+
+	// a CertificateSigningRequest is created
+	cert, err := issuer.Issue(context.Background(), request)
+	require.NoError(t, err)
+	require.Nil(t, cert)
+	csrs := listCSRs(t, c)
+	require.Len(t, csrs, 1)
+	require.Equal(t, testSignerName, csrs[0].Spec.SignerName)
+	require.Equal(t, []certificatesv1.KeyUsage{
+		certificatesv1.UsageDigitalSignature, certificatesv1.UsageKeyEncipherment,
+		certificatesv1.UsageServerAuth, certificatesv1.UsageClientAuth,
+	}, csrs[0].Spec.Usages)
+
+	// it is not issued yet
+	cert, err = issuer.Issue(context.Background(), request)
+	require.NoError(t, err)
+	require.Nil(t, cert)
+	require.Len(t, listCSRs(t, c), 1)
+
+	// once signed, the certificate is returned and the CertificateSigningRequest deleted
+	signCSR(t, c, csrs[0], testCA)
+	cert, err = issuer.Issue(context.Background(), request)
+	require.NoError(t, err)
+	certs, err := ParsePEMCerts(cert)
+	require.NoError(t, err)
+	require.Len(t, certs, 2)
+	require.Equal(t, template.Subject.CommonName, certs[0].Subject.CommonName)
+	require.Equal(t, template.DNSNames, certs[0].DNSNames)
+	require.Empty(t, listCSRs(t, c))
+
+	// a denied CertificateSigningRequest is reported and deleted
+	request.PrivateKey, err = issuer.NewPrivateKey()
+	require.NoError(t, err)
+	_, err = issuer.Issue(context.Background(), request)
+	require.NoError(t, err)
+	csrs = listCSRs(t, c)
+	require.Len(t, csrs, 1)
+	csrs[0].Status.Conditions = []certificatesv1.CertificateSigningRequestCondition{
+		{Type: certificatesv1.CertificateDenied, Status: corev1.ConditionTrue, Reason: "PolicyDenied", Message: "not allowed"},
+	}
+	require.NoError(t, c.Update(context.Background(), &csrs[0]))
+	_, err = issuer.Issue(context.Background(), request)
+	require.EqualError(t, err, "certificate signing request "+csrs[0].Name+
+		" for test-namespace/test-es-name-es-http-certs-internal was denied: PolicyDenied not allowed")
+	require.Empty(t, listCSRs(t, c))
+}
+
+func TestRetrieveIssuerCA(t *testing.T) {
+	issuer := commonv1.CertificateIssuer{SignerName: testSignerName, CASecretName: "issuer-ca"}
+	tests := []struct {
+		name    string
+		secret  *corev1.Secret
+		wantErr bool
+	}{
+		{
+			name:    "no CA secret",
+			wantErr: true,
+		},
+		{
+			name: "no CA certificate",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "issuer-ca"},
+				Data:       map[string][]byte{CertFileName: EncodePEMCert(testCA.Cert.Raw)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "CA certificate",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "issuer-ca"},
+				Data:       map[string][]byte{CAFileName: EncodePEMCert(testCA.Cert.Raw)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := k8s.NewFakeClient()
+			if tt.secret != nil {
+				c = k8s.NewFakeClient(tt.secret)
+			}
+			ca, err := RetrieveIssuerCA(c, "ns", issuer)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Nil(t, ca.PrivateKey)
+			require.Equal(t, testCA.Cert.Raw, ca.Cert.Raw)
+		})
+	}
+}
+
+func TestReconcileCAAndHTTPCerts_ExternalIssuer(t *testing.T) {
+	c := k8s.NewFakeClient(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: obj.Namespace, Name: "issuer-ca"},
+		Data:       map[string][]byte{CAFileName: EncodePEMCert(testCA.Cert.Raw)},
+	})
+	r := Reconciler{
+		K8sClient:      c,
+		DynamicWatches: watches.NewDynamicWatches(),
+		Owner:          &obj,
+		TLSOptions: commonv1.TLSOptions{
+			Issuer: &commonv1.CertificateIssuer{SignerName: testSignerName, CASecretName: "issuer-ca"},
+		},
+		Namer:          esv1.ESNamer,
+		Labels:         labels,
+		CACertRotation: rotation,
+		CertRotation:   rotation,
+	}
+
+	// the certificate is requested but not issued yet
+	httpCerts, results := r.ReconcileCAAndHTTPCerts(context.Background())
+	_, err := results.Aggregate()
+	require.EqualError(t, err, "HTTP certificate for ns/es not issued yet")
+	require.Nil(t, httpCerts)
+	var internalCerts corev1.Secret
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: obj.Namespace, Name: InternalCertsSecretName(esv1.ESNamer, obj.Name)}, &internalCerts))
+	require.NotEmpty(t, internalCerts.Data[KeyFileName])
+	require.Empty(t, internalCerts.Data[CertFileName])
+	csrs := listCSRs(t, c)
+	require.Len(t, csrs, 1)
+
+	// the issued certificate is stored along with the CA of the issuer
+	signCSR(t, c, csrs[0], testCA)
+	httpCerts, results = r.ReconcileCAAndHTTPCerts(context.Background())
+	aggregateResult, err := results.Aggregate()
+	require.NoError(t, err)
+	require.NotZero(t, aggregateResult.RequeueAfter)
+	require.NotEmpty(t, httpCerts.CertPem())
+	require.Equal(t, EncodePEMCert(testCA.Cert.Raw), httpCerts.CAPem())
+	require.Empty(t, listCSRs(t, c))
+
+	// no self-signed CA is created
+	var caCerts corev1.Secret
+	err = c.Get(context.Background(), types.NamespacedName{Namespace: obj.Namespace, Name: CAInternalSecretName(esv1.ESNamer, obj.Name, HTTPCAType)}, &caCerts)
+	require.Error(t, err)
+
+	// running again should lead to the same results without requesting a new certificate
+	httpCerts2, results := r.ReconcileCAAndHTTPCerts(context.Background())
+	_, err = results.Aggregate()
+	require.NoError(t, err)
+	require.Equal(t, httpCerts.CertPem(), httpCerts2.CertPem())
+	require.Empty(t, listCSRs(t, c))
+}
@@ -16,6 +16,12 @@ func CertificateWatchKey(namer name.Namer, ownerName string) string {
 	return namer.Suffix(ownerName, "http-certificate")
 }
 
+// IssuerCAWatchKey returns the key used by the dynamic watch registration for the CA certificate of the external issuer
+// of http certificates
+func IssuerCAWatchKey(namer name.Namer, ownerName string) string {
+	return namer.Suffix(ownerName, "http-issuer-ca")
+}
+
 // ReconcileCustomCertWatch takes a SecretRef and either creates or removes a dynamic watch for watchKey depending on
 // whether secretRef empty or not.
 func ReconcileCustomCertWatch(
@@ -51,8 +51,9 @@ func (r Reconciler) ReconcilePublicHTTPCerts(internalCerts *CertificatesSecret)
 	return err
 }
 
-// ReconcileInternalHTTPCerts reconciles the internal resources for the HTTP certificate.
-func (r Reconciler) ReconcileInternalHTTPCerts(ca *CA, customCertificates *CertificatesSecret) (*CertificatesSecret, error) {
+// ReconcileInternalHTTPCerts reconciles the internal resources for the HTTP certificate, either provided by the user
+// or issued by the given issuer.
+func (r Reconciler) ReconcileInternalHTTPCerts(issuer Issuer, customCertificates *CertificatesSecret) (*CertificatesSecret, error) {
 	ownerNSN := k8s.ExtractNamespacedName(r.Owner)
 
 	watchKey := CertificateWatchKey(r.Namer, ownerNSN.Name)
@@ -111,7 +112,7 @@ func (r Reconciler) ReconcileInternalHTTPCerts(ca *CA, customCertificates *Certi
 			// Ensure that the CA certificate is never empty, otherwise Elasticsearch is not able to reload the certificates.
 			// Default to our self-signed (useless) CA if none is provided by the user.
 			// See https://github.com/elastic/cloud-on-k8s/issues/2243
-			expectedSecretData[CAFileName] = EncodePEMCert(ca.Cert.Raw)
+			expectedSecretData[CAFileName] = EncodePEMCert(issuer.CA().Cert.Raw)
 			// The CA has been set in the internal HTTP secret but it's only for convenience, in order to circumvent the
 			// aforementioned issue. We need to remove it later from the result.
 			caCertProvided = false
@@ -123,7 +124,7 @@ func (r Reconciler) ReconcileInternalHTTPCerts(ca *CA, customCertificates *Certi
 		}
 	} else {
 		selfSignedNeedsUpdate, err := ensureInternalSelfSignedCertificateSecretContents(
-			&secret, ownerNSN, r.Namer, r.TLSOptions, r.ExtraHTTPSANs, r.Services, ca, r.CertRotation,
+			&secret, ownerNSN, r.Namer, r.TLSOptions, r.ExtraHTTPSANs, r.Services, issuer, r.CertRotation,
 		)
 		if err != nil {
 			return nil, err
@@ -155,8 +156,9 @@ func (r Reconciler) ReconcileInternalHTTPCerts(ca *CA, customCertificates *Certi
 	return &internalCerts, nil
 }
 
-// ensureInternalSelfSignedCertificateSecretContents ensures that contents of a secret containing self-signed
-// certificates is valid. The provided secret is updated in-place.
+// ensureInternalSelfSignedCertificateSecretContents ensures that contents of a secret containing certificates
+// issued by the given issuer is valid. The provided secret is updated in-place. The certificate is left unchanged if a
+// new certificate is not issued yet.
 //
 // Returns true if the secret was changed.
 func ensureInternalSelfSignedCertificateSecretContents(
@@ -166,17 +168,17 @@ func ensureInternalSelfSignedCertificateSecretContents(
 	tls commonv1.TLSOptions,
 	controllerSANs []commonv1.SubjectAlternativeName,
 	svcs []corev1.Service,
-	ca *CA,
+	issuer Issuer,
 	rotationParam RotationParams,
 ) (bool, error) {
 	secretWasChanged := false
 
 	// verify that the secret contains a parsable and compatible private key
-	privateKey := GetCompatiblePrivateKey(ca.PrivateKey, secret, KeyFileName)
+	privateKey := issuer.CompatiblePrivateKey(secret, KeyFileName)
 
 	// if we need a new private key, generate it
 	if privateKey == nil {
-		generatedPrivateKey, err := NewPrivateKey(ca.PrivateKey)
+		generatedPrivateKey, err := issuer.NewPrivateKey()
 		if err != nil {
 			return false, err
 		}
@@ -190,6 +192,7 @@ func ensureInternalSelfSignedCertificateSecretContents(
 	}
 
 	// check if the existing cert should be re-issued
+	ca := issuer.CA()
 	if shouldIssueNewHTTPCertificate(owner, namer, tls, controllerSANs, secret, svcs, ca, rotationParam.RotateBefore) {
 		log.Info(
 			"Issuing new HTTP certificate",
@@ -214,16 +217,25 @@ func ensureInternalSelfSignedCertificateSecretContents(
 		validatedCertificateTemplate := createValidatedHTTPCertificateTemplate(
 			owner, namer, tls, controllerSANs, svcs, parsedCSR, rotationParam.Validity,
 		)
-		// sign the certificate
-		certData, err := ca.CreateCertificate(*validatedCertificateTemplate)
+		// issue the certificate
+		certChain, err := issuer.Issue(context.Background(), CertificateRequest{
+			Owner:      owner,
+			Name:       secret.Name,
+			Template:   *validatedCertificateTemplate,
+			PrivateKey: privateKey,
+		})
 		if err != nil {
 			return secretWasChanged, err
 		}
+		if certChain == nil {
+			log.Info("Waiting for the HTTP certificate to be issued", "namespace", secret.Namespace, "secret_name", secret.Name)
+			return secretWasChanged, nil
+		}
 
 		secretWasChanged = true
 		// store certificate and signed certificate in a secret mounted into the pod
 		secret.Data[CAFileName] = EncodePEMCert(ca.Cert.Raw)
-		secret.Data[CertFileName] = EncodePEMCert(certData, ca.Cert.Raw)
+		secret.Data[CertFileName] = certChain
 	}
 
 	return secretWasChanged, nil
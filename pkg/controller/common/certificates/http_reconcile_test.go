@@ -369,7 +369,7 @@ func TestReconcileInternalHTTPCerts(t *testing.T) {
 					Validity:     DefaultCertValidity,
 					RotateBefore: DefaultRotateBefore,
 				},
-			}.ReconcileInternalHTTPCerts(tt.args.ca, tt.args.custCerts)
+			}.ReconcileInternalHTTPCerts(NewCAIssuer(tt.args.ca), tt.args.custCerts)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ReconcileInternalHTTPCerts() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -0,0 +1,72 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package certificates
+
+import (
+	"context"
+	"crypto"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// CertificateRequest is a request for a certificate to be issued.
+type CertificateRequest struct {
+	// Owner is the resource the certificate is issued for.
+	Owner types.NamespacedName
+	// Name identifies the certificate among the certificates of the owner, eg. the name of a Pod.
+	Name string
+	// Template holds the subject, the SANs, the key usages and the validity of the certificate.
+	Template ValidatedCertificateTemplate
+	// PrivateKey is the private key of the certificate.
+	PrivateKey crypto.Signer
+}
+
+// Issuer issues certificates signed by a certificate authority.
+type Issuer interface {
+	// CA returns the certificate authority whose certificate must be trusted to verify the issued certificates. Its
+	// private key is not available if the certificates are signed by an external signer.
+	CA() *CA
+	// CompatiblePrivateKey returns the private key stored in the given Secret entry if it can be used for the
+	// certificates issued by this issuer, nil otherwise.
+	CompatiblePrivateKey(secret *corev1.Secret, fileName string) crypto.Signer
+	// NewPrivateKey generates a new private key for a certificate issued by this issuer.
+	NewPrivateKey() (crypto.Signer, error)
+	// Issue issues a certificate for the given request and returns the PEM encoded certificate chain. A nil chain is
+	// returned if the certificate is not issued yet, in which case the request must be retried later.
+	Issue(ctx context.Context, request CertificateRequest) ([]byte, error)
+}
+
+// caIssuer issues certificates signed by a CA whose private key is known by the operator.
+type caIssuer struct {
+	ca *CA
+}
+
+var _ Issuer = &caIssuer{}
+
+// NewCAIssuer returns an Issuer signing certificates with the given CA.
+func NewCAIssuer(ca *CA) Issuer {
+	return &caIssuer{ca: ca}
+}
+
+func (i *caIssuer) CA() *CA {
+	return i.ca
+}
+
+func (i *caIssuer) CompatiblePrivateKey(secret *corev1.Secret, fileName string) crypto.Signer {
+	return GetCompatiblePrivateKey(i.ca.PrivateKey, secret, fileName)
+}
+
+func (i *caIssuer) NewPrivateKey() (crypto.Signer, error) {
+	return NewPrivateKey(i.ca.PrivateKey)
+}
+
+func (i *caIssuer) Issue(_ context.Context, request CertificateRequest) ([]byte, error) {
+	certData, err := i.ca.CreateCertificate(request.Template)
+	if err != nil {
+		return nil, err
+	}
+	return EncodePEMCert(certData, i.ca.Cert.Raw), nil
+}
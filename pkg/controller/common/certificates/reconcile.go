@@ -6,6 +6,7 @@ package certificates
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
@@ -67,13 +68,33 @@ func (r Reconciler) ReconcileCAAndHTTPCerts(ctx context.Context) (*CertificatesS
 		return nil, results.WithError(err)
 	}
 
-	var httpCa *CA
-	if customCerts.HasCAPrivateKey() {
+	// watch the Secret holding the CA certificate of the external issuer, if any
+	externalIssuer := r.TLSOptions.ExternalIssuer()
+	var issuerCASecret commonv1.SecretRef
+	if externalIssuer != nil {
+		issuerCASecret.SecretName = externalIssuer.CASecretName
+	}
+	if err := ReconcileCustomCertWatch(
+		r.DynamicWatches, IssuerCAWatchKey(r.Namer, r.Owner.GetName()), k8s.ExtractNamespacedName(r.Owner), issuerCASecret,
+	); err != nil {
+		return nil, results.WithError(err)
+	}
+
+	var httpIssuer Issuer
+	switch {
+	case customCerts.HasCAPrivateKey():
 		// if we have user-provided CA cert + key use that
-		httpCa = customCerts.CA()
-	} else {
+		httpIssuer = NewCAIssuer(customCerts.CA())
+	case externalIssuer != nil:
+		// request the certificate to the external issuer
+		issuerCA, err := RetrieveIssuerCA(r.K8sClient, r.Owner.GetNamespace(), *externalIssuer)
+		if err != nil {
+			return nil, results.WithError(err)
+		}
+		httpIssuer = NewCSRIssuer(r.K8sClient, externalIssuer.SignerName, issuerCA)
+	default:
 		// if not then reconcile self-signed CA
-		httpCa, err = ReconcileCAForOwner(
+		httpCa, err := ReconcileCAForOwner(
 			r.K8sClient,
 			r.Namer,
 			r.Owner,
@@ -88,13 +109,18 @@ func (r Reconciler) ReconcileCAAndHTTPCerts(ctx context.Context) (*CertificatesS
 		results.WithResult(reconcile.Result{
 			RequeueAfter: ShouldRotateIn(time.Now(), httpCa.Cert.NotAfter, r.CACertRotation.RotateBefore),
 		})
+		httpIssuer = NewCAIssuer(httpCa)
 	}
 
-	// reconcile http customCerts: either self-signed or user-provided
-	httpCertificates, err := r.ReconcileInternalHTTPCerts(httpCa, customCerts)
+	// reconcile http customCerts: either issued or user-provided
+	httpCertificates, err := r.ReconcileInternalHTTPCerts(httpIssuer, customCerts)
 	if err != nil {
 		return nil, results.WithError(err)
 	}
+	if len(httpCertificates.CertPem()) == 0 {
+		// the certificate is not issued yet by the external issuer
+		return nil, results.WithError(fmt.Errorf("HTTP certificate for %s/%s not issued yet", r.Owner.GetNamespace(), r.Owner.GetName()))
+	}
 	primaryCert, err := GetPrimaryCertificate(httpCertificates.CertPem())
 	if err != nil {
 		return nil, results.WithError(err)
@@ -129,8 +155,9 @@ func (r *Reconciler) removeCAAndHTTPCertsSecrets() error {
 		return err
 	}
 
-	// remove watches on user-provided certs secret
+	// remove watches on user-provided certs secret and on the CA secret of the external issuer
 	r.DynamicWatches.Secrets.RemoveHandlerForKey(CertificateWatchKey(r.Namer, r.Owner.GetName()))
+	r.DynamicWatches.Secrets.RemoveHandlerForKey(IssuerCAWatchKey(r.Namer, r.Owner.GetName()))
 
 	return nil
 }
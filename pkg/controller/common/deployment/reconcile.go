@@ -29,10 +29,17 @@ type Params struct {
 	PodTemplateSpec corev1.PodTemplateSpec
 	Replicas        int32
 	Strategy        appsv1.DeploymentStrategy
+	// Autoscaled indicates the number of replicas is managed by a HorizontalPodAutoscaler, in which case Replicas is
+	// ignored and the current number of replicas of the Deployment is preserved.
+	Autoscaled bool
 }
 
 // New creates a Deployment from the given params.
 func New(params Params) appsv1.Deployment {
+	var replicas *int32
+	if !params.Autoscaled {
+		replicas = &params.Replicas
+	}
 	return appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      params.Name,
@@ -45,13 +52,14 @@ func New(params Params) appsv1.Deployment {
 				MatchLabels: params.Selector,
 			},
 			Template: params.PodTemplateSpec,
-			Replicas: &params.Replicas,
+			Replicas: replicas,
 			Strategy: params.Strategy,
 		},
 	}
 }
 
 // ReconcileDeployment creates or updates the given deployment for the specified owner.
+// If the expected deployment does not specify a number of replicas, the current one is preserved.
 func Reconcile(
 	k8sClient k8s.Client,
 	expected appsv1.Deployment,
@@ -71,7 +79,12 @@ func Reconcile(
 			return hash.GetTemplateHashLabel(reconciled.Labels) != hash.GetTemplateHashLabel(expected.Labels)
 		},
 		UpdateReconciled: func() {
+			replicas := reconciled.Spec.Replicas
 			expected.DeepCopyInto(reconciled)
+			if expected.Spec.Replicas == nil {
+				// replicas are managed by a HorizontalPodAutoscaler
+				reconciled.Spec.Replicas = replicas
+			}
 		},
 	})
 	return *reconciled, err
@@ -93,3 +93,33 @@ func TestReconcile(t *testing.T) {
 	require.NoError(t, err)
 	comparison.RequireEqual(t, &reconciled, &retrieved)
 }
+
+func TestReconcile_Autoscaled(t *testing.T) {
+	controllerscheme.SetupScheme()
+	k8sClient := k8s.NewFakeClient()
+	params := Params{Name: "dep", Namespace: "ns", Replicas: 2, Labels: map[string]string{"a": "b"}}
+	owner := esv1.Elasticsearch{} // can be any type
+
+	reconciled, err := Reconcile(k8sClient, New(params), &owner)
+	require.NoError(t, err)
+	require.Equal(t, pointer.Int32(2), reconciled.Spec.Replicas)
+
+	// simulate a HorizontalPodAutoscaler scaling the deployment
+	reconciled.Spec.Replicas = pointer.Int32(5)
+	require.NoError(t, k8sClient.Update(context.Background(), &reconciled))
+
+	// replicas are not specified anymore once autoscaled
+	params.Autoscaled = true
+	expected := New(params)
+	require.Nil(t, expected.Spec.Replicas)
+
+	// the current number of replicas should be preserved when the deployment is updated
+	params.Labels = map[string]string{"a": "c"}
+	reconciled, err = Reconcile(k8sClient, New(params), &owner)
+	require.NoError(t, err)
+	require.Equal(t, "c", reconciled.Labels["a"])
+	require.Equal(t, pointer.Int32(5), reconciled.Spec.Replicas)
+	var retrieved appsv1.Deployment
+	require.NoError(t, k8sClient.Get(context.Background(), k8s.ExtractNamespacedName(&reconciled), &retrieved))
+	require.Equal(t, pointer.Int32(5), retrieved.Spec.Replicas)
+}
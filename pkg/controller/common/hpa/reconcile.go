@@ -0,0 +1,163 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package hpa
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/hash"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/reconciler"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/maps"
+)
+
+// Params to specify the HorizontalPodAutoscaler managing the number of replicas of the Deployment of a resource.
+type Params struct {
+	// Owner is the resource owning the Deployment. It is set as the controller of the HPA.
+	Owner client.Object
+	// Spec is the autoscaling specification from the resource specification, if any.
+	Spec *commonv1.AutoscalingSpec
+	// Deployment is the name of the scaled Deployment, also used as the name of the HPA.
+	Deployment string
+	// Labels to set on the HPA.
+	Labels map[string]string
+}
+
+// Reconcile ensures that the HorizontalPodAutoscaler of the Deployment of the owner matches the given params, or that
+// it does not exist if autoscaling is not specified. It returns the autoscaling status to report in the status of the
+// owner, nil if there is no HPA.
+func Reconcile(k8sClient k8s.Client, params Params) (*commonv1.AutoscalingStatus, error) {
+	expected := Expected(params)
+	if expected == nil {
+		return nil, deleteHPA(k8sClient, params)
+	}
+
+	// label the HPA with a hash of its content, for comparison purposes
+	expected.Labels = hash.SetTemplateHashLabel(expected.Labels, expected)
+
+	reconciled := &autoscalingv2beta2.HorizontalPodAutoscaler{}
+	err := reconciler.ReconcileResource(reconciler.Params{
+		Client:     k8sClient,
+		Owner:      params.Owner,
+		Expected:   expected,
+		Reconciled: reconciled,
+		NeedsUpdate: func() bool {
+			return hash.GetTemplateHashLabel(expected.Labels) != hash.GetTemplateHashLabel(reconciled.Labels)
+		},
+		UpdateReconciled: func() {
+			reconciled.Labels = expected.Labels
+			reconciled.Spec = expected.Spec
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return Status(*reconciled), nil
+}
+
+// Expected returns the HorizontalPodAutoscaler expected for the given params, or nil if autoscaling is not specified.
+func Expected(params Params) *autoscalingv2beta2.HorizontalPodAutoscaler {
+	if params.Spec == nil {
+		return nil
+	}
+	minReplicas := params.Spec.GetMinReplicas()
+	return &autoscalingv2beta2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      params.Deployment,
+			Namespace: params.Owner.GetNamespace(),
+			Labels:    maps.Merge(map[string]string{}, params.Labels),
+		},
+		Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       "Deployment",
+				Name:       params.Deployment,
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: params.Spec.MaxReplicas,
+			Metrics:     metrics(*params.Spec),
+			Behavior:    params.Spec.Behavior.DeepCopy(),
+		},
+	}
+}
+
+// metrics returns the metrics of the HPA: the CPU and memory utilization targets, followed by any additional metric.
+func metrics(spec commonv1.AutoscalingSpec) []autoscalingv2beta2.MetricSpec {
+	var metrics []autoscalingv2beta2.MetricSpec
+	if spec.TargetCPUUtilizationPercentage != nil {
+		metrics = append(metrics, resourceUtilization(corev1.ResourceCPU, *spec.TargetCPUUtilizationPercentage))
+	}
+	if spec.TargetMemoryUtilizationPercentage != nil {
+		metrics = append(metrics, resourceUtilization(corev1.ResourceMemory, *spec.TargetMemoryUtilizationPercentage))
+	}
+	for _, m := range spec.Metrics {
+		metrics = append(metrics, *m.DeepCopy())
+	}
+	return metrics
+}
+
+func resourceUtilization(resource corev1.ResourceName, percentage int32) autoscalingv2beta2.MetricSpec {
+	return autoscalingv2beta2.MetricSpec{
+		Type: autoscalingv2beta2.ResourceMetricSourceType,
+		Resource: &autoscalingv2beta2.ResourceMetricSource{
+			Name: resource,
+			Target: autoscalingv2beta2.MetricTarget{
+				Type:               autoscalingv2beta2.UtilizationMetricType,
+				AverageUtilization: &percentage,
+			},
+		},
+	}
+}
+
+// Status returns the autoscaling status corresponding to the given HPA.
+func Status(hpa autoscalingv2beta2.HorizontalPodAutoscaler) *commonv1.AutoscalingStatus {
+	minReplicas := int32(1)
+	if hpa.Spec.MinReplicas != nil {
+		minReplicas = *hpa.Spec.MinReplicas
+	}
+	return &commonv1.AutoscalingStatus{
+		MinReplicas:     minReplicas,
+		MaxReplicas:     hpa.Spec.MaxReplicas,
+		CurrentReplicas: hpa.Status.CurrentReplicas,
+		DesiredReplicas: hpa.Status.DesiredReplicas,
+	}
+}
+
+// MaxReplicas returns the maximum number of replicas of a Deployment: the upper limit of the HPA if autoscaling is
+// specified, the given count otherwise.
+func MaxReplicas(spec *commonv1.AutoscalingSpec, count int32) int32 {
+	if spec == nil {
+		return count
+	}
+	return spec.MaxReplicas
+}
+
+// deleteHPA deletes the HPA if it exists.
+func deleteHPA(k8sClient k8s.Client, params Params) error {
+	var hpa autoscalingv2beta2.HorizontalPodAutoscaler
+	nsn := client.ObjectKey{Namespace: params.Owner.GetNamespace(), Name: params.Deployment}
+	if err := k8sClient.Get(context.Background(), nsn, &hpa); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(&hpa, params.Owner) {
+		// not created by the operator for this resource, leave it alone
+		return nil
+	}
+	if err := k8sClient.Delete(context.Background(), &hpa); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package hpa
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/pointer"
+)
+
+var (
+	testKibana = kbv1.Kibana{ObjectMeta: metav1.ObjectMeta{Name: "kb", Namespace: "ns", UID: "uid"}}
+	hpaName    = types.NamespacedName{Namespace: "ns", Name: "kb-kb"}
+)
+
+func params(spec *commonv1.AutoscalingSpec) Params {
+	return Params{
+		Owner:      testKibana.DeepCopy(),
+		Spec:       spec,
+		Deployment: "kb-kb",
+		Labels:     map[string]string{"kibana.k8s.elastic.co/name": "kb"},
+	}
+}
+
+func existingHPA(owned bool) *autoscalingv2beta2.HorizontalPodAutoscaler {
+	hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: hpaName.Name, Namespace: hpaName.Namespace},
+		Spec:       autoscalingv2beta2.HorizontalPodAutoscalerSpec{MaxReplicas: 10},
+		Status:     autoscalingv2beta2.HorizontalPodAutoscalerStatus{CurrentReplicas: 3, DesiredReplicas: 4},
+	}
+	if owned {
+		if err := controllerutil.SetControllerReference(testKibana.DeepCopy(), hpa, k8s.Scheme()); err != nil {
+			panic(err)
+		}
+	}
+	return hpa
+}
+
+func TestExpected(t *testing.T) {
+	require.Nil(t, Expected(params(nil)))
+
+	customMetric := autoscalingv2beta2.MetricSpec{
+		Type: autoscalingv2beta2.PodsMetricSourceType,
+		Pods: &autoscalingv2beta2.PodsMetricSource{
+			Metric: autoscalingv2beta2.MetricIdentifier{Name: "http_requests"},
+			Target: autoscalingv2beta2.MetricTarget{Type: autoscalingv2beta2.AverageValueMetricType},
+		},
+	}
+	hpa := Expected(params(&commonv1.AutoscalingSpec{
+		MaxReplicas:                       5,
+		TargetCPUUtilizationPercentage:    pointer.Int32(70),
+		TargetMemoryUtilizationPercentage: pointer.Int32(90),
+		Metrics:                           []autoscalingv2beta2.MetricSpec{customMetric},
+	}))
+	require.Equal(t, "kb-kb", hpa.Name)
+	require.Equal(t, "ns", hpa.Namespace)
+	require.Equal(t, "kb", hpa.Labels["kibana.k8s.elastic.co/name"])
+	require.Equal(t, autoscalingv2beta2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "kb-kb"}, hpa.Spec.ScaleTargetRef)
+	require.Equal(t, pointer.Int32(1), hpa.Spec.MinReplicas)
+	require.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+	require.Len(t, hpa.Spec.Metrics, 3)
+	require.Equal(t, corev1.ResourceCPU, hpa.Spec.Metrics[0].Resource.Name)
+	require.Equal(t, pointer.Int32(70), hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+	require.Equal(t, corev1.ResourceMemory, hpa.Spec.Metrics[1].Resource.Name)
+	require.Equal(t, pointer.Int32(90), hpa.Spec.Metrics[1].Resource.Target.AverageUtilization)
+	require.Equal(t, customMetric, hpa.Spec.Metrics[2])
+}
+
+func TestReconcile(t *testing.T) {
+	spec := &commonv1.AutoscalingSpec{MinReplicas: pointer.Int32(2), MaxReplicas: 6}
+	tests := []struct {
+		name       string
+		client     k8s.Client
+		spec       *commonv1.AutoscalingSpec
+		wantStatus *commonv1.AutoscalingStatus
+		wantExists bool
+	}{
+		{
+			name:       "create the HPA",
+			client:     k8s.NewFakeClient(),
+			spec:       spec,
+			wantStatus: &commonv1.AutoscalingStatus{MinReplicas: 2, MaxReplicas: 6},
+			wantExists: true,
+		},
+		{
+			name:       "update the existing HPA and report its status",
+			client:     k8s.NewFakeClient(existingHPA(true)),
+			spec:       spec,
+			wantStatus: &commonv1.AutoscalingStatus{MinReplicas: 2, MaxReplicas: 6, CurrentReplicas: 3, DesiredReplicas: 4},
+			wantExists: true,
+		},
+		{
+			name:       "nothing to do if autoscaling is not specified",
+			client:     k8s.NewFakeClient(),
+			wantExists: false,
+		},
+		{
+			name:       "delete the HPA when autoscaling is not specified anymore",
+			client:     k8s.NewFakeClient(existingHPA(true)),
+			wantExists: false,
+		},
+		{
+			name:       "do not delete an HPA not owned by the resource",
+			client:     k8s.NewFakeClient(existingHPA(false)),
+			wantExists: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := Reconcile(tt.client, params(tt.spec))
+			require.NoError(t, err)
+			require.Equal(t, tt.wantStatus, status)
+
+			var hpa autoscalingv2beta2.HorizontalPodAutoscaler
+			err = tt.client.Get(context.Background(), hpaName, &hpa)
+			if !tt.wantExists {
+				require.True(t, apierrors.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+			if tt.spec != nil {
+				require.Equal(t, Expected(params(tt.spec)).Spec, hpa.Spec)
+				require.True(t, metav1.IsControlledBy(&hpa, &testKibana))
+			}
+		})
+	}
+}
+
+func TestMaxReplicas(t *testing.T) {
+	require.Equal(t, int32(3), MaxReplicas(nil, 3))
+	require.Equal(t, int32(10), MaxReplicas(&commonv1.AutoscalingSpec{MaxReplicas: 10}, 3))
+}
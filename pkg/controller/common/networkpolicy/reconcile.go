@@ -0,0 +1,159 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package networkpolicy
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/hash"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/name"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/reconciler"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/maps"
+)
+
+const suffix = "network-policy"
+
+// Name returns the name of the NetworkPolicy allowing the traffic to the Pods of the given resource.
+func Name(namer name.Namer, ownerName string) string {
+	return namer.Suffix(ownerName, suffix)
+}
+
+// Params to specify a NetworkPolicy allowing some traffic to the Pods of a resource.
+type Params struct {
+	// Owner is the resource owning the Pods. It is set as the controller of the NetworkPolicy.
+	Owner client.Object
+	// Enabled is false if the NetworkPolicy should not exist.
+	Enabled bool
+	// Name of the NetworkPolicy. It is created in the namespace of the owner.
+	Name string
+	// Labels are set on the NetworkPolicy.
+	Labels map[string]string
+	// PodSelector matches the Pods the traffic is allowed to.
+	PodSelector map[string]string
+	// Ingress rules allowing the traffic to the selected Pods.
+	Ingress []networkingv1.NetworkPolicyIngressRule
+}
+
+// Reconcile ensures that the NetworkPolicy matches the given params, or that it does not exist if not enabled.
+func Reconcile(k8sClient k8s.Client, params Params) error {
+	if !params.Enabled {
+		return Delete(k8sClient, params.Owner, params.Name)
+	}
+
+	expected := Expected(params)
+	reconciled := &networkingv1.NetworkPolicy{}
+	return reconciler.ReconcileResource(reconciler.Params{
+		Client:     k8sClient,
+		Owner:      params.Owner,
+		Expected:   expected,
+		Reconciled: reconciled,
+		NeedsUpdate: func() bool {
+			return hash.GetTemplateHashLabel(expected.Labels) != hash.GetTemplateHashLabel(reconciled.Labels)
+		},
+		UpdateReconciled: func() {
+			reconciled.Labels = expected.Labels
+			reconciled.Spec = expected.Spec
+		},
+	})
+}
+
+// Expected returns the NetworkPolicy expected for the given params, labelled with a hash of its content.
+func Expected(params Params) *networkingv1.NetworkPolicy {
+	expected := networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      params.Name,
+			Namespace: params.Owner.GetNamespace(),
+			Labels:    maps.Merge(map[string]string{}, params.Labels),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: params.PodSelector},
+			Ingress:     params.Ingress,
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+	// label the NetworkPolicy with a hash of its content, for comparison purposes
+	expected.Labels = hash.SetTemplateHashLabel(expected.Labels, expected)
+	return &expected
+}
+
+// Delete deletes the NetworkPolicy with the given name if it exists and is controlled by the owner.
+func Delete(k8sClient k8s.Client, owner client.Object, name string) error {
+	// we do this by getting first because that is a local cache read,
+	// versus a Delete call, which would hit the API.
+	var policy networkingv1.NetworkPolicy
+	nsn := client.ObjectKey{Namespace: owner.GetNamespace(), Name: name}
+	if err := k8sClient.Get(context.Background(), nsn, &policy); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(&policy, owner) {
+		// not created by the operator for this resource, leave it alone
+		return nil
+	}
+	if err := k8sClient.Delete(context.Background(), &policy); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// DeleteMatching deletes all the NetworkPolicies matching the given labels, in all namespaces.
+func DeleteMatching(k8sClient k8s.Client, labels client.MatchingLabels) error {
+	var policies networkingv1.NetworkPolicyList
+	if err := k8sClient.List(context.Background(), &policies, labels); err != nil {
+		return err
+	}
+	for i := range policies.Items {
+		if err := k8sClient.Delete(context.Background(), &policies.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// FromOperator returns a rule allowing the traffic from the operator, identified by its namespace, to the given ports.
+func FromOperator(operatorNamespace string, ports ...int32) networkingv1.NetworkPolicyIngressRule {
+	return networkingv1.NetworkPolicyIngressRule{
+		From: []networkingv1.NetworkPolicyPeer{{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{corev1.LabelMetadataName: operatorNamespace},
+			},
+		}},
+		Ports: policyPorts(ports),
+	}
+}
+
+// FromPods returns a rule allowing the traffic from the Pods matching the given labels in the given namespace,
+// to the given ports.
+func FromPods(namespace string, podLabels map[string]string, ports ...int32) networkingv1.NetworkPolicyIngressRule {
+	return networkingv1.NetworkPolicyIngressRule{
+		From: []networkingv1.NetworkPolicyPeer{{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{corev1.LabelMetadataName: namespace},
+			},
+			PodSelector: &metav1.LabelSelector{MatchLabels: podLabels},
+		}},
+		Ports: policyPorts(ports),
+	}
+}
+
+func policyPorts(ports []int32) []networkingv1.NetworkPolicyPort {
+	policyPorts := make([]networkingv1.NetworkPolicyPort, len(ports))
+	for i, port := range ports {
+		protocol := corev1.ProtocolTCP
+		portNumber := intstr.FromInt(int(port))
+		policyPorts[i] = networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &portNumber}
+	}
+	return policyPorts
+}
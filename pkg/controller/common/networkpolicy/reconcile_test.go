@@ -0,0 +1,154 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package networkpolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+var (
+	testKibana = kbv1.Kibana{ObjectMeta: metav1.ObjectMeta{Name: "kb", Namespace: "ns", UID: "uid"}}
+	podLabels  = map[string]string{"kibana.k8s.elastic.co/name": "kb"}
+	policyName = types.NamespacedName{Namespace: "ns", Name: "kb-kb-network-policy"}
+)
+
+func params(enabled bool) Params {
+	return Params{
+		Owner:       testKibana.DeepCopy(),
+		Enabled:     enabled,
+		Name:        Name(kbv1.KBNamer, "kb"),
+		Labels:      podLabels,
+		PodSelector: podLabels,
+		Ingress:     []networkingv1.NetworkPolicyIngressRule{FromOperator("elastic-system", 5601)},
+	}
+}
+
+func existingPolicy(owned bool) *networkingv1.NetworkPolicy {
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: policyName.Name, Namespace: policyName.Namespace},
+	}
+	if owned {
+		if err := controllerutil.SetControllerReference(testKibana.DeepCopy(), policy, k8s.Scheme()); err != nil {
+			panic(err)
+		}
+	}
+	return policy
+}
+
+func TestReconcile(t *testing.T) {
+	tests := []struct {
+		name       string
+		client     k8s.Client
+		params     Params
+		wantExists bool
+	}{
+		{
+			name:       "create the NetworkPolicy",
+			client:     k8s.NewFakeClient(),
+			params:     params(true),
+			wantExists: true,
+		},
+		{
+			name:       "update the existing NetworkPolicy",
+			client:     k8s.NewFakeClient(existingPolicy(true)),
+			params:     params(true),
+			wantExists: true,
+		},
+		{
+			name:       "nothing to do if disabled",
+			client:     k8s.NewFakeClient(),
+			params:     params(false),
+			wantExists: false,
+		},
+		{
+			name:       "delete the NetworkPolicy when disabled",
+			client:     k8s.NewFakeClient(existingPolicy(true)),
+			params:     params(false),
+			wantExists: false,
+		},
+		{
+			name:       "do not delete a NetworkPolicy not owned by the resource",
+			client:     k8s.NewFakeClient(existingPolicy(false)),
+			params:     params(false),
+			wantExists: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, Reconcile(tt.client, tt.params))
+			var policy networkingv1.NetworkPolicy
+			err := tt.client.Get(context.Background(), policyName, &policy)
+			if !tt.wantExists {
+				require.True(t, apierrors.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+			if tt.params.Enabled {
+				require.Equal(t, Expected(tt.params).Spec, policy.Spec)
+				require.True(t, metav1.IsControlledBy(&policy, &testKibana))
+			}
+		})
+	}
+}
+
+func TestExpected(t *testing.T) {
+	tcp := corev1.ProtocolTCP
+	port := intstr.FromInt(5601)
+	policy := Expected(params(true))
+	require.Equal(t, "kb-kb-network-policy", policy.Name)
+	require.Equal(t, "ns", policy.Namespace)
+	require.Equal(t, "kb", policy.Labels["kibana.k8s.elastic.co/name"])
+	require.Equal(t, networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{MatchLabels: podLabels},
+		Ingress: []networkingv1.NetworkPolicyIngressRule{{
+			From: []networkingv1.NetworkPolicyPeer{{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"kubernetes.io/metadata.name": "elastic-system"},
+				},
+			}},
+			Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &port}},
+		}},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+	}, policy.Spec)
+	// the given labels must not be modified by the hash label
+	require.Len(t, podLabels, 1)
+}
+
+func TestFromPods(t *testing.T) {
+	rule := FromPods("other-ns", map[string]string{"elasticsearch.k8s.elastic.co/cluster-name": "es"}, 9300)
+	require.Len(t, rule.From, 1)
+	require.Equal(t, map[string]string{"kubernetes.io/metadata.name": "other-ns"}, rule.From[0].NamespaceSelector.MatchLabels)
+	require.Equal(t, map[string]string{"elasticsearch.k8s.elastic.co/cluster-name": "es"}, rule.From[0].PodSelector.MatchLabels)
+	require.Len(t, rule.Ports, 1)
+	require.Equal(t, 9300, rule.Ports[0].Port.IntValue())
+}
+
+func TestDeleteMatching(t *testing.T) {
+	matching := existingPolicy(false)
+	matching.Labels = map[string]string{"a": "b"}
+	other := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "ns"}}
+	c := k8s.NewFakeClient(matching, other)
+
+	require.NoError(t, DeleteMatching(c, client.MatchingLabels{"a": "b"}))
+
+	var policies networkingv1.NetworkPolicyList
+	require.NoError(t, c.List(context.Background(), &policies))
+	require.Len(t, policies.Items, 1)
+	require.Equal(t, "other", policies.Items[0].Name)
+}
@@ -0,0 +1,39 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package networkpolicy
+
+import (
+	"strconv"
+	"strings"
+
+	k8sversion "k8s.io/apimachinery/pkg/version"
+)
+
+// namespaceNameLabelMinMinor is the minor version of Kubernetes 1.x from which the API server sets the
+// kubernetes.io/metadata.name label on all the namespaces, which the ingress rules rely on to select namespaces.
+const namespaceNameLabelMinMinor = 21
+
+// SupportedBy returns true if the Kubernetes API server of the given version labels the namespaces with their name,
+// so that the NetworkPolicies managed by the operator select the expected namespaces.
+func SupportedBy(serverVersion k8sversion.Info) bool {
+	major, err := strconv.Atoi(leadingDigits(serverVersion.Major))
+	if err != nil {
+		return false
+	}
+	minor, err := strconv.Atoi(leadingDigits(serverVersion.Minor))
+	if err != nil {
+		return false
+	}
+	return major > 1 || (major == 1 && minor >= namespaceNameLabelMinMinor)
+}
+
+// leadingDigits returns the digits at the beginning of the given string: some distributions report minor versions
+// such as "20+".
+func leadingDigits(s string) string {
+	if i := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' }); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
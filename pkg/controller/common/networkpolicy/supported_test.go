@@ -0,0 +1,31 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	k8sversion "k8s.io/apimachinery/pkg/version"
+)
+
+func TestSupportedBy(t *testing.T) {
+	tests := []struct {
+		name          string
+		serverVersion k8sversion.Info
+		want          bool
+	}{
+		{name: "1.18", serverVersion: k8sversion.Info{Major: "1", Minor: "18"}, want: false},
+		{name: "1.20 with a suffix", serverVersion: k8sversion.Info{Major: "1", Minor: "20+"}, want: false},
+		{name: "1.21", serverVersion: k8sversion.Info{Major: "1", Minor: "21"}, want: true},
+		{name: "1.22 with a suffix", serverVersion: k8sversion.Info{Major: "1", Minor: "22+"}, want: true},
+		{name: "unknown version", serverVersion: k8sversion.Info{}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, SupportedBy(tt.serverVersion))
+		})
+	}
+}
@@ -23,6 +23,7 @@ const (
 	EnforceRBACOnRefsFlag         = "enforce-rbac-on-refs"
 	IPFamilyFlag                  = "ip-family"
 	KubeClientTimeout             = "kube-client-timeout"
+	ManageNetworkPoliciesFlag     = "manage-network-policies"
 	ManageWebhookCertsFlag        = "manage-webhook-certs"
 	MaxConcurrentReconcilesFlag   = "max-concurrent-reconciles"
 	MetricsPortFlag               = "metrics-port"
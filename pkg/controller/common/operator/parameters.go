@@ -9,6 +9,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/elastic/cloud-on-k8s/pkg/about"
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/certificates"
 	"github.com/elastic/cloud-on-k8s/pkg/utils/net"
 )
@@ -35,6 +36,18 @@ type Parameters struct {
 	// ValidateStorageClass specifies whether the operator should retrieve storage classes to verify volume expansion support.
 	// Can be disabled if cluster-wide storage class RBAC access is not available.
 	ValidateStorageClass bool
+	// ManageNetworkPolicies is the default for resources which do not specify whether the operator should manage
+	// NetworkPolicies allowing the traffic to their Pods.
+	ManageNetworkPolicies bool
+	// NetworkPoliciesSupported is false if the Kubernetes API server does not label the namespaces with their name,
+	// which the NetworkPolicies managed by the operator rely on. They are not managed in that case.
+	NetworkPoliciesSupported bool
 	// Tracer is a shared APM tracer instance or nil
 	Tracer *apm.Tracer
 }
+
+// NetworkPoliciesEnabled returns true if the operator should manage the NetworkPolicies of a resource with the given
+// settings.
+func (p Parameters) NetworkPoliciesEnabled(settings *commonv1.NetworkPolicySettings) bool {
+	return p.NetworkPoliciesSupported && settings.IsEnabled(p.ManageNetworkPolicies)
+}
@@ -0,0 +1,123 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package pdb
+
+import (
+	"context"
+
+	"k8s.io/api/policy/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/hash"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/reconciler"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/maps"
+)
+
+// Params to specify the PodDisruptionBudget of the Pods of a resource.
+type Params struct {
+	// Owner is the resource owning the Pods. It is set as the controller of the PDB.
+	Owner client.Object
+	// Template is the PDB template from the resource specification, if any.
+	Template *commonv1.PodDisruptionBudgetTemplate
+	// Name of the PDB.
+	Name string
+	// Selector matches the Pods of the owner. These labels are also set on the PDB.
+	Selector map[string]string
+	// Replicas is the expected number of Pods.
+	Replicas int32
+}
+
+// Reconcile ensures that the PodDisruptionBudget of the Pods of the owner matches the given params, or that it does not
+// exist if it is not expected.
+func Reconcile(k8sClient k8s.Client, params Params) error {
+	expected := Expected(params)
+	if expected == nil {
+		return deletePDB(k8sClient, params)
+	}
+
+	// label the PDB with a hash of its content, for comparison purposes
+	expected.Labels = hash.SetTemplateHashLabel(expected.Labels, expected)
+
+	reconciled := &v1beta1.PodDisruptionBudget{}
+	return reconciler.ReconcileResource(reconciler.Params{
+		Client:     k8sClient,
+		Owner:      params.Owner,
+		Expected:   expected,
+		Reconciled: reconciled,
+		NeedsUpdate: func() bool {
+			return hash.GetTemplateHashLabel(expected.Labels) != hash.GetTemplateHashLabel(reconciled.Labels)
+		},
+		UpdateReconciled: func() {
+			reconciled.Labels = expected.Labels
+			reconciled.Annotations = expected.Annotations
+			reconciled.Spec = expected.Spec
+		},
+	})
+}
+
+// Expected returns the PodDisruptionBudget expected for the given params, or nil if there should be none:
+// - if the template is explicitly disabled, there is no PDB
+// - if the template specifies a selector, min available or max unavailable, its spec is used as is
+// - otherwise, by default, one Pod can be disrupted at a time if there are several of them.
+func Expected(params Params) *v1beta1.PodDisruptionBudget {
+	template := params.Template.DeepCopy()
+	if template.IsDisabled() {
+		return nil
+	}
+	if template == nil {
+		template = &commonv1.PodDisruptionBudgetTemplate{}
+	}
+	userDefined := template.Spec.Selector != nil || template.Spec.MaxUnavailable != nil || template.Spec.MinAvailable != nil
+	if !userDefined && params.Replicas <= 1 {
+		// a single Pod cannot be protected without blocking the maintenance of the Kubernetes nodes
+		return nil
+	}
+
+	// inherit user-provided ObjectMeta, but set our own name & namespace, and append our labels
+	expected := v1beta1.PodDisruptionBudget{
+		ObjectMeta: template.ObjectMeta,
+	}
+	expected.Name = params.Name
+	expected.Namespace = params.Owner.GetNamespace()
+	expected.Labels = maps.MergePreservingExistingKeys(expected.Labels, params.Selector)
+
+	if userDefined {
+		expected.Spec = template.Spec
+	} else {
+		maxUnavailable := commonv1.DefaultPodDisruptionBudgetMaxUnavailable
+		expected.Spec = v1beta1.PodDisruptionBudgetSpec{
+			Selector:       &metav1.LabelSelector{MatchLabels: params.Selector},
+			MaxUnavailable: &maxUnavailable,
+		}
+	}
+	return &expected
+}
+
+// deletePDB deletes the PDB if it exists.
+func deletePDB(k8sClient k8s.Client, params Params) error {
+	// we do this by getting first because that is a local cache read,
+	// versus a Delete call, which would hit the API.
+	var pdb v1beta1.PodDisruptionBudget
+	nsn := client.ObjectKey{Namespace: params.Owner.GetNamespace(), Name: params.Name}
+	if err := k8sClient.Get(context.Background(), nsn, &pdb); err != nil {
+		if apierrors.IsNotFound(err) {
+			// already deleted, which is fine
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(&pdb, params.Owner) {
+		// not created by the operator for this resource, leave it alone
+		return nil
+	}
+	if err := k8sClient.Delete(context.Background(), &pdb); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
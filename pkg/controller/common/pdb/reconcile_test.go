@@ -0,0 +1,177 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package pdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/api/policy/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+var (
+	testKibana = kbv1.Kibana{ObjectMeta: metav1.ObjectMeta{Name: "kb", Namespace: "ns", UID: "uid"}}
+	selector   = map[string]string{"kibana.k8s.elastic.co/name": "kb"}
+)
+
+func intStrPtr(intStr intstr.IntOrString) *intstr.IntOrString {
+	return &intStr
+}
+
+func params(template *commonv1.PodDisruptionBudgetTemplate, replicas int32) Params {
+	kb := testKibana.DeepCopy()
+	return Params{
+		Owner:    kb,
+		Template: template,
+		Name:     kbv1.PodDisruptionBudget(kb.Name),
+		Selector: selector,
+		Replicas: replicas,
+	}
+}
+
+func existingPDB(owned bool) *v1beta1.PodDisruptionBudget {
+	pdb := &v1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: kbv1.PodDisruptionBudget("kb"), Namespace: "ns"},
+	}
+	if owned {
+		if err := controllerutil.SetControllerReference(testKibana.DeepCopy(), pdb, k8s.Scheme()); err != nil {
+			panic(err)
+		}
+	}
+	return pdb
+}
+
+func TestExpected(t *testing.T) {
+	tests := []struct {
+		name   string
+		params Params
+		want   *v1beta1.PodDisruptionBudget
+	}{
+		{
+			name:   "no PDB for a single Pod by default",
+			params: params(nil, 1),
+			want:   nil,
+		},
+		{
+			name:   "PDB disabled in the spec",
+			params: params(&commonv1.PodDisruptionBudgetTemplate{}, 3),
+			want:   nil,
+		},
+		{
+			name:   "default PDB for several Pods",
+			params: params(nil, 3),
+			want: &v1beta1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{Name: "kb-kb-default", Namespace: "ns", Labels: selector},
+				Spec: v1beta1.PodDisruptionBudgetSpec{
+					Selector:       &metav1.LabelSelector{MatchLabels: selector},
+					MaxUnavailable: intStrPtr(intstr.FromInt(1)),
+				},
+			},
+		},
+		{
+			name: "inherit user-provided labels",
+			params: params(&commonv1.PodDisruptionBudgetTemplate{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"a": "b"}},
+			}, 2),
+			want: &v1beta1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "kb-kb-default",
+					Namespace: "ns",
+					Labels:    map[string]string{"a": "b", "kibana.k8s.elastic.co/name": "kb"},
+				},
+				Spec: v1beta1.PodDisruptionBudgetSpec{
+					Selector:       &metav1.LabelSelector{MatchLabels: selector},
+					MaxUnavailable: intStrPtr(intstr.FromInt(1)),
+				},
+			},
+		},
+		{
+			name: "use the user-provided spec, even for a single Pod",
+			params: params(&commonv1.PodDisruptionBudgetTemplate{
+				Spec: v1beta1.PodDisruptionBudgetSpec{MinAvailable: intStrPtr(intstr.FromInt(1))},
+			}, 1),
+			want: &v1beta1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{Name: "kb-kb-default", Namespace: "ns", Labels: selector},
+				Spec:       v1beta1.PodDisruptionBudgetSpec{MinAvailable: intStrPtr(intstr.FromInt(1))},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, Expected(tt.params))
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	pdbName := types.NamespacedName{Namespace: "ns", Name: kbv1.PodDisruptionBudget("kb")}
+	tests := []struct {
+		name       string
+		client     k8s.Client
+		params     Params
+		wantExists bool
+	}{
+		{
+			name:       "create the default PDB",
+			client:     k8s.NewFakeClient(),
+			params:     params(nil, 2),
+			wantExists: true,
+		},
+		{
+			name:       "delete the PDB when scaled down to a single Pod",
+			client:     k8s.NewFakeClient(existingPDB(true)),
+			params:     params(nil, 1),
+			wantExists: false,
+		},
+		{
+			name:       "delete the PDB when disabled",
+			client:     k8s.NewFakeClient(existingPDB(true)),
+			params:     params(&commonv1.PodDisruptionBudgetTemplate{}, 3),
+			wantExists: false,
+		},
+		{
+			name:       "do not delete a PDB not owned by the resource",
+			client:     k8s.NewFakeClient(existingPDB(false)),
+			params:     params(&commonv1.PodDisruptionBudgetTemplate{}, 3),
+			wantExists: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, Reconcile(tt.client, tt.params))
+			var pdb v1beta1.PodDisruptionBudget
+			err := tt.client.Get(context.Background(), pdbName, &pdb)
+			if !tt.wantExists {
+				require.True(t, apierrors.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestReconcile_Update(t *testing.T) {
+	c := k8s.NewFakeClient()
+	require.NoError(t, Reconcile(c, params(nil, 3)))
+	require.NoError(t, Reconcile(c, params(&commonv1.PodDisruptionBudgetTemplate{
+		Spec: v1beta1.PodDisruptionBudgetSpec{MinAvailable: intStrPtr(intstr.FromInt(2))},
+	}, 3)))
+
+	var pdb v1beta1.PodDisruptionBudget
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "kb-kb-default"}, &pdb))
+	require.Equal(t, intStrPtr(intstr.FromInt(2)), pdb.Spec.MinAvailable)
+	require.Nil(t, pdb.Spec.MaxUnavailable)
+	require.True(t, metav1.IsControlledBy(&pdb, &testKibana))
+}
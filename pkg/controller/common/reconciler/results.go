@@ -36,8 +36,10 @@ func kindOf(r reconcile.Result) resultKind {
 type Results struct {
 	currResult reconcile.Result
 	currKind   resultKind
-	errors     []error
-	ctx        context.Context
+	// requeued is true if any of the results asked for the reconciliation to be retried
+	requeued bool
+	errors   []error
+	ctx      context.Context
 }
 
 func NewResult(ctx context.Context) *Results {
@@ -51,10 +53,17 @@ func (r *Results) HasError() bool {
 	return len(r.errors) > 0
 }
 
+// IsReconciled returns true if no error occurred and no result asked for the reconciliation to be retried.
+// Results that only ask for a periodic requeue (RequeueAfter without Requeue) are considered reconciled.
+func (r *Results) IsReconciled() bool {
+	return !r.HasError() && !r.requeued
+}
+
 // WithResults appends the results and error from the other Results.
 func (r *Results) WithResults(other *Results) *Results {
 	if other != nil {
 		r.mergeResult(other.currKind, other.currResult)
+		r.requeued = r.requeued || other.requeued
 		r.errors = append(r.errors, other.errors...)
 	}
 	return r
@@ -79,6 +88,9 @@ func (r *Results) WithResult(res reconcile.Result) *Results {
 // Order of priority is: noqueue < specific < generic
 // When there are two specific results, the one with the lowest RequeueAfter takes precedence.
 func (r *Results) mergeResult(kind resultKind, res reconcile.Result) {
+	if res.Requeue {
+		r.requeued = true
+	}
 	switch {
 	case kind > r.currKind:
 		r.currKind = kind
@@ -132,3 +132,22 @@ func TestResultsHasError(t *testing.T) {
 	r = r.WithError(errors.New("some error"))
 	require.True(t, r.HasError())
 }
+
+func TestResultsIsReconciled(t *testing.T) {
+	r := NewResult(context.Background())
+	require.True(t, r.IsReconciled())
+
+	// a periodic requeue does not prevent the reconciliation from being complete
+	r = r.WithResult(reconcile.Result{RequeueAfter: time.Minute})
+	require.True(t, r.IsReconciled())
+
+	// a requeue asked by a nested step is propagated
+	nested := NewResult(context.Background()).WithResult(reconcile.Result{Requeue: true, RequeueAfter: 10 * time.Second})
+	require.False(t, NewResult(context.Background()).WithResults(nested).IsReconciled())
+
+	// a generic requeue takes precedence over the periodic one
+	require.False(t, r.WithResult(reconcile.Result{Requeue: true}).IsReconciled())
+
+	// so does an error
+	require.False(t, NewResult(context.Background()).WithError(errors.New("some error")).IsReconciled())
+}
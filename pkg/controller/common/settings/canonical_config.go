@@ -141,6 +141,113 @@ func (c *CanonicalConfig) MergeWith(cfgs ...*CanonicalConfig) error {
 	return nil
 }
 
+// OverrideWith merges the content of c2 into c.
+// Unlike MergeWith, the settings of c which are also set in c2 are removed before merging: arrays are replaced rather
+// than appended, and objects are replaced by the scalar values set at the same key.
+func (c *CanonicalConfig) OverrideWith(c2 *CanonicalConfig) error {
+	if c2 == nil {
+		return nil
+	}
+	overlaps, err := c.overlappingKeys(c2)
+	if err != nil {
+		return err
+	}
+	for _, o := range overlaps {
+		if _, err := c.asUCfg().Remove(o.key, -1, Options...); err != nil {
+			return err
+		}
+	}
+	return c.MergeWith(c2)
+}
+
+// ConflictingKeys returns the sorted flattened keys of c2 which are also set in c to a different value. Arrays are
+// compared as a whole.
+func (c *CanonicalConfig) ConflictingKeys(c2 *CanonicalConfig) ([]string, error) {
+	overlaps, err := c.overlappingKeys(c2)
+	if err != nil {
+		return nil, err
+	}
+	var conflicts []string
+	for _, o := range overlaps {
+		if o.conflict && (len(conflicts) == 0 || conflicts[len(conflicts)-1] != o.otherKey) {
+			conflicts = append(conflicts, o.otherKey)
+		}
+	}
+	return conflicts, nil
+}
+
+// keyOverlap is a leaf setting of a config which overlaps with a leaf setting of another config, either because they
+// have the same key or because one of them is nested into the other.
+type keyOverlap struct {
+	key      string
+	otherKey string
+	conflict bool
+}
+
+// overlappingKeys returns the overlaps between the leaf settings of c and c2, sorted by key of c2.
+func (c *CanonicalConfig) overlappingKeys(c2 *CanonicalConfig) ([]keyOverlap, error) {
+	if c == nil || c2 == nil {
+		return nil, nil
+	}
+	leaves, err := c.leaves()
+	if err != nil {
+		return nil, err
+	}
+	otherLeaves, err := c2.leaves()
+	if err != nil {
+		return nil, err
+	}
+	otherKeys := make([]string, 0, len(otherLeaves))
+	for k := range otherLeaves {
+		otherKeys = append(otherKeys, k)
+	}
+	sort.Strings(otherKeys)
+
+	var overlaps []keyOverlap
+	for _, otherKey := range otherKeys {
+		if v, exists := leaves[otherKey]; exists {
+			overlaps = append(overlaps, keyOverlap{key: otherKey, otherKey: otherKey, conflict: !reflect.DeepEqual(v, otherLeaves[otherKey])})
+			continue
+		}
+		var nested []string
+		for k := range leaves {
+			if strings.HasPrefix(k, otherKey+".") || strings.HasPrefix(otherKey, k+".") {
+				nested = append(nested, k)
+			}
+		}
+		sort.Strings(nested)
+		for _, k := range nested {
+			overlaps = append(overlaps, keyOverlap{key: k, otherKey: otherKey, conflict: true})
+		}
+	}
+	return overlaps, nil
+}
+
+// leaves returns the leaf values of c indexed by their flattened key. Arrays are considered as leaf values.
+func (c *CanonicalConfig) leaves() (map[string]interface{}, error) {
+	var out untypedDict
+	if err := c.asUCfg().Unpack(&out, Options...); err != nil {
+		return nil, err
+	}
+	leaves := make(map[string]interface{})
+	flatten(out, "", leaves)
+	return leaves, nil
+}
+
+func flatten(m untypedDict, prefix string, into map[string]interface{}) {
+	for k, v := range m {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if nested, isDict := v.(untypedDict); isDict {
+			flatten(nested, key, into)
+			continue
+		}
+		into[key] = v
+	}
+}
+
 // HasKeys returns all keys in c that are also in keys
 func (c *CanonicalConfig) HasKeys(keys []string) []string {
 	var has []string
@@ -89,6 +89,112 @@ func TestCanonicalConfig_MergeWith(t *testing.T) {
 	}
 }
 
+func TestCanonicalConfig_OverrideWith(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *CanonicalConfig
+		c2   *CanonicalConfig
+		want *CanonicalConfig
+	}{
+		{
+			name: "c2 nil",
+			c:    MustNewSingleValue("a", "b"),
+			c2:   nil,
+			want: MustNewSingleValue("a", "b"),
+		},
+		{
+			name: "different values",
+			c:    MustNewSingleValue("a", "b"),
+			c2:   MustNewSingleValue("c", "d"),
+			want: MustCanonicalConfig(map[string]string{"a": "b", "c": "d"}),
+		},
+		{
+			name: "replace arrays",
+			c:    MustCanonicalConfig(map[string][]string{"a": {"x"}}),
+			c2:   MustCanonicalConfig(map[string][]string{"a": {"y"}}),
+			want: MustCanonicalConfig(map[string][]string{"a": {"y"}}),
+		},
+		{
+			name: "same arrays",
+			c:    MustCanonicalConfig(map[string][]string{"a": {"x"}}),
+			c2:   MustCanonicalConfig(map[string][]string{"a": {"x"}}),
+			want: MustCanonicalConfig(map[string][]string{"a": {"x"}}),
+		},
+		{
+			name: "replace object by a scalar",
+			c:    MustCanonicalConfig(map[string]interface{}{"a": map[string]interface{}{"b": "c", "d": "e"}, "f": "g"}),
+			c2:   MustNewSingleValue("a", "h"),
+			want: MustCanonicalConfig(map[string]string{"a": "h", "f": "g"}),
+		},
+		{
+			name: "replace scalar by an object",
+			c:    MustCanonicalConfig(map[string]string{"a": "h", "f": "g"}),
+			c2:   MustCanonicalConfig(map[string]interface{}{"a.b": "c"}),
+			want: MustCanonicalConfig(map[string]string{"a.b": "c", "f": "g"}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Override mutates c
+			require.NoError(t, tt.c.OverrideWith(tt.c2))
+			if diff := tt.c.Diff(tt.want, nil); diff != nil {
+				var wantMap map[string]interface{}
+				require.NoError(t, tt.want.Unpack(&wantMap))
+				var gotMap map[string]interface{}
+				require.NoError(t, tt.c.Unpack(&gotMap))
+				t.Errorf("CanonicalConfig.OverrideWith() = %v, want %+v, got %+v ", diff, wantMap, gotMap)
+			}
+		})
+	}
+}
+
+func TestCanonicalConfig_ConflictingKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *CanonicalConfig
+		c2   *CanonicalConfig
+		want []string
+	}{
+		{
+			name: "nil",
+			c:    MustNewSingleValue("a", "b"),
+			c2:   nil,
+			want: nil,
+		},
+		{
+			name: "no common keys",
+			c:    MustNewSingleValue("a", "b"),
+			c2:   MustNewSingleValue("c", "d"),
+			want: nil,
+		},
+		{
+			name: "same values",
+			c:    MustCanonicalConfig(map[string]interface{}{"a": "b", "c": []string{"x", "y"}}),
+			c2:   MustCanonicalConfig(map[string]interface{}{"a": "b", "c": []string{"x", "y"}}),
+			want: nil,
+		},
+		{
+			name: "different values",
+			c:    MustCanonicalConfig(map[string]interface{}{"a.b": "c", "d": []string{"x"}, "e": "f"}),
+			c2:   MustCanonicalConfig(map[string]interface{}{"a": map[string]interface{}{"b": "z"}, "d": []string{"x", "y"}, "e": "f"}),
+			want: []string{"a.b", "d"},
+		},
+		{
+			name: "nested keys",
+			c:    MustCanonicalConfig(map[string]interface{}{"a.b": "c", "a.d": "e", "f": "g"}),
+			c2:   MustCanonicalConfig(map[string]interface{}{"a": "z", "f.h": "i"}),
+			want: []string{"a", "f.h"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.c.ConflictingKeys(tt.c2)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestParseConfig(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,97 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package statefulset
+
+import (
+	"context"
+	"testing"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/comparison"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/hash"
+	controllerscheme "github.com/elastic/cloud-on-k8s/pkg/controller/common/scheme"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/pointer"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWithTemplateHash(t *testing.T) {
+	s := appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sset",
+			Namespace: "ns",
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: pointer.Int32(2),
+		},
+	}
+
+	withHash := WithTemplateHash(s)
+	// the label should be set
+	require.NotEmpty(t, withHash.Labels[hash.TemplateHashLabelName])
+	// original object should be kept unmodified
+	require.Empty(t, s.Labels)
+
+	// label should stay the same if no spec change
+	withSameHash := WithTemplateHash(s)
+	require.Equal(t, withHash.Labels[hash.TemplateHashLabelName], withSameHash.Labels[hash.TemplateHashLabelName])
+
+	// label should be different if the spec changed
+	s.Spec.Replicas = pointer.Int32(3)
+	withDifferentHash := WithTemplateHash(s)
+	require.NotEmpty(t, withDifferentHash.Labels[hash.TemplateHashLabelName])
+	require.NotEqual(t, withHash.Labels[hash.TemplateHashLabelName], withDifferentHash.Labels[hash.TemplateHashLabelName])
+}
+
+func TestReconcile(t *testing.T) {
+	controllerscheme.SetupScheme()
+	k8sClient := k8s.NewFakeClient()
+	expected := New(Params{
+		Name:        "sset",
+		Namespace:   "ns",
+		ServiceName: "svc",
+		Labels:      map[string]string{"a": "b"},
+		Selector:    map[string]string{"a": "b"},
+		VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+			{ObjectMeta: metav1.ObjectMeta{Name: "data"}},
+		},
+		Replicas: 2,
+	})
+	owner := esv1.Elasticsearch{} // can be any type
+
+	// should create a new StatefulSet
+	reconciled, err := Reconcile(k8sClient, expected, &owner)
+	require.NoError(t, err)
+	// reconciled should match expected spec, and have the hash label set
+	require.Equal(t, pointer.Int32(2), reconciled.Spec.Replicas)
+	require.Equal(t, "b", reconciled.Labels["a"])
+	require.NotEmpty(t, reconciled.Labels[hash.TemplateHashLabelName])
+	// resource should exist in the apiserver
+	var retrieved appsv1.StatefulSet
+	err = k8sClient.Get(context.Background(), k8s.ExtractNamespacedName(&expected), &retrieved)
+	require.NoError(t, err)
+	comparison.RequireEqual(t, &reconciled, &retrieved)
+
+	// reconciling the same should be a no-op
+	reconciledAgain, err := Reconcile(k8sClient, expected, &owner)
+	require.NoError(t, err)
+	comparison.RequireEqual(t, &reconciled, &reconciledAgain)
+
+	// update with a new spec, volume claim templates are left untouched
+	expected.Spec.Replicas = pointer.Int32(3)
+	expected.Spec.VolumeClaimTemplates = nil
+	reconciled, err = Reconcile(k8sClient, expected, &owner)
+	require.NoError(t, err)
+	// both returned and retrieved should match that new spec
+	require.Equal(t, 3, int(*reconciled.Spec.Replicas))
+	require.Len(t, reconciled.Spec.VolumeClaimTemplates, 1)
+	require.NotEqual(t, reconciled.Labels[hash.TemplateHashLabelName], reconciledAgain.Labels[hash.TemplateHashLabelName])
+	err = k8sClient.Get(context.Background(), k8s.ExtractNamespacedName(&expected), &retrieved)
+	require.NoError(t, err)
+	comparison.RequireEqual(t, &reconciled, &retrieved)
+}
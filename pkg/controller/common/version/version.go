@@ -33,6 +33,8 @@ var (
 	// Due to bugfixes present in 7.14 that ECK depends on, this is the lowest version we support in Fleet mode.
 	SupportedFleetModeAgentVersions = MinMaxVersion{Min: MustParse("7.14.0-SNAPSHOT"), Max: From(8, 99, 99)}
 	SupportedMapsVersions           = MinMaxVersion{Min: From(7, 11, 0), Max: From(8, 99, 99)}
+	// Logstash relies on the `api.http.host` setting introduced in 7.16.0 to expose its API.
+	SupportedLogstashVersions = MinMaxVersion{Min: From(7, 16, 0), Max: From(8, 99, 99)}
 )
 
 // MinMaxVersion holds the minimum and maximum supported versions.
@@ -0,0 +1,34 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package ccr
+
+import (
+	"context"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/annotation"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/set"
+)
+
+const (
+	// ManagedAutoFollowPatternsAnnotationName holds the list of the auto-follow patterns which have been created by the operator.
+	ManagedAutoFollowPatternsAnnotationName = "elasticsearch.k8s.elastic.co/managed-auto-follow-patterns"
+	// PromotedFollowerIndicesAnnotationName holds the list of the follower indices declared in the specification which
+	// have been promoted to regular indices, so that they are not followed again once they are not marked as promoted.
+	PromotedFollowerIndicesAnnotationName = "elasticsearch.k8s.elastic.co/promoted-follower-indices"
+)
+
+// annotateWithManagedAutoFollowPatterns patches the annotation of the Elasticsearch resource which keeps track of the
+// managed auto-follow patterns, if it has changed. es is updated with the patched resource.
+func annotateWithManagedAutoFollowPatterns(ctx context.Context, c k8s.Client, es *esv1.Elasticsearch, names set.StringSet) error {
+	return annotation.PatchAnnotations(ctx, c, es, map[string]string{ManagedAutoFollowPatternsAnnotationName: annotation.NamesValue(names)})
+}
+
+// annotateWithPromotedFollowerIndices patches the annotation of the Elasticsearch resource which keeps track of the
+// promoted follower indices, if it has changed. es is updated with the patched resource.
+func annotateWithPromotedFollowerIndices(ctx context.Context, c k8s.Client, es *esv1.Elasticsearch, names set.StringSet) error {
+	return annotation.PatchAnnotations(ctx, c, es, map[string]string{PromotedFollowerIndicesAnnotationName: annotation.NamesValue(names)})
+}
@@ -0,0 +1,262 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package ccr
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.elastic.co/apm"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/annotation"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
+	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	ulog "github.com/elastic/cloud-on-k8s/pkg/utils/log"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/set"
+)
+
+var log = ulog.Log.WithName("ccr")
+
+// Reconcile ensures that the auto-follow patterns and the follower indices declared in the Elasticsearch specification
+// exist in Elasticsearch. Auto-follow patterns previously created by the operator but which are not declared anymore
+// are deleted, while the ones created through the Elasticsearch API are left untouched: the auto-follow patterns
+// managed by the operator are tracked in an annotation. Follower indices are never deleted, and are promoted to
+// regular indices on demand: the promoted follower indices are tracked in another annotation, so that they are not
+// followed again if they are not marked as promoted anymore. es is updated with the patched resource.
+// The status of each follower index declared in the specification is returned.
+func Reconcile(
+	ctx context.Context,
+	c k8s.Client,
+	esClient esclient.CrossClusterReplicationClient,
+	es *esv1.Elasticsearch,
+) ([]esv1.FollowerIndexStatus, error) {
+	span, ctx := apm.StartSpan(ctx, "reconcile_cross_cluster_replication", tracing.SpanTypeApp)
+	defer span.End()
+
+	managedPatterns := annotation.GetNames(es, ManagedAutoFollowPatternsAnnotationName)
+	promotedIndices := annotation.GetNames(es, PromotedFollowerIndicesAnnotationName)
+	if !es.Spec.CrossClusterReplication.IsDefined() && len(managedPatterns) == 0 && len(promotedIndices) == 0 {
+		// nothing to do, avoid any call to the Elasticsearch API
+		return nil, nil
+	}
+
+	// track the auto-follow patterns before they are created
+	declaredPatterns := make(set.StringSet, len(es.Spec.CrossClusterReplication.AutoFollowPatterns))
+	for _, pattern := range es.Spec.CrossClusterReplication.AutoFollowPatterns {
+		declaredPatterns[pattern.Name] = struct{}{}
+		managedPatterns[pattern.Name] = struct{}{}
+	}
+	if err := annotateWithManagedAutoFollowPatterns(ctx, c, es, managedPatterns); err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	if err := reconcileAutoFollowPatterns(ctx, esClient, *es); err != nil {
+		errs = append(errs, err)
+	}
+	if err := deleteUndeclaredAutoFollowPatterns(ctx, esClient, managedPatterns, declaredPatterns); err != nil {
+		errs = append(errs, err)
+	}
+	if err := annotateWithManagedAutoFollowPatterns(ctx, c, es, managedPatterns); err != nil {
+		errs = append(errs, err)
+	}
+
+	statuses, err := reconcileFollowerIndices(ctx, esClient, *es, promotedIndices)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if err := annotateWithPromotedFollowerIndices(ctx, c, es, promotedIndices); err != nil {
+		errs = append(errs, err)
+	}
+	return statuses, utilerrors.NewAggregate(errs)
+}
+
+// reconcileAutoFollowPatterns creates or updates the auto-follow patterns declared in the specification.
+func reconcileAutoFollowPatterns(ctx context.Context, esClient esclient.CrossClusterReplicationClient, es esv1.Elasticsearch) error {
+	if len(es.Spec.CrossClusterReplication.AutoFollowPatterns) == 0 {
+		return nil
+	}
+
+	current, err := esClient.GetAutoFollowPatterns(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, pattern := range es.Spec.CrossClusterReplication.AutoFollowPatterns {
+		expected := esclient.AutoFollowPattern{
+			RemoteCluster:       pattern.RemoteCluster,
+			LeaderIndexPatterns: pattern.LeaderIndexPatterns,
+			FollowIndexPattern:  pattern.FollowIndexPattern,
+		}
+		if actual, exists := current[pattern.Name]; exists && reflect.DeepEqual(expected, actual) {
+			continue
+		}
+		log.Info("Updating auto-follow pattern", "namespace", es.Namespace, "es_name", es.Name, "auto_follow_pattern", pattern.Name)
+		if err := esClient.UpsertAutoFollowPattern(ctx, pattern.Name, expected); err != nil {
+			errs = append(errs, fmt.Errorf("while updating auto-follow pattern %s: %w", pattern.Name, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// deleteUndeclaredAutoFollowPatterns deletes the managed auto-follow patterns which are not declared anymore, and
+// removes the ones that have been deleted or do not exist anymore from the managed set.
+func deleteUndeclaredAutoFollowPatterns(
+	ctx context.Context,
+	esClient esclient.CrossClusterReplicationClient,
+	managed, declared set.StringSet,
+) error {
+	var errs []error
+	for name := range managed {
+		if _, isDeclared := declared[name]; isDeclared {
+			continue
+		}
+		if err := esClient.DeleteAutoFollowPattern(ctx, name); err != nil && !esclient.IsNotFound(err) {
+			errs = append(errs, err)
+			continue
+		}
+		delete(managed, name)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// reconcileFollowerIndices creates the follower indices declared in the specification, promotes the ones which must be
+// promoted, and returns their status. Errors while creating or promoting a follower index are reported in its status
+// rather than returned, so that a single misconfigured follower index does not prevent the others from being reconciled.
+// The given set of promoted follower indices is updated with the indices promoted, and pruned from the indices which
+// are not declared anymore.
+func reconcileFollowerIndices(
+	ctx context.Context,
+	esClient esclient.CrossClusterReplicationClient,
+	es esv1.Elasticsearch,
+	promoted set.StringSet,
+) ([]esv1.FollowerIndexStatus, error) {
+	declared := make(set.StringSet, len(es.Spec.CrossClusterReplication.FollowerIndices))
+	for _, followerIndex := range es.Spec.CrossClusterReplication.FollowerIndices {
+		declared[followerIndex.Name] = struct{}{}
+	}
+	for name := range promoted {
+		if _, isDeclared := declared[name]; !isDeclared {
+			delete(promoted, name)
+		}
+	}
+	if len(es.Spec.CrossClusterReplication.FollowerIndices) == 0 {
+		return nil, nil
+	}
+
+	current, err := esClient.GetFollowerIndices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := esClient.GetFollowerIndicesStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]esv1.FollowerIndexStatus, 0, len(es.Spec.CrossClusterReplication.FollowerIndices))
+	for _, followerIndex := range es.Spec.CrossClusterReplication.FollowerIndices {
+		status := esv1.FollowerIndexStatus{Name: followerIndex.Name}
+		actual, isFollower := current[followerIndex.Name]
+		_, isPromoted := promoted[followerIndex.Name]
+		switch {
+		case followerIndex.Promote && !isFollower:
+			// already promoted, unless the index does not exist
+			status = promotedIndexStatus(ctx, esClient, followerIndex.Name)
+			if status.Phase == esv1.FollowerIndexPromotedPhase {
+				promoted[followerIndex.Name] = struct{}{}
+			}
+		case followerIndex.Promote:
+			log.Info("Promoting follower index", "namespace", es.Namespace, "es_name", es.Name, "index", followerIndex.Name)
+			status.Phase = esv1.FollowerIndexPromotedPhase
+			if err := promote(ctx, esClient, actual); err != nil {
+				status.Phase = esv1.FollowerIndexFailedPhase
+				status.Message = err.Error()
+				break
+			}
+			promoted[followerIndex.Name] = struct{}{}
+		case !isFollower && isPromoted:
+			// promoted earlier: the index must not follow its leader index again
+			status = promotedIndexStatus(ctx, esClient, followerIndex.Name)
+		case !isFollower:
+			log.Info("Creating follower index", "namespace", es.Namespace, "es_name", es.Name, "index", followerIndex.Name)
+			status.Phase = esv1.FollowerIndexFollowingPhase
+			request := esclient.FollowRequest{RemoteCluster: followerIndex.RemoteCluster, LeaderIndex: followerIndex.LeaderIndex}
+			if err := esClient.Follow(ctx, followerIndex.Name, request); err != nil {
+				status.Phase = esv1.FollowerIndexFailedPhase
+				status.Message = err.Error()
+			}
+		case actual.RemoteCluster != followerIndex.RemoteCluster || actual.LeaderIndex != followerIndex.LeaderIndex:
+			// the leader index of a follower index cannot be changed
+			status.Phase = esv1.FollowerIndexFailedPhase
+			status.Message = fmt.Sprintf("index already follows index %s of remote cluster %s", actual.LeaderIndex, actual.RemoteCluster)
+		default:
+			status = followerIndexStatus(actual, stats[followerIndex.Name])
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// promotedIndexStatus returns the status of a follower index which is not following its leader index anymore. It is
+// reported as promoted if it exists in Elasticsearch.
+func promotedIndexStatus(ctx context.Context, esClient esclient.CrossClusterReplicationClient, index string) esv1.FollowerIndexStatus {
+	status := esv1.FollowerIndexStatus{Name: index, Phase: esv1.FollowerIndexPromotedPhase}
+	exists, err := esClient.IndexExists(ctx, index)
+	switch {
+	case err != nil:
+		status.Phase = esv1.FollowerIndexFailedPhase
+		status.Message = fmt.Sprintf("while checking the existence of the index: %s", err)
+	case !exists:
+		status.Phase = esv1.FollowerIndexFailedPhase
+		status.Message = "index does not exist"
+	}
+	return status
+}
+
+// followerIndexStatus returns the status of an existing follower index, based on the statistics of its shards.
+func followerIndexStatus(index esclient.FollowerIndex, shards []esclient.FollowerShardStats) esv1.FollowerIndexStatus {
+	status := esv1.FollowerIndexStatus{Name: index.FollowerIndex, Phase: esv1.FollowerIndexFollowingPhase}
+	if index.Status == esclient.FollowerIndexPaused {
+		status.Phase = esv1.FollowerIndexPausedPhase
+	}
+	var reasons []string
+	for _, shard := range shards {
+		if lag := shard.LeaderGlobalCheckpoint - shard.FollowerGlobalCheckpoint; lag > 0 {
+			status.OperationsBehind += lag
+		}
+		if shard.FatalException != nil {
+			reasons = append(reasons, fmt.Sprintf("shard %d: %s", shard.ShardID, shard.FatalException.Reason))
+		}
+	}
+	status.Message = strings.Join(reasons, "; ")
+	return status
+}
+
+// promote converts a follower index into a regular index: the replication is paused, then the index is closed,
+// converted and reopened. Each step can be applied again, so that a promotion interrupted by an error is resumed at
+// the next reconciliation.
+func promote(ctx context.Context, esClient esclient.CrossClusterReplicationClient, index esclient.FollowerIndex) error {
+	if index.Status == esclient.FollowerIndexActive {
+		if err := esClient.PauseFollow(ctx, index.FollowerIndex); err != nil {
+			return fmt.Errorf("while pausing replication: %w", err)
+		}
+	}
+	if err := esClient.CloseIndex(ctx, index.FollowerIndex); err != nil {
+		return fmt.Errorf("while closing index: %w", err)
+	}
+	if err := esClient.Unfollow(ctx, index.FollowerIndex); err != nil {
+		return fmt.Errorf("while converting index: %w", err)
+	}
+	if err := esClient.OpenIndex(ctx, index.FollowerIndex); err != nil {
+		return fmt.Errorf("while opening index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,313 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package ccr
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+type fakeCCRClient struct {
+	patterns     esclient.AutoFollowPatterns
+	indices      esclient.FollowerIndices
+	stats        esclient.FollowerIndicesStats
+	regular      map[string]bool
+	calls        []string
+	failingCalls map[string]bool
+}
+
+func newFakeCCRClient() *fakeCCRClient {
+	return &fakeCCRClient{
+		patterns:     esclient.AutoFollowPatterns{},
+		indices:      esclient.FollowerIndices{},
+		stats:        esclient.FollowerIndicesStats{},
+		regular:      map[string]bool{},
+		failingCalls: map[string]bool{},
+	}
+}
+
+func (f *fakeCCRClient) call(name string) error {
+	if f.failingCalls[name] {
+		return errors.New("illegal_state_exception")
+	}
+	f.calls = append(f.calls, name)
+	return nil
+}
+
+func (f *fakeCCRClient) GetAutoFollowPatterns(_ context.Context) (esclient.AutoFollowPatterns, error) {
+	return f.patterns, nil
+}
+
+func (f *fakeCCRClient) UpsertAutoFollowPattern(_ context.Context, name string, pattern esclient.AutoFollowPattern) error {
+	if err := f.call("upsert/" + name); err != nil {
+		return err
+	}
+	f.patterns[name] = pattern
+	return nil
+}
+
+func (f *fakeCCRClient) DeleteAutoFollowPattern(_ context.Context, name string) error {
+	if err := f.call("delete/" + name); err != nil {
+		return err
+	}
+	delete(f.patterns, name)
+	return nil
+}
+
+func (f *fakeCCRClient) GetFollowerIndices(_ context.Context) (esclient.FollowerIndices, error) {
+	return f.indices, nil
+}
+
+func (f *fakeCCRClient) GetFollowerIndicesStats(_ context.Context) (esclient.FollowerIndicesStats, error) {
+	return f.stats, nil
+}
+
+func (f *fakeCCRClient) Follow(_ context.Context, index string, request esclient.FollowRequest) error {
+	if err := f.call("follow/" + index); err != nil {
+		return err
+	}
+	f.indices[index] = esclient.FollowerIndex{
+		FollowerIndex: index,
+		RemoteCluster: request.RemoteCluster,
+		LeaderIndex:   request.LeaderIndex,
+		Status:        esclient.FollowerIndexActive,
+	}
+	return nil
+}
+
+func (f *fakeCCRClient) PauseFollow(_ context.Context, index string) error {
+	if err := f.call("pause/" + index); err != nil {
+		return err
+	}
+	paused := f.indices[index]
+	paused.Status = esclient.FollowerIndexPaused
+	f.indices[index] = paused
+	return nil
+}
+
+func (f *fakeCCRClient) Unfollow(_ context.Context, index string) error {
+	if err := f.call("unfollow/" + index); err != nil {
+		return err
+	}
+	delete(f.indices, index)
+	f.regular[index] = true
+	return nil
+}
+
+func (f *fakeCCRClient) CloseIndex(_ context.Context, index string) error {
+	return f.call("close/" + index)
+}
+
+func (f *fakeCCRClient) OpenIndex(_ context.Context, index string) error {
+	return f.call("open/" + index)
+}
+
+func (f *fakeCCRClient) IndexExists(_ context.Context, index string) (bool, error) {
+	_, isFollower := f.indices[index]
+	return isFollower || f.regular[index], nil
+}
+
+var _ esclient.CrossClusterReplicationClient = &fakeCCRClient{}
+
+func newES(annotations map[string]string, ccr esv1.CrossClusterReplication) esv1.Elasticsearch {
+	return esv1.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "es", Annotations: annotations},
+		Spec:       esv1.ElasticsearchSpec{Version: "7.15.0", CrossClusterReplication: ccr},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	t.Run("nothing declared, nothing managed: no Elasticsearch call", func(t *testing.T) {
+		es := newES(nil, esv1.CrossClusterReplication{})
+		statuses, err := Reconcile(context.Background(), k8s.NewFakeClient(&es), nil, &es)
+		require.NoError(t, err)
+		require.Nil(t, statuses)
+	})
+
+	t.Run("create, leave in place, update on drift, then delete auto-follow patterns", func(t *testing.T) {
+		es := newES(nil, esv1.CrossClusterReplication{
+			AutoFollowPatterns: []esv1.AutoFollowPattern{
+				{Name: "logs", RemoteCluster: "eu-west", LeaderIndexPatterns: []string{"logs-*"}, FollowIndexPattern: "{{leader_index}}-copy"},
+			},
+		})
+		c := k8s.NewFakeClient(&es)
+		esClient := newFakeCCRClient()
+		// an existing auto-follow pattern created by the user through the API
+		esClient.patterns["user-pattern"] = esclient.AutoFollowPattern{RemoteCluster: "eu-west", LeaderIndexPatterns: []string{"*"}}
+
+		statuses, err := Reconcile(context.Background(), c, esClient, &es)
+		require.NoError(t, err)
+		require.Nil(t, statuses)
+		require.Equal(t, []string{"upsert/logs"}, esClient.calls)
+
+		var updated esv1.Elasticsearch
+		require.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "es"}, &updated))
+		require.Equal(t, "logs", updated.Annotations[ManagedAutoFollowPatternsAnnotationName])
+		// the Elasticsearch resource is updated in place
+		require.Equal(t, updated.ResourceVersion, es.ResourceVersion)
+		require.Equal(t, updated.Annotations, es.Annotations)
+
+		// second reconciliation: nothing changes
+		esClient.calls = nil
+		_, err = Reconcile(context.Background(), c, esClient, &updated)
+		require.NoError(t, err)
+		require.Empty(t, esClient.calls)
+
+		// drift in Elasticsearch is corrected
+		esClient.patterns["logs"] = esclient.AutoFollowPattern{RemoteCluster: "eu-west", LeaderIndexPatterns: []string{"other-*"}}
+		_, err = Reconcile(context.Background(), c, esClient, &updated)
+		require.NoError(t, err)
+		require.Equal(t, []string{"upsert/logs"}, esClient.calls)
+
+		// removing the auto-follow pattern from the spec deletes the managed one only
+		esClient.calls = nil
+		updated.Spec.CrossClusterReplication = esv1.CrossClusterReplication{}
+		_, err = Reconcile(context.Background(), c, esClient, &updated)
+		require.NoError(t, err)
+		require.Equal(t, []string{"delete/logs"}, esClient.calls)
+		require.Contains(t, esClient.patterns, "user-pattern")
+
+		var cleaned esv1.Elasticsearch
+		require.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "es"}, &cleaned))
+		require.NotContains(t, cleaned.Annotations, ManagedAutoFollowPatternsAnnotationName)
+	})
+
+	t.Run("auto-follow patterns which cannot be deleted remain tracked", func(t *testing.T) {
+		es := newES(map[string]string{ManagedAutoFollowPatternsAnnotationName: "logs"}, esv1.CrossClusterReplication{})
+		c := k8s.NewFakeClient(&es)
+		esClient := newFakeCCRClient()
+		esClient.failingCalls["delete/logs"] = true
+		_, err := Reconcile(context.Background(), c, esClient, &es)
+		require.Error(t, err)
+
+		var updated esv1.Elasticsearch
+		require.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "es"}, &updated))
+		require.Equal(t, "logs", updated.Annotations[ManagedAutoFollowPatternsAnnotationName])
+	})
+
+	t.Run("follower indices are created and report their replication status", func(t *testing.T) {
+		es := newES(nil, esv1.CrossClusterReplication{
+			FollowerIndices: []esv1.FollowerIndex{
+				{Name: "orders-copy", RemoteCluster: "eu-west", LeaderIndex: "orders"},
+				{Name: "customers-copy", RemoteCluster: "eu-west", LeaderIndex: "customers"},
+				{Name: "broken", RemoteCluster: "eu-west", LeaderIndex: "missing"},
+				{Name: "moved", RemoteCluster: "eu-west", LeaderIndex: "moved"},
+			},
+		})
+		esClient := newFakeCCRClient()
+		esClient.failingCalls["follow/broken"] = true
+		esClient.indices["customers-copy"] = esclient.FollowerIndex{
+			FollowerIndex: "customers-copy", RemoteCluster: "eu-west", LeaderIndex: "customers", Status: esclient.FollowerIndexPaused,
+		}
+		esClient.indices["moved"] = esclient.FollowerIndex{
+			FollowerIndex: "moved", RemoteCluster: "us-east", LeaderIndex: "moved", Status: esclient.FollowerIndexActive,
+		}
+		esClient.stats["customers-copy"] = []esclient.FollowerShardStats{
+			{ShardID: 0, LeaderGlobalCheckpoint: 1024, FollowerGlobalCheckpoint: 768},
+			{ShardID: 1, LeaderGlobalCheckpoint: 512, FollowerGlobalCheckpoint: 500,
+				FatalException: &esclient.FollowFatalException{Reason: "no such index [customers]"}},
+		}
+
+		statuses, err := Reconcile(context.Background(), k8s.NewFakeClient(&es), esClient, &es)
+		require.NoError(t, err)
+		require.Equal(t, []esv1.FollowerIndexStatus{
+			{Name: "orders-copy", Phase: esv1.FollowerIndexFollowingPhase},
+			{Name: "customers-copy", Phase: esv1.FollowerIndexPausedPhase, OperationsBehind: 268, Message: "shard 1: no such index [customers]"},
+			{Name: "broken", Phase: esv1.FollowerIndexFailedPhase, Message: "illegal_state_exception"},
+			{Name: "moved", Phase: esv1.FollowerIndexFailedPhase, Message: "index already follows index moved of remote cluster us-east"},
+		}, statuses)
+		require.Equal(t, []string{"follow/orders-copy"}, esClient.calls)
+	})
+
+	t.Run("follower indices are promoted on demand", func(t *testing.T) {
+		es := newES(nil, esv1.CrossClusterReplication{
+			FollowerIndices: []esv1.FollowerIndex{{Name: "orders-copy", RemoteCluster: "eu-west", LeaderIndex: "orders", Promote: true}},
+		})
+		esClient := newFakeCCRClient()
+		esClient.indices["orders-copy"] = esclient.FollowerIndex{
+			FollowerIndex: "orders-copy", RemoteCluster: "eu-west", LeaderIndex: "orders", Status: esclient.FollowerIndexActive,
+		}
+		c := k8s.NewFakeClient(&es)
+
+		// the promotion is interrupted once the replication is paused
+		esClient.failingCalls["close/orders-copy"] = true
+		statuses, err := Reconcile(context.Background(), c, esClient, &es)
+		require.NoError(t, err)
+		require.Equal(t, []esv1.FollowerIndexStatus{
+			{Name: "orders-copy", Phase: esv1.FollowerIndexFailedPhase, Message: "while closing index: illegal_state_exception"},
+		}, statuses)
+		require.Equal(t, []string{"pause/orders-copy"}, esClient.calls)
+
+		// the promotion is resumed without pausing the replication again
+		esClient.calls = nil
+		delete(esClient.failingCalls, "close/orders-copy")
+		statuses, err = Reconcile(context.Background(), c, esClient, &es)
+		require.NoError(t, err)
+		require.Equal(t, []esv1.FollowerIndexStatus{{Name: "orders-copy", Phase: esv1.FollowerIndexPromotedPhase}}, statuses)
+		require.Equal(t, []string{"close/orders-copy", "unfollow/orders-copy", "open/orders-copy"}, esClient.calls)
+
+		// the promoted index is left untouched
+		esClient.calls = nil
+		statuses, err = Reconcile(context.Background(), c, esClient, &es)
+		require.NoError(t, err)
+		require.Equal(t, []esv1.FollowerIndexStatus{{Name: "orders-copy", Phase: esv1.FollowerIndexPromotedPhase}}, statuses)
+		require.Empty(t, esClient.calls)
+	})
+
+	t.Run("promoted follower indices are tracked and not followed again", func(t *testing.T) {
+		es := newES(nil, esv1.CrossClusterReplication{
+			FollowerIndices: []esv1.FollowerIndex{
+				{Name: "orders-copy", RemoteCluster: "eu-west", LeaderIndex: "orders", Promote: true},
+				{Name: "missing", RemoteCluster: "eu-west", LeaderIndex: "missing", Promote: true},
+			},
+		})
+		esClient := newFakeCCRClient()
+		esClient.indices["orders-copy"] = esclient.FollowerIndex{
+			FollowerIndex: "orders-copy", RemoteCluster: "eu-west", LeaderIndex: "orders", Status: esclient.FollowerIndexActive,
+		}
+		c := k8s.NewFakeClient(&es)
+		key := types.NamespacedName{Namespace: "ns", Name: "es"}
+
+		// an index which does not exist is not reported as promoted
+		statuses, err := Reconcile(context.Background(), c, esClient, &es)
+		require.NoError(t, err)
+		require.Equal(t, []esv1.FollowerIndexStatus{
+			{Name: "orders-copy", Phase: esv1.FollowerIndexPromotedPhase},
+			{Name: "missing", Phase: esv1.FollowerIndexFailedPhase, Message: "index does not exist"},
+		}, statuses)
+		var updated esv1.Elasticsearch
+		require.NoError(t, c.Get(context.Background(), key, &updated))
+		require.Equal(t, "orders-copy", updated.Annotations[PromotedFollowerIndicesAnnotationName])
+
+		// the promoted index is not followed again once the promotion is removed from the specification
+		esClient.calls = nil
+		updated.Spec.CrossClusterReplication.FollowerIndices = []esv1.FollowerIndex{
+			{Name: "orders-copy", RemoteCluster: "eu-west", LeaderIndex: "orders"},
+		}
+		statuses, err = Reconcile(context.Background(), c, esClient, &updated)
+		require.NoError(t, err)
+		require.Equal(t, []esv1.FollowerIndexStatus{{Name: "orders-copy", Phase: esv1.FollowerIndexPromotedPhase}}, statuses)
+		require.Empty(t, esClient.calls)
+
+		// the promoted index is not tracked anymore once it is removed from the specification
+		updated = esv1.Elasticsearch{}
+		require.NoError(t, c.Get(context.Background(), key, &updated))
+		updated.Spec.CrossClusterReplication = esv1.CrossClusterReplication{}
+		_, err = Reconcile(context.Background(), c, esClient, &updated)
+		require.NoError(t, err)
+		var cleaned esv1.Elasticsearch
+		require.NoError(t, c.Get(context.Background(), key, &cleaned))
+		require.NotContains(t, cleaned.Annotations, PromotedFollowerIndicesAnnotationName)
+	})
+}
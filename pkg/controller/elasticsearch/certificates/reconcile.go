@@ -32,6 +32,9 @@ type CertificateResources struct {
 
 	// TransportCA is the CA used for Transport certificates
 	TransportCA *certificates.CA
+
+	// TransportCertificates describes the expiry and the upcoming rotations of the transport certificates.
+	TransportCertificates *esv1.TransportCertificatesStatus
 }
 
 // Reconcile reconciles the certificates of a cluster.
@@ -104,7 +107,7 @@ func Reconcile(
 	// reconcile transport certificates
 	transportResults := transport.ReconcileTransportCertificatesSecrets(
 		driver.K8sClient(),
-		transportCA,
+		transport.NewIssuer(driver.K8sClient(), es, transportCA),
 		es,
 		certRotation,
 	)
@@ -118,6 +121,11 @@ func Reconcile(
 		return nil, results
 	}
 
+	transportCertificates, err := transport.CertificatesStatus(driver.K8sClient(), es, transportCA, caRotation, certRotation, time.Now())
+	if err != nil {
+		return nil, results.WithError(err)
+	}
+
 	trustedHTTPCertificates, err := certificates.ParsePEMCerts(httpCerts.CertPem())
 	if err != nil {
 		return nil, results.WithError(err)
@@ -126,5 +134,6 @@ func Reconcile(
 	return &CertificateResources{
 		TrustedHTTPCertificates: trustedHTTPCertificates,
 		TransportCA:             transportCA,
+		TransportCertificates:   transportCertificates,
 	}, results
 }
@@ -7,6 +7,7 @@ package transport
 import (
 	"context"
 
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
 	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/certificates"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/driver"
@@ -22,8 +23,21 @@ func CustomTransportCertsWatchKey(es types.NamespacedName) string {
 	return esv1.ESNamer.Suffix(es.Name, "custom-transport-certs")
 }
 
+func IssuerCAWatchKey(es types.NamespacedName) string {
+	return esv1.ESNamer.Suffix(es.Name, "transport-issuer-ca")
+}
+
+// NewIssuer returns the issuer of the transport certificates: either the external issuer specified in the
+// Elasticsearch resource, whose CA is the given one, or the given CA itself.
+func NewIssuer(c k8s.Client, es esv1.Elasticsearch, ca *certificates.CA) certificates.Issuer {
+	if externalIssuer := es.Spec.Transport.TLS.ExternalIssuer(); externalIssuer != nil {
+		return certificates.NewCSRIssuer(c, externalIssuer.SignerName, ca)
+	}
+	return certificates.NewCAIssuer(ca)
+}
+
 // ReconcileOrRetrieveCA either reconciles a self-signed CA generated by the operator
-// or it retrieves a user defined CA certificate.
+// or it retrieves a user defined CA certificate, or the CA certificate of the external issuer.
 func ReconcileOrRetrieveCA(
 	driver driver.Interface,
 	es esv1.Elasticsearch,
@@ -49,7 +63,32 @@ func ReconcileOrRetrieveCA(
 		driver.Recorder().Eventf(&es, corev1.EventTypeWarning, events.EventReasonUnexpected, err.Error())
 		return nil, err
 	}
-	// 1. No custom certs are specified, reconcile our internal self-signed CA instead (probably the common case)
+	// Same for the Secret holding the CA certificate of the external issuer, if any.
+	var issuerCASecret commonv1.SecretRef
+	externalIssuer := es.Spec.Transport.TLS.ExternalIssuer()
+	if externalIssuer != nil {
+		issuerCASecret.SecretName = externalIssuer.CASecretName
+	}
+	if err := certificates.ReconcileCustomCertWatch(
+		driver.DynamicWatches(),
+		IssuerCAWatchKey(esNSN),
+		esNSN,
+		issuerCASecret,
+	); err != nil {
+		return nil, err
+	}
+
+	// 1. No custom certs are specified but an external issuer is, retrieve the CA certificate of the issuer
+	if customCASecret == nil && externalIssuer != nil {
+		ca, err := certificates.RetrieveIssuerCA(driver.K8sClient(), es.Namespace, *externalIssuer)
+		if err != nil {
+			driver.Recorder().Eventf(&es, corev1.EventTypeWarning, events.EventReasonUnexpected, err.Error())
+			return nil, err
+		}
+		return ca, nil
+	}
+
+	// 2. No custom certs are specified, reconcile our internal self-signed CA instead (probably the common case)
 	if customCASecret == nil {
 		return certificates.ReconcileCAForOwner(
 			driver.K8sClient(),
@@ -61,7 +100,7 @@ func ReconcileOrRetrieveCA(
 		)
 	}
 
-	// 2. Assuming from here on the user wants to use custom certs and has configured a secret with them.
+	// 3. Assuming from here on the user wants to use custom certs and has configured a secret with them.
 
 	// Try to parse the provided secret to get to the CA and to report any validation errors to the user.
 	ca, err := certificates.ParseCustomCASecret(*customCASecret)
@@ -5,6 +5,7 @@
 package transport
 
 import (
+	"context"
 	"crypto"
 	cryptorand "crypto/rand"
 	"crypto/x509"
@@ -15,6 +16,7 @@ import (
 
 	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/certificates"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -29,66 +31,81 @@ func PodCertFileName(podName string) string {
 }
 
 // ensureTransportCertificatesSecretContentsForPod ensures that the transport certificates secret has the correct
-// content for a specific pod
+// content for a specific pod. A new certificate is issued if forceRotation is true. The certificate is left unchanged
+// if a new certificate is not issued yet.
+//
+// Returns true if a new certificate was issued.
 func ensureTransportCertificatesSecretContentsForPod(
 	es esv1.Elasticsearch,
 	secret *corev1.Secret,
 	pod corev1.Pod,
-	ca *certificates.CA,
+	issuer certificates.Issuer,
 	rotationParams certificates.RotationParams,
-) error {
+	forceRotation bool,
+) (bool, error) {
 	// verify that the secret contains a parsable and compatible private key
-	privateKey := certificates.GetCompatiblePrivateKey(ca.PrivateKey, secret, PodKeyFileName(pod.Name))
+	privateKey := issuer.CompatiblePrivateKey(secret, PodKeyFileName(pod.Name))
 
 	// if we need a new private key, generate it
 	if privateKey == nil {
-		generatedPrivateKey, err := certificates.NewPrivateKey(ca.PrivateKey)
+		generatedPrivateKey, err := issuer.NewPrivateKey()
 		if err != nil {
-			return err
+			return false, err
 		}
 
 		privateKey = generatedPrivateKey
 		pemPrivateKey, err := certificates.EncodePEMPrivateKey(privateKey)
 		if err != nil {
-			return err
+			return false, err
 		}
 		secret.Data[PodKeyFileName(pod.Name)] = pemPrivateKey
 	}
 
-	if shouldIssueNewCertificate(es, *secret, pod, privateKey, ca, rotationParams.RotateBefore) {
+	if forceRotation || shouldIssueNewCertificate(es, *secret, pod, privateKey, issuer.CA(), rotationParams.RotateBefore) {
 		log.Info(
 			"Issuing new certificate",
 			"pod_name", pod.Name,
+			"forced", forceRotation,
 		)
 
 		csr, err := x509.CreateCertificateRequest(cryptorand.Reader, &x509.CertificateRequest{}, privateKey)
 		if err != nil {
-			return err
+			return false, err
 		}
 
 		// create a cert from the csr
 		parsedCSR, err := x509.ParseCertificateRequest(csr)
 		if err != nil {
-			return err
+			return false, err
 		}
 
 		validatedCertificateTemplate, err := createValidatedCertificateTemplate(
 			pod, es, parsedCSR, rotationParams.Validity,
 		)
 		if err != nil {
-			return err
+			return false, err
 		}
-		// sign the certificate
-		certData, err := ca.CreateCertificate(*validatedCertificateTemplate)
+		// issue the certificate
+		certChain, err := issuer.Issue(context.Background(), certificates.CertificateRequest{
+			Owner:      k8s.ExtractNamespacedName(&es),
+			Name:       pod.Name,
+			Template:   *validatedCertificateTemplate,
+			PrivateKey: privateKey,
+		})
 		if err != nil {
-			return err
+			return false, err
+		}
+		if certChain == nil {
+			log.Info("Waiting for the certificate to be issued", "namespace", pod.Namespace, "pod_name", pod.Name)
+			return false, nil
 		}
 
 		// store the issued certificate in a secret mounted into the pod
-		secret.Data[PodCertFileName(pod.Name)] = certificates.EncodePEMCert(certData, ca.Cert.Raw)
+		secret.Data[PodCertFileName(pod.Name)] = certChain
+		return true, nil
 	}
 
-	return nil
+	return false, nil
 }
 
 // shouldIssueNewCertificate returns true if we should issue a new certificate.
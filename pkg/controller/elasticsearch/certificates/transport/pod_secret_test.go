@@ -5,6 +5,7 @@
 package transport
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
@@ -107,11 +108,12 @@ func Test_shouldIssueNewCertificate(t *testing.T) {
 
 func Test_ensureTransportCertificatesSecretContentsForPod(t *testing.T) {
 	tests := []struct {
-		name       string
-		secret     *corev1.Secret
-		pod        *corev1.Pod
-		assertions func(t *testing.T, before corev1.Secret, after corev1.Secret)
-		wantErr    func(t *testing.T, err error)
+		name          string
+		secret        *corev1.Secret
+		pod           *corev1.Pod
+		forceRotation bool
+		assertions    func(t *testing.T, before corev1.Secret, after corev1.Secret)
+		wantErr       func(t *testing.T, err error)
 	}{
 		{
 			name: "no private key in the secret",
@@ -194,6 +196,22 @@ func Test_ensureTransportCertificatesSecretContentsForPod(t *testing.T) {
 				assert.Equal(t, before, after)
 			},
 		},
+		{
+			name: "valid data should be updated if the rotation is forced",
+			secret: &corev1.Secret{
+				Data: map[string][]byte{
+					PodKeyFileName(testPod.Name):  testRSAPEMPrivateKey,
+					PodCertFileName(testPod.Name): rsaCert,
+				},
+			},
+			forceRotation: true,
+			assertions: func(t *testing.T, before corev1.Secret, after corev1.Secret) {
+				t.Helper()
+				// key should be re-used
+				assert.Equal(t, before.Data[PodKeyFileName(testPod.Name)], after.Data[PodKeyFileName(testPod.Name)])
+				assert.NotEqual(t, after.Data[PodCertFileName(testPod.Name)], before.Data[PodCertFileName(testPod.Name)])
+			},
+		},
 		{
 			name: "ECDSA key should be replaced by a RSA private key",
 			secret: &corev1.Secret{
@@ -224,21 +242,23 @@ func Test_ensureTransportCertificatesSecretContentsForPod(t *testing.T) {
 
 			beforeSecret := tt.secret.DeepCopy()
 
-			err := ensureTransportCertificatesSecretContentsForPod(
+			issued, err := ensureTransportCertificatesSecretContentsForPod(
 				testES,
 				tt.secret,
 				*tt.pod,
-				testRSACA,
+				certificates.NewCAIssuer(testRSACA),
 				certificates.RotationParams{
 					Validity:     certificates.DefaultCertValidity,
 					RotateBefore: certificates.DefaultRotateBefore,
 				},
+				tt.forceRotation,
 			)
 			if tt.wantErr != nil {
 				tt.wantErr(t, err)
 				return
 			}
 			require.NoError(t, err)
+			require.Equal(t, !bytes.Equal(beforeSecret.Data[PodCertFileName(testPod.Name)], tt.secret.Data[PodCertFileName(testPod.Name)]), issued)
 
 			tt.assertions(t, *beforeSecret, *tt.secret)
 		})
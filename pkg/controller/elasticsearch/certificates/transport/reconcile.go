@@ -30,12 +30,23 @@ import (
 
 var log = ulog.Log.WithName("transport")
 
+// RotateCertificatesAnnotation can be set on an Elasticsearch resource to force the rotation of the transport
+// certificates of all the nodes, without restarting them. Its value identifies the rotation, for example a timestamp:
+// once the certificate of a node is rotated, the annotation is set with the same value on the Pod of the node.
+const RotateCertificatesAnnotation = "eck.k8s.elastic.co/rotate-transport-certificates"
+
+// forceRotation returns true if the transport certificate of the given Pod must be rotated on request of the user.
+func forceRotation(es esv1.Elasticsearch, pod corev1.Pod) bool {
+	requested := es.Annotations[RotateCertificatesAnnotation]
+	return requested != "" && pod.Annotations[RotateCertificatesAnnotation] != requested
+}
+
 // ReconcileTransportCertificatesSecrets reconciles the secret containing transport certificates for all nodes in the
 // cluster.
 // Secrets which are not used anymore are deleted as part of the downscale process.
 func ReconcileTransportCertificatesSecrets(
 	c k8s.Client,
-	ca *certificates.CA,
+	issuer certificates.Issuer,
 	es esv1.Elasticsearch,
 	rotationParams certificates.RotationParams,
 ) *reconciler.Results {
@@ -57,7 +68,7 @@ func ReconcileTransportCertificatesSecrets(
 	}
 
 	for ssetName := range ssets {
-		if err := reconcileNodeSetTransportCertificatesSecrets(c, ca, es, ssetName, rotationParams); err != nil {
+		if err := reconcileNodeSetTransportCertificatesSecrets(c, issuer, es, ssetName, rotationParams); err != nil {
 			results.WithError(err)
 		}
 	}
@@ -90,7 +101,7 @@ func DeleteLegacyTransportCertificate(client k8s.Client, es esv1.Elasticsearch)
 // a given StatefulSet.
 func reconcileNodeSetTransportCertificatesSecrets(
 	c k8s.Client,
-	ca *certificates.CA,
+	issuer certificates.Issuer,
 	es esv1.Elasticsearch,
 	ssetName string,
 	rotationParams certificates.RotationParams,
@@ -110,21 +121,33 @@ func reconcileNodeSetTransportCertificatesSecrets(
 	}
 	// defensive copy of the current secret so we can check whether we need to update later on
 	currentTransportCertificatesSecret := secret.DeepCopy()
-	for _, pod := range pods.Items {
+	var pendingPods []string
+	for i, pod := range pods.Items {
 		if pod.Status.PodIP == "" {
 			log.Info("Skipping pod because it has no IP yet", "namespace", pod.Namespace, "pod_name", pod.Name)
 			continue
 		}
 
-		if err := ensureTransportCertificatesSecretContentsForPod(
-			es, secret, pod, ca, rotationParams,
-		); err != nil {
+		forced := forceRotation(es, pod)
+		issued, err := ensureTransportCertificatesSecretContentsForPod(
+			es, secret, pod, issuer, rotationParams, forced,
+		)
+		if err != nil {
 			return err
 		}
+		if forced && issued {
+			// record the rotation on the Pod, which is updated below along with the certificate
+			if pods.Items[i].Annotations == nil {
+				pods.Items[i].Annotations = map[string]string{}
+			}
+			pods.Items[i].Annotations[RotateCertificatesAnnotation] = es.Annotations[RotateCertificatesAnnotation]
+		}
 		certCommonName := buildCertificateCommonName(pod, es.Name, es.Namespace)
 		cert := extractTransportCert(*secret, pod, certCommonName)
 		if cert == nil {
-			return errors.New("no certificate found for pod")
+			// the certificate is not issued yet by the external issuer, keep the private key to issue it later
+			pendingPods = append(pendingPods, pod.Name)
+			continue
 		}
 		// handle cert expiry via requeue
 		results.WithResult(reconcile.Result{
@@ -157,7 +180,7 @@ func reconcileNodeSetTransportCertificatesSecrets(
 		}
 	}
 
-	caBytes := certificates.EncodePEMCert(ca.Cert.Raw)
+	caBytes := certificates.EncodePEMCert(issuer.CA().Cert.Raw)
 
 	// compare with current trusted CA certs.
 	if !bytes.Equal(caBytes, secret.Data[certificates.CAFileName]) {
@@ -173,6 +196,9 @@ func reconcileNodeSetTransportCertificatesSecrets(
 		}
 	}
 
+	if len(pendingPods) > 0 {
+		return errors.Errorf("no certificate issued yet for pods %v", pendingPods)
+	}
 	return nil
 }
 
@@ -6,8 +6,11 @@ package transport
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"reflect"
 	"testing"
+	"time"
 
 	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/certificates"
@@ -16,6 +19,8 @@ import (
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/label"
 	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	certificatesv1 "k8s.io/api/certificates/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -189,7 +194,7 @@ func TestReconcileTransportCertificatesSecrets(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			k8sClient := k8s.NewFakeClient(tt.args.initialObjects...)
-			if got := ReconcileTransportCertificatesSecrets(k8sClient, tt.args.ca, *tt.args.es, tt.args.rotationParams); !reflect.DeepEqual(got, tt.want) {
+			if got := ReconcileTransportCertificatesSecrets(k8sClient, certificates.NewCAIssuer(tt.args.ca), *tt.args.es, tt.args.rotationParams); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("ReconcileTransportCertificatesSecrets() = %v, want %v", got, tt.want)
 			}
 			// Check Secrets
@@ -202,6 +207,67 @@ func TestReconcileTransportCertificatesSecrets(t *testing.T) {
 	}
 }
 
+func TestReconcileTransportCertificatesSecrets_ExternalIssuer(t *testing.T) {
+	es := newEsBuilder().addNodeSet("sset1", 2).build()
+	k8sClient := k8s.NewFakeClient(
+		newPodBuilder().forEs(testEsName).inNodeSet("sset1").withIndex(0).withIP("1.1.1.2").build(),
+		newPodBuilder().forEs(testEsName).inNodeSet("sset1").withIndex(1).withIP("1.1.1.3").build(),
+	)
+	issuer := certificates.NewCSRIssuer(k8sClient, "example.com/corporate-pki", certificates.NewCA(nil, testRSACA.Cert))
+	rotationParams := certificates.RotationParams{Validity: certificates.DefaultCertValidity, RotateBefore: certificates.DefaultRotateBefore}
+	getTransportCerts := func() corev1.Secret {
+		var secret corev1.Secret
+		nsn := types.NamespacedName{Namespace: testNamespace, Name: "test-es-name-es-sset1-es-transport-certs"}
+		require.NoError(t, k8sClient.Get(context.Background(), nsn, &secret))
+		return secret
+	}
+
+	// certificates are requested for both Pods but not issued yet
+	_, err := ReconcileTransportCertificatesSecrets(k8sClient, issuer, *es, rotationParams).Aggregate()
+	require.EqualError(t, err, "no certificate issued yet for pods [test-es-name-es-sset1-0 test-es-name-es-sset1-1]")
+	transportCerts := getTransportCerts()
+	// the CA of the issuer and the private keys are stored
+	require.Len(t, transportCerts.Data, 3)
+	require.Equal(t, testRSACABytes, transportCerts.Data["ca.crt"])
+	var csrs certificatesv1.CertificateSigningRequestList
+	require.NoError(t, k8sClient.List(context.Background(), &csrs))
+	require.Len(t, csrs.Items, 2)
+
+	// simulate the external signer
+	for i := range csrs.Items {
+		block, _ := pem.Decode(csrs.Items[i].Spec.Request)
+		request, err := x509.ParseCertificateRequest(block.Bytes)
+		require.NoError(t, err)
+		certData, err := testRSACA.CreateCertificate(certificates.ValidatedCertificateTemplate(x509.Certificate{
+			Subject:         request.Subject,
+			ExtraExtensions: request.Extensions,
+			PublicKey:       request.PublicKey,
+			NotBefore:       time.Now().Add(-10 * time.Minute),
+			NotAfter:        time.Now().Add(certificates.DefaultCertValidity),
+		}))
+		require.NoError(t, err)
+		csrs.Items[i].Status.Certificate = certificates.EncodePEMCert(certData, testRSACA.Cert.Raw)
+		require.NoError(t, k8sClient.Update(context.Background(), &csrs.Items[i]))
+	}
+
+	// the issued certificates are stored
+	_, err = ReconcileTransportCertificatesSecrets(k8sClient, issuer, *es, rotationParams).Aggregate()
+	require.NoError(t, err)
+	transportCerts = getTransportCerts()
+	require.Len(t, transportCerts.Data, 5)
+	require.Contains(t, transportCerts.Data, "test-es-name-es-sset1-0.tls.crt")
+	require.Contains(t, transportCerts.Data, "test-es-name-es-sset1-1.tls.crt")
+	require.NoError(t, k8sClient.List(context.Background(), &csrs))
+	require.Empty(t, csrs.Items)
+
+	// running again does not request new certificates
+	_, err = ReconcileTransportCertificatesSecrets(k8sClient, issuer, *es, rotationParams).Aggregate()
+	require.NoError(t, err)
+	require.Equal(t, transportCerts.Data, getTransportCerts().Data)
+	require.NoError(t, k8sClient.List(context.Background(), &csrs))
+	require.Empty(t, csrs.Items)
+}
+
 func TestDeleteStatefulSetTransportCertificate(t *testing.T) {
 	type args struct {
 		client   k8s.Client
@@ -0,0 +1,85 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package transport
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/certificates"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/label"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CertificatesStatus returns the expiry and the upcoming rotations of the transport certificates of the nodes of the
+// given cluster, issued by the given CA. The CA is only rotated by the operator if it is self-signed.
+func CertificatesStatus(
+	c k8s.Client,
+	es esv1.Elasticsearch,
+	ca *certificates.CA,
+	caRotation certificates.RotationParams,
+	certRotation certificates.RotationParams,
+	now time.Time,
+) (*esv1.TransportCertificatesStatus, error) {
+	status := esv1.TransportCertificatesStatus{
+		CAExpiration: metav1.NewTime(ca.Cert.NotAfter),
+	}
+	if !es.Spec.Transport.TLS.UserDefinedCA() && es.Spec.Transport.TLS.ExternalIssuer() == nil {
+		caNextRotation := nextRotation(now, ca.Cert.NotAfter, caRotation.RotateBefore)
+		status.CANextRotation = &caNextRotation
+	}
+
+	var pods corev1.PodList
+	if err := c.List(context.Background(), &pods, label.NewLabelSelectorForElasticsearch(es), client.InNamespace(es.Namespace)); err != nil {
+		return nil, err
+	}
+	sort.Slice(pods.Items, func(i, j int) bool { return pods.Items[i].Name < pods.Items[j].Name })
+
+	secrets := map[string]*corev1.Secret{}
+	forcedRotation := es.Annotations[RotateCertificatesAnnotation]
+	for _, pod := range pods.Items {
+		if forceRotation(es, pod) {
+			// the forced rotation is not completed yet
+			forcedRotation = ""
+		}
+		ssetName := pod.Labels[label.StatefulSetNameLabelName]
+		secret, exists := secrets[ssetName]
+		if !exists {
+			secret = &corev1.Secret{}
+			nsn := types.NamespacedName{Namespace: es.Namespace, Name: esv1.StatefulSetTransportCertificatesSecret(ssetName)}
+			if err := c.Get(context.Background(), nsn, secret); err != nil && !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+			secrets[ssetName] = secret
+		}
+		cert := extractTransportCert(*secret, pod, buildCertificateCommonName(pod, es.Name, es.Namespace))
+		if cert == nil {
+			continue
+		}
+		nodeStatus := esv1.NodeTransportCertificateStatus{
+			Pod:          pod.Name,
+			Expiration:   metav1.NewTime(cert.NotAfter),
+			NextRotation: nextRotation(now, cert.NotAfter, certRotation.RotateBefore),
+		}
+		if status.NextRotation == nil || nodeStatus.NextRotation.Before(status.NextRotation) {
+			status.NextRotation = nodeStatus.NextRotation.DeepCopy()
+		}
+		status.Nodes = append(status.Nodes, nodeStatus)
+	}
+	status.ForcedRotation = forcedRotation
+	return &status, nil
+}
+
+// nextRotation returns the time at which a certificate expiring at the given time is rotated.
+func nextRotation(now time.Time, expiration time.Time, rotateBefore time.Duration) metav1.Time {
+	return metav1.NewTime(now.Add(certificates.ShouldRotateIn(now, expiration, rotateBefore)).Truncate(time.Second))
+}
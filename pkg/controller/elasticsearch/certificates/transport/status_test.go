@@ -0,0 +1,101 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package transport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/certificates"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestCertificatesStatus(t *testing.T) {
+	es := newEsBuilder().addNodeSet("sset1", 2).build()
+	k8sClient := k8s.NewFakeClient(
+		newPodBuilder().forEs(testEsName).inNodeSet("sset1").withIndex(1).withIP("1.1.1.3").build(),
+		newPodBuilder().forEs(testEsName).inNodeSet("sset1").withIndex(0).withIP("1.1.1.2").build(),
+		// no certificate issued yet for this Pod
+		newPodBuilder().forEs(testEsName).inNodeSet("sset1").withIndex(2).build(),
+	)
+	issuer := certificates.NewCAIssuer(testRSACA)
+	rotationParams := certificates.RotationParams{Validity: certificates.DefaultCertValidity, RotateBefore: certificates.DefaultRotateBefore}
+	_, err := ReconcileTransportCertificatesSecrets(k8sClient, issuer, *es, rotationParams).Aggregate()
+	require.NoError(t, err)
+
+	now := time.Now()
+	status, err := CertificatesStatus(k8sClient, *es, testRSACA, rotationParams, rotationParams, now)
+	require.NoError(t, err)
+	require.Equal(t, testRSACA.Cert.NotAfter.Unix(), status.CAExpiration.Unix())
+	require.NotNil(t, status.CANextRotation)
+	require.Len(t, status.Nodes, 2)
+	require.Equal(t, "test-es-name-es-sset1-0", status.Nodes[0].Pod)
+	require.Equal(t, "test-es-name-es-sset1-1", status.Nodes[1].Pod)
+	for _, node := range status.Nodes {
+		require.True(t, node.Expiration.After(now.Add(certificates.DefaultCertValidity-time.Minute)))
+		require.True(t, node.NextRotation.After(now.Add(certificates.DefaultCertValidity-certificates.DefaultRotateBefore-time.Minute)))
+		require.False(t, status.NextRotation.After(node.NextRotation.Time))
+	}
+	require.Empty(t, status.ForcedRotation)
+
+	// the CA is not rotated by the operator if it is provided by the user
+	userCAES := es.DeepCopy()
+	userCAES.Spec.Transport.TLS.Certificate = commonv1.SecretRef{SecretName: "user-ca"}
+	status, err = CertificatesStatus(k8sClient, *userCAES, testRSACA, rotationParams, rotationParams, now)
+	require.NoError(t, err)
+	require.Nil(t, status.CANextRotation)
+}
+
+func TestReconcileTransportCertificatesSecrets_ForcedRotation(t *testing.T) {
+	es := newEsBuilder().addNodeSet("sset1", 1).build()
+	k8sClient := k8s.NewFakeClient(
+		newPodBuilder().forEs(testEsName).inNodeSet("sset1").withIndex(0).withIP("1.1.1.2").build(),
+	)
+	issuer := certificates.NewCAIssuer(testRSACA)
+	rotationParams := certificates.RotationParams{Validity: certificates.DefaultCertValidity, RotateBefore: certificates.DefaultRotateBefore}
+	podCert := func() []byte {
+		var secret corev1.Secret
+		nsn := types.NamespacedName{Namespace: testNamespace, Name: "test-es-name-es-sset1-es-transport-certs"}
+		require.NoError(t, k8sClient.Get(context.Background(), nsn, &secret))
+		return secret.Data["test-es-name-es-sset1-0.tls.crt"]
+	}
+	podAnnotation := func() string {
+		var pod corev1.Pod
+		nsn := types.NamespacedName{Namespace: testNamespace, Name: "test-es-name-es-sset1-0"}
+		require.NoError(t, k8sClient.Get(context.Background(), nsn, &pod))
+		return pod.Annotations[RotateCertificatesAnnotation]
+	}
+
+	_, err := ReconcileTransportCertificatesSecrets(k8sClient, issuer, *es, rotationParams).Aggregate()
+	require.NoError(t, err)
+	initialCert := podCert()
+	require.NotEmpty(t, initialCert)
+
+	// request a rotation
+	es.Annotations = map[string]string{RotateCertificatesAnnotation: "1"}
+	status, err := CertificatesStatus(k8sClient, *es, testRSACA, rotationParams, rotationParams, time.Now())
+	require.NoError(t, err)
+	require.Empty(t, status.ForcedRotation)
+
+	// the certificate is rotated and the rotation recorded on the Pod
+	_, err = ReconcileTransportCertificatesSecrets(k8sClient, issuer, *es, rotationParams).Aggregate()
+	require.NoError(t, err)
+	rotatedCert := podCert()
+	require.NotEqual(t, initialCert, rotatedCert)
+	require.Equal(t, "1", podAnnotation())
+	status, err = CertificatesStatus(k8sClient, *es, testRSACA, rotationParams, rotationParams, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, "1", status.ForcedRotation)
+
+	// the certificate is not rotated again for the same request
+	_, err = ReconcileTransportCertificatesSecrets(k8sClient, issuer, *es, rotationParams).Aggregate()
+	require.NoError(t, err)
+	require.Equal(t, rotatedCert, podCert())
+}
@@ -59,6 +59,12 @@ type Client interface {
 	AutoscalingClient
 	ShardLister
 	LicenseClient
+	SnapshotClient
+	IndexManagementClient
+	RoleMappingClient
+	CrossClusterReplicationClient
+	NativeRealmClient
+	ShutdownClient
 	// Close idle connections in the underlying http client.
 	Close()
 	// Equal returns true if other can be considered as the same client.
@@ -96,6 +102,8 @@ type Client interface {
 	UpdateRemoteClusterSettings(ctx context.Context, settings RemoteClustersSettings) error
 	// GetRemoteClusterSettings retrieves the remote clusters of a cluster.
 	GetRemoteClusterSettings(ctx context.Context) (RemoteClustersSettings, error)
+	// GetRemoteClustersInfo retrieves the connection information of the remote clusters of a cluster.
+	GetRemoteClustersInfo(ctx context.Context) (RemoteClustersInfo, error)
 	// AddVotingConfigExclusions sets the transient and persistent setting of the same name in cluster settings.
 	// Introduced in: Elasticsearch 7.0.0
 	AddVotingConfigExclusions(ctx context.Context, nodeNames []string) error
@@ -103,6 +111,9 @@ type Client interface {
 	//
 	// Introduced in: Elasticsearch 7.0.0
 	DeleteVotingConfigExclusions(ctx context.Context, waitForRemoval bool) error
+	// GetDeprecations calls the _migration/deprecations api to retrieve the deprecated settings and features in use,
+	// which may prevent the cluster from running the next major version.
+	GetDeprecations(ctx context.Context) (Deprecations, error)
 	// Request exposes a low level interface to the underlying HTTP client e.g. for testing purposes.
 	// The Elasticsearch endpoint will be added automatically to the request URL which should therefore just be the path
 	// with a leading /
@@ -328,6 +328,25 @@ func TestClientGetNodesStats(t *testing.T) {
 	require.Equal(t, "3221225472", resp.Nodes["Rt-o5-ZBQaq-Nkhhy0p7JA"].OS.CGroup.Memory.LimitInBytes)
 }
 
+func TestClientGetRemoteClustersInfo(t *testing.T) {
+	expectedPath := "/_remote/info"
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, expectedPath, req.URL.Path)
+		return &http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(strings.NewReader(fixtures.RemoteInfoSample)),
+			Header:     make(http.Header),
+			Request:    req,
+		}
+	})
+	info, err := testClient.GetRemoteClustersInfo(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, RemoteClustersInfo{
+		"cluster-two": {Connected: true, Mode: "sniff", NumNodesConnected: 3},
+		"eu-west":     {Connected: false, Mode: "proxy", SkipUnavailable: true},
+	}, info)
+}
+
 func TestGetInfo(t *testing.T) {
 	expectedPath := "/"
 	testClient := NewMockClient(version.MustParse("6.4.1"), func(req *http.Request) *http.Response {
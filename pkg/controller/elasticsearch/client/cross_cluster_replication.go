@@ -0,0 +1,187 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// CrossClusterReplicationClient captures Elasticsearch API calls around cross-cluster replication.
+type CrossClusterReplicationClient interface {
+	// GetAutoFollowPatterns returns all the auto-follow patterns of the cluster.
+	GetAutoFollowPatterns(ctx context.Context) (AutoFollowPatterns, error)
+	// UpsertAutoFollowPattern creates or updates an auto-follow pattern.
+	UpsertAutoFollowPattern(ctx context.Context, name string, pattern AutoFollowPattern) error
+	// DeleteAutoFollowPattern deletes an auto-follow pattern. The existing follower indices are left untouched.
+	DeleteAutoFollowPattern(ctx context.Context, name string) error
+	// GetFollowerIndices returns all the follower indices of the cluster.
+	GetFollowerIndices(ctx context.Context) (FollowerIndices, error)
+	// GetFollowerIndicesStats returns the shard-level replication statistics of all the follower indices of the cluster.
+	GetFollowerIndicesStats(ctx context.Context) (FollowerIndicesStats, error)
+	// Follow creates a follower index replicating the given leader index of a remote cluster.
+	Follow(ctx context.Context, index string, request FollowRequest) error
+	// PauseFollow pauses the replication of a follower index.
+	PauseFollow(ctx context.Context, index string) error
+	// Unfollow converts a paused and closed follower index into a regular index.
+	Unfollow(ctx context.Context, index string) error
+	// CloseIndex closes an index.
+	CloseIndex(ctx context.Context, index string) error
+	// OpenIndex opens a closed index.
+	OpenIndex(ctx context.Context, index string) error
+	// IndexExists returns true if the given index exists.
+	IndexExists(ctx context.Context, index string) (bool, error)
+}
+
+// AutoFollowPatterns maps the names of the auto-follow patterns to their definition.
+type AutoFollowPatterns map[string]AutoFollowPattern
+
+// AutoFollowPattern models an auto-follow pattern.
+type AutoFollowPattern struct {
+	RemoteCluster       string   `json:"remote_cluster"`
+	LeaderIndexPatterns []string `json:"leader_index_patterns"`
+	FollowIndexPattern  string   `json:"follow_index_pattern,omitempty"`
+}
+
+// AutoFollowPatternsResponse models the response from a request to /_ccr/auto_follow.
+type AutoFollowPatternsResponse struct {
+	Patterns []struct {
+		Name    string            `json:"name"`
+		Pattern AutoFollowPattern `json:"pattern"`
+	} `json:"patterns"`
+}
+
+// Follower index statuses, as returned by Elasticsearch.
+const (
+	FollowerIndexActive = "active"
+	FollowerIndexPaused = "paused"
+)
+
+// FollowerIndices maps the names of the follower indices to their definition.
+type FollowerIndices map[string]FollowerIndex
+
+// FollowerIndex partially models a follower index as returned by Elasticsearch.
+type FollowerIndex struct {
+	FollowerIndex string `json:"follower_index"`
+	RemoteCluster string `json:"remote_cluster"`
+	LeaderIndex   string `json:"leader_index"`
+	Status        string `json:"status"`
+}
+
+// FollowerIndicesResponse models the response from a request to /<index>/_ccr/info.
+type FollowerIndicesResponse struct {
+	FollowerIndices []FollowerIndex `json:"follower_indices"`
+}
+
+// FollowerIndicesStats maps the names of the follower indices to the replication statistics of their shards.
+type FollowerIndicesStats map[string][]FollowerShardStats
+
+// FollowerShardStats partially models the replication statistics of a shard of a follower index.
+type FollowerShardStats struct {
+	ShardID                  int32                 `json:"shard_id"`
+	LeaderGlobalCheckpoint   int64                 `json:"leader_global_checkpoint"`
+	FollowerGlobalCheckpoint int64                 `json:"follower_global_checkpoint"`
+	FatalException           *FollowFatalException `json:"fatal_exception,omitempty"`
+}
+
+// FollowFatalException is the error which stopped the replication of a shard.
+type FollowFatalException struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// CrossClusterReplicationStatsResponse partially models the response from a request to /_ccr/stats.
+type CrossClusterReplicationStatsResponse struct {
+	FollowStats struct {
+		Indices []struct {
+			Index  string               `json:"index"`
+			Shards []FollowerShardStats `json:"shards"`
+		} `json:"indices"`
+	} `json:"follow_stats"`
+}
+
+// FollowRequest is the request to create a follower index.
+type FollowRequest struct {
+	RemoteCluster string `json:"remote_cluster"`
+	LeaderIndex   string `json:"leader_index"`
+}
+
+func (c *clientV6) GetAutoFollowPatterns(ctx context.Context) (AutoFollowPatterns, error) {
+	var response AutoFollowPatternsResponse
+	if err := c.get(ctx, "/_ccr/auto_follow", &response); err != nil {
+		if IsNotFound(err) {
+			// some versions of Elasticsearch respond with a 404 error if there is no auto-follow pattern
+			return AutoFollowPatterns{}, nil
+		}
+		return nil, err
+	}
+	patterns := make(AutoFollowPatterns, len(response.Patterns))
+	for _, pattern := range response.Patterns {
+		patterns[pattern.Name] = pattern.Pattern
+	}
+	return patterns, nil
+}
+
+func (c *clientV6) UpsertAutoFollowPattern(ctx context.Context, name string, pattern AutoFollowPattern) error {
+	return c.put(ctx, fmt.Sprintf("/_ccr/auto_follow/%s", url.PathEscape(name)), pattern, nil)
+}
+
+func (c *clientV6) DeleteAutoFollowPattern(ctx context.Context, name string) error {
+	return c.delete(ctx, fmt.Sprintf("/_ccr/auto_follow/%s", url.PathEscape(name)), nil, nil)
+}
+
+func (c *clientV6) GetFollowerIndices(ctx context.Context) (FollowerIndices, error) {
+	var response FollowerIndicesResponse
+	if err := c.get(ctx, "/_all/_ccr/info", &response); err != nil {
+		return nil, err
+	}
+	indices := make(FollowerIndices, len(response.FollowerIndices))
+	for _, index := range response.FollowerIndices {
+		indices[index.FollowerIndex] = index
+	}
+	return indices, nil
+}
+
+func (c *clientV6) GetFollowerIndicesStats(ctx context.Context) (FollowerIndicesStats, error) {
+	var response CrossClusterReplicationStatsResponse
+	if err := c.get(ctx, "/_ccr/stats", &response); err != nil {
+		return nil, err
+	}
+	stats := make(FollowerIndicesStats, len(response.FollowStats.Indices))
+	for _, index := range response.FollowStats.Indices {
+		stats[index.Index] = index.Shards
+	}
+	return stats, nil
+}
+
+func (c *clientV6) Follow(ctx context.Context, index string, request FollowRequest) error {
+	return c.put(ctx, fmt.Sprintf("/%s/_ccr/follow", url.PathEscape(index)), request, nil)
+}
+
+func (c *clientV6) PauseFollow(ctx context.Context, index string) error {
+	return c.post(ctx, fmt.Sprintf("/%s/_ccr/pause_follow", url.PathEscape(index)), nil, nil)
+}
+
+func (c *clientV6) Unfollow(ctx context.Context, index string) error {
+	return c.post(ctx, fmt.Sprintf("/%s/_ccr/unfollow", url.PathEscape(index)), nil, nil)
+}
+
+func (c *clientV6) CloseIndex(ctx context.Context, index string) error {
+	return c.post(ctx, fmt.Sprintf("/%s/_close", url.PathEscape(index)), nil, nil)
+}
+
+func (c *clientV6) OpenIndex(ctx context.Context, index string) error {
+	return c.post(ctx, fmt.Sprintf("/%s/_open", url.PathEscape(index)), nil, nil)
+}
+
+func (c *clientV6) IndexExists(ctx context.Context, index string) (bool, error) {
+	err := c.request(ctx, http.MethodHead, fmt.Sprintf("/%s", url.PathEscape(index)), nil, nil, nil)
+	if IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,158 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	. "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+)
+
+func TestClient_GetAutoFollowPatterns(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodGet, req.Method)
+		require.Equal(t, "/_ccr/auto_follow", req.URL.Path)
+		return fixtureResponse(t, req, "ccr_auto_follow_patterns.json")
+	})
+	got, err := testClient.GetAutoFollowPatterns(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, AutoFollowPatterns{
+		"eu-west-logs": {
+			RemoteCluster:       "eu-west",
+			LeaderIndexPatterns: []string{"logs-*"},
+			FollowIndexPattern:  "{{leader_index}}-copy",
+		},
+		"us-east-metrics": {
+			RemoteCluster:       "us-east",
+			LeaderIndexPatterns: []string{"metrics-*", "traces-*"},
+		},
+	}, got)
+}
+
+func TestClient_GetAutoFollowPatterns_NotFound(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("6.8.0"), func(req *http.Request) *http.Response {
+		return NewMockResponse(404, req, `{"error": {"type": "resource_not_found_exception"}}`)
+	})
+	got, err := testClient.GetAutoFollowPatterns(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestClient_UpsertAutoFollowPattern(t *testing.T) {
+	pattern := AutoFollowPattern{RemoteCluster: "eu-west", LeaderIndexPatterns: []string{"logs-*"}}
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodPut, req.Method)
+		require.Equal(t, "/_ccr/auto_follow/eu-west-logs", req.URL.Path)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		// the follow index pattern is omitted to use the name of the leader index
+		require.Equal(t, map[string]interface{}{
+			"remote_cluster":        "eu-west",
+			"leader_index_patterns": []interface{}{"logs-*"},
+		}, body)
+		return NewMockResponse(200, req, `{"acknowledged": true}`)
+	})
+	assert.NoError(t, testClient.UpsertAutoFollowPattern(context.Background(), "eu-west-logs", pattern))
+}
+
+func TestClient_GetFollowerIndices(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodGet, req.Method)
+		require.Equal(t, "/_all/_ccr/info", req.URL.Path)
+		return fixtureResponse(t, req, "ccr_follower_indices.json")
+	})
+	got, err := testClient.GetFollowerIndices(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, FollowerIndices{
+		"orders-copy": {
+			FollowerIndex: "orders-copy",
+			RemoteCluster: "eu-west",
+			LeaderIndex:   "orders",
+			Status:        FollowerIndexActive,
+		},
+		"customers-copy": {
+			FollowerIndex: "customers-copy",
+			RemoteCluster: "eu-west",
+			LeaderIndex:   "customers",
+			Status:        FollowerIndexPaused,
+		},
+	}, got)
+}
+
+func TestClient_GetFollowerIndicesStats(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodGet, req.Method)
+		require.Equal(t, "/_ccr/stats", req.URL.Path)
+		return fixtureResponse(t, req, "ccr_stats.json")
+	})
+	got, err := testClient.GetFollowerIndicesStats(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, FollowerIndicesStats{
+		"orders-copy": {
+			{ShardID: 0, LeaderGlobalCheckpoint: 1024, FollowerGlobalCheckpoint: 768},
+			{
+				ShardID:                  1,
+				LeaderGlobalCheckpoint:   512,
+				FollowerGlobalCheckpoint: 512,
+				FatalException:           &FollowFatalException{Type: "index_not_found_exception", Reason: "no such index [orders]"},
+			},
+		},
+	}, got)
+}
+
+func TestClient_Follow(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodPut, req.Method)
+		require.Equal(t, "/orders-copy/_ccr/follow", req.URL.Path)
+		var body FollowRequest
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		require.Equal(t, FollowRequest{RemoteCluster: "eu-west", LeaderIndex: "orders"}, body)
+		return NewMockResponse(200, req, `{"follow_index_created": true, "follow_index_shards_acked": false, "index_following_started": false}`)
+	})
+	assert.NoError(t, testClient.Follow(context.Background(), "orders-copy", FollowRequest{RemoteCluster: "eu-west", LeaderIndex: "orders"}))
+}
+
+func TestClient_PromoteFollowerIndex(t *testing.T) {
+	var requests []string
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodPost, req.Method)
+		requests = append(requests, req.URL.Path)
+		return NewMockResponse(200, req, `{"acknowledged": true}`)
+	})
+	ctx := context.Background()
+	require.NoError(t, testClient.PauseFollow(ctx, "orders-copy"))
+	require.NoError(t, testClient.CloseIndex(ctx, "orders-copy"))
+	require.NoError(t, testClient.Unfollow(ctx, "orders-copy"))
+	require.NoError(t, testClient.OpenIndex(ctx, "orders-copy"))
+	assert.Equal(t, []string{
+		"/orders-copy/_ccr/pause_follow",
+		"/orders-copy/_close",
+		"/orders-copy/_ccr/unfollow",
+		"/orders-copy/_open",
+	}, requests)
+}
+
+func TestClient_IndexExists(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodHead, req.Method)
+		if req.URL.Path == "/orders-copy" {
+			return NewMockResponse(200, req, "")
+		}
+		return NewMockResponse(404, req, "")
+	})
+	exists, err := testClient.IndexExists(context.Background(), "orders-copy")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = testClient.IndexExists(context.Background(), "missing")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
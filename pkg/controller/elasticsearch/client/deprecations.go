@@ -0,0 +1,69 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// DeprecationLevelCritical is the level of the deprecation issues which must be resolved before upgrading to the next
+// major version.
+const DeprecationLevelCritical = "critical"
+
+// Deprecation models a deprecation issue reported by the _migration/deprecations api.
+type Deprecation struct {
+	Level   string `json:"level"`
+	Message string `json:"message"`
+	URL     string `json:"url,omitempty"`
+	Details string `json:"details,omitempty"`
+}
+
+// Deprecations models the response from a request to /_migration/deprecations.
+type Deprecations struct {
+	ClusterSettings []Deprecation            `json:"cluster_settings"`
+	NodeSettings    []Deprecation            `json:"node_settings"`
+	MLSettings      []Deprecation            `json:"ml_settings"`
+	IndexSettings   map[string][]Deprecation `json:"index_settings"`
+	// DataStreams, Templates and ILMPolicies are reported from Elasticsearch 7.16.0.
+	DataStreams map[string][]Deprecation `json:"data_streams,omitempty"`
+	Templates   map[string][]Deprecation `json:"templates,omitempty"`
+	ILMPolicies map[string][]Deprecation `json:"ilm_policies,omitempty"`
+}
+
+// CriticalIssues returns a sorted description of the critical deprecation issues, each prefixed by the part of the
+// cluster it relates to.
+func (d Deprecations) CriticalIssues() []string {
+	var issues []string
+	addIssues := func(prefix string, deprecations []Deprecation) {
+		for _, deprecation := range deprecations {
+			if deprecation.Level == DeprecationLevelCritical {
+				issues = append(issues, fmt.Sprintf("%s: %s", prefix, deprecation.Message))
+			}
+		}
+	}
+	addIssues("cluster settings", d.ClusterSettings)
+	addIssues("node settings", d.NodeSettings)
+	addIssues("machine learning settings", d.MLSettings)
+	for prefix, byName := range map[string]map[string][]Deprecation{
+		"index":       d.IndexSettings,
+		"data stream": d.DataStreams,
+		"template":    d.Templates,
+		"ILM policy":  d.ILMPolicies,
+	} {
+		for name, deprecations := range byName {
+			addIssues(fmt.Sprintf("%s %s", prefix, name), deprecations)
+		}
+	}
+	sort.Strings(issues)
+	return issues
+}
+
+func (c *clientV6) GetDeprecations(ctx context.Context) (Deprecations, error) {
+	var deprecations Deprecations
+	err := c.get(ctx, "/_migration/deprecations", &deprecations)
+	return deprecations, err
+}
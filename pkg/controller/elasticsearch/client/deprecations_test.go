@@ -0,0 +1,31 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	. "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+)
+
+func TestClient_GetDeprecations(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.17.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodGet, req.Method)
+		require.Equal(t, "/_migration/deprecations", req.URL.Path)
+		return fixtureResponse(t, req, "deprecations.json")
+	})
+	got, err := testClient.GetDeprecations(context.Background())
+	require.NoError(t, err)
+	require.Len(t, got.ClusterSettings, 1)
+	require.Equal(t, []string{
+		"index logs-2018.01.01: Index created before 7.0",
+		"node settings: Setting [node.ml] is deprecated",
+	}, got.CriticalIssues())
+}
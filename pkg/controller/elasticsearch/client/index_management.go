@@ -0,0 +1,163 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// IndexManagementClient captures Elasticsearch API calls around index lifecycle management policies, component and
+// index templates, and ingest pipelines. Getters return either an error matching IsNotFound or a nil object if the
+// object does not exist.
+type IndexManagementClient interface {
+	// GetILMPolicy returns an index lifecycle management policy, along with its version and modification date.
+	GetILMPolicy(ctx context.Context, name string) (map[string]interface{}, error)
+	// UpsertILMPolicy creates or updates an index lifecycle management policy.
+	// The given policy is the content of the `policy` object of the request.
+	UpsertILMPolicy(ctx context.Context, name string, policy map[string]interface{}) error
+	// DeleteILMPolicy deletes an index lifecycle management policy.
+	DeleteILMPolicy(ctx context.Context, name string) error
+	// GetComponentTemplate returns a component template.
+	// Introduced in: Elasticsearch 7.8.0
+	GetComponentTemplate(ctx context.Context, name string) (map[string]interface{}, error)
+	// UpsertComponentTemplate creates or updates a component template.
+	// Introduced in: Elasticsearch 7.8.0
+	UpsertComponentTemplate(ctx context.Context, name string, template map[string]interface{}) error
+	// DeleteComponentTemplate deletes a component template.
+	// Introduced in: Elasticsearch 7.8.0
+	DeleteComponentTemplate(ctx context.Context, name string) error
+	// GetIndexTemplate returns a composable index template.
+	// Introduced in: Elasticsearch 7.8.0
+	GetIndexTemplate(ctx context.Context, name string) (map[string]interface{}, error)
+	// UpsertIndexTemplate creates or updates a composable index template.
+	// Introduced in: Elasticsearch 7.8.0
+	UpsertIndexTemplate(ctx context.Context, name string, template map[string]interface{}) error
+	// DeleteIndexTemplate deletes a composable index template.
+	// Introduced in: Elasticsearch 7.8.0
+	DeleteIndexTemplate(ctx context.Context, name string) error
+	// GetIngestPipeline returns an ingest pipeline.
+	GetIngestPipeline(ctx context.Context, name string) (map[string]interface{}, error)
+	// UpsertIngestPipeline creates or updates an ingest pipeline.
+	UpsertIngestPipeline(ctx context.Context, name string, pipeline map[string]interface{}) error
+	// DeleteIngestPipeline deletes an ingest pipeline.
+	DeleteIngestPipeline(ctx context.Context, name string) error
+}
+
+// componentTemplatesResponse models the response from a request to /_component_template/<name>.
+type componentTemplatesResponse struct {
+	ComponentTemplates []struct {
+		Name              string                 `json:"name"`
+		ComponentTemplate map[string]interface{} `json:"component_template"`
+	} `json:"component_templates"`
+}
+
+// indexTemplatesResponse models the response from a request to /_index_template/<name>.
+type indexTemplatesResponse struct {
+	IndexTemplates []struct {
+		Name          string                 `json:"name"`
+		IndexTemplate map[string]interface{} `json:"index_template"`
+	} `json:"index_templates"`
+}
+
+func (c *clientV6) GetILMPolicy(ctx context.Context, name string) (map[string]interface{}, error) {
+	var policies map[string]map[string]interface{}
+	if err := c.get(ctx, fmt.Sprintf("/_ilm/policy/%s", url.PathEscape(name)), &policies); err != nil {
+		return nil, err
+	}
+	return policies[name], nil
+}
+
+func (c *clientV6) UpsertILMPolicy(ctx context.Context, name string, policy map[string]interface{}) error {
+	body := map[string]interface{}{"policy": policy}
+	return c.put(ctx, fmt.Sprintf("/_ilm/policy/%s", url.PathEscape(name)), body, nil)
+}
+
+func (c *clientV6) DeleteILMPolicy(ctx context.Context, name string) error {
+	return c.delete(ctx, fmt.Sprintf("/_ilm/policy/%s", url.PathEscape(name)), nil, nil)
+}
+
+func (c *clientV6) GetComponentTemplate(_ context.Context, _ string) (map[string]interface{}, error) {
+	return nil, errNotSupportedInEs6x
+}
+
+func (c *clientV6) UpsertComponentTemplate(_ context.Context, _ string, _ map[string]interface{}) error {
+	return errNotSupportedInEs6x
+}
+
+func (c *clientV6) DeleteComponentTemplate(_ context.Context, _ string) error {
+	return errNotSupportedInEs6x
+}
+
+func (c *clientV6) GetIndexTemplate(_ context.Context, _ string) (map[string]interface{}, error) {
+	return nil, errNotSupportedInEs6x
+}
+
+func (c *clientV6) UpsertIndexTemplate(_ context.Context, _ string, _ map[string]interface{}) error {
+	return errNotSupportedInEs6x
+}
+
+func (c *clientV6) DeleteIndexTemplate(_ context.Context, _ string) error {
+	return errNotSupportedInEs6x
+}
+
+func (c *clientV6) GetIngestPipeline(ctx context.Context, name string) (map[string]interface{}, error) {
+	var pipelines map[string]map[string]interface{}
+	if err := c.get(ctx, fmt.Sprintf("/_ingest/pipeline/%s", url.PathEscape(name)), &pipelines); err != nil {
+		return nil, err
+	}
+	return pipelines[name], nil
+}
+
+func (c *clientV6) UpsertIngestPipeline(ctx context.Context, name string, pipeline map[string]interface{}) error {
+	return c.put(ctx, fmt.Sprintf("/_ingest/pipeline/%s", url.PathEscape(name)), pipeline, nil)
+}
+
+func (c *clientV6) DeleteIngestPipeline(ctx context.Context, name string) error {
+	return c.delete(ctx, fmt.Sprintf("/_ingest/pipeline/%s", url.PathEscape(name)), nil, nil)
+}
+
+func (c *clientV7) GetComponentTemplate(ctx context.Context, name string) (map[string]interface{}, error) {
+	var response componentTemplatesResponse
+	if err := c.get(ctx, fmt.Sprintf("/_component_template/%s", url.PathEscape(name)), &response); err != nil {
+		return nil, err
+	}
+	for _, template := range response.ComponentTemplates {
+		if template.Name == name {
+			return template.ComponentTemplate, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *clientV7) UpsertComponentTemplate(ctx context.Context, name string, template map[string]interface{}) error {
+	return c.put(ctx, fmt.Sprintf("/_component_template/%s", url.PathEscape(name)), template, nil)
+}
+
+func (c *clientV7) DeleteComponentTemplate(ctx context.Context, name string) error {
+	return c.delete(ctx, fmt.Sprintf("/_component_template/%s", url.PathEscape(name)), nil, nil)
+}
+
+func (c *clientV7) GetIndexTemplate(ctx context.Context, name string) (map[string]interface{}, error) {
+	var response indexTemplatesResponse
+	if err := c.get(ctx, fmt.Sprintf("/_index_template/%s", url.PathEscape(name)), &response); err != nil {
+		return nil, err
+	}
+	for _, template := range response.IndexTemplates {
+		if template.Name == name {
+			return template.IndexTemplate, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *clientV7) UpsertIndexTemplate(ctx context.Context, name string, template map[string]interface{}) error {
+	return c.put(ctx, fmt.Sprintf("/_index_template/%s", url.PathEscape(name)), template, nil)
+}
+
+func (c *clientV7) DeleteIndexTemplate(ctx context.Context, name string) error {
+	return c.delete(ctx, fmt.Sprintf("/_index_template/%s", url.PathEscape(name)), nil, nil)
+}
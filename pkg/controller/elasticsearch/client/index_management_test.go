@@ -0,0 +1,120 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	. "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetILMPolicy(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodGet, req.Method)
+		require.Equal(t, "/_ilm/policy/my-policy", req.URL.Path)
+		return NewMockResponse(200, req, `{"my-policy":{"version":2,"modified_date":"2021-10-18T10:00:00.000Z",
+			"policy":{"phases":{"delete":{"min_age":"30d","actions":{"delete":{}}}}}}}`)
+	})
+	got, err := testClient.GetILMPolicy(context.Background(), "my-policy")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"version":       float64(2),
+		"modified_date": "2021-10-18T10:00:00.000Z",
+		"policy": map[string]interface{}{
+			"phases": map[string]interface{}{
+				"delete": map[string]interface{}{"min_age": "30d", "actions": map[string]interface{}{"delete": map[string]interface{}{}}},
+			},
+		},
+	}, got)
+}
+
+func TestClient_GetILMPolicy_NotFound(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		return NewMockResponse(404, req, `{"error":{"type":"resource_not_found_exception"},"status":404}`)
+	})
+	_, err := testClient.GetILMPolicy(context.Background(), "my-policy")
+	require.True(t, IsNotFound(err))
+}
+
+func TestClient_UpsertILMPolicy(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("6.8.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodPut, req.Method)
+		require.Equal(t, "/_ilm/policy/my-policy", req.URL.Path)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		require.Equal(t, map[string]interface{}{"policy": map[string]interface{}{"phases": map[string]interface{}{}}}, body)
+		return NewMockResponse(200, req, `{"acknowledged": true}`)
+	})
+	assert.NoError(t, testClient.UpsertILMPolicy(context.Background(), "my-policy", map[string]interface{}{"phases": map[string]interface{}{}}))
+}
+
+func TestClient_GetComponentTemplate(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodGet, req.Method)
+		require.Equal(t, "/_component_template/my-template", req.URL.Path)
+		return NewMockResponse(200, req, `{"component_templates":[{"name":"my-template",
+			"component_template":{"template":{"settings":{"index":{"number_of_shards":"2"}}}}}]}`)
+	})
+	got, err := testClient.GetComponentTemplate(context.Background(), "my-template")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"template": map[string]interface{}{
+			"settings": map[string]interface{}{"index": map[string]interface{}{"number_of_shards": "2"}},
+		},
+	}, got)
+}
+
+func TestClient_ComposableTemplatesNotSupportedInEs6x(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("6.8.0"), func(req *http.Request) *http.Response {
+		t.Fatalf("unexpected request to %s", req.URL.Path)
+		return nil
+	})
+	_, err := testClient.GetComponentTemplate(context.Background(), "my-template")
+	require.Error(t, err)
+	require.Error(t, testClient.UpsertIndexTemplate(context.Background(), "my-template", nil))
+	require.Error(t, testClient.DeleteIndexTemplate(context.Background(), "my-template"))
+}
+
+func TestClient_GetIndexTemplate(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodGet, req.Method)
+		require.Equal(t, "/_index_template/logs", req.URL.Path)
+		return NewMockResponse(200, req, `{"index_templates":[{"name":"logs",
+			"index_template":{"index_patterns":["logs-*"],"composed_of":["my-template"]}}]}`)
+	})
+	got, err := testClient.GetIndexTemplate(context.Background(), "logs")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"index_patterns": []interface{}{"logs-*"},
+		"composed_of":    []interface{}{"my-template"},
+	}, got)
+}
+
+func TestClient_UpsertIngestPipeline(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodPut, req.Method)
+		require.Equal(t, "/_ingest/pipeline/my-pipeline", req.URL.Path)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		require.Equal(t, map[string]interface{}{"processors": []interface{}{}}, body)
+		return NewMockResponse(200, req, `{"acknowledged": true}`)
+	})
+	assert.NoError(t, testClient.UpsertIngestPipeline(context.Background(), "my-pipeline", map[string]interface{}{"processors": []interface{}{}}))
+}
+
+func TestClient_DeleteIngestPipeline(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodDelete, req.Method)
+		require.Equal(t, "/_ingest/pipeline/my-pipeline", req.URL.Path)
+		return NewMockResponse(200, req, `{"acknowledged": true}`)
+	})
+	assert.NoError(t, testClient.DeleteIngestPipeline(context.Background(), "my-pipeline"))
+}
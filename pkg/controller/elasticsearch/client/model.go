@@ -376,6 +376,48 @@ type RemoteClusters struct {
 // RemoteClusterSeeds is the set of seeds to use in a remote cluster setting.
 type RemoteCluster struct {
 	Seeds []string `json:"seeds"`
+	// RemoteClusterOptions is not serialized if nil.
+	*RemoteClusterOptions
+	// RemoteClusterConnectionMode is not serialized if nil, for versions of Elasticsearch which do not support it.
+	*RemoteClusterConnectionMode
+}
+
+// RemoteClusterOptions holds the connection options of a remote cluster. They are strings, as returned by the
+// Elasticsearch settings API. Nil settings are reset.
+type RemoteClusterOptions struct {
+	SkipUnavailable *string                       `json:"skip_unavailable"`
+	Transport       RemoteClusterTransportOptions `json:"transport"`
+}
+
+// RemoteClusterTransportOptions holds the transport options of a remote cluster. Nil settings are reset.
+type RemoteClusterTransportOptions struct {
+	Compress     *string `json:"compress"`
+	PingSchedule *string `json:"ping_schedule"`
+}
+
+// RemoteClusterConnectionMode holds the settings of the connection mode of a remote cluster, available from
+// Elasticsearch 7.7.0. Nil settings are reset.
+type RemoteClusterConnectionMode struct {
+	Mode                   *string `json:"mode"`
+	ProxyAddress           *string `json:"proxy_address"`
+	ServerName             *string `json:"server_name"`
+	NodeConnections        *string `json:"node_connections"`
+	ProxySocketConnections *string `json:"proxy_socket_connections"`
+}
+
+// RemoteClustersInfo is the connection information of the remote clusters, by name, as returned by the remote cluster
+// info API.
+type RemoteClustersInfo map[string]RemoteClusterInfo
+
+// RemoteClusterInfo is the connection information of a remote cluster.
+type RemoteClusterInfo struct {
+	Connected bool   `json:"connected"`
+	Mode      string `json:"mode"`
+	// NumNodesConnected is the number of connected nodes in sniff mode.
+	NumNodesConnected int32 `json:"num_nodes_connected"`
+	// NumProxySocketsConnected is the number of open socket connections in proxy mode.
+	NumProxySocketsConnected int32 `json:"num_proxy_sockets_connected"`
+	SkipUnavailable          bool  `json:"skip_unavailable"`
 }
 
 // Hit represents a single search hit.
@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/utils/pointer"
 )
 
 func TestModel_RemoteCluster(t *testing.T) {
@@ -48,6 +49,59 @@ func TestModel_RemoteCluster(t *testing.T) {
 			},
 			want: `{"persistent":{"cluster":{"remote":{"leader":{"seeds":null}}}}}`,
 		},
+		{
+			name: "Remote cluster in proxy mode",
+			arg: RemoteClustersSettings{
+				PersistentSettings: &SettingsGroup{
+					Cluster: RemoteClusters{
+						RemoteClusters: map[string]RemoteCluster{
+							"leader": {
+								RemoteClusterConnectionMode: &RemoteClusterConnectionMode{
+									Mode:         pointer.StringPtr("proxy"),
+									ProxyAddress: pointer.StringPtr("leader.example.com:9300"),
+								},
+							},
+						},
+					},
+				},
+			},
+			want: `{"persistent":{"cluster":{"remote":{"leader":{"seeds":null,"mode":"proxy","proxy_address":"leader.example.com:9300","server_name":null,"node_connections":null,"proxy_socket_connections":null}}}}}`,
+		},
+		{
+			name: "Remote cluster with options",
+			arg: RemoteClustersSettings{
+				PersistentSettings: &SettingsGroup{
+					Cluster: RemoteClusters{
+						RemoteClusters: map[string]RemoteCluster{
+							"leader": {
+								Seeds: []string{"127.0.0.1:9300"},
+								RemoteClusterOptions: &RemoteClusterOptions{
+									SkipUnavailable: pointer.StringPtr("true"),
+									Transport:       RemoteClusterTransportOptions{PingSchedule: pointer.StringPtr("30s")},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: `{"persistent":{"cluster":{"remote":{"leader":{"seeds":["127.0.0.1:9300"],"skip_unavailable":"true","transport":{"compress":null,"ping_schedule":"30s"}}}}}}`,
+		},
+		{
+			name: "Deleted remote cluster with connection mode",
+			arg: RemoteClustersSettings{
+				PersistentSettings: &SettingsGroup{
+					Cluster: RemoteClusters{
+						RemoteClusters: map[string]RemoteCluster{
+							"leader": {
+								RemoteClusterOptions:        &RemoteClusterOptions{},
+								RemoteClusterConnectionMode: &RemoteClusterConnectionMode{},
+							},
+						},
+					},
+				},
+			},
+			want: `{"persistent":{"cluster":{"remote":{"leader":{"seeds":null,"skip_unavailable":null,"transport":{"compress":null,"ping_schedule":null},"mode":null,"proxy_address":null,"server_name":null,"node_connections":null,"proxy_socket_connections":null}}}}}`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
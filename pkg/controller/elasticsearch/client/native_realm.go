@@ -0,0 +1,99 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// NativeRealmClient captures Elasticsearch API calls around the users and the roles of the native realm. GetUser and
+// GetRole return either an error matching IsNotFound or a nil result if the user or the role does not exist.
+type NativeRealmClient interface {
+	// GetUser returns a user of the native realm. The password of the user is never returned.
+	GetUser(ctx context.Context, name string) (*NativeUser, error)
+	// UpsertUser creates or updates a user of the native realm. The password is required to create the user, and left
+	// unchanged if empty when the user is updated.
+	UpsertUser(ctx context.Context, name string, user NativeUser) error
+	// ChangePassword changes the password of a user of the native realm.
+	ChangePassword(ctx context.Context, name string, password string) error
+	// DeleteUser deletes a user of the native realm.
+	DeleteUser(ctx context.Context, name string) error
+	// Authenticate returns true if the given credentials are valid, false if they are rejected by Elasticsearch.
+	Authenticate(ctx context.Context, credentials BasicAuth) (bool, error)
+	// GetRole returns a role of the native realm.
+	GetRole(ctx context.Context, name string) (map[string]interface{}, error)
+	// UpsertRole creates or updates a role of the native realm.
+	UpsertRole(ctx context.Context, name string, role map[string]interface{}) error
+	// DeleteRole deletes a role of the native realm.
+	DeleteRole(ctx context.Context, name string) error
+}
+
+// NativeUser models a user of the native realm.
+type NativeUser struct {
+	Password string                 `json:"password,omitempty"`
+	Roles    []string               `json:"roles"`
+	FullName string                 `json:"full_name,omitempty"`
+	Email    string                 `json:"email,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Enabled  bool                   `json:"enabled"`
+}
+
+// ChangePasswordRequest is the request to change the password of a user.
+type ChangePasswordRequest struct {
+	Password string `json:"password"`
+}
+
+func (c *clientV6) GetUser(ctx context.Context, name string) (*NativeUser, error) {
+	var users map[string]NativeUser
+	if err := c.get(ctx, fmt.Sprintf("/_security/user/%s", url.PathEscape(name)), &users); err != nil {
+		return nil, err
+	}
+	user, exists := users[name]
+	if !exists {
+		return nil, nil
+	}
+	return &user, nil
+}
+
+func (c *clientV6) UpsertUser(ctx context.Context, name string, user NativeUser) error {
+	return c.put(ctx, fmt.Sprintf("/_security/user/%s", url.PathEscape(name)), user, nil)
+}
+
+func (c *clientV6) ChangePassword(ctx context.Context, name string, password string) error {
+	return c.post(ctx, fmt.Sprintf("/_security/user/%s/_password", url.PathEscape(name)), ChangePasswordRequest{Password: password}, nil)
+}
+
+func (c *clientV6) DeleteUser(ctx context.Context, name string) error {
+	return c.delete(ctx, fmt.Sprintf("/_security/user/%s", url.PathEscape(name)), nil, nil)
+}
+
+func (c *clientV6) Authenticate(ctx context.Context, credentials BasicAuth) (bool, error) {
+	// the request is sent with the given credentials instead of the ones of the client
+	authenticating := c.baseClient
+	authenticating.User = credentials
+	err := authenticating.get(ctx, "/_security/_authenticate", nil)
+	if IsUnauthorized(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (c *clientV6) GetRole(ctx context.Context, name string) (map[string]interface{}, error) {
+	var roles map[string]map[string]interface{}
+	if err := c.get(ctx, fmt.Sprintf("/_security/role/%s", url.PathEscape(name)), &roles); err != nil {
+		return nil, err
+	}
+	return roles[name], nil
+}
+
+func (c *clientV6) UpsertRole(ctx context.Context, name string, role map[string]interface{}) error {
+	return c.put(ctx, fmt.Sprintf("/_security/role/%s", url.PathEscape(name)), role, nil)
+}
+
+func (c *clientV6) DeleteRole(ctx context.Context, name string) error {
+	return c.delete(ctx, fmt.Sprintf("/_security/role/%s", url.PathEscape(name)), nil, nil)
+}
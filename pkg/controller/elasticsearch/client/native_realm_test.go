@@ -0,0 +1,143 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	. "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetUser(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodGet, req.Method)
+		require.Equal(t, "/_security/user/jacknich", req.URL.Path)
+		return NewMockResponse(200, req, `{"jacknich":{"username":"jacknich","roles":["admin"],
+			"full_name":"Jack Nicholson","email":"jacknich@example.com","metadata":{"intelligence":7},"enabled":true}}`)
+	})
+	got, err := testClient.GetUser(context.Background(), "jacknich")
+	require.NoError(t, err)
+	assert.Equal(t, &NativeUser{
+		Roles:    []string{"admin"},
+		FullName: "Jack Nicholson",
+		Email:    "jacknich@example.com",
+		Metadata: map[string]interface{}{"intelligence": float64(7)},
+		Enabled:  true,
+	}, got)
+}
+
+func TestClient_GetUser_NotFound(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		return NewMockResponse(404, req, `{}`)
+	})
+	_, err := testClient.GetUser(context.Background(), "jacknich")
+	require.True(t, IsNotFound(err))
+}
+
+func TestClient_UpsertUser(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodPut, req.Method)
+		require.Equal(t, "/_security/user/jacknich", req.URL.Path)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		require.Equal(t, map[string]interface{}{
+			"password": "l0ng-r4nd0m-p@ssw0rd",
+			"roles":    []interface{}{"admin"},
+			"enabled":  true,
+		}, body)
+		return NewMockResponse(200, req, `{"created":true}`)
+	})
+	assert.NoError(t, testClient.UpsertUser(context.Background(), "jacknich",
+		NativeUser{Password: "l0ng-r4nd0m-p@ssw0rd", Roles: []string{"admin"}, Enabled: true}))
+}
+
+func TestClient_ChangePassword(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodPost, req.Method)
+		require.Equal(t, "/_security/user/jacknich/_password", req.URL.Path)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		require.Equal(t, map[string]interface{}{"password": "s3cr3t"}, body)
+		return NewMockResponse(200, req, `{}`)
+	})
+	assert.NoError(t, testClient.ChangePassword(context.Background(), "jacknich", "s3cr3t"))
+}
+
+func TestClient_DeleteUser(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodDelete, req.Method)
+		require.Equal(t, "/_security/user/jacknich", req.URL.Path)
+		return NewMockResponse(200, req, `{"found":true}`)
+	})
+	assert.NoError(t, testClient.DeleteUser(context.Background(), "jacknich"))
+}
+
+func TestClient_Authenticate(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		want       bool
+		wantErr    bool
+	}{
+		{name: "valid credentials", statusCode: 200, want: true},
+		{name: "invalid credentials", statusCode: 401, want: false},
+		{name: "unexpected error", statusCode: 500, want: false, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+				require.Equal(t, http.MethodGet, req.Method)
+				require.Equal(t, "/_security/_authenticate", req.URL.Path)
+				username, password, ok := req.BasicAuth()
+				require.True(t, ok)
+				require.Equal(t, "jacknich", username)
+				require.Equal(t, "s3cr3t", password)
+				return NewMockResponse(tt.statusCode, req, `{}`)
+			})
+			got, err := testClient.Authenticate(context.Background(), BasicAuth{Name: "jacknich", Password: "s3cr3t"})
+			require.Equal(t, tt.wantErr, err != nil)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClient_GetRole(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodGet, req.Method)
+		require.Equal(t, "/_security/role/monitoring", req.URL.Path)
+		return NewMockResponse(200, req, `{"monitoring":{"cluster":["monitor"]}}`)
+	})
+	got, err := testClient.GetRole(context.Background(), "monitoring")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"cluster": []interface{}{"monitor"}}, got)
+}
+
+func TestClient_UpsertRole(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodPut, req.Method)
+		require.Equal(t, "/_security/role/monitoring", req.URL.Path)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		require.Equal(t, map[string]interface{}{"cluster": []interface{}{"monitor"}}, body)
+		return NewMockResponse(200, req, `{"role":{"created":true}}`)
+	})
+	assert.NoError(t, testClient.UpsertRole(context.Background(), "monitoring",
+		map[string]interface{}{"cluster": []interface{}{"monitor"}}))
+}
+
+func TestClient_DeleteRole(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodDelete, req.Method)
+		require.Equal(t, "/_security/role/monitoring", req.URL.Path)
+		return NewMockResponse(200, req, `{"found":true}`)
+	})
+	assert.NoError(t, testClient.DeleteRole(context.Background(), "monitoring"))
+}
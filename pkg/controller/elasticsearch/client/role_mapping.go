@@ -0,0 +1,38 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// RoleMappingClient captures Elasticsearch API calls around role mappings. GetRoleMapping returns either an error
+// matching IsNotFound or a nil role mapping if the role mapping does not exist.
+type RoleMappingClient interface {
+	// GetRoleMapping returns a role mapping.
+	GetRoleMapping(ctx context.Context, name string) (map[string]interface{}, error)
+	// UpsertRoleMapping creates or updates a role mapping.
+	UpsertRoleMapping(ctx context.Context, name string, roleMapping map[string]interface{}) error
+	// DeleteRoleMapping deletes a role mapping.
+	DeleteRoleMapping(ctx context.Context, name string) error
+}
+
+func (c *clientV6) GetRoleMapping(ctx context.Context, name string) (map[string]interface{}, error) {
+	var roleMappings map[string]map[string]interface{}
+	if err := c.get(ctx, fmt.Sprintf("/_security/role_mapping/%s", url.PathEscape(name)), &roleMappings); err != nil {
+		return nil, err
+	}
+	return roleMappings[name], nil
+}
+
+func (c *clientV6) UpsertRoleMapping(ctx context.Context, name string, roleMapping map[string]interface{}) error {
+	return c.put(ctx, fmt.Sprintf("/_security/role_mapping/%s", url.PathEscape(name)), roleMapping, nil)
+}
+
+func (c *clientV6) DeleteRoleMapping(ctx context.Context, name string) error {
+	return c.delete(ctx, fmt.Sprintf("/_security/role_mapping/%s", url.PathEscape(name)), nil, nil)
+}
@@ -0,0 +1,64 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	. "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetRoleMapping(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodGet, req.Method)
+		require.Equal(t, "/_security/role_mapping/admins", req.URL.Path)
+		return NewMockResponse(200, req, `{"admins":{"enabled":true,"roles":["superuser"],
+			"rules":{"field":{"groups":"admins"}},"metadata":{}}}`)
+	})
+	got, err := testClient.GetRoleMapping(context.Background(), "admins")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"enabled":  true,
+		"roles":    []interface{}{"superuser"},
+		"rules":    map[string]interface{}{"field": map[string]interface{}{"groups": "admins"}},
+		"metadata": map[string]interface{}{},
+	}, got)
+}
+
+func TestClient_GetRoleMapping_NotFound(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		return NewMockResponse(404, req, `{}`)
+	})
+	_, err := testClient.GetRoleMapping(context.Background(), "admins")
+	require.True(t, IsNotFound(err))
+}
+
+func TestClient_UpsertRoleMapping(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("6.8.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodPut, req.Method)
+		require.Equal(t, "/_security/role_mapping/admins", req.URL.Path)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		require.Equal(t, map[string]interface{}{"enabled": true, "roles": []interface{}{"superuser"}}, body)
+		return NewMockResponse(200, req, `{"role_mapping":{"created":true}}`)
+	})
+	assert.NoError(t, testClient.UpsertRoleMapping(context.Background(), "admins",
+		map[string]interface{}{"enabled": true, "roles": []interface{}{"superuser"}}))
+}
+
+func TestClient_DeleteRoleMapping(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodDelete, req.Method)
+		require.Equal(t, "/_security/role_mapping/admins", req.URL.Path)
+		return NewMockResponse(200, req, `{"found":true}`)
+	})
+	assert.NoError(t, testClient.DeleteRoleMapping(context.Background(), "admins"))
+}
@@ -0,0 +1,109 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ShutdownType is the type of a node shutdown.
+type ShutdownType string
+
+const (
+	// Restart indicates a node is shut down temporarily, and is expected to rejoin the cluster with its data.
+	Restart ShutdownType = "restart"
+	// Remove indicates a node is removed permanently from the cluster, its data must be migrated to other nodes.
+	Remove ShutdownType = "remove"
+)
+
+// ShutdownStatus is the status of a node shutdown.
+type ShutdownStatus string
+
+const (
+	// ShutdownComplete indicates the node is ready to be shut down.
+	ShutdownComplete ShutdownStatus = "COMPLETE"
+	// ShutdownInProgress indicates the node is being prepared for the shutdown, for example its shards are migrating.
+	ShutdownInProgress ShutdownStatus = "IN_PROGRESS"
+	// ShutdownStalled indicates the preparation of the node for the shutdown cannot progress, for example because some
+	// shards cannot be allocated to any other node.
+	ShutdownStalled ShutdownStatus = "STALLED"
+	// ShutdownNotStarted indicates the preparation of the node for the shutdown has not started yet.
+	ShutdownNotStarted ShutdownStatus = "NOT_STARTED"
+)
+
+// ShutdownClient captures Elasticsearch API calls around the node shutdown API.
+// Introduced in: Elasticsearch 7.15.0
+type ShutdownClient interface {
+	// GetShutdown returns the shutdown records of all the nodes of the cluster.
+	GetShutdown(ctx context.Context) (ShutdownResponse, error)
+	// PutShutdown registers a shutdown of the given type for the node with the given id, so that Elasticsearch prepares
+	// the node to be shut down.
+	PutShutdown(ctx context.Context, nodeID string, shutdownType ShutdownType, reason string) error
+	// DeleteShutdown deletes the shutdown record of the node with the given id, so that the node is used normally again.
+	DeleteShutdown(ctx context.Context, nodeID string) error
+}
+
+// ShutdownRequest models the body of a request to /_nodes/<node_id>/shutdown.
+type ShutdownRequest struct {
+	Type   ShutdownType `json:"type"`
+	Reason string       `json:"reason"`
+}
+
+// ShutdownResponse models the response from a request to /_nodes/shutdown.
+type ShutdownResponse struct {
+	Nodes []NodeShutdown `json:"nodes"`
+}
+
+// NodeShutdown partially models the shutdown record of a node.
+type NodeShutdown struct {
+	NodeID                string         `json:"node_id"`
+	Type                  string         `json:"type"`
+	Reason                string         `json:"reason"`
+	ShutdownStartedMillis int64          `json:"shutdown_startedmillis"`
+	Status                ShutdownStatus `json:"status"`
+	ShardMigration        ShardMigration `json:"shard_migration"`
+}
+
+// ShardMigration models the progress of the migration of the shards of a node being shut down.
+type ShardMigration struct {
+	Status                   ShutdownStatus `json:"status"`
+	ShardMigrationsRemaining int            `json:"shard_migrations_remaining"`
+	Explanation              string         `json:"explanation,omitempty"`
+}
+
+// Is returns true if the shutdown record is of the given type. Elasticsearch reports the type in upper case.
+func (ns NodeShutdown) Is(shutdownType ShutdownType) bool {
+	return ShutdownType(strings.ToLower(ns.Type)) == shutdownType
+}
+
+func (c *clientV6) GetShutdown(_ context.Context) (ShutdownResponse, error) {
+	return ShutdownResponse{}, errNotSupportedInEs6x
+}
+
+func (c *clientV6) PutShutdown(_ context.Context, _ string, _ ShutdownType, _ string) error {
+	return errNotSupportedInEs6x
+}
+
+func (c *clientV6) DeleteShutdown(_ context.Context, _ string) error {
+	return errNotSupportedInEs6x
+}
+
+func (c *clientV7) GetShutdown(ctx context.Context) (ShutdownResponse, error) {
+	var response ShutdownResponse
+	err := c.get(ctx, "/_nodes/shutdown", &response)
+	return response, err
+}
+
+func (c *clientV7) PutShutdown(ctx context.Context, nodeID string, shutdownType ShutdownType, reason string) error {
+	path := fmt.Sprintf("/_nodes/%s/shutdown", url.PathEscape(nodeID))
+	return c.put(ctx, path, ShutdownRequest{Type: shutdownType, Reason: reason}, nil)
+}
+
+func (c *clientV7) DeleteShutdown(ctx context.Context, nodeID string) error {
+	return c.delete(ctx, fmt.Sprintf("/_nodes/%s/shutdown", url.PathEscape(nodeID)), nil, nil)
+}
@@ -0,0 +1,88 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	. "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+)
+
+func TestClient_GetShutdown(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.2"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodGet, req.Method)
+		require.Equal(t, "/_nodes/shutdown", req.URL.Path)
+		return fixtureResponse(t, req, "shutdown.json")
+	})
+	got, err := testClient.GetShutdown(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, ShutdownResponse{Nodes: []NodeShutdown{
+		{
+			NodeID:                "5tbXYfFcSqq2Bv7PkRbj1w",
+			Type:                  "RESTART",
+			Reason:                "eck-rolling-upgrade",
+			ShutdownStartedMillis: 1634045342316,
+			Status:                ShutdownComplete,
+			ShardMigration: ShardMigration{
+				Status:      ShutdownComplete,
+				Explanation: "no shard relocation is necessary for a node restart",
+			},
+		},
+		{
+			NodeID:                "Hb0fGRoaTh2Bxw1MvKXdyw",
+			Type:                  "REMOVE",
+			Reason:                "eck-downscale",
+			ShutdownStartedMillis: 1634045402458,
+			Status:                ShutdownStalled,
+			ShardMigration: ShardMigration{
+				Status:                   ShutdownStalled,
+				ShardMigrationsRemaining: 2,
+				Explanation:              "shard [0] [primary] of index [my-index] cannot move",
+			},
+		},
+	}}, got)
+	assert.True(t, got.Nodes[0].Is(Restart))
+	assert.False(t, got.Nodes[0].Is(Remove))
+	assert.True(t, got.Nodes[1].Is(Remove))
+}
+
+func TestClient_PutShutdown(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.2"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodPut, req.Method)
+		require.Equal(t, "/_nodes/5tbXYfFcSqq2Bv7PkRbj1w/shutdown", req.URL.Path)
+		var body ShutdownRequest
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		require.Equal(t, ShutdownRequest{Type: Restart, Reason: "eck-rolling-upgrade"}, body)
+		return NewMockResponse(200, req, `{"acknowledged": true}`)
+	})
+	assert.NoError(t, testClient.PutShutdown(context.Background(), "5tbXYfFcSqq2Bv7PkRbj1w", Restart, "eck-rolling-upgrade"))
+}
+
+func TestClient_DeleteShutdown(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.2"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodDelete, req.Method)
+		require.Equal(t, "/_nodes/5tbXYfFcSqq2Bv7PkRbj1w/shutdown", req.URL.Path)
+		return NewMockResponse(200, req, `{"acknowledged": true}`)
+	})
+	assert.NoError(t, testClient.DeleteShutdown(context.Background(), "5tbXYfFcSqq2Bv7PkRbj1w"))
+}
+
+func TestClient_ShutdownNotSupportedInEs6x(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("6.8.0"), func(req *http.Request) *http.Response {
+		t.Fatalf("unexpected request %s %s", req.Method, req.URL.Path)
+		return nil
+	})
+	_, err := testClient.GetShutdown(context.Background())
+	assert.Error(t, err)
+	assert.Error(t, testClient.PutShutdown(context.Background(), "node", Remove, "eck-downscale"))
+	assert.Error(t, testClient.DeleteShutdown(context.Background(), "node"))
+}
@@ -0,0 +1,149 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// SnapshotClient captures Elasticsearch API calls around snapshot repositories and snapshot lifecycle management.
+type SnapshotClient interface {
+	// GetSnapshotRepositories returns all the snapshot repositories registered in the cluster.
+	GetSnapshotRepositories(ctx context.Context) (SnapshotRepositories, error)
+	// UpsertSnapshotRepository creates or updates a snapshot repository.
+	UpsertSnapshotRepository(ctx context.Context, name string, repository SnapshotRepository) error
+	// DeleteSnapshotRepository unregisters a snapshot repository.
+	DeleteSnapshotRepository(ctx context.Context, name string) error
+	// CreateSnapshot starts taking a snapshot of all the indices and of the cluster state in the given repository,
+	// without waiting for its completion.
+	CreateSnapshot(ctx context.Context, repository string, name string) error
+	// GetSnapshot returns a snapshot stored in the given repository, or an error matching IsNotFound if the snapshot
+	// does not exist.
+	GetSnapshot(ctx context.Context, repository string, name string) (Snapshot, error)
+	// GetSnapshotLifecyclePolicies returns all the snapshot lifecycle management policies of the cluster.
+	// Introduced in: Elasticsearch 7.4.0
+	GetSnapshotLifecyclePolicies(ctx context.Context) (SnapshotLifecyclePolicies, error)
+	// UpsertSnapshotLifecyclePolicy creates or updates a snapshot lifecycle management policy.
+	// Introduced in: Elasticsearch 7.4.0
+	UpsertSnapshotLifecyclePolicy(ctx context.Context, name string, policy SnapshotLifecyclePolicy) error
+	// DeleteSnapshotLifecyclePolicy deletes a snapshot lifecycle management policy.
+	// Introduced in: Elasticsearch 7.4.0
+	DeleteSnapshotLifecyclePolicy(ctx context.Context, name string) error
+}
+
+// SnapshotRepositories models the response from a request to /_snapshot, mapping repository names to their definition.
+type SnapshotRepositories map[string]SnapshotRepository
+
+// SnapshotRepository models a snapshot repository definition.
+type SnapshotRepository struct {
+	Type     string                 `json:"type"`
+	Settings map[string]interface{} `json:"settings,omitempty"`
+}
+
+// Snapshot states, as returned by Elasticsearch.
+const (
+	SnapshotInProgress = "IN_PROGRESS"
+	SnapshotSuccess    = "SUCCESS"
+)
+
+// Snapshot partially models a snapshot as returned by Elasticsearch.
+type Snapshot struct {
+	Snapshot string `json:"snapshot"`
+	State    string `json:"state"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// SnapshotsResponse models the response from a request to /_snapshot/<repository>/<snapshot>.
+type SnapshotsResponse struct {
+	Snapshots []Snapshot `json:"snapshots"`
+}
+
+// SnapshotLifecyclePolicies models the response from a request to /_slm/policy, mapping policy names to their definition.
+type SnapshotLifecyclePolicies map[string]SnapshotLifecyclePolicyResponse
+
+// SnapshotLifecyclePolicyResponse partially models an element of the response from a request to /_slm/policy.
+type SnapshotLifecyclePolicyResponse struct {
+	Version int64                   `json:"version"`
+	Policy  SnapshotLifecyclePolicy `json:"policy"`
+}
+
+// SnapshotLifecyclePolicy models a snapshot lifecycle management policy.
+// Name is the name assigned to the snapshots created by the policy, not the name of the policy itself.
+type SnapshotLifecyclePolicy struct {
+	Name       string                      `json:"name"`
+	Schedule   string                      `json:"schedule"`
+	Repository string                      `json:"repository"`
+	Config     map[string]interface{}      `json:"config,omitempty"`
+	Retention  *SnapshotLifecycleRetention `json:"retention,omitempty"`
+}
+
+// SnapshotLifecycleRetention models the retention rules of a snapshot lifecycle management policy.
+type SnapshotLifecycleRetention struct {
+	ExpireAfter string `json:"expire_after,omitempty"`
+	MinCount    *int32 `json:"min_count,omitempty"`
+	MaxCount    *int32 `json:"max_count,omitempty"`
+}
+
+func (c *clientV6) GetSnapshotRepositories(ctx context.Context) (SnapshotRepositories, error) {
+	var repositories SnapshotRepositories
+	err := c.get(ctx, "/_snapshot", &repositories)
+	return repositories, err
+}
+
+func (c *clientV6) UpsertSnapshotRepository(ctx context.Context, name string, repository SnapshotRepository) error {
+	return c.put(ctx, fmt.Sprintf("/_snapshot/%s", url.PathEscape(name)), repository, nil)
+}
+
+func (c *clientV6) DeleteSnapshotRepository(ctx context.Context, name string) error {
+	return c.delete(ctx, fmt.Sprintf("/_snapshot/%s", url.PathEscape(name)), nil, nil)
+}
+
+func (c *clientV6) CreateSnapshot(ctx context.Context, repository string, name string) error {
+	path := fmt.Sprintf("/_snapshot/%s/%s", url.PathEscape(repository), url.PathEscape(name))
+	return c.put(ctx, path, map[string]interface{}{"include_global_state": true}, nil)
+}
+
+func (c *clientV6) GetSnapshot(ctx context.Context, repository string, name string) (Snapshot, error) {
+	var response SnapshotsResponse
+	path := fmt.Sprintf("/_snapshot/%s/%s", url.PathEscape(repository), url.PathEscape(name))
+	if err := c.get(ctx, path, &response); err != nil {
+		return Snapshot{}, err
+	}
+	for _, snapshot := range response.Snapshots {
+		if snapshot.Snapshot == name {
+			return snapshot, nil
+		}
+	}
+	// Elasticsearch responds with a 404 error if the snapshot does not exist
+	return Snapshot{}, fmt.Errorf("snapshot %s missing from the response of repository %s", name, repository)
+}
+
+func (c *clientV6) GetSnapshotLifecyclePolicies(_ context.Context) (SnapshotLifecyclePolicies, error) {
+	return nil, errNotSupportedInEs6x
+}
+
+func (c *clientV6) UpsertSnapshotLifecyclePolicy(_ context.Context, _ string, _ SnapshotLifecyclePolicy) error {
+	return errNotSupportedInEs6x
+}
+
+func (c *clientV6) DeleteSnapshotLifecyclePolicy(_ context.Context, _ string) error {
+	return errNotSupportedInEs6x
+}
+
+func (c *clientV7) GetSnapshotLifecyclePolicies(ctx context.Context) (SnapshotLifecyclePolicies, error) {
+	var policies SnapshotLifecyclePolicies
+	err := c.get(ctx, "/_slm/policy", &policies)
+	return policies, err
+}
+
+func (c *clientV7) UpsertSnapshotLifecyclePolicy(ctx context.Context, name string, policy SnapshotLifecyclePolicy) error {
+	return c.put(ctx, fmt.Sprintf("/_slm/policy/%s", url.PathEscape(name)), policy, nil)
+}
+
+func (c *clientV7) DeleteSnapshotLifecyclePolicy(ctx context.Context, name string) error {
+	return c.delete(ctx, fmt.Sprintf("/_slm/policy/%s", url.PathEscape(name)), nil, nil)
+}
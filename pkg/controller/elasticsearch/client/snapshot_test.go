@@ -0,0 +1,170 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	. "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/pointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fixtureResponse(t *testing.T, req *http.Request, fixture string) *http.Response {
+	t.Helper()
+	body, err := ioutil.ReadFile(filepath.Join("testdata", fixture))
+	require.NoError(t, err)
+	return NewMockResponse(200, req, string(body))
+}
+
+func TestClient_GetSnapshotRepositories(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodGet, req.Method)
+		require.Equal(t, "/_snapshot", req.URL.Path)
+		return fixtureResponse(t, req, "snapshot_repositories.json")
+	})
+	got, err := testClient.GetSnapshotRepositories(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, SnapshotRepositories{
+		"my-s3-repository": {
+			Type:     "s3",
+			Settings: map[string]interface{}{"bucket": "my-bucket", "client": "default", "compress": "true"},
+		},
+		"my-fs-repository": {
+			Type:     "fs",
+			Settings: map[string]interface{}{"location": "/mnt/backups"},
+		},
+	}, got)
+}
+
+func TestClient_UpsertSnapshotRepository(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("6.8.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodPut, req.Method)
+		require.Equal(t, "/_snapshot/my-s3-repository", req.URL.Path)
+		var body SnapshotRepository
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		require.Equal(t, SnapshotRepository{Type: "s3", Settings: map[string]interface{}{"bucket": "my-bucket"}}, body)
+		return NewMockResponse(200, req, `{"acknowledged": true}`)
+	})
+	assert.NoError(t, testClient.UpsertSnapshotRepository(
+		context.Background(),
+		"my-s3-repository",
+		SnapshotRepository{Type: "s3", Settings: map[string]interface{}{"bucket": "my-bucket"}},
+	))
+}
+
+func TestClient_DeleteSnapshotRepository(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodDelete, req.Method)
+		require.Equal(t, "/_snapshot/my-s3-repository", req.URL.Path)
+		return NewMockResponse(200, req, `{"acknowledged": true}`)
+	})
+	assert.NoError(t, testClient.DeleteSnapshotRepository(context.Background(), "my-s3-repository"))
+}
+
+func TestClient_CreateSnapshot(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodPut, req.Method)
+		require.Equal(t, "/_snapshot/my-s3-repository/es-upgrade-to-7.16.0", req.URL.Path)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		require.Equal(t, map[string]interface{}{"include_global_state": true}, body)
+		return NewMockResponse(200, req, `{"accepted": true}`)
+	})
+	assert.NoError(t, testClient.CreateSnapshot(context.Background(), "my-s3-repository", "es-upgrade-to-7.16.0"))
+}
+
+func TestClient_GetSnapshot(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodGet, req.Method)
+		require.Equal(t, "/_snapshot/my-s3-repository/es-upgrade-to-7.16.0", req.URL.Path)
+		return fixtureResponse(t, req, "snapshot.json")
+	})
+	got, err := testClient.GetSnapshot(context.Background(), "my-s3-repository", "es-upgrade-to-7.16.0")
+	require.NoError(t, err)
+	assert.Equal(t, Snapshot{Snapshot: "es-upgrade-to-7.16.0", State: SnapshotSuccess}, got)
+
+	notFoundClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		return NewMockResponse(404, req, `{"error":{"type":"snapshot_missing_exception"},"status":404}`)
+	})
+	_, err = notFoundClient.GetSnapshot(context.Background(), "my-s3-repository", "es-upgrade-to-7.16.0")
+	assert.True(t, IsNotFound(err))
+}
+
+func TestClient_GetSnapshotLifecyclePolicies(t *testing.T) {
+	testClient := NewMockClient(version.MustParse("7.15.0"), func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodGet, req.Method)
+		require.Equal(t, "/_slm/policy", req.URL.Path)
+		return fixtureResponse(t, req, "slm_policies.json")
+	})
+	got, err := testClient.GetSnapshotLifecyclePolicies(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, SnapshotLifecyclePolicies{
+		"nightly-snapshots": {
+			Version: 1,
+			Policy: SnapshotLifecyclePolicy{
+				Name:       "<nightly-snap-{now/d}>",
+				Schedule:   "0 30 1 * * ?",
+				Repository: "my-s3-repository",
+				Config:     map[string]interface{}{"indices": []interface{}{"*"}},
+				Retention: &SnapshotLifecycleRetention{
+					ExpireAfter: "30d",
+					MinCount:    pointer.Int32(5),
+					MaxCount:    pointer.Int32(50),
+				},
+			},
+		},
+	}, got)
+}
+
+func TestClient_SnapshotLifecyclePolicies(t *testing.T) {
+	tests := []struct {
+		name         string
+		version      version.Version
+		expectedPath string
+		wantErr      bool
+	}{
+		{
+			name:    "not supported in 6.x",
+			version: version.MustParse("6.8.0"),
+			wantErr: true,
+		},
+		{
+			name:         "7.x",
+			version:      version.MustParse("7.15.0"),
+			expectedPath: "/_slm/policy/nightly-snapshots",
+		},
+		{
+			name:         "8.x",
+			version:      version.MustParse("8.0.0"),
+			expectedPath: "/_slm/policy/nightly-snapshots",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var methods []string
+			testClient := NewMockClient(tt.version, func(req *http.Request) *http.Response {
+				require.Equal(t, tt.expectedPath, req.URL.Path)
+				methods = append(methods, req.Method)
+				return NewMockResponse(200, req, `{"acknowledged": true}`)
+			})
+			policy := SnapshotLifecyclePolicy{Name: "<nightly-snap-{now/d}>", Schedule: "0 30 1 * * ?", Repository: "my-s3-repository"}
+			err := testClient.UpsertSnapshotLifecyclePolicy(context.Background(), "nightly-snapshots", policy)
+			assert.Equal(t, tt.wantErr, err != nil)
+			err = testClient.DeleteSnapshotLifecyclePolicy(context.Background(), "nightly-snapshots")
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, []string{http.MethodPut, http.MethodDelete}, methods)
+			}
+		})
+	}
+}
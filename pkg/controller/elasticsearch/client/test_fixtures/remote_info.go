@@ -0,0 +1,31 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package fixtures
+
+const (
+	RemoteInfoSample = `{
+	"cluster-two": {
+	  "connected": true,
+	  "mode": "sniff",
+	  "seeds": [
+		"cluster-two-es-transport.default.svc:9300"
+	  ],
+	  "num_nodes_connected": 3,
+	  "max_connections_per_cluster": 3,
+	  "initial_connect_timeout": "30s",
+	  "skip_unavailable": false
+	},
+	"eu-west": {
+	  "connected": false,
+	  "mode": "proxy",
+	  "proxy_address": "es.eu-west.example.com:9300",
+	  "server_name": "es.eu-west.example.com",
+	  "num_proxy_sockets_connected": 0,
+	  "max_proxy_socket_connections": 18,
+	  "initial_connect_timeout": "30s",
+	  "skip_unavailable": true
+	}
+  }`
+)
@@ -116,6 +116,12 @@ func (c *clientV6) GetRemoteClusterSettings(ctx context.Context) (RemoteClusters
 	return remoteClustersSettings, err
 }
 
+func (c *clientV6) GetRemoteClustersInfo(ctx context.Context) (RemoteClustersInfo, error) {
+	var remoteClustersInfo RemoteClustersInfo
+	err := c.get(ctx, "/_remote/info", &remoteClustersInfo)
+	return remoteClustersInfo, err
+}
+
 func (c *clientV6) GetLicense(ctx context.Context) (License, error) {
 	var license LicenseResponse
 	err := c.get(ctx, "/_xpack/license", &license)
@@ -7,24 +7,28 @@ package driver
 import (
 	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/autoscaling/elasticsearch/status"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
 )
 
 // autoscaledResourcesSynced checks that the autoscaler controller has updated the resources
 // when autoscaling is enabled. This is to avoid situations where resources have been manually
 // deleted or replaced by an external event. The Elasticsearch controller should then wait for
 // the Elasticsearch autoscaling controller to update again the resources in the NodeSets.
-func autoscaledResourcesSynced(es esv1.Elasticsearch) (bool, error) {
-	if !es.IsAutoscalingDefined() {
-		return true, nil
+func autoscaledResourcesSynced(c k8s.Client, es esv1.Elasticsearch) (bool, error) {
+	if es.IsAutoscalingAnnotationSet() {
+		// The deprecated autoscaling annotation has not been converted yet into an ElasticsearchAutoscaler.
+		log.Info("Waiting for the autoscaling annotation to be migrated", "namespace", es.Namespace, "es_name", es.Name)
+		return false, nil
 	}
-	autoscalingSpec, err := es.GetAutoscalingSpecification()
+	esa, err := status.GetElasticsearchAutoscaler(c, es)
 	if err != nil {
 		return false, err
 	}
-	autoscalingStatus, err := status.From(es)
-	if err != nil {
-		return false, err
+	if esa == nil {
+		return true, nil
 	}
+	autoscalingSpec := esa.GetAutoscalingSpecification(es)
+	autoscalingStatus := status.From(*esa)
 
 	for _, nodeSet := range es.Spec.NodeSets {
 		nodeSetAutoscalingSpec, err := autoscalingSpec.GetAutoscalingSpecFor(nodeSet)
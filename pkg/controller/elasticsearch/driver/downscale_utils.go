@@ -12,6 +12,7 @@ import (
 	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/observer"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/reconcile"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/shutdown"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/sset"
 	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
 	appsv1 "k8s.io/api/apps/v1"
@@ -24,6 +25,8 @@ type downscaleContext struct {
 	k8sClient   k8s.Client
 	esClient    esclient.Client
 	shardLister esclient.ShardLister
+	// nodeShutdown is nil if the node shutdown API is not supported by all the nodes of the cluster.
+	nodeShutdown *shutdown.NodeShutdown
 	// driver states
 	resourcesState reconcile.ResourcesState
 	observedState  observer.State
@@ -39,6 +42,7 @@ func newDownscaleContext(
 	ctx context.Context,
 	k8sClient k8s.Client,
 	esClient esclient.Client,
+	nodeShutdown *shutdown.NodeShutdown,
 	resourcesState reconcile.ResourcesState,
 	observedState observer.State,
 	reconcileState *reconcile.State,
@@ -50,6 +54,7 @@ func newDownscaleContext(
 		k8sClient:      k8sClient,
 		esClient:       esClient,
 		shardLister:    esClient,
+		nodeShutdown:   nodeShutdown,
 		resourcesState: resourcesState,
 		observedState:  observedState,
 		reconcileState: reconcileState,
@@ -17,6 +17,7 @@ import (
 	controller "sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	policyv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/stackconfigpolicy/v1alpha1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/association"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common"
 	commondriver "github.com/elastic/cloud-on-k8s/pkg/controller/common/driver"
@@ -29,20 +30,26 @@ import (
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/watches"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/bootstrap"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/ccr"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/certificates"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/cleanup"
 	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/configmap"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/indexmanagement"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/initcontainer"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/label"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/license"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/nativerealm"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/observer"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/reconcile"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/remotecluster"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/rolemapping"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/services"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/settings"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/snapshot"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/stackmon"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/user"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/stackconfigpolicy"
 	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
 )
 
@@ -134,6 +141,10 @@ func (d *defaultDriver) Reconcile(ctx context.Context) *reconciler.Results {
 		return results.WithError(err)
 	}
 
+	if err := d.reconcileNetworkPolicy(); err != nil {
+		return results.WithError(err)
+	}
+
 	certificateResources, res := certificates.Reconcile(
 		ctx,
 		d,
@@ -145,6 +156,7 @@ func (d *defaultDriver) Reconcile(ctx context.Context) *reconciler.Results {
 	if results.WithResults(res).HasError() {
 		return results
 	}
+	d.ReconcileState.UpdateTransportCertificates(certificateResources.TransportCertificates)
 
 	controllerUser, err := user.ReconcileUsersAndRoles(ctx, d.Client, d.ES, d.DynamicWatches(), d.Recorder())
 	if err != nil {
@@ -195,6 +207,14 @@ func (d *defaultDriver) Reconcile(ctx context.Context) *reconciler.Results {
 	if err != nil {
 		return results.WithError(err)
 	}
+	d.ReconcileState.UpdateReachable(esReachable)
+
+	// settings enforced by the StackConfigPolicies selecting this cluster
+	policySettings, err := stackconfigpolicy.ForElasticsearch(d.Client, d.OperatorParameters.OperatorNamespace, d.ES)
+	if err != nil {
+		return results.WithError(err)
+	}
+	d.ReconcileState.UpdateStackConfigPolicies(policySettings.Status())
 
 	if esReachable {
 		// reconcile the Elasticsearch license
@@ -224,6 +244,20 @@ func (d *defaultDriver) Reconcile(ctx context.Context) *reconciler.Results {
 		if requeue {
 			results.WithResult(defaultRequeue)
 		}
+		d.reconcileRemoteClustersStatus(ctx, esClient, results)
+
+		// reconcile cross-cluster replication auto-follow patterns and follower indices
+		d.reconcileCrossClusterReplication(ctx, esClient, results)
+
+		// reconcile snapshot repositories and snapshot lifecycle policies
+		d.reconcileSnapshots(ctx, esClient, *min, results)
+
+		// reconcile ILM policies, component and index templates, and ingest pipelines
+		d.reconcileIndexManagement(ctx, esClient, *min, results)
+
+		// reconcile role mappings enforced by StackConfigPolicies
+		d.reconcileRoleMappings(ctx, esClient, policySettings.RoleMappings, results)
+		d.reconcileNativeRealm(ctx, esClient, results)
 	}
 
 	// Compute seed hosts based on current masters with a podIP
@@ -264,8 +298,23 @@ func (d *defaultDriver) Reconcile(ctx context.Context) *reconciler.Results {
 		results.WithResult(defaultRequeue)
 	}
 
+	// block version upgrades until critical deprecation issues are resolved and a snapshot has been taken, if
+	// required: the StatefulSets are held at the running version in the meantime, while any other change is applied
+	var heldVersion *version.Version
+	canUpgrade, err := d.verifyDeprecationsBeforeMajorUpgrade(ctx, esClient, esReachable, *min)
+	if err == nil && canUpgrade {
+		canUpgrade, err = d.snapshotBeforeUpgrade(ctx, esClient, esReachable, resourcesState.CurrentPods)
+	}
+	if err != nil {
+		results.WithError(err)
+	}
+	if err != nil || !canUpgrade {
+		heldVersion = min
+		results.WithResult(defaultRequeue)
+	}
+
 	// reconcile StatefulSets and nodes configuration
-	res = d.reconcileNodeSpecs(ctx, esReachable, esClient, d.ReconcileState, observedState, *resourcesState, keystoreResources)
+	res = d.reconcileNodeSpecs(ctx, esReachable, esClient, d.ReconcileState, observedState, *resourcesState, keystoreResources, policySettings.Config, heldVersion)
 	results = results.WithResults(res)
 
 	if res.HasError() {
@@ -276,6 +325,152 @@ func (d *defaultDriver) Reconcile(ctx context.Context) *reconciler.Results {
 	return results
 }
 
+// reconcileRemoteClustersStatus reports the status of the connections to the remote clusters declared in the
+// specification, which is refreshed periodically.
+func (d *defaultDriver) reconcileRemoteClustersStatus(
+	ctx context.Context,
+	esClient esclient.Client,
+	results *reconciler.Results,
+) {
+	statuses, err := remotecluster.Status(ctx, esClient, d.ES)
+	if err != nil {
+		// keep the previous status
+		log.Error(err, "Could not retrieve the status of the remote clusters", "namespace", d.ES.Namespace, "es_name", d.ES.Name)
+		results.WithResult(defaultRequeue)
+		return
+	}
+	if len(statuses) > 0 {
+		results.WithResult(controller.Result{RequeueAfter: remotecluster.StatusRefreshPeriod})
+	}
+	d.ReconcileState.UpdateRemoteClusters(statuses)
+}
+
+// reconcileCrossClusterReplication reconciles the auto-follow patterns and the follower indices declared in the
+// specification, and reports the status of the follower indices, which is refreshed periodically.
+func (d *defaultDriver) reconcileCrossClusterReplication(
+	ctx context.Context,
+	esClient esclient.Client,
+	results *reconciler.Results,
+) {
+	statuses, err := ccr.Reconcile(ctx, d.Client, esClient, &d.ES)
+	if err != nil {
+		msg := "Could not reconcile cross-cluster replication"
+		d.ReconcileState.AddEvent(corev1.EventTypeWarning, events.EventReasonUnexpected, fmt.Sprintf("%s: %s", msg, err.Error()))
+		log.Error(err, msg, "namespace", d.ES.Namespace, "es_name", d.ES.Name)
+		results.WithResult(defaultRequeue)
+		if statuses == nil {
+			// keep the previous status, the follower indices have not been reconciled
+			return
+		}
+	}
+	for _, status := range statuses {
+		if status.Phase == esv1.FollowerIndexFailedPhase {
+			d.ReconcileState.AddEvent(
+				corev1.EventTypeWarning,
+				events.EventReasonUnexpected,
+				fmt.Sprintf("Could not reconcile follower index %s: %s", status.Name, status.Message),
+			)
+			results.WithResult(defaultRequeue)
+		}
+	}
+	if len(statuses) > 0 {
+		// refresh the replication lag
+		results.WithResult(controller.Result{RequeueAfter: remotecluster.StatusRefreshPeriod})
+	}
+	d.ReconcileState.UpdateFollowerIndices(statuses)
+}
+
+// reconcileSnapshots reconciles the snapshot repositories and the snapshot lifecycle policies declared in the
+// specification, and reports the status of the repositories.
+func (d *defaultDriver) reconcileSnapshots(
+	ctx context.Context,
+	esClient esclient.Client,
+	minVersion version.Version,
+	results *reconciler.Results,
+) {
+	repositoriesStatus, err := snapshot.Reconcile(ctx, d.Client, esClient, &d.ES, minVersion)
+	if err != nil {
+		msg := "Could not reconcile snapshot repositories and policies"
+		d.ReconcileState.AddEvent(corev1.EventTypeWarning, events.EventReasonUnexpected, fmt.Sprintf("%s: %s", msg, err.Error()))
+		log.Error(err, msg, "namespace", d.ES.Namespace, "es_name", d.ES.Name)
+		results.WithResult(defaultRequeue)
+		if repositoriesStatus == nil {
+			// keep the previous status, the repositories have not been reconciled
+			return
+		}
+	}
+	for _, status := range repositoriesStatus {
+		if status.Phase == esv1.SnapshotRepositoryFailedPhase {
+			d.ReconcileState.AddEvent(
+				corev1.EventTypeWarning,
+				events.EventReasonUnexpected,
+				fmt.Sprintf("Could not register snapshot repository %s: %s", status.Name, status.Message),
+			)
+			results.WithResult(defaultRequeue)
+		}
+	}
+	d.ReconcileState.UpdateSnapshotRepositories(repositoriesStatus)
+}
+
+// reconcileIndexManagement reconciles the ILM policies, component and index templates, and ingest pipelines declared in
+// the specification, and reports their status.
+func (d *defaultDriver) reconcileIndexManagement(
+	ctx context.Context,
+	esClient esclient.Client,
+	minVersion version.Version,
+	results *reconciler.Results,
+) {
+	statuses, err := indexmanagement.Reconcile(ctx, d.Client, esClient, &d.ES, minVersion)
+	if err != nil {
+		msg := "Could not reconcile index management objects"
+		d.ReconcileState.AddEvent(corev1.EventTypeWarning, events.EventReasonUnexpected, fmt.Sprintf("%s: %s", msg, err.Error()))
+		log.Error(err, msg, "namespace", d.ES.Namespace, "es_name", d.ES.Name)
+		results.WithResult(defaultRequeue)
+	}
+	for _, status := range statuses {
+		if status.Phase == esv1.IndexManagementObjectFailedPhase {
+			d.ReconcileState.AddEvent(
+				corev1.EventTypeWarning,
+				events.EventReasonUnexpected,
+				fmt.Sprintf("Could not apply %s %s: %s", status.Type, status.Name, status.Message),
+			)
+			results.WithResult(defaultRequeue)
+		}
+	}
+	d.ReconcileState.UpdateIndexManagement(statuses)
+}
+
+// reconcileRoleMappings reconciles the role mappings enforced by the StackConfigPolicies selecting this cluster.
+func (d *defaultDriver) reconcileRoleMappings(
+	ctx context.Context,
+	esClient esclient.Client,
+	declared []policyv1alpha1.RoleMapping,
+	results *reconciler.Results,
+) {
+	if err := rolemapping.Reconcile(ctx, d.Client, esClient, &d.ES, declared); err != nil {
+		msg := "Could not reconcile role mappings"
+		d.ReconcileState.AddEvent(corev1.EventTypeWarning, events.EventReasonUnexpected, fmt.Sprintf("%s: %s", msg, err.Error()))
+		log.Error(err, msg, "namespace", d.ES.Namespace, "es_name", d.ES.Name)
+		results.WithResult(defaultRequeue)
+	}
+}
+
+// reconcileNativeRealm reconciles the users and the roles declared by the ElasticsearchUser and ElasticsearchRole
+// resources referencing this cluster, which are reconciled periodically.
+func (d *defaultDriver) reconcileNativeRealm(ctx context.Context, esClient esclient.Client, results *reconciler.Results) {
+	requeue, err := nativerealm.Reconcile(ctx, d.Client, esClient, &d.ES, d.DynamicWatches())
+	if err != nil {
+		msg := "Could not reconcile native users and roles"
+		d.ReconcileState.AddEvent(corev1.EventTypeWarning, events.EventReasonUnexpected, fmt.Sprintf("%s: %s", msg, err.Error()))
+		log.Error(err, msg, "namespace", d.ES.Namespace, "es_name", d.ES.Name)
+		results.WithResult(defaultRequeue)
+	}
+	if requeue {
+		// correct the changes made through the Elasticsearch API
+		results.WithResult(controller.Result{RequeueAfter: nativerealm.RefreshPeriod})
+	}
+}
+
 // newElasticsearchClient creates a new Elasticsearch HTTP client for this cluster using the provided user
 func (d *defaultDriver) newElasticsearchClient(
 	state *reconcile.ResourcesState,
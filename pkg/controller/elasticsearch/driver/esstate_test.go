@@ -45,6 +45,10 @@ type fakeESClient struct { //nolint:maligned
 
 	health                      esclient.Health
 	GetClusterHealthCalledCount int
+
+	shutdowns                esclient.ShutdownResponse
+	PutShutdownCalledWith    []string
+	DeleteShutdownCalledWith []string
 }
 
 func (f *fakeESClient) SetMinimumMasterNodes(_ context.Context, n int) error {
@@ -105,6 +109,20 @@ func (f *fakeESClient) GetClusterHealthWaitForAllEvents(_ context.Context) (escl
 	return f.health, nil
 }
 
+func (f *fakeESClient) GetShutdown(_ context.Context) (esclient.ShutdownResponse, error) {
+	return f.shutdowns, nil
+}
+
+func (f *fakeESClient) PutShutdown(_ context.Context, nodeID string, _ esclient.ShutdownType, _ string) error {
+	f.PutShutdownCalledWith = append(f.PutShutdownCalledWith, nodeID)
+	return nil
+}
+
+func (f *fakeESClient) DeleteShutdown(_ context.Context, nodeID string) error {
+	f.DeleteShutdownCalledWith = append(f.DeleteShutdownCalledWith, nodeID)
+	return nil
+}
+
 // -- ESState tests
 
 func Test_memoizingNodes_NodesInCluster(t *testing.T) {
@@ -16,6 +16,16 @@ import (
 // (eg. incorrect number of nodes or master-eligible nodes topology)
 // - create or delete more than one master node at once
 func (d *defaultDriver) expectationsSatisfied() (bool, error) {
+	satisfied, err := d.cacheUpToDate()
+	if err != nil {
+		return false, err
+	}
+	reportExpectations(k8s.ExtractNamespacedName(&d.ES), satisfied)
+	return satisfied, nil
+}
+
+// cacheUpToDate returns true if the StatefulSets and Pods in the cache reflect the changes made by the operator.
+func (d *defaultDriver) cacheUpToDate() (bool, error) {
 	// make sure the cache is up-to-date
 	expectationsOK, err := d.Expectations.Satisfied()
 	if err != nil {
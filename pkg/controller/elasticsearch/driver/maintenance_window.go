@@ -0,0 +1,89 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package driver
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/label"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/chrono"
+)
+
+// nodeSetOf returns the NodeSet the given Pod belongs to, if it still exists in the specification.
+func nodeSetOf(es esv1.Elasticsearch, pod corev1.Pod) (esv1.NodeSet, bool) {
+	return nodeSetOfStatefulSet(es, pod.Labels[label.StatefulSetNameLabelName])
+}
+
+// nodeSetOfStatefulSet returns the NodeSet of the given StatefulSet, if it still exists in the specification.
+func nodeSetOfStatefulSet(es esv1.Elasticsearch, ssetName string) (esv1.NodeSet, bool) {
+	for _, nodeSet := range es.Spec.NodeSets {
+		if esv1.StatefulSet(es.Name, nodeSet.Name) == ssetName {
+			return nodeSet, true
+		}
+	}
+	return esv1.NodeSet{}, false
+}
+
+// maintenanceWindowOpen returns true if the healthy nodes of the given NodeSet can be restarted at the given time.
+// Otherwise, the start of the next maintenance window of the NodeSet is returned, or the zero time if there is none.
+func maintenanceWindowOpen(es esv1.Elasticsearch, nodeSet esv1.NodeSet, now time.Time) (bool, time.Time, error) {
+	window := es.Spec.UpdateStrategy.MaintenanceWindowFor(nodeSet)
+	if window == nil {
+		return true, time.Time{}, nil
+	}
+	schedule, err := chrono.ParseSchedule(window.Schedule)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("invalid maintenance window for node set %s: %w", nodeSet.Name, err)
+	}
+	w := chrono.Window{Schedule: schedule, Duration: window.Duration.Duration}
+	if w.IsOpen(now) {
+		return true, time.Time{}, nil
+	}
+	return false, w.NextOpening(now), nil
+}
+
+// deferredChanges returns the NodeSets with healthy Pods to upgrade whose maintenance window is closed at the given
+// time, in the order of the specification.
+func deferredChanges(
+	es esv1.Elasticsearch,
+	podsToUpgrade []corev1.Pod,
+	healthyPods map[string]corev1.Pod,
+	now time.Time,
+) ([]esv1.DeferredChangesStatus, error) {
+	pendingPods := make(map[string]int32)
+	for _, pod := range podsToUpgrade {
+		if _, healthy := healthyPods[pod.Name]; !healthy {
+			// unhealthy Pods are restarted regardless of the maintenance window
+			continue
+		}
+		if nodeSet, exists := nodeSetOf(es, pod); exists {
+			pendingPods[nodeSet.Name]++
+		}
+	}
+	var deferred []esv1.DeferredChangesStatus
+	for _, nodeSet := range es.Spec.NodeSets {
+		if pendingPods[nodeSet.Name] == 0 {
+			continue
+		}
+		open, next, err := maintenanceWindowOpen(es, nodeSet, now)
+		if err != nil {
+			return nil, err
+		}
+		if open {
+			continue
+		}
+		status := esv1.DeferredChangesStatus{NodeSet: nodeSet.Name, PendingPods: pendingPods[nodeSet.Name]}
+		if !next.IsZero() {
+			status.NextMaintenanceWindow = &metav1.Time{Time: next}
+		}
+		deferred = append(deferred, status)
+	}
+	return deferred, nil
+}
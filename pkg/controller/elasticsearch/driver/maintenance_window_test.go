@@ -0,0 +1,168 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package driver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/label"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/sset"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/pointer"
+)
+
+// maintenanceTestES returns a cluster with a hot node set restarted at night, and a cold node set restarted during
+// the week-end, 2 nodes at a time.
+func maintenanceTestES() esv1.Elasticsearch {
+	return esv1.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "es"},
+		Spec: esv1.ElasticsearchSpec{
+			Version: "7.15.2",
+			UpdateStrategy: esv1.UpdateStrategy{
+				MaintenanceWindow: &esv1.MaintenanceWindow{Schedule: "0 22 * * *", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+			},
+			NodeSets: []esv1.NodeSet{
+				{Name: "hot", Count: 3},
+				{Name: "cold", Count: 3, UpdateStrategy: &esv1.NodeSetUpdateStrategy{
+					ChangeBudget:      esv1.NodeSetChangeBudget{MaxUnavailable: pointer.Int32(2)},
+					MaintenanceWindow: &esv1.MaintenanceWindow{Schedule: "0 0 * * 6", Duration: metav1.Duration{Duration: 48 * time.Hour}},
+				}},
+			},
+		},
+	}
+}
+
+func maintenanceTestPod(nodeSet string, ordinal int32) corev1.Pod {
+	ssetName := esv1.StatefulSet("es", nodeSet)
+	return corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "ns",
+		Name:      sset.PodName(ssetName, ordinal),
+		Labels:    map[string]string{label.StatefulSetNameLabelName: ssetName},
+	}}
+}
+
+func healthy(pods ...corev1.Pod) map[string]corev1.Pod {
+	healthyPods := make(map[string]corev1.Pod, len(pods))
+	for _, pod := range pods {
+		healthyPods[pod.Name] = pod
+	}
+	return healthyPods
+}
+
+func predicateNamed(t *testing.T, name string) Predicate {
+	t.Helper()
+	for _, p := range predicates {
+		if p.name == name {
+			return p
+		}
+	}
+	t.Fatalf("no predicate named %s", name)
+	return Predicate{}
+}
+
+// October 18th 2021 is a Monday.
+var (
+	mondayNoon      = time.Date(2021, time.October, 18, 12, 0, 0, 0, time.UTC)
+	mondayNight     = time.Date(2021, time.October, 18, 23, 0, 0, 0, time.UTC)
+	saturdayMorning = time.Date(2021, time.October, 23, 9, 0, 0, 0, time.UTC)
+)
+
+func Test_deferredChanges(t *testing.T) {
+	es := maintenanceTestES()
+	hot0, hot1, cold0, cold1 := maintenanceTestPod("hot", 0), maintenanceTestPod("hot", 1), maintenanceTestPod("cold", 0), maintenanceTestPod("cold", 1)
+	podsToUpgrade := []corev1.Pod{hot0, hot1, cold0, cold1}
+
+	// both windows are closed, the unhealthy Pod is not deferred
+	deferred, err := deferredChanges(es, podsToUpgrade, healthy(hot0, hot1, cold0), mondayNoon)
+	require.NoError(t, err)
+	require.Equal(t, []esv1.DeferredChangesStatus{
+		{NodeSet: "hot", PendingPods: 2, NextMaintenanceWindow: &metav1.Time{Time: time.Date(2021, time.October, 18, 22, 0, 0, 0, time.UTC)}},
+		{NodeSet: "cold", PendingPods: 1, NextMaintenanceWindow: &metav1.Time{Time: time.Date(2021, time.October, 23, 0, 0, 0, 0, time.UTC)}},
+	}, deferred)
+
+	// the window of the hot node set is open
+	deferred, err = deferredChanges(es, podsToUpgrade, healthy(hot0, hot1, cold0), mondayNight)
+	require.NoError(t, err)
+	require.Equal(t, []esv1.DeferredChangesStatus{
+		{NodeSet: "cold", PendingPods: 1, NextMaintenanceWindow: &metav1.Time{Time: time.Date(2021, time.October, 23, 0, 0, 0, 0, time.UTC)}},
+	}, deferred)
+
+	// the next window of a schedule which is never activated is unknown
+	neverActivated := es.DeepCopy()
+	neverActivated.Spec.UpdateStrategy.MaintenanceWindow.Schedule = "0 0 31 2 *"
+	deferred, err = deferredChanges(*neverActivated, podsToUpgrade, healthy(hot0, hot1, cold0), mondayNoon)
+	require.NoError(t, err)
+	require.Equal(t, []esv1.DeferredChangesStatus{
+		{NodeSet: "hot", PendingPods: 2},
+		{NodeSet: "cold", PendingPods: 1, NextMaintenanceWindow: &metav1.Time{Time: time.Date(2021, time.October, 23, 0, 0, 0, 0, time.UTC)}},
+	}, deferred)
+
+	// nothing to upgrade
+	deferred, err = deferredChanges(es, nil, healthy(hot0, hot1, cold0), mondayNoon)
+	require.NoError(t, err)
+	require.Nil(t, deferred)
+}
+
+func Test_predicate_onlyRestartHealthyNodeDuringMaintenanceWindow(t *testing.T) {
+	predicate := predicateNamed(t, "only_restart_healthy_node_during_maintenance_window")
+	es := maintenanceTestES()
+	hot0, cold0 := maintenanceTestPod("hot", 0), maintenanceTestPod("cold", 0)
+	tests := []struct {
+		name        string
+		candidate   corev1.Pod
+		healthyPods map[string]corev1.Pod
+		now         time.Time
+		want        bool
+	}{
+		{name: "cluster window closed", candidate: hot0, healthyPods: healthy(hot0), now: mondayNoon, want: false},
+		{name: "cluster window open", candidate: hot0, healthyPods: healthy(hot0), now: mondayNight, want: true},
+		{name: "node set window closed", candidate: cold0, healthyPods: healthy(cold0), now: mondayNight, want: false},
+		{name: "node set window open", candidate: cold0, healthyPods: healthy(cold0), now: saturdayMorning, want: true},
+		{name: "unhealthy Pod", candidate: hot0, healthyPods: healthy(), now: mondayNoon, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := PredicateContext{es: es, healthyPods: tt.healthyPods, now: tt.now}
+			got, err := predicate.fn(ctx, tt.candidate, nil, false)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_predicate_doNotRestartHealthyNodeIfNodeSetMaxUnavailableReached(t *testing.T) {
+	predicate := predicateNamed(t, "do_not_restart_healthy_node_if_NodeSet_MaxUnavailable_reached")
+	es := maintenanceTestES()
+	statefulSets := sset.StatefulSetList{
+		sset.TestSset{Namespace: "ns", Name: esv1.StatefulSet("es", "hot"), Replicas: 3}.Build(),
+		sset.TestSset{Namespace: "ns", Name: esv1.StatefulSet("es", "cold"), Replicas: 3}.Build(),
+	}
+	hot0, hot1, hot2 := maintenanceTestPod("hot", 0), maintenanceTestPod("hot", 1), maintenanceTestPod("hot", 2)
+	cold0, cold1, cold2 := maintenanceTestPod("cold", 0), maintenanceTestPod("cold", 1), maintenanceTestPod("cold", 2)
+	tests := []struct {
+		name        string
+		candidate   corev1.Pod
+		healthyPods map[string]corev1.Pod
+		want        bool
+	}{
+		{name: "no node set budget", candidate: hot0, healthyPods: healthy(hot0), want: true},
+		{name: "within the node set budget", candidate: cold0, healthyPods: healthy(cold0, cold1), want: true},
+		{name: "node set budget reached", candidate: cold0, healthyPods: healthy(cold0, hot0, hot1, hot2), want: false},
+		{name: "unhealthy Pod", candidate: cold0, healthyPods: healthy(cold2), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := PredicateContext{es: es, healthyPods: tt.healthyPods, statefulSets: statefulSets}
+			got, err := predicate.fn(ctx, tt.candidate, nil, false)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
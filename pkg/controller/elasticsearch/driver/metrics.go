@@ -0,0 +1,53 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package driver
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/elastic/cloud-on-k8s/pkg/utils/metrics"
+)
+
+func clusterLabels(es types.NamespacedName) prometheus.Labels {
+	return prometheus.Labels{metrics.NamespaceLabel: es.Namespace, metrics.NameLabel: es.Name}
+}
+
+func predicateLabels(es types.NamespacedName, predicate string) prometheus.Labels {
+	labels := clusterLabels(es)
+	labels[metrics.PredicateLabel] = predicate
+	return labels
+}
+
+// reportExpectations reports whether the orchestration of the given cluster is waiting for the cache to be up-to-date.
+func reportExpectations(es types.NamespacedName, satisfied bool) {
+	value := float64(1)
+	if satisfied {
+		value = 0
+	}
+	metrics.ElasticsearchExpectationsUnsatisfiedGauge.With(clusterLabels(es)).Set(value)
+}
+
+// reportPendingPods reports the number of Pods of the given cluster to restart to apply a change.
+func reportPendingPods(es types.NamespacedName, pending int) {
+	metrics.ElasticsearchUpgradePendingPodsGauge.With(clusterLabels(es)).Set(float64(pending))
+}
+
+// reportBlockedPods reports the number of Pods of the given cluster which cannot be restarted, for each predicate.
+func reportBlockedPods(es types.NamespacedName, podsByPredicates map[string][]string) {
+	for _, predicate := range predicates {
+		metrics.ElasticsearchUpgradeBlockedPodsGauge.With(predicateLabels(es, predicate.name)).
+			Set(float64(len(podsByPredicates[predicate.name])))
+	}
+}
+
+// DeleteMetrics removes the metrics reported by the driver for the given cluster.
+func DeleteMetrics(es types.NamespacedName) {
+	metrics.ElasticsearchExpectationsUnsatisfiedGauge.Delete(clusterLabels(es))
+	metrics.ElasticsearchUpgradePendingPodsGauge.Delete(clusterLabels(es))
+	for _, predicate := range predicates {
+		metrics.ElasticsearchUpgradeBlockedPodsGauge.Delete(predicateLabels(es, predicate.name))
+	}
+}
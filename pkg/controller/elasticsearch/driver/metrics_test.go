@@ -0,0 +1,42 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package driver
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/elastic/cloud-on-k8s/pkg/utils/metrics"
+)
+
+func TestDeleteMetrics(t *testing.T) {
+	es := types.NamespacedName{Namespace: "ns", Name: "metrics"}
+	blockedPods := func(predicate string) float64 {
+		return testutil.ToFloat64(metrics.ElasticsearchUpgradeBlockedPodsGauge.With(predicateLabels(es, predicate)))
+	}
+
+	reportExpectations(es, false)
+	reportPendingPods(es, 3)
+	reportBlockedPods(es, map[string][]string{"one_master_at_a_time": {"master-0", "master-1"}})
+	require.Equal(t, float64(1), testutil.ToFloat64(metrics.ElasticsearchExpectationsUnsatisfiedGauge.With(clusterLabels(es))))
+	require.Equal(t, float64(3), testutil.ToFloat64(metrics.ElasticsearchUpgradePendingPodsGauge.With(clusterLabels(es))))
+	require.Equal(t, float64(2), blockedPods("one_master_at_a_time"))
+	require.Equal(t, float64(0), blockedPods("require_started_replica"))
+
+	// the predicates which do not block the upgrade anymore are reset
+	reportBlockedPods(es, nil)
+	require.Equal(t, float64(0), blockedPods("one_master_at_a_time"))
+
+	DeleteMetrics(es)
+	// nothing left to delete
+	require.False(t, metrics.ElasticsearchExpectationsUnsatisfiedGauge.Delete(clusterLabels(es)))
+	require.False(t, metrics.ElasticsearchUpgradePendingPodsGauge.Delete(clusterLabels(es)))
+	for _, predicate := range predicates {
+		require.False(t, metrics.ElasticsearchUpgradeBlockedPodsGauge.Delete(predicateLabels(es, predicate.name)))
+	}
+}
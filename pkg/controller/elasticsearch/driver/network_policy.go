@@ -0,0 +1,93 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package driver
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/networkpolicy"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/certificates/remoteca"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/label"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/network"
+	remotecactl "github.com/elastic/cloud-on-k8s/pkg/controller/remoteca"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+// reconcileNetworkPolicy reconciles the NetworkPolicy allowing the traffic to the Elasticsearch Pods:
+// - from the operator to the HTTP port
+// - between the nodes of the cluster, and from the nodes of the remote clusters, to the transport port.
+// The traffic from the associated resources is allowed by NetworkPolicies reconciled by the association controllers.
+func (d *defaultDriver) reconcileNetworkPolicy() error {
+	enabled := d.OperatorParameters.NetworkPoliciesEnabled(d.ES.GetNetworkPolicySettings())
+	params := networkpolicy.Params{
+		Owner:   &d.ES,
+		Enabled: enabled,
+		Name:    networkpolicy.Name(esv1.ESNamer, d.ES.Name),
+	}
+	if !enabled {
+		return networkpolicy.Reconcile(d.Client, params)
+	}
+
+	remoteClusters, err := remoteClusters(d.Client, d.ES)
+	if err != nil {
+		return err
+	}
+	params.Labels = label.NewLabels(k8s.ExtractNamespacedName(&d.ES))
+	params.PodSelector = clusterPodLabels(d.ES.Name)
+	params.Ingress = expectedIngressRules(d.ES, d.OperatorParameters.OperatorNamespace, remoteClusters)
+	return networkpolicy.Reconcile(d.Client, params)
+}
+
+func expectedIngressRules(
+	es esv1.Elasticsearch,
+	operatorNamespace string,
+	remoteClusters []types.NamespacedName,
+) []networkingv1.NetworkPolicyIngressRule {
+	rules := []networkingv1.NetworkPolicyIngressRule{
+		networkpolicy.FromOperator(operatorNamespace, network.HTTPPort),
+		networkpolicy.FromPods(es.Namespace, clusterPodLabels(es.Name), network.TransportPort),
+	}
+	for _, remoteCluster := range remoteClusters {
+		rules = append(rules, networkpolicy.FromPods(remoteCluster.Namespace, clusterPodLabels(remoteCluster.Name), network.TransportPort))
+	}
+	return rules
+}
+
+func clusterPodLabels(esName string) map[string]string {
+	return map[string]string{label.ClusterNameLabelName: esName}
+}
+
+// remoteClusters returns the clusters involved in a remote cluster relationship with the given cluster, in any
+// direction. They are inferred from the remote certificate authorities copied into the namespace of the cluster, which
+// only exist for the relationships allowed by the operator.
+func remoteClusters(c k8s.Client, es esv1.Elasticsearch) ([]types.NamespacedName, error) {
+	var remoteCAs corev1.SecretList
+	if err := c.List(context.Background(), &remoteCAs, client.InNamespace(es.Namespace), remoteca.Labels(es.Name)); err != nil {
+		return nil, err
+	}
+	remoteClusters := make([]types.NamespacedName, 0, len(remoteCAs.Items))
+	for _, remoteCA := range remoteCAs.Items {
+		if _, external := remoteCA.Labels[remotecactl.ExternalRemoteClusterNameLabelName]; external {
+			// the Pods of the remote clusters running outside of the k8s cluster cannot be selected
+			continue
+		}
+		remoteClusters = append(remoteClusters, types.NamespacedName{
+			Namespace: remoteCA.Labels[remotecactl.RemoteClusterNamespaceLabelName],
+			Name:      remoteCA.Labels[remotecactl.RemoteClusterNameLabelName],
+		})
+	}
+	// sort the remote clusters for a stable comparison with the reconciled NetworkPolicy
+	sort.Slice(remoteClusters, func(i, j int) bool {
+		return remoteClusters[i].String() < remoteClusters[j].String()
+	})
+	return remoteClusters, nil
+}
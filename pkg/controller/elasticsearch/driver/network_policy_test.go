@@ -0,0 +1,74 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package driver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/certificates/remoteca"
+	remotecactl "github.com/elastic/cloud-on-k8s/pkg/controller/remoteca"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/maps"
+)
+
+func remoteCASecret(es esv1.Elasticsearch, remote types.NamespacedName) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      es.Name + "-" + remote.Namespace + "-" + remote.Name,
+			Namespace: es.Namespace,
+			Labels: maps.Merge(
+				map[string]string{
+					remotecactl.RemoteClusterNamespaceLabelName: remote.Namespace,
+					remotecactl.RemoteClusterNameLabelName:      remote.Name,
+				},
+				remoteca.Labels(es.Name),
+			),
+		},
+	}
+}
+
+func Test_remoteClusters(t *testing.T) {
+	es := esv1.Elasticsearch{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "es"}}
+	other := esv1.Elasticsearch{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "other"}}
+	c := k8s.NewFakeClient(
+		remoteCASecret(es, types.NamespacedName{Namespace: "ns2", Name: "es2"}),
+		remoteCASecret(es, types.NamespacedName{Namespace: "ns1", Name: "es1"}),
+		// remote cluster of another cluster
+		remoteCASecret(other, types.NamespacedName{Namespace: "ns3", Name: "es3"}),
+		// unrelated Secret
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "es-es-http-certs-public"}},
+	)
+
+	got, err := remoteClusters(c, es)
+	require.NoError(t, err)
+	require.Equal(t, []types.NamespacedName{{Namespace: "ns1", Name: "es1"}, {Namespace: "ns2", Name: "es2"}}, got)
+}
+
+func Test_expectedIngressRules(t *testing.T) {
+	es := esv1.Elasticsearch{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "es"}}
+	rules := expectedIngressRules(es, "elastic-system", []types.NamespacedName{{Namespace: "ns1", Name: "es1"}})
+	require.Len(t, rules, 3)
+
+	// operator to the HTTP port
+	require.Equal(t, map[string]string{"kubernetes.io/metadata.name": "elastic-system"}, rules[0].From[0].NamespaceSelector.MatchLabels)
+	require.Nil(t, rules[0].From[0].PodSelector)
+	require.Equal(t, 9200, rules[0].Ports[0].Port.IntValue())
+
+	// nodes of the cluster to the transport port
+	require.Equal(t, map[string]string{"kubernetes.io/metadata.name": "ns"}, rules[1].From[0].NamespaceSelector.MatchLabels)
+	require.Equal(t, map[string]string{"elasticsearch.k8s.elastic.co/cluster-name": "es"}, rules[1].From[0].PodSelector.MatchLabels)
+	require.Equal(t, 9300, rules[1].Ports[0].Port.IntValue())
+
+	// nodes of the remote cluster to the transport port
+	require.Equal(t, map[string]string{"kubernetes.io/metadata.name": "ns1"}, rules[2].From[0].NamespaceSelector.MatchLabels)
+	require.Equal(t, map[string]string{"elasticsearch.k8s.elastic.co/cluster-name": "es1"}, rules[2].From[0].PodSelector.MatchLabels)
+	require.Equal(t, 9300, rules[2].Ports[0].Port.IntValue())
+}
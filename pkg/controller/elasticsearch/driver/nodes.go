@@ -12,7 +12,9 @@ import (
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/events"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/keystore"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/reconciler"
+	common "github.com/elastic/cloud-on-k8s/pkg/controller/common/settings"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/certificates/transport"
 	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/nodespec"
@@ -36,6 +38,8 @@ func (d *defaultDriver) reconcileNodeSpecs(
 	observedState observer.State,
 	resourcesState reconcile.ResourcesState,
 	keystoreResources *keystore.Resources,
+	policyConfig *common.CanonicalConfig,
+	heldVersion *version.Version,
 ) *reconciler.Results {
 	span, ctx := apm.StartSpan(ctx, "reconcile_node_spec", tracing.SpanTypeApp)
 	defer span.End()
@@ -43,7 +47,7 @@ func (d *defaultDriver) reconcileNodeSpecs(
 	results := &reconciler.Results{}
 
 	// If some nodeSets are managed by the autoscaler, wait for them to be updated.
-	if ok, err := autoscaledResourcesSynced(d.ES); err != nil {
+	if ok, err := autoscaledResourcesSynced(d.Client, d.ES); err != nil {
 		return results.WithError(fmt.Errorf("StatefulSet recreation: %w", err))
 	} else if !ok {
 		return results.WithResult(defaultRequeue)
@@ -78,18 +82,28 @@ func (d *defaultDriver) reconcileNodeSpecs(
 		return results.WithError(err)
 	}
 
-	expectedResources, err := nodespec.BuildExpectedResources(d.Client, d.ES, keystoreResources, actualStatefulSets, d.OperatorParameters.IPFamily, d.OperatorParameters.SetDefaultSecurityContext)
+	// while a version upgrade is held, the StatefulSets are built for the running version
+	es := d.ES
+	if heldVersion != nil {
+		es = withHeldVersion(d.ES, *heldVersion, actualStatefulSets)
+	}
+
+	expectedResources, err := nodespec.BuildExpectedResources(d.Client, es, keystoreResources, actualStatefulSets, d.OperatorParameters.IPFamily, d.OperatorParameters.SetDefaultSecurityContext, policyConfig)
 	if err != nil {
 		return results.WithError(err)
 	}
 
 	esState := NewMemoizingESState(ctx, esClient)
+	restartShutdown, removeShutdown, err := d.newNodeShutdowns(esClient, resourcesState)
+	if err != nil {
+		return results.WithError(err)
+	}
 
 	// Phase 1: apply expected StatefulSets resources and scale up.
 	upscaleCtx := upscaleCtx{
 		parentCtx:            ctx,
 		k8sClient:            d.K8sClient(),
-		es:                   d.ES,
+		es:                   es,
 		observedState:        observedState,
 		esState:              esState,
 		expectations:         d.Expectations,
@@ -177,6 +191,7 @@ func (d *defaultDriver) reconcileNodeSpecs(
 		ctx,
 		d.Client,
 		esClient,
+		removeShutdown,
 		resourcesState,
 		observedState,
 		reconcileState,
@@ -190,7 +205,7 @@ func (d *defaultDriver) reconcileNodeSpecs(
 	}
 
 	// Phase 3: handle rolling upgrades.
-	rollingUpgradesRes := d.handleRollingUpgrades(ctx, esClient, esState, expectedResources.MasterNodesNames())
+	rollingUpgradesRes := d.handleRollingUpgrades(ctx, esClient, esState, restartShutdown, expectedResources.MasterNodesNames())
 	results.WithResults(rollingUpgradesRes)
 	if rollingUpgradesRes.HasError() {
 		return results
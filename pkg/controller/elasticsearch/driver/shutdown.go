@@ -0,0 +1,126 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package driver
+
+import (
+	"context"
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/label"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/migration"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/reconcile"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/shutdown"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/sset"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/stringsutil"
+)
+
+const (
+	// restartShutdownReason is the reason of the restart shutdown records registered during rolling upgrades.
+	restartShutdownReason = "Rolling upgrade orchestrated by ECK"
+	// removeShutdownReason is the reason of the remove shutdown records registered during downscales.
+	removeShutdownReason = "Downscale orchestrated by ECK"
+)
+
+// newNodeShutdowns returns the NodeShutdowns managing the restart and the remove shutdown records of the cluster, or
+// nil if the node shutdown API is not supported by all its nodes: nodes are then prepared for restarts by disabling
+// the shards allocation, and for removals with allocation filters.
+func (d *defaultDriver) newNodeShutdowns(
+	esClient esclient.Client,
+	resourcesState reconcile.ResourcesState,
+) (restart *shutdown.NodeShutdown, remove *shutdown.NodeShutdown, err error) {
+	minVersion, err := version.MinInPods(resourcesState.CurrentPods, label.VersionLabelName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if minVersion == nil {
+		minVersion = &d.Version
+	}
+	if !shutdown.IsSupported(*minVersion) {
+		return nil, nil, nil
+	}
+	logger := log.WithValues("namespace", d.ES.Namespace, "es_name", d.ES.Name)
+	return shutdown.NewNodeShutdown(esClient, esclient.Restart, restartShutdownReason, logger),
+		shutdown.NewNodeShutdown(esClient, esclient.Remove, removeShutdownReason, logger),
+		nil
+}
+
+// requestNodeRestarts registers a restart shutdown record for the nodes of the given Pods, and returns the Pods whose
+// node is ready to be restarted. Pods whose node is not a member of the cluster can be restarted right away.
+func (ctx *rollingUpgradeCtx) requestNodeRestarts(podsToRestart []corev1.Pod) ([]corev1.Pod, error) {
+	if err := ctx.nodeShutdown.Request(ctx.parentCtx, k8s.PodNames(podsToRestart)); err != nil {
+		return nil, err
+	}
+	ready := make([]corev1.Pod, 0, len(podsToRestart))
+	for _, pod := range podsToRestart {
+		status, err := ctx.nodeShutdown.ShutdownStatus(ctx.parentCtx, pod.Name)
+		if err != nil && !errors.Is(err, shutdown.ErrNodeNotFound) {
+			return nil, err
+		}
+		if err == nil && status.Status != esclient.ShutdownComplete {
+			log.Info("Node not ready for restart yet",
+				"es_name", ctx.ES.Name, "namespace", ctx.ES.Namespace, "pod_name", pod.Name,
+				"status", status.Status, "explanation", status.Explanation)
+			continue
+		}
+		ready = append(ready, pod)
+	}
+	return ready, nil
+}
+
+// clearNodeRestarts deletes the restart shutdown records of the upgraded nodes. Records of the nodes which are not back
+// into the cluster yet are kept, to keep delaying the allocation of their replicas.
+func clearNodeRestarts(
+	ctx context.Context,
+	nodeShutdown *shutdown.NodeShutdown,
+	statefulSets sset.StatefulSetList,
+	podsToUpgrade []corev1.Pod,
+) error {
+	toUpgrade := k8s.PodNames(podsToUpgrade)
+	var upgraded []string
+	for _, name := range statefulSets.PodNames() {
+		if !stringsutil.StringInSlice(name, toUpgrade) {
+			upgraded = append(upgraded, name)
+		}
+	}
+	return nodeShutdown.Clear(ctx, upgraded)
+}
+
+// migrateData prepares the given nodes for their removal from the cluster, through the node shutdown API if supported
+// or by setting allocation filters otherwise. Nodes not listed are not prepared for removal anymore.
+func migrateData(ctx downscaleContext, leavingNodes []string) error {
+	if ctx.nodeShutdown == nil {
+		return migration.MigrateData(ctx.parentCtx, ctx.es, ctx.esClient, leavingNodes)
+	}
+	// clear the allocation filters which may have been set before the cluster supported the node shutdown API
+	if err := migration.MigrateData(ctx.parentCtx, ctx.es, ctx.esClient, nil); err != nil {
+		return err
+	}
+	return ctx.nodeShutdown.ReconcileShutdowns(ctx.parentCtx, leavingNodes)
+}
+
+// nodeMayHaveData returns true if the data of the given leaving node may not be fully migrated to other nodes yet.
+func nodeMayHaveData(ctx downscaleContext, node string) (bool, error) {
+	if ctx.nodeShutdown == nil {
+		return migration.NodeMayHaveShard(ctx.parentCtx, ctx.es, ctx.shardLister, node)
+	}
+	status, err := ctx.nodeShutdown.ShutdownStatus(ctx.parentCtx, node)
+	if errors.Is(err, shutdown.ErrNodeNotFound) {
+		// the node cannot be prepared for its removal, check the location of the shards instead
+		return migration.NodeMayHaveShard(ctx.parentCtx, ctx.es, ctx.shardLister, node)
+	}
+	if err != nil {
+		return true, err
+	}
+	if status.Status == esclient.ShutdownStalled {
+		log.Info("Data migration stalled",
+			"namespace", ctx.es.Namespace, "es_name", ctx.es.Name, "node", node, "explanation", status.Explanation)
+	}
+	return status.Status != esclient.ShutdownComplete, nil
+}
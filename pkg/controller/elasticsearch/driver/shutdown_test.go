@@ -0,0 +1,139 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package driver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/migration"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/shutdown"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/sset"
+	ulog "github.com/elastic/cloud-on-k8s/pkg/utils/log"
+)
+
+var testNodes = esclient.Nodes{Nodes: map[string]esclient.Node{
+	"id-0": {Name: "es-default-0"},
+	"id-1": {Name: "es-default-1"},
+}}
+
+func shutdownTestPod(name string) corev1.Pod {
+	return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name}}
+}
+
+func Test_rollingUpgradeCtx_prepareClusterForNodeRestart(t *testing.T) {
+	es := esv1.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "es"},
+		Spec:       esv1.ElasticsearchSpec{Version: "7.15.2"},
+	}
+	tests := []struct {
+		name                    string
+		esClient                *fakeESClient
+		withNodeShutdown        bool
+		podsToRestart           []corev1.Pod
+		wantPods                []string
+		wantPutShutdown         []string
+		wantDisabledAllocations bool
+	}{
+		{
+			name:                    "node shutdown API not supported: disable shards allocation",
+			esClient:                &fakeESClient{},
+			podsToRestart:           []corev1.Pod{shutdownTestPod("es-default-0"), shutdownTestPod("es-default-1")},
+			wantPods:                []string{"es-default-0", "es-default-1"},
+			wantDisabledAllocations: true,
+		},
+		{
+			name:             "restart requested: wait for the shutdown to be complete",
+			esClient:         &fakeESClient{nodes: testNodes},
+			withNodeShutdown: true,
+			podsToRestart:    []corev1.Pod{shutdownTestPod("es-default-0"), shutdownTestPod("es-default-1")},
+			wantPods:         nil,
+			wantPutShutdown:  []string{"id-0", "id-1"},
+		},
+		{
+			name: "some nodes ready for restart, nodes not in the cluster can be restarted right away",
+			esClient: &fakeESClient{
+				nodes: testNodes,
+				shutdowns: esclient.ShutdownResponse{Nodes: []esclient.NodeShutdown{
+					{NodeID: "id-0", Type: "RESTART", Reason: restartShutdownReason, Status: esclient.ShutdownComplete},
+					{NodeID: "id-1", Type: "RESTART", Reason: restartShutdownReason, Status: esclient.ShutdownInProgress},
+				}},
+			},
+			withNodeShutdown: true,
+			podsToRestart:    []corev1.Pod{shutdownTestPod("es-default-0"), shutdownTestPod("es-default-1"), shutdownTestPod("es-default-2")},
+			wantPods:         []string{"es-default-0", "es-default-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := rollingUpgradeCtx{
+				parentCtx: context.Background(),
+				ES:        es,
+				esClient:  tt.esClient,
+				esState:   NewMemoizingESState(context.Background(), tt.esClient),
+			}
+			if tt.withNodeShutdown {
+				ctx.nodeShutdown = shutdown.NewNodeShutdown(tt.esClient, esclient.Restart, restartShutdownReason, ulog.Log)
+			}
+			got, err := ctx.prepareClusterForNodeRestart(tt.podsToRestart)
+			require.NoError(t, err)
+			var gotNames []string
+			for _, p := range got {
+				gotNames = append(gotNames, p.Name)
+			}
+			require.Equal(t, tt.wantPods, gotNames)
+			require.ElementsMatch(t, tt.wantPutShutdown, tt.esClient.PutShutdownCalledWith)
+			require.Equal(t, tt.wantDisabledAllocations, tt.esClient.DisableReplicaShardsAllocationCalled)
+			// the cluster is flushed only if some nodes are restarted
+			require.Equal(t, len(tt.wantPods) > 0, tt.esClient.SyncedFlushCalled)
+		})
+	}
+}
+
+func Test_nodeMayHaveData(t *testing.T) {
+	esClient := &fakeESClient{
+		nodes: testNodes,
+		shutdowns: esclient.ShutdownResponse{Nodes: []esclient.NodeShutdown{
+			{NodeID: "id-0", Type: "REMOVE", Reason: removeShutdownReason, Status: esclient.ShutdownComplete},
+			{NodeID: "id-1", Type: "REMOVE", Reason: removeShutdownReason, Status: esclient.ShutdownStalled},
+		}},
+	}
+	ctx := downscaleContext{
+		parentCtx:    context.Background(),
+		nodeShutdown: shutdown.NewNodeShutdown(esClient, esclient.Remove, removeShutdownReason, ulog.Log),
+		shardLister:  migration.NewFakeShardLister(esclient.Shards{{Index: "index-1", Shard: "0", NodeName: "es-default-1"}}),
+	}
+	for node, want := range map[string]bool{
+		"es-default-0": false,
+		"es-default-1": true,
+		// not in the cluster, does not hold any shard
+		"es-default-2": false,
+	} {
+		got, err := nodeMayHaveData(ctx, node)
+		require.NoError(t, err)
+		require.Equal(t, want, got, node)
+	}
+}
+
+func Test_clearNodeRestarts(t *testing.T) {
+	esClient := &fakeESClient{
+		nodes: testNodes,
+		shutdowns: esclient.ShutdownResponse{Nodes: []esclient.NodeShutdown{
+			{NodeID: "id-0", Type: "RESTART", Reason: restartShutdownReason, Status: esclient.ShutdownComplete},
+			{NodeID: "id-1", Type: "RESTART", Reason: restartShutdownReason, Status: esclient.ShutdownComplete},
+		}},
+	}
+	nodeShutdown := shutdown.NewNodeShutdown(esClient, esclient.Restart, restartShutdownReason, ulog.Log)
+	statefulSets := sset.StatefulSetList{sset.TestSset{Namespace: "ns", Name: "es-default", Replicas: 3}.Build()}
+	// es-default-1 is not upgraded yet, es-default-2 is not back into the cluster yet
+	require.NoError(t, clearNodeRestarts(context.Background(), nodeShutdown, statefulSets, []corev1.Pod{shutdownTestPod("es-default-1")}))
+	require.Equal(t, []string{"id-0"}, esClient.DeleteShutdownCalledWith)
+}
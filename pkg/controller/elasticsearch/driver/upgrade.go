@@ -6,6 +6,7 @@ package driver
 
 import (
 	"context"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -17,6 +18,7 @@ import (
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
 	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/reconcile"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/shutdown"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/sset"
 	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
 )
@@ -25,6 +27,7 @@ func (d *defaultDriver) handleRollingUpgrades(
 	ctx context.Context,
 	esClient esclient.Client,
 	esState ESState,
+	nodeShutdown *shutdown.NodeShutdown,
 	expectedMaster []string,
 ) *reconciler.Results {
 	results := &reconciler.Results{}
@@ -49,6 +52,12 @@ func (d *defaultDriver) handleRollingUpgrades(
 	if err != nil {
 		return results.WithError(err)
 	}
+	reportPendingPods(k8s.ExtractNamespacedName(&d.ES), len(podsToUpgrade))
+	if len(podsToUpgrade) == 0 {
+		// no predicate can block the upgrade anymore
+		reportBlockedPods(k8s.ExtractNamespacedName(&d.ES), nil)
+		d.ReconcileState.UpdateUpgradeBlocked(nil)
+	}
 	// Get the healthy Pods (from a K8S point of view + in the ES cluster)
 	healthyPods, err := healthyPods(d.Client, statefulSets, esState)
 	if err != nil {
@@ -65,6 +74,14 @@ func (d *defaultDriver) handleRollingUpgrades(
 		return results.WithError(err)
 	}
 	numberOfPods := len(currentPods)
+
+	// Report the NodeSets whose changes are deferred until their next maintenance window.
+	deferred, err := deferredChanges(d.ES, podsToUpgrade, healthyPods, time.Now())
+	if err != nil {
+		return results.WithError(err)
+	}
+	d.ReconcileState.UpdateDeferredChanges(deferred)
+
 	// Maybe upgrade some of the nodes.
 	deletedPods, err := newRollingUpgrade(
 		ctx,
@@ -72,6 +89,7 @@ func (d *defaultDriver) handleRollingUpgrades(
 		statefulSets,
 		esClient,
 		esState,
+		nodeShutdown,
 		expectedMaster,
 		actualMasters,
 		podsToUpgrade,
@@ -90,6 +108,13 @@ func (d *defaultDriver) handleRollingUpgrades(
 		results.WithResult(defaultRequeue)
 	}
 
+	// Maybe delete the restart shutdown records of the upgraded nodes which are back into the cluster.
+	if nodeShutdown != nil {
+		if err := clearNodeRestarts(ctx, nodeShutdown, statefulSets, podsToUpgrade); err != nil {
+			return results.WithError(err)
+		}
+	}
+
 	// Maybe re-enable shards allocation if upgraded nodes are back into the cluster.
 	res := d.MaybeEnableShardsAllocation(ctx, esClient, esState)
 	results.WithResults(res)
@@ -105,6 +130,7 @@ type rollingUpgradeCtx struct {
 	esClient        esclient.Client
 	shardLister     esclient.ShardLister
 	esState         ESState
+	nodeShutdown    *shutdown.NodeShutdown
 	expectations    *expectations.Expectations
 	reconcileState  *reconcile.State
 	expectedMasters []string
@@ -120,6 +146,7 @@ func newRollingUpgrade(
 	statefulSets sset.StatefulSetList,
 	esClient esclient.Client,
 	esState ESState,
+	nodeShutdown *shutdown.NodeShutdown,
 	expectedMaster []string,
 	actualMasters []corev1.Pod,
 	podsToUpgrade []corev1.Pod,
@@ -134,6 +161,7 @@ func newRollingUpgrade(
 		esClient:        esClient,
 		shardLister:     esClient,
 		esState:         esState,
+		nodeShutdown:    nodeShutdown,
 		expectations:    d.Expectations,
 		reconcileState:  d.ReconcileState,
 		expectedMasters: expectedMaster,
@@ -296,24 +324,36 @@ func (d *defaultDriver) MaybeEnableShardsAllocation(
 	return results
 }
 
-func (ctx *rollingUpgradeCtx) prepareClusterForNodeRestart(esClient esclient.Client, esState ESState) error {
-	// Disable shard allocations to avoid shards moving around while the node is temporarily down
-	shardsAllocationEnabled, err := esState.ShardAllocationsEnabled()
-	if err != nil {
-		return err
-	}
-	if shardsAllocationEnabled {
-		log.Info("Disabling shards allocation", "es_name", ctx.ES.Name, "namespace", ctx.ES.Namespace)
-		if err := esClient.DisableReplicaShardsAllocation(ctx.parentCtx); err != nil {
-			return err
+// prepareClusterForNodeRestart prepares the cluster for the restart of the given Pods, and returns the Pods which are
+// ready to be restarted.
+func (ctx *rollingUpgradeCtx) prepareClusterForNodeRestart(podsToRestart []corev1.Pod) ([]corev1.Pod, error) {
+	if ctx.nodeShutdown != nil {
+		// Let Elasticsearch prepare the nodes for the restart, the allocation of their replicas is delayed while they
+		// are down.
+		ready, err := ctx.requestNodeRestarts(podsToRestart)
+		if err != nil || len(ready) == 0 {
+			return nil, err
+		}
+		podsToRestart = ready
+	} else {
+		// Disable shard allocations to avoid shards moving around while the node is temporarily down
+		shardsAllocationEnabled, err := ctx.esState.ShardAllocationsEnabled()
+		if err != nil {
+			return nil, err
+		}
+		if shardsAllocationEnabled {
+			log.Info("Disabling shards allocation", "es_name", ctx.ES.Name, "namespace", ctx.ES.Namespace)
+			if err := ctx.esClient.DisableReplicaShardsAllocation(ctx.parentCtx); err != nil {
+				return nil, err
+			}
 		}
 	}
 
 	// Request a flush to optimize indices recovery when the node restarts.
-	if err := doFlush(ctx.parentCtx, ctx.ES, esClient); err != nil {
-		return err
+	if err := doFlush(ctx.parentCtx, ctx.ES, ctx.esClient); err != nil {
+		return nil, err
 	}
 
 	// TODO: halt ML jobs on that node
-	return nil
+	return podsToRestart, nil
 }
@@ -34,25 +34,32 @@ func (ctx *rollingUpgradeCtx) Delete() ([]corev1.Pod, error) {
 	sortCandidates(candidates)
 
 	// Step 2: Apply predicates
+	podZones, err := zonesOfPods(ctx.client, ctx.statefulSets)
+	if err != nil {
+		return nil, err
+	}
 	predicateContext := NewPredicateContext(
 		ctx.parentCtx,
 		ctx.ES,
 		ctx.esState,
 		ctx.shardLister,
 		ctx.healthyPods,
+		ctx.statefulSets,
 		ctx.podsToUpgrade,
 		ctx.expectedMasters,
 		ctx.actualMasters,
 		ctx.numberOfPods,
+		podZones,
 	)
 	log.V(1).Info("Applying predicates",
 		"maxUnavailableReached", maxUnavailableReached,
 		"allowedDeletions", allowedDeletions,
 	)
-	podsToDelete, err := applyPredicates(predicateContext, candidates, maxUnavailableReached, allowedDeletions)
+	podsToDelete, podsByPredicates, err := applyPredicates(predicateContext, candidates, maxUnavailableReached, allowedDeletions)
 	if err != nil {
 		return podsToDelete, err
 	}
+	ctx.reconcileState.UpdateUpgradeBlocked(podsByPredicates)
 
 	if len(podsToDelete) == 0 {
 		log.V(1).Info(
@@ -63,8 +70,9 @@ func (ctx *rollingUpgradeCtx) Delete() ([]corev1.Pod, error) {
 		return podsToDelete, nil
 	}
 
-	if err := ctx.prepareClusterForNodeRestart(ctx.esClient, ctx.esState); err != nil {
-		return podsToDelete, err
+	podsToDelete, err = ctx.prepareClusterForNodeRestart(podsToDelete)
+	if err != nil || len(podsToDelete) == 0 {
+		return nil, err
 	}
 	// TODO: If master is changed into a data node (or the opposite) it must be excluded or we should update m_m_n
 	deletedPods := []corev1.Pod{}
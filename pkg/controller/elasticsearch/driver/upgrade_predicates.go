@@ -6,10 +6,13 @@ package driver
 
 import (
 	"context"
+	"time"
 
 	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/label"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/sset"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
 	"github.com/elastic/cloud-on-k8s/pkg/utils/stringsutil"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -19,12 +22,16 @@ type PredicateContext struct {
 	masterNodesNames       []string
 	actualMasters          []corev1.Pod
 	healthyPods            map[string]corev1.Pod
+	statefulSets           sset.StatefulSetList
 	toUpdate               []corev1.Pod
 	esState                ESState
 	shardLister            client.ShardLister
 	masterUpdateInProgress bool
 	ctx                    context.Context
 	numberOfPods           int
+	now                    time.Time
+	// podZones are the zones of the Kubernetes nodes the Pods are scheduled on, indexed by Pod name
+	podZones map[string]string
 }
 
 // Predicate is a function that indicates if a Pod can be deleted (or not).
@@ -57,32 +64,44 @@ func NewPredicateContext(
 	state ESState,
 	shardLister client.ShardLister,
 	healthyPods map[string]corev1.Pod,
+	statefulSets sset.StatefulSetList,
 	podsToUpgrade []corev1.Pod,
 	masterNodesNames []string,
 	actualMasters []corev1.Pod,
 	numberOfPods int,
+	podZones map[string]string,
 ) PredicateContext {
 	return PredicateContext{
 		es:               es,
 		masterNodesNames: masterNodesNames,
 		actualMasters:    actualMasters,
 		healthyPods:      healthyPods,
+		statefulSets:     statefulSets,
 		toUpdate:         podsToUpgrade,
 		esState:          state,
 		shardLister:      shardLister,
 		ctx:              ctx,
 		numberOfPods:     numberOfPods,
+		now:              time.Now(),
+		podZones:         podZones,
 	}
 }
 
-func applyPredicates(ctx PredicateContext, candidates []corev1.Pod, maxUnavailableReached bool, allowedDeletions int) (deletedPods []corev1.Pod, err error) {
+// applyPredicates returns the candidates which can be deleted, and the names of the Pods which cannot be restarted
+// indexed by the name of the predicate preventing their restart.
+func applyPredicates(
+	ctx PredicateContext,
+	candidates []corev1.Pod,
+	maxUnavailableReached bool,
+	allowedDeletions int,
+) (deletedPods []corev1.Pod, podsByPredicates map[string][]string, err error) {
 	var failedPredicates failedPredicates
 
 Loop:
 	for _, candidate := range candidates {
 		switch predicateErr, err := runPredicates(ctx, candidate, deletedPods, maxUnavailableReached); {
 		case err != nil:
-			return deletedPods, err
+			return deletedPods, nil, err
 		case predicateErr != nil:
 			// A predicate has failed on this Pod
 			failedPredicates = append(failedPredicates, *predicateErr)
@@ -105,14 +124,16 @@ Loop:
 
 	// If some predicates have failed print a summary of the failures to help
 	// the user to understand why.
+	podsByPredicates = groupByPredicates(failedPredicates)
+	reportBlockedPods(k8s.ExtractNamespacedName(&ctx.es), podsByPredicates)
 	if len(failedPredicates) > 0 {
 		log.Info(
 			"Cannot restart some nodes for upgrade at this time",
 			"namespace", ctx.es.Namespace,
 			"es_name", ctx.es.Name,
-			"failed_predicates", groupByPredicates(failedPredicates))
+			"failed_predicates", podsByPredicates)
 	}
-	return deletedPods, nil
+	return deletedPods, podsByPredicates, nil
 }
 
 var predicates = [...]Predicate{
@@ -134,6 +155,102 @@ var predicates = [...]Predicate{
 			return true, nil
 		},
 	},
+	{
+		// If MaxUnavailable of the NodeSet of the candidate is reached, only allow unhealthy Pods to be deleted.
+		name: "do_not_restart_healthy_node_if_NodeSet_MaxUnavailable_reached",
+		fn: func(
+			context PredicateContext,
+			candidate corev1.Pod,
+			deletedPods []corev1.Pod,
+			maxUnavailableReached bool,
+		) (b bool, e error) {
+			if _, healthy := context.healthyPods[candidate.Name]; !healthy {
+				return true, nil
+			}
+			nodeSet, exists := nodeSetOf(context.es, candidate)
+			if !exists {
+				return true, nil
+			}
+			maxUnavailable := nodeSet.UpdateStrategy.GetMaxUnavailable()
+			if maxUnavailable == nil {
+				return true, nil
+			}
+			statefulSet, exists := context.statefulSets.GetByName(candidate.Labels[label.StatefulSetNameLabelName])
+			if !exists {
+				return true, nil
+			}
+			// Pods deleted during this reconciliation have already been removed from the healthy Pods
+			unavailable := int32(0)
+			for _, podName := range sset.PodNames(statefulSet) {
+				if _, healthy := context.healthyPods[podName]; !healthy {
+					unavailable++
+				}
+			}
+			return unavailable < *maxUnavailable, nil
+		},
+	},
+	{
+		// With shard allocation awareness across availability zones, the copies of a shard are spread over several
+		// zones. Only restart healthy nodes in the zone of the nodes which are already unavailable, if any, so that
+		// several nodes can be restarted concurrently without making all the copies of a shard unavailable.
+		name: "only_restart_healthy_nodes_in_a_single_zone",
+		fn: func(
+			context PredicateContext,
+			candidate corev1.Pod,
+			deletedPods []corev1.Pod,
+			maxUnavailableReached bool,
+		) (b bool, e error) {
+			if _, healthy := context.healthyPods[candidate.Name]; !healthy {
+				return true, nil
+			}
+			statefulSet, exists := context.statefulSets.GetByName(candidate.Labels[label.StatefulSetNameLabelName])
+			if !exists {
+				return true, nil
+			}
+			zone, err := zoneOfPod(context, statefulSet, candidate.Name)
+			if err != nil || zone == "" || zone == unknownZone {
+				return err == nil, err
+			}
+			zones, err := unavailableZones(context)
+			if err != nil {
+				return false, err
+			}
+			for unavailableZone := range zones {
+				if unavailableZone != zone {
+					log.V(1).Info(
+						"Cannot restart a node while nodes in another zone are unavailable",
+						"namespace", candidate.Namespace,
+						"candidate", candidate.Name,
+						"candidate_zone", zone,
+						"unavailable_zone", unavailableZone,
+					)
+					return false, nil
+				}
+			}
+			return true, nil
+		},
+	},
+	{
+		// Healthy Pods are only restarted during the maintenance window of their NodeSet, if any.
+		// Unhealthy Pods can be restarted at any time to give them a chance to recover.
+		name: "only_restart_healthy_node_during_maintenance_window",
+		fn: func(
+			context PredicateContext,
+			candidate corev1.Pod,
+			deletedPods []corev1.Pod,
+			maxUnavailableReached bool,
+		) (b bool, e error) {
+			if _, healthy := context.healthyPods[candidate.Name]; !healthy {
+				return true, nil
+			}
+			nodeSet, exists := nodeSetOf(context.es, candidate)
+			if !exists {
+				return true, nil
+			}
+			open, _, err := maintenanceWindowOpen(context.es, nodeSet, context.now)
+			return open, err
+		},
+	},
 	{
 		name: "skip_already_terminating_pods",
 		fn: func(
@@ -821,6 +821,7 @@ func TestUpgradePodsDeletion_Delete(t *testing.T) {
 			podsToUpgrade:   tt.fields.upgradeTestPods.toUpgrade(),
 			healthyPods:     tt.fields.upgradeTestPods.toHealthyPods(),
 			numberOfPods:    tt.fields.numberOfPods,
+			reconcileState:  reconcile.NewState(esv1.Elasticsearch{}),
 		}
 
 		deleted, err := ctx.Delete()
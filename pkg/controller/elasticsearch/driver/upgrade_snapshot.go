@@ -0,0 +1,147 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package driver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/events"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/label"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/sset"
+)
+
+// upgradeSnapshotName returns the name of the snapshot taken before upgrading the given cluster to the version of its
+// specification. Snapshot names must be lowercase.
+func upgradeSnapshotName(es esv1.Elasticsearch) string {
+	return strings.ToLower(fmt.Sprintf("%s-%s-upgrade-to-%s", es.Namespace, es.Name, es.Spec.Version))
+}
+
+// isVersionUpgrade returns true if at least one of the given Pods runs a different version than the version of the
+// specification.
+func isVersionUpgrade(es esv1.Elasticsearch, pods []corev1.Pod) (bool, error) {
+	expected, err := version.Parse(es.Spec.Version)
+	if err != nil {
+		return false, err
+	}
+	for _, pod := range pods {
+		actual, err := label.ExtractVersion(pod.Labels)
+		if err != nil {
+			return false, err
+		}
+		if !actual.EQ(expected) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// withHeldVersion returns a copy of the given Elasticsearch resource which declares the running version instead of the
+// version of the specification, to build the StatefulSets while the upgrade is held. A custom image is replaced by the
+// image of the existing StatefulSets.
+func withHeldVersion(es esv1.Elasticsearch, running version.Version, statefulSets sset.StatefulSetList) esv1.Elasticsearch {
+	held := *es.DeepCopy()
+	held.Spec.Version = running.String()
+	if held.Spec.Image != "" {
+		held.Spec.Image = runningImage(statefulSets)
+	}
+	return held
+}
+
+// runningImage returns the image of the Elasticsearch container of the given StatefulSets, or an empty string if there
+// is none.
+func runningImage(statefulSets sset.StatefulSetList) string {
+	for _, statefulSet := range statefulSets {
+		for _, container := range statefulSet.Spec.Template.Spec.Containers {
+			if container.Name == esv1.ElasticsearchContainerName {
+				return container.Image
+			}
+		}
+	}
+	return ""
+}
+
+// snapshotBeforeUpgrade ensures that a snapshot of the cluster has succeeded before any node is upgraded to the version
+// of the specification, if the specification requires it and if the given Pods run a different version. The snapshot
+// is taken during the first call, its progress is checked during the next ones. It returns true if the StatefulSets can
+// be updated to the version of the specification, which must otherwise be held at the running version so that no Pod,
+// including the ones created by an upscale, starts with the new version before the snapshot succeeds.
+// A failed snapshot blocks the upgrade: it is attempted again only once it has been deleted from the repository, or if
+// the repository changes.
+func (d *defaultDriver) snapshotBeforeUpgrade(
+	ctx context.Context,
+	esClient esclient.Client,
+	esReachable bool,
+	pods []corev1.Pod,
+) (bool, error) {
+	snapshotSpec := d.ES.Spec.UpdateStrategy.SnapshotBeforeUpgrade
+	if snapshotSpec == nil {
+		return true, nil
+	}
+	upgrade, err := isVersionUpgrade(d.ES, pods)
+	if err != nil || !upgrade {
+		return !upgrade, err
+	}
+
+	previous := d.ES.Status.UpgradeSnapshot
+	status := esv1.UpgradeSnapshotStatus{
+		Name:       upgradeSnapshotName(d.ES),
+		Repository: snapshotSpec.Repository,
+		Version:    d.ES.Spec.Version,
+	}
+	sameSnapshot := previous != nil && previous.Name == status.Name && previous.Repository == status.Repository
+	if sameSnapshot && previous.Phase == esv1.UpgradeSnapshotSucceededPhase {
+		return true, nil
+	}
+	if !esReachable {
+		log.Info("Elasticsearch cannot be reached to take a snapshot before upgrade, re-queuing",
+			"namespace", d.ES.Namespace, "es_name", d.ES.Name)
+		return false, nil
+	}
+
+	snapshot, err := esClient.GetSnapshot(ctx, status.Repository, status.Name)
+	switch {
+	case esclient.IsNotFound(err):
+		log.Info("Taking a snapshot before upgrade",
+			"namespace", d.ES.Namespace, "es_name", d.ES.Name, "snapshot", status.Name, "repository", status.Repository)
+		if err := esClient.CreateSnapshot(ctx, status.Repository, status.Name); err != nil {
+			status.Phase = esv1.UpgradeSnapshotFailedPhase
+			status.Message = err.Error()
+			break
+		}
+		status.Phase = esv1.UpgradeSnapshotInProgressPhase
+		d.ReconcileState.AddEvent(corev1.EventTypeNormal, events.EventReasonDelayed, fmt.Sprintf(
+			"Upgrade to version %s delayed until snapshot %s succeeds in repository %s", status.Version, status.Name, status.Repository))
+	case err != nil:
+		return false, err
+	case snapshot.State == esclient.SnapshotSuccess:
+		status.Phase = esv1.UpgradeSnapshotSucceededPhase
+	case snapshot.State == esclient.SnapshotInProgress:
+		status.Phase = esv1.UpgradeSnapshotInProgressPhase
+	default:
+		status.Phase = esv1.UpgradeSnapshotFailedPhase
+		status.Message = fmt.Sprintf("snapshot state is %s", snapshot.State)
+		if snapshot.Reason != "" {
+			status.Message = fmt.Sprintf("%s: %s", status.Message, snapshot.Reason)
+		}
+	}
+
+	if status.Phase == esv1.UpgradeSnapshotFailedPhase && !(sameSnapshot && previous.Phase == esv1.UpgradeSnapshotFailedPhase) {
+		d.ReconcileState.AddEvent(corev1.EventTypeWarning, events.EventReasonDelayed, fmt.Sprintf(
+			"Upgrade to version %s blocked, snapshot %s failed in repository %s: %s", status.Version, status.Name, status.Repository, status.Message))
+	}
+	if status.Phase == esv1.UpgradeSnapshotFailedPhase {
+		d.ReconcileState.BlockVersionUpgrade(fmt.Sprintf("Upgrade to version %s blocked by failed snapshot %s in repository %s",
+			status.Version, status.Name, status.Repository))
+	}
+	d.ReconcileState.UpdateUpgradeSnapshot(&status)
+	return status.Phase == esv1.UpgradeSnapshotSucceededPhase, nil
+}
@@ -0,0 +1,219 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package driver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/label"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/reconcile"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/sset"
+)
+
+// fakeSnapshotClient stores the state of the snapshots by name.
+type fakeSnapshotClient struct {
+	esclient.Client
+	states    map[string]string
+	createErr error
+	created   []string
+}
+
+func (f *fakeSnapshotClient) GetSnapshot(_ context.Context, _ string, name string) (esclient.Snapshot, error) {
+	state, exists := f.states[name]
+	if !exists {
+		apiErr := esclient.FakeAPIError(404)
+		return esclient.Snapshot{}, &apiErr
+	}
+	return esclient.Snapshot{Snapshot: name, State: state}, nil
+}
+
+func (f *fakeSnapshotClient) CreateSnapshot(_ context.Context, _ string, name string) error {
+	if f.createErr != nil {
+		return f.createErr
+	}
+	f.created = append(f.created, name)
+	f.states[name] = esclient.SnapshotInProgress
+	return nil
+}
+
+func podWithVersion(v string) corev1.Pod {
+	return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "es-default-0", Labels: map[string]string{label.VersionLabelName: v}}}
+}
+
+func Test_defaultDriver_snapshotBeforeUpgrade(t *testing.T) {
+	es := func(snapshotSpec *esv1.SnapshotBeforeUpgrade, status *esv1.UpgradeSnapshotStatus) esv1.Elasticsearch {
+		return esv1.Elasticsearch{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "es"},
+			Spec: esv1.ElasticsearchSpec{
+				Version:        "7.16.0",
+				UpdateStrategy: esv1.UpdateStrategy{SnapshotBeforeUpgrade: snapshotSpec},
+			},
+			Status: esv1.ElasticsearchStatus{UpgradeSnapshot: status},
+		}
+	}
+	snapshotSpec := &esv1.SnapshotBeforeUpgrade{Repository: "backups"}
+	snapshotName := "ns-es-upgrade-to-7.16.0"
+	status := func(phase esv1.UpgradeSnapshotPhase, message string) *esv1.UpgradeSnapshotStatus {
+		return &esv1.UpgradeSnapshotStatus{Name: snapshotName, Repository: "backups", Version: "7.16.0", Phase: phase, Message: message}
+	}
+
+	tests := []struct {
+		name        string
+		es          esv1.Elasticsearch
+		pod         corev1.Pod
+		unreachable bool
+		states      map[string]string
+		createErr   error
+		wantRestart bool
+		wantCreated []string
+		wantStatus  *esv1.UpgradeSnapshotStatus
+		wantEvents  int
+	}{
+		{
+			name:        "no snapshot required",
+			es:          es(nil, nil),
+			pod:         podWithVersion("7.15.0"),
+			wantRestart: true,
+		},
+		{
+			name:        "not a version upgrade",
+			es:          es(snapshotSpec, nil),
+			pod:         podWithVersion("7.16.0"),
+			wantRestart: true,
+		},
+		{
+			name:        "snapshot is taken",
+			es:          es(snapshotSpec, nil),
+			pod:         podWithVersion("7.15.0"),
+			states:      map[string]string{},
+			wantCreated: []string{snapshotName},
+			wantStatus:  status(esv1.UpgradeSnapshotInProgressPhase, ""),
+			wantEvents:  1,
+		},
+		{
+			name:       "snapshot in progress",
+			es:         es(snapshotSpec, status(esv1.UpgradeSnapshotInProgressPhase, "")),
+			pod:        podWithVersion("7.15.0"),
+			states:     map[string]string{snapshotName: esclient.SnapshotInProgress},
+			wantStatus: status(esv1.UpgradeSnapshotInProgressPhase, ""),
+		},
+		{
+			name:        "snapshot succeeded",
+			es:          es(snapshotSpec, status(esv1.UpgradeSnapshotInProgressPhase, "")),
+			pod:         podWithVersion("7.15.0"),
+			states:      map[string]string{snapshotName: esclient.SnapshotSuccess},
+			wantRestart: true,
+			wantStatus:  status(esv1.UpgradeSnapshotSucceededPhase, ""),
+		},
+		{
+			name:        "snapshot already succeeded",
+			es:          es(snapshotSpec, status(esv1.UpgradeSnapshotSucceededPhase, "")),
+			pod:         podWithVersion("7.15.0"),
+			wantRestart: true,
+			wantStatus:  status(esv1.UpgradeSnapshotSucceededPhase, ""),
+		},
+		{
+			name:        "Elasticsearch not reachable",
+			es:          es(snapshotSpec, nil),
+			pod:         podWithVersion("7.15.0"),
+			unreachable: true,
+			states:      map[string]string{},
+		},
+		{
+			name:        "snapshot already succeeded, Elasticsearch not reachable",
+			es:          es(snapshotSpec, status(esv1.UpgradeSnapshotSucceededPhase, "")),
+			pod:         podWithVersion("7.15.0"),
+			unreachable: true,
+			wantRestart: true,
+			wantStatus:  status(esv1.UpgradeSnapshotSucceededPhase, ""),
+		},
+		{
+			name:       "snapshot failed",
+			es:         es(snapshotSpec, status(esv1.UpgradeSnapshotInProgressPhase, "")),
+			pod:        podWithVersion("7.15.0"),
+			states:     map[string]string{snapshotName: "PARTIAL"},
+			wantStatus: status(esv1.UpgradeSnapshotFailedPhase, "snapshot state is PARTIAL"),
+			wantEvents: 1,
+		},
+		{
+			name:       "snapshot still failed: no new event",
+			es:         es(snapshotSpec, status(esv1.UpgradeSnapshotFailedPhase, "snapshot state is FAILED")),
+			pod:        podWithVersion("7.15.0"),
+			states:     map[string]string{snapshotName: "FAILED"},
+			wantStatus: status(esv1.UpgradeSnapshotFailedPhase, "snapshot state is FAILED"),
+		},
+		{
+			name:       "snapshot cannot be created",
+			es:         es(snapshotSpec, nil),
+			pod:        podWithVersion("7.15.0"),
+			states:     map[string]string{},
+			createErr:  errors.New("repository_missing_exception"),
+			wantStatus: status(esv1.UpgradeSnapshotFailedPhase, "repository_missing_exception"),
+			wantEvents: 1,
+		},
+		{
+			name:        "previous snapshot for another version is ignored",
+			es:          es(snapshotSpec, &esv1.UpgradeSnapshotStatus{Name: "ns-es-upgrade-to-7.15.0", Repository: "backups", Version: "7.15.0", Phase: esv1.UpgradeSnapshotSucceededPhase}),
+			pod:         podWithVersion("7.15.0"),
+			states:      map[string]string{"ns-es-upgrade-to-7.15.0": esclient.SnapshotSuccess},
+			wantCreated: []string{snapshotName},
+			wantStatus:  status(esv1.UpgradeSnapshotInProgressPhase, ""),
+			wantEvents:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			esClient := &fakeSnapshotClient{states: tt.states, createErr: tt.createErr}
+			reconcileState := reconcile.NewState(tt.es)
+			d := defaultDriver{
+				DefaultDriverParameters{
+					ES:             tt.es,
+					ReconcileState: reconcileState,
+				},
+			}
+			canRestart, err := d.snapshotBeforeUpgrade(context.Background(), esClient, !tt.unreachable, []corev1.Pod{tt.pod})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantRestart, canRestart)
+			require.Equal(t, tt.wantCreated, esClient.created)
+			require.Len(t, reconcileState.Events(), tt.wantEvents)
+			_, updated := reconcileState.Apply()
+			if updated == nil {
+				// status unchanged
+				require.Equal(t, tt.wantStatus, tt.es.Status.UpgradeSnapshot)
+				return
+			}
+			require.Equal(t, tt.wantStatus, updated.Status.UpgradeSnapshot)
+		})
+	}
+}
+
+func Test_withHeldVersion(t *testing.T) {
+	statefulSet := sset.TestSset{Name: "default", Version: "7.15.0"}.Build()
+	statefulSet.Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: esv1.ElasticsearchContainerName, Image: "my-registry/elasticsearch:7.15.0"},
+	}
+	statefulSets := sset.StatefulSetList{statefulSet}
+
+	es := esv1.Elasticsearch{Spec: esv1.ElasticsearchSpec{Version: "7.16.0"}}
+	held := withHeldVersion(es, version.MustParse("7.15.0"), statefulSets)
+	require.Equal(t, "7.15.0", held.Spec.Version)
+	require.Empty(t, held.Spec.Image)
+	// the original resource is left untouched
+	require.Equal(t, "7.16.0", es.Spec.Version)
+
+	// a custom image is replaced by the running one
+	es.Spec.Image = "my-registry/elasticsearch:7.16.0"
+	held = withHeldVersion(es, version.MustParse("7.15.0"), statefulSets)
+	require.Equal(t, "my-registry/elasticsearch:7.15.0", held.Spec.Image)
+}
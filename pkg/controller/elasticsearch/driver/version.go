@@ -5,12 +5,24 @@
 package driver
 
 import (
+	"context"
+	"fmt"
+	"strings"
+
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/events"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/label"
 )
 
+// SkipDeprecationCheckAnnotation can be set to "true" on an Elasticsearch resource to upgrade it to the next major
+// version despite critical deprecation issues.
+const SkipDeprecationCheckAnnotation = "eck.k8s.elastic.co/skip-deprecation-check"
+
 func (d *defaultDriver) verifySupportsExistingPods(pods []corev1.Pod) error {
 	for _, pod := range pods {
 		v, err := label.ExtractVersion(pod.Labels)
@@ -23,3 +35,63 @@ func (d *defaultDriver) verifySupportsExistingPods(pods []corev1.Pod) error {
 	}
 	return nil
 }
+
+// verifyDeprecationsBeforeMajorUpgrade checks that the deprecations API does not report any critical issue before
+// upgrading the cluster from the given running version to the next major version. It returns true if the StatefulSets
+// can be updated to the version of the specification, which must otherwise be held at the running version.
+// The result of the check is recorded in the status for the version of the specification, so that the check is not
+// performed again once the upgrade has started.
+func (d *defaultDriver) verifyDeprecationsBeforeMajorUpgrade(
+	ctx context.Context,
+	esClient esclient.Client,
+	esReachable bool,
+	runningVersion version.Version,
+) (bool, error) {
+	if runningVersion.Major >= d.Version.Major {
+		// not a major version upgrade
+		return true, nil
+	}
+
+	previous := d.ES.Status.DeprecationCheck
+	sameVersion := previous != nil && previous.Version == d.ES.Spec.Version
+	if sameVersion && (previous.Phase == esv1.DeprecationCheckPassedPhase || previous.Phase == esv1.DeprecationCheckSkippedPhase) {
+		return true, nil
+	}
+
+	status := esv1.DeprecationCheckStatus{Version: d.ES.Spec.Version}
+	if d.ES.Annotations[SkipDeprecationCheckAnnotation] == "true" {
+		log.Info("Skipping deprecation check before major version upgrade",
+			"namespace", d.ES.Namespace, "es_name", d.ES.Name, "version", d.ES.Spec.Version)
+		status.Phase = esv1.DeprecationCheckSkippedPhase
+		d.ReconcileState.UpdateDeprecationCheck(&status)
+		return true, nil
+	}
+
+	if !esReachable {
+		log.Info("Elasticsearch cannot be reached to check deprecations before major version upgrade, re-queuing",
+			"namespace", d.ES.Namespace, "es_name", d.ES.Name)
+		return false, nil
+	}
+	deprecations, err := esClient.GetDeprecations(ctx)
+	if err != nil {
+		return false, err
+	}
+	status.CriticalIssues = deprecations.CriticalIssues()
+	if len(status.CriticalIssues) == 0 {
+		status.Phase = esv1.DeprecationCheckPassedPhase
+		d.ReconcileState.UpdateDeprecationCheck(&status)
+		return true, nil
+	}
+
+	status.Phase = esv1.DeprecationCheckBlockedPhase
+	if !sameVersion || previous.Phase != esv1.DeprecationCheckBlockedPhase {
+		d.ReconcileState.AddEvent(corev1.EventTypeWarning, events.EventReasonDelayed, fmt.Sprintf(
+			"Upgrade to version %s blocked by %d critical deprecation issue(s) listed in status.deprecationCheck, "+
+				"resolve them or set the annotation %s=true to upgrade anyway",
+			d.ES.Spec.Version, len(status.CriticalIssues), SkipDeprecationCheckAnnotation))
+	}
+	d.ReconcileState.UpdateDeprecationCheck(&status)
+	d.ReconcileState.BlockVersionUpgrade(fmt.Sprintf("Upgrade to version %s blocked by critical deprecation issues: %s",
+		d.ES.Spec.Version, strings.Join(status.CriticalIssues, ", ")))
+	return false, nil
+}
@@ -5,12 +5,19 @@
 package driver
 
 import (
+	"context"
 	"testing"
 
-	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
-	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/label"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/label"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/reconcile"
 )
 
 var (
@@ -115,3 +122,152 @@ func Test_lowestHighestSupportedVersions_VerifySupportsExistingPods(t *testing.T
 		})
 	}
 }
+
+// fakeDeprecationsClient returns the given deprecations and counts the calls to the deprecations API.
+type fakeDeprecationsClient struct {
+	esclient.Client
+	deprecations esclient.Deprecations
+	calls        int
+}
+
+func (f *fakeDeprecationsClient) GetDeprecations(_ context.Context) (esclient.Deprecations, error) {
+	f.calls++
+	return f.deprecations, nil
+}
+
+func Test_defaultDriver_verifyDeprecationsBeforeMajorUpgrade(t *testing.T) {
+	critical := esclient.Deprecations{
+		NodeSettings: []esclient.Deprecation{{Level: esclient.DeprecationLevelCritical, Message: "Setting [node.ml] is deprecated"}},
+	}
+	warningOnly := esclient.Deprecations{
+		ClusterSettings: []esclient.Deprecation{{Level: "warning", Message: "Transport SSL settings are not configured"}},
+	}
+	newES := func(annotations map[string]string, status *esv1.DeprecationCheckStatus) esv1.Elasticsearch {
+		return esv1.Elasticsearch{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "es", Annotations: annotations},
+			Spec:       esv1.ElasticsearchSpec{Version: "8.0.0"},
+			Status:     esv1.ElasticsearchStatus{DeprecationCheck: status},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		es             esv1.Elasticsearch
+		runningVersion string
+		esReachable    bool
+		deprecations   esclient.Deprecations
+		wantUpgrade    bool
+		wantCalls      int
+		wantStatus     *esv1.DeprecationCheckStatus
+		wantEvents     int
+		wantBlocked    string
+	}{
+		{
+			name:           "not a major upgrade",
+			es:             newES(nil, nil),
+			runningVersion: "8.0.0",
+			esReachable:    true,
+			deprecations:   critical,
+			wantUpgrade:    true,
+		},
+		{
+			name:           "no critical issue",
+			es:             newES(nil, nil),
+			runningVersion: "7.17.0",
+			esReachable:    true,
+			deprecations:   warningOnly,
+			wantUpgrade:    true,
+			wantCalls:      1,
+			wantStatus:     &esv1.DeprecationCheckStatus{Version: "8.0.0", Phase: esv1.DeprecationCheckPassedPhase},
+		},
+		{
+			name:           "critical issues block the upgrade",
+			es:             newES(nil, nil),
+			runningVersion: "7.17.0",
+			esReachable:    true,
+			deprecations:   critical,
+			wantCalls:      1,
+			wantStatus: &esv1.DeprecationCheckStatus{
+				Version:        "8.0.0",
+				Phase:          esv1.DeprecationCheckBlockedPhase,
+				CriticalIssues: []string{"node settings: Setting [node.ml] is deprecated"},
+			},
+			wantEvents:  1,
+			wantBlocked: "Upgrade to version 8.0.0 blocked by critical deprecation issues: node settings: Setting [node.ml] is deprecated",
+		},
+		{
+			name: "still blocked: no new event",
+			es: newES(nil, &esv1.DeprecationCheckStatus{
+				Version:        "8.0.0",
+				Phase:          esv1.DeprecationCheckBlockedPhase,
+				CriticalIssues: []string{"node settings: Setting [node.ml] is deprecated"},
+			}),
+			runningVersion: "7.17.0",
+			esReachable:    true,
+			deprecations:   critical,
+			wantCalls:      1,
+			wantStatus: &esv1.DeprecationCheckStatus{
+				Version:        "8.0.0",
+				Phase:          esv1.DeprecationCheckBlockedPhase,
+				CriticalIssues: []string{"node settings: Setting [node.ml] is deprecated"},
+			},
+			wantBlocked: "Upgrade to version 8.0.0 blocked by critical deprecation issues: node settings: Setting [node.ml] is deprecated",
+		},
+		{
+			name:           "check skipped with the annotation",
+			es:             newES(map[string]string{SkipDeprecationCheckAnnotation: "true"}, nil),
+			runningVersion: "7.17.0",
+			esReachable:    true,
+			deprecations:   critical,
+			wantUpgrade:    true,
+			wantStatus:     &esv1.DeprecationCheckStatus{Version: "8.0.0", Phase: esv1.DeprecationCheckSkippedPhase},
+		},
+		{
+			name:           "check already passed for this version: upgrade in progress",
+			es:             newES(nil, &esv1.DeprecationCheckStatus{Version: "8.0.0", Phase: esv1.DeprecationCheckPassedPhase}),
+			runningVersion: "7.17.0",
+			esReachable:    false,
+			deprecations:   critical,
+			wantUpgrade:    true,
+			wantStatus:     &esv1.DeprecationCheckStatus{Version: "8.0.0", Phase: esv1.DeprecationCheckPassedPhase},
+		},
+		{
+			name:           "Elasticsearch not reachable",
+			es:             newES(nil, nil),
+			runningVersion: "7.17.0",
+			esReachable:    false,
+			deprecations:   warningOnly,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			esClient := &fakeDeprecationsClient{deprecations: tt.deprecations}
+			d := defaultDriver{
+				DefaultDriverParameters{
+					ES:             tt.es,
+					Version:        version.MustParse(tt.es.Spec.Version),
+					ReconcileState: reconcile.NewState(tt.es),
+				},
+			}
+			canUpgrade, err := d.verifyDeprecationsBeforeMajorUpgrade(context.Background(), esClient, tt.esReachable, version.MustParse(tt.runningVersion))
+			require.NoError(t, err)
+			require.Equal(t, tt.wantUpgrade, canUpgrade)
+			require.Equal(t, tt.wantCalls, esClient.calls)
+			require.Len(t, d.ReconcileState.Events(), tt.wantEvents)
+			_, updated := d.ReconcileState.Apply()
+			if tt.wantBlocked != "" {
+				require.NotNil(t, updated)
+				condition := meta.FindStatusCondition(updated.Status.Conditions, esv1.UpgradeBlockedCondition)
+				require.NotNil(t, condition)
+				require.Equal(t, metav1.ConditionTrue, condition.Status)
+				require.Equal(t, tt.wantBlocked, condition.Message)
+			}
+			if updated == nil {
+				// status unchanged
+				require.Equal(t, tt.wantStatus, tt.es.Status.DeprecationCheck)
+				return
+			}
+			require.Equal(t, tt.wantStatus, updated.Status.DeprecationCheck)
+		})
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package driver
+
+import (
+	"context"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/sset"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/stringsutil"
+)
+
+// nodeNameAttribute is the awareness attribute set by default by the operator with the name of the Kubernetes node.
+// It is ignored when looking for the zone of a node.
+const nodeNameAttribute = "k8s_node_name"
+
+// unknownZone is the zone of the unavailable Pods of a zone-aware NodeSet whose zone cannot be found. It differs from
+// any other zone, so that no healthy node with a zone is restarted while these Pods are unavailable.
+const unknownZone = "<unknown>"
+
+// zoneLabels are the well-known labels of the Kubernetes nodes indicating their availability zone.
+var zoneLabels = []string{corev1.LabelTopologyZone, corev1.LabelFailureDomainBetaZone}
+
+// zoneOf returns the availability zone of the Pods of the given StatefulSet, as seen by the shard allocation awareness
+// of Elasticsearch: the value of the first awareness attribute, other than the Kubernetes node name, in the configuration
+// of the NodeSet. If this value is set dynamically, for example from an environment variable, the zone is read from the
+// scheduling constraints of the Pods on the zone labels of the Kubernetes nodes.
+// It also returns whether the NodeSet uses a zone awareness attribute: in that case, an empty zone means that the Pods
+// are not restricted to a single zone, and the zone of each Pod is the one of the Kubernetes node it is scheduled on.
+func zoneOf(es esv1.Elasticsearch, statefulSet appsv1.StatefulSet) (string, bool, error) {
+	nodeSet, exists := nodeSetOfStatefulSet(es, statefulSet.Name)
+	if !exists {
+		return "", false, nil
+	}
+	v, err := version.Parse(es.Spec.Version)
+	if err != nil {
+		return "", false, err
+	}
+	cfg := esv1.ElasticsearchSettings{}
+	if err := esv1.UnpackConfig(nodeSet.Config, v, &cfg); err != nil {
+		return "", false, err
+	}
+	for _, attribute := range cfg.Cluster.AwarenessAttributes() {
+		if attribute == nodeNameAttribute {
+			continue
+		}
+		if cfg.Node != nil {
+			if value := cfg.Node.Attributes[attribute]; value != "" && !strings.Contains(value, "${") {
+				return value, true, nil
+			}
+		}
+		return scheduledZone(statefulSet.Spec.Template.Spec), true, nil
+	}
+	return "", false, nil
+}
+
+// zoneOfPod returns the availability zone of the given Pod of the given StatefulSet: the zone of its NodeSet if the
+// NodeSet is restricted to a single zone, otherwise the zone of the Kubernetes node the Pod is scheduled on.
+// It returns unknownZone if the NodeSet uses a zone awareness attribute but the zone of the Pod cannot be found, and an
+// empty string if the NodeSet does not use zone awareness.
+func zoneOfPod(context PredicateContext, statefulSet appsv1.StatefulSet, podName string) (string, error) {
+	zone, aware, err := zoneOf(context.es, statefulSet)
+	if err != nil || !aware || zone != "" {
+		return zone, err
+	}
+	if zone, exists := context.podZones[podName]; exists {
+		return zone, nil
+	}
+	return unknownZone, nil
+}
+
+// zonesOfPods returns the zones of the Kubernetes nodes the Pods of the given StatefulSets are scheduled on, indexed
+// by Pod name. Pods which are not scheduled yet, or whose Kubernetes node cannot be read or has no zone label, are
+// ignored.
+func zonesOfPods(c k8s.Client, statefulSets sset.StatefulSetList) (map[string]string, error) {
+	pods, err := statefulSets.GetActualPods(c)
+	if err != nil {
+		return nil, err
+	}
+	podZones := make(map[string]string, len(pods))
+	nodeZones := make(map[string]string)
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		zone, cached := nodeZones[pod.Spec.NodeName]
+		if !cached {
+			var node corev1.Node
+			err := c.Get(context.Background(), types.NamespacedName{Name: pod.Spec.NodeName}, &node)
+			switch {
+			case apierrors.IsNotFound(err) || apierrors.IsForbidden(err):
+				// the operator may not be allowed to read the Kubernetes nodes
+				log.V(1).Info("Cannot read the zone of a Kubernetes node", "node", pod.Spec.NodeName, "error", err.Error())
+			case err != nil:
+				return nil, err
+			}
+			for _, zoneLabel := range zoneLabels {
+				if zone = node.Labels[zoneLabel]; zone != "" {
+					break
+				}
+			}
+			nodeZones[pod.Spec.NodeName] = zone
+		}
+		if zone != "" {
+			podZones[pod.Name] = zone
+		}
+	}
+	return podZones, nil
+}
+
+// scheduledZone returns the only zone the given Pods can be scheduled in, according to their node selector or to
+// their required node affinity, or an empty string if there is none.
+func scheduledZone(spec corev1.PodSpec) string {
+	for _, zoneLabel := range zoneLabels {
+		if zone, exists := spec.NodeSelector[zoneLabel]; exists {
+			return zone
+		}
+	}
+	if spec.Affinity == nil || spec.Affinity.NodeAffinity == nil ||
+		spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return ""
+	}
+	// node selector terms are ORed: all of them must be restricted to the same zone
+	var zone string
+	for _, term := range spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		termZone := ""
+		for _, expression := range term.MatchExpressions {
+			if stringsutil.StringInSlice(expression.Key, zoneLabels) &&
+				expression.Operator == corev1.NodeSelectorOpIn && len(expression.Values) == 1 {
+				termZone = expression.Values[0]
+				break
+			}
+		}
+		if termZone == "" || (zone != "" && zone != termZone) {
+			return ""
+		}
+		zone = termZone
+	}
+	return zone
+}
+
+// unavailableZones returns the zones of the Pods which are not healthy, including the ones deleted during the current
+// reconciliation.
+func unavailableZones(context PredicateContext) (map[string]struct{}, error) {
+	zones := make(map[string]struct{})
+	for _, statefulSet := range context.statefulSets {
+		for _, podName := range sset.PodNames(statefulSet) {
+			if _, healthy := context.healthyPods[podName]; healthy {
+				continue
+			}
+			zone, err := zoneOfPod(context, statefulSet, podName)
+			if err != nil {
+				return nil, err
+			}
+			if zone != "" {
+				zones[zone] = struct{}{}
+			}
+		}
+	}
+	return zones, nil
+}
@@ -0,0 +1,251 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package driver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/sset"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+func zoneAffinity(zones ...string) *corev1.Affinity {
+	return &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+				MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key:      corev1.LabelTopologyZone,
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   zones,
+				}},
+			}},
+		},
+	}}
+}
+
+func Test_scheduledZone(t *testing.T) {
+	tests := []struct {
+		name string
+		spec corev1.PodSpec
+		want string
+	}{
+		{
+			name: "no scheduling constraint",
+			spec: corev1.PodSpec{},
+			want: "",
+		},
+		{
+			name: "node selector",
+			spec: corev1.PodSpec{NodeSelector: map[string]string{corev1.LabelFailureDomainBetaZone: "zone-a"}},
+			want: "zone-a",
+		},
+		{
+			name: "required node affinity",
+			spec: corev1.PodSpec{Affinity: zoneAffinity("zone-b")},
+			want: "zone-b",
+		},
+		{
+			name: "several zones allowed",
+			spec: corev1.PodSpec{Affinity: zoneAffinity("zone-a", "zone-b")},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, scheduledZone(tt.spec))
+		})
+	}
+}
+
+func zoneTestStatefulSet(nodeSet string, spec corev1.PodSpec) appsv1.StatefulSet {
+	statefulSet := sset.TestSset{Namespace: "ns", Name: esv1.StatefulSet("es", nodeSet), Replicas: 2}.Build()
+	statefulSet.Spec.Template.Spec = spec
+	return statefulSet
+}
+
+func zoneTestES(nodeSets ...esv1.NodeSet) esv1.Elasticsearch {
+	return esv1.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "es"},
+		Spec:       esv1.ElasticsearchSpec{Version: "7.15.2", NodeSets: nodeSets},
+	}
+}
+
+func zoneNodeSet(name string, config map[string]interface{}) esv1.NodeSet {
+	return esv1.NodeSet{Name: name, Count: 2, Config: &commonv1.Config{Data: config}}
+}
+
+func Test_zoneOf(t *testing.T) {
+	tests := []struct {
+		name      string
+		nodeSet   esv1.NodeSet
+		spec      corev1.PodSpec
+		want      string
+		wantAware bool
+	}{
+		{
+			name:    "no awareness attribute",
+			nodeSet: esv1.NodeSet{Name: "default", Count: 2},
+			spec:    corev1.PodSpec{Affinity: zoneAffinity("zone-a")},
+			want:    "",
+		},
+		{
+			name: "only the Kubernetes node name",
+			nodeSet: zoneNodeSet("default", map[string]interface{}{
+				"cluster.routing.allocation.awareness.attributes": "k8s_node_name",
+			}),
+			spec: corev1.PodSpec{Affinity: zoneAffinity("zone-a")},
+			want: "",
+		},
+		{
+			name: "static attribute",
+			nodeSet: zoneNodeSet("default", map[string]interface{}{
+				"node.attr.zone": "zone-a",
+				"cluster.routing.allocation.awareness.attributes": "k8s_node_name,zone",
+			}),
+			want:      "zone-a",
+			wantAware: true,
+		},
+		{
+			name: "dynamic attribute",
+			nodeSet: zoneNodeSet("default", map[string]interface{}{
+				"node.attr.zone": "${ZONE}",
+				"cluster.routing.allocation.awareness.attributes": []interface{}{"zone"},
+			}),
+			spec:      corev1.PodSpec{Affinity: zoneAffinity("zone-b")},
+			want:      "zone-b",
+			wantAware: true,
+		},
+		{
+			name: "dynamic attribute without scheduling constraint",
+			nodeSet: zoneNodeSet("default", map[string]interface{}{
+				"node.attr.zone": "${ZONE}",
+				"cluster.routing.allocation.awareness.attributes": "zone",
+			}),
+			want:      "",
+			wantAware: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, aware, err := zoneOf(zoneTestES(tt.nodeSet), zoneTestStatefulSet(tt.nodeSet.Name, tt.spec))
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantAware, aware)
+		})
+	}
+}
+
+func Test_predicate_onlyRestartHealthyNodesInASingleZone(t *testing.T) {
+	predicate := predicateNamed(t, "only_restart_healthy_nodes_in_a_single_zone")
+	zoneConfig := func(zone string) map[string]interface{} {
+		return map[string]interface{}{
+			"node.attr.zone": zone,
+			"cluster.routing.allocation.awareness.attributes": "k8s_node_name,zone",
+		}
+	}
+	es := zoneTestES(
+		zoneNodeSet("zone-a", zoneConfig("zone-a")),
+		zoneNodeSet("zone-b", zoneConfig("zone-b")),
+		esv1.NodeSet{Name: "masters", Count: 2},
+	)
+	statefulSets := sset.StatefulSetList{
+		zoneTestStatefulSet("zone-a", corev1.PodSpec{}),
+		zoneTestStatefulSet("zone-b", corev1.PodSpec{}),
+		zoneTestStatefulSet("masters", corev1.PodSpec{}),
+	}
+	a0, a1 := maintenanceTestPod("zone-a", 0), maintenanceTestPod("zone-a", 1)
+	b0, b1 := maintenanceTestPod("zone-b", 0), maintenanceTestPod("zone-b", 1)
+	m0, m1 := maintenanceTestPod("masters", 0), maintenanceTestPod("masters", 1)
+	tests := []struct {
+		name        string
+		candidate   corev1.Pod
+		healthyPods map[string]corev1.Pod
+		want        bool
+	}{
+		{name: "all nodes available", candidate: a0, healthyPods: healthy(a0, a1, b0, b1, m0, m1), want: true},
+		{name: "node unavailable in the same zone", candidate: a0, healthyPods: healthy(a0, b0, b1, m0, m1), want: true},
+		{name: "node unavailable in another zone", candidate: a0, healthyPods: healthy(a0, a1, b1, m0, m1), want: false},
+		{name: "node without zone unavailable", candidate: a0, healthyPods: healthy(a0, a1, b0, b1, m0), want: true},
+		{name: "candidate without zone", candidate: m0, healthyPods: healthy(a0, a1, b1, m0, m1), want: true},
+		{name: "unhealthy candidate", candidate: a0, healthyPods: healthy(a1, b1, m0, m1), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := PredicateContext{es: es, healthyPods: tt.healthyPods, statefulSets: statefulSets}
+			got, err := predicate.fn(ctx, tt.candidate, nil, false)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func zoneTestNode(name, zone string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{corev1.LabelTopologyZone: zone}}}
+}
+
+func zoneTestScheduledPod(nodeSet string, ordinal int32, nodeName string) *corev1.Pod {
+	pod := maintenanceTestPod(nodeSet, ordinal)
+	pod.Spec.NodeName = nodeName
+	return &pod
+}
+
+func Test_zonesOfPods(t *testing.T) {
+	statefulSets := sset.StatefulSetList{zoneTestStatefulSet("data", corev1.PodSpec{})}
+	c := k8s.NewFakeClient(
+		zoneTestNode("node-a", "zone-a"),
+		zoneTestNode("node-b", "zone-b"),
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-without-zone"}},
+		zoneTestScheduledPod("data", 0, "node-a"),
+		zoneTestScheduledPod("data", 1, "node-b"),
+		zoneTestScheduledPod("data", 2, "node-a"),
+		zoneTestScheduledPod("data", 3, "node-without-zone"),
+		zoneTestScheduledPod("data", 4, "deleted-node"),
+		zoneTestScheduledPod("data", 5, ""),
+	)
+	podZones, err := zonesOfPods(c, statefulSets)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"es-es-data-0": "zone-a", "es-es-data-1": "zone-b", "es-es-data-2": "zone-a"}, podZones)
+}
+
+func Test_predicate_onlyRestartHealthyNodesInASingleZone_multiZoneNodeSet(t *testing.T) {
+	predicate := predicateNamed(t, "only_restart_healthy_nodes_in_a_single_zone")
+	// the zone of the nodes is set dynamically, and the Pods of the NodeSet are spread over several zones
+	es := zoneTestES(zoneNodeSet("data", map[string]interface{}{
+		"node.attr.zone": "${ZONE}",
+		"cluster.routing.allocation.awareness.attributes": "k8s_node_name,zone",
+	}))
+	statefulSet := zoneTestStatefulSet("data", corev1.PodSpec{})
+	statefulSet.Spec.Replicas = pointer.Int32(4)
+	d0, d1, d2, d3 := maintenanceTestPod("data", 0), maintenanceTestPod("data", 1), maintenanceTestPod("data", 2), maintenanceTestPod("data", 3)
+	podZones := map[string]string{d0.Name: "zone-a", d1.Name: "zone-b", d2.Name: "zone-a"}
+	tests := []struct {
+		name        string
+		candidate   corev1.Pod
+		healthyPods map[string]corev1.Pod
+		want        bool
+	}{
+		{name: "all nodes available", candidate: d1, healthyPods: healthy(d0, d1, d2, d3), want: true},
+		{name: "node unavailable in the same zone", candidate: d2, healthyPods: healthy(d1, d2, d3), want: true},
+		{name: "node unavailable in another zone", candidate: d1, healthyPods: healthy(d1, d2, d3), want: false},
+		{name: "node unavailable in an unknown zone", candidate: d1, healthyPods: healthy(d0, d1, d2), want: false},
+		{name: "candidate in an unknown zone", candidate: d3, healthyPods: healthy(d1, d2, d3), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := PredicateContext{es: es, healthyPods: tt.healthyPods, statefulSets: sset.StatefulSetList{statefulSet}, podZones: podZones}
+			got, err := predicate.fn(ctx, tt.candidate, nil, false)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
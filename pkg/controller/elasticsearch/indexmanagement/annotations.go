@@ -0,0 +1,64 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package indexmanagement
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/annotation"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+// AppliedObjectsAnnotationName holds the hashes of the index management objects which have been applied by the operator.
+const AppliedObjectsAnnotationName = "elasticsearch.k8s.elastic.co/applied-index-management"
+
+// appliedHashes holds the hashes used to detect whether an object must be applied again.
+type appliedHashes struct {
+	// Spec is the hash of the definition declared in the specification, when it was last applied.
+	Spec string `json:"spec"`
+	// Observed is the hash of the object as returned by Elasticsearch once applied. It is empty if the object could
+	// not be applied.
+	Observed string `json:"observed,omitempty"`
+}
+
+// appliedObjects maps object keys, made of the object type and name, to the hashes of the applied objects.
+type appliedObjects map[string]appliedHashes
+
+func objectKey(typ esv1.IndexManagementObjectType, name string) string {
+	return fmt.Sprintf("%s/%s", typ, name)
+}
+
+// parseObjectKey returns the type and the name of the object identified by the given key.
+func parseObjectKey(key string) (esv1.IndexManagementObjectType, string) {
+	parts := strings.SplitN(key, "/", 2)
+	if len(parts) != 2 {
+		return "", key
+	}
+	return esv1.IndexManagementObjectType(parts[0]), parts[1]
+}
+
+// getAppliedObjects returns the applied objects serialized in the annotation of the Elasticsearch resource.
+// If the annotation does not exist or cannot be parsed the result is empty but not nil.
+func getAppliedObjects(es esv1.Elasticsearch) appliedObjects {
+	applied := make(appliedObjects)
+	if err := annotation.GetJSON(&es, AppliedObjectsAnnotationName, &applied); err != nil {
+		log.Error(err, "Ignoring invalid annotation", "namespace", es.Namespace, "es_name", es.Name, "annotation", AppliedObjectsAnnotationName)
+		return make(appliedObjects)
+	}
+	return applied
+}
+
+// annotateWithAppliedObjects patches the annotation of the Elasticsearch resource which keeps track of the applied
+// objects, if it has changed. es is updated with the patched resource.
+func annotateWithAppliedObjects(ctx context.Context, c k8s.Client, es *esv1.Elasticsearch, applied appliedObjects) error {
+	value, err := annotation.JSONValue(applied)
+	if err != nil {
+		return err
+	}
+	return annotation.PatchAnnotations(ctx, c, es, map[string]string{AppliedObjectsAnnotationName: value})
+}
@@ -0,0 +1,221 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package indexmanagement
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.elastic.co/apm"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/hash"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	ulog "github.com/elastic/cloud-on-k8s/pkg/utils/log"
+)
+
+var (
+	log = ulog.Log.WithName("index-management")
+
+	// ComposableTemplatesMinVersion is the first version of Elasticsearch which supports component templates and
+	// composable index templates.
+	ComposableTemplatesMinVersion = version.From(7, 8, 0)
+)
+
+// objectHandler applies and deletes the objects of a given type.
+type objectHandler struct {
+	typ        esv1.IndexManagementObjectType
+	minVersion *version.Version
+	declared   []esv1.IndexManagementObject
+	get        func(context.Context, string) (map[string]interface{}, error)
+	upsert     func(context.Context, string, map[string]interface{}) error
+	delete     func(context.Context, string) error
+}
+
+func (h objectHandler) supportedBy(v version.Version) bool {
+	return h.minVersion == nil || v.GTE(*h.minVersion)
+}
+
+// objectHandlers returns the handlers of each type of object, in the order in which the objects must be applied:
+// index templates may reference component templates and ILM policies.
+func objectHandlers(esClient esclient.IndexManagementClient, spec esv1.IndexManagement) []objectHandler {
+	return []objectHandler{
+		{
+			typ:      esv1.ILMPolicyType,
+			declared: spec.ILMPolicies,
+			get:      esClient.GetILMPolicy,
+			upsert:   esClient.UpsertILMPolicy,
+			delete:   esClient.DeleteILMPolicy,
+		},
+		{
+			typ:        esv1.ComponentTemplateType,
+			minVersion: &ComposableTemplatesMinVersion,
+			declared:   spec.ComponentTemplates,
+			get:        esClient.GetComponentTemplate,
+			upsert:     esClient.UpsertComponentTemplate,
+			delete:     esClient.DeleteComponentTemplate,
+		},
+		{
+			typ:        esv1.IndexTemplateType,
+			minVersion: &ComposableTemplatesMinVersion,
+			declared:   spec.IndexTemplates,
+			get:        esClient.GetIndexTemplate,
+			upsert:     esClient.UpsertIndexTemplate,
+			delete:     esClient.DeleteIndexTemplate,
+		},
+		{
+			typ:      esv1.IngestPipelineType,
+			declared: spec.IngestPipelines,
+			get:      esClient.GetIngestPipeline,
+			upsert:   esClient.UpsertIngestPipeline,
+			delete:   esClient.DeleteIngestPipeline,
+		},
+	}
+}
+
+// Reconcile ensures that the ILM policies, component and index templates, and ingest pipelines declared in the
+// Elasticsearch specification exist in Elasticsearch as specified.
+// Drift is detected by hashing both the declared definition and the object returned by Elasticsearch once applied:
+// an object is applied again if its definition changed, or if it has been modified or deleted through the
+// Elasticsearch API. Objects previously applied by the operator but which are not declared anymore are deleted.
+// Objects created through the Elasticsearch API are left untouched: the ones managed by the operator are tracked in
+// an annotation, es is updated with the patched resource. The status of each object declared in the specification is
+// returned.
+func Reconcile(
+	ctx context.Context,
+	c k8s.Client,
+	esClient esclient.IndexManagementClient,
+	es *esv1.Elasticsearch,
+	v version.Version,
+) ([]esv1.IndexManagementObjectStatus, error) {
+	span, ctx := apm.StartSpan(ctx, "reconcile_index_management", tracing.SpanTypeApp)
+	defer span.End()
+
+	previouslyApplied := getAppliedObjects(*es)
+	if !es.Spec.IndexManagement.IsDefined() && len(previouslyApplied) == 0 {
+		// nothing to do, avoid any call to the Elasticsearch API
+		return nil, nil
+	}
+
+	handlers := objectHandlers(esClient, es.Spec.IndexManagement)
+	applied := make(appliedObjects, len(previouslyApplied))
+	var statuses []esv1.IndexManagementObjectStatus
+	for _, h := range handlers {
+		if !h.supportedBy(v) {
+			if len(h.declared) > 0 {
+				log.Info("Object type not supported by the running version of Elasticsearch, skipping",
+					"namespace", es.Namespace, "es_name", es.Name, "type", h.typ, "version", v.String())
+			}
+			continue
+		}
+		for _, obj := range h.declared {
+			key := objectKey(h.typ, obj.Name)
+			status, hashes := apply(ctx, *es, h, obj, previouslyApplied[key])
+			applied[key] = hashes
+			statuses = append(statuses, status)
+		}
+	}
+
+	// delete objects in the reverse order: templates must be deleted before the policies and templates they reference
+	var errs []error
+	for i := len(handlers) - 1; i >= 0; i-- {
+		if err := deleteUndeclared(ctx, *es, handlers[i], previouslyApplied, applied, v); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := annotateWithAppliedObjects(ctx, c, es, applied); err != nil {
+		errs = append(errs, err)
+	}
+	return statuses, utilerrors.NewAggregate(errs)
+}
+
+// apply creates or updates the given object if it does not match the previously applied one, and returns its status
+// along with the hashes to record. Errors are reported in the status rather than returned, so that a single
+// misconfigured object does not prevent the others from being applied.
+func apply(
+	ctx context.Context,
+	es esv1.Elasticsearch,
+	h objectHandler,
+	obj esv1.IndexManagementObject,
+	previous appliedHashes,
+) (esv1.IndexManagementObjectStatus, appliedHashes) {
+	status := esv1.IndexManagementObjectStatus{Type: h.typ, Name: obj.Name, Phase: esv1.IndexManagementObjectReadyPhase}
+	definition := map[string]interface{}{}
+	if obj.Definition != nil && obj.Definition.Data != nil {
+		definition = obj.Definition.Data
+	}
+	// an empty observed hash forces the object to be applied again during the next reconciliation
+	failed := appliedHashes{Spec: hash.HashObject(definition)}
+
+	current, err := h.get(ctx, obj.Name)
+	if err != nil && !esclient.IsNotFound(err) {
+		return withError(status, err), failed
+	}
+	if current != nil && previous.Observed != "" &&
+		previous.Spec == failed.Spec && previous.Observed == hash.HashObject(current) {
+		// up-to-date
+		return status, previous
+	}
+
+	log.Info("Applying index management object", "namespace", es.Namespace, "es_name", es.Name, "type", h.typ, "name", obj.Name)
+	if err := h.upsert(ctx, obj.Name, definition); err != nil {
+		return withError(status, err), failed
+	}
+	observed, err := h.get(ctx, obj.Name)
+	if err != nil || observed == nil {
+		// the object has been applied but cannot be observed, try again later
+		return status, failed
+	}
+	return status, appliedHashes{Spec: failed.Spec, Observed: hash.HashObject(observed)}
+}
+
+func withError(status esv1.IndexManagementObjectStatus, err error) esv1.IndexManagementObjectStatus {
+	status.Phase = esv1.IndexManagementObjectFailedPhase
+	status.Message = err.Error()
+	return status
+}
+
+// deleteUndeclared deletes the objects of the handler type which were previously applied but are not declared
+// anymore. Objects which could not be deleted are kept in the applied objects so that deletion is retried.
+func deleteUndeclared(
+	ctx context.Context,
+	es esv1.Elasticsearch,
+	h objectHandler,
+	previouslyApplied, applied appliedObjects,
+	v version.Version,
+) error {
+	keys := make([]string, 0, len(previouslyApplied))
+	for key := range previouslyApplied {
+		if typ, _ := parseObjectKey(key); typ == h.typ {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		if _, declared := applied[key]; declared {
+			continue
+		}
+		_, name := parseObjectKey(key)
+		if !h.supportedBy(v) {
+			// should not happen since versions cannot be downgraded, keep track of the object
+			applied[key] = previouslyApplied[key]
+			continue
+		}
+		log.Info("Deleting index management object", "namespace", es.Namespace, "es_name", es.Name, "type", h.typ, "name", name)
+		if err := h.delete(ctx, name); err != nil && !esclient.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("while deleting %s %s: %w", h.typ, name, err))
+			applied[key] = previouslyApplied[key]
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
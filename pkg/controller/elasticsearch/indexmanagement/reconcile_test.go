@@ -0,0 +1,284 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package indexmanagement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+// fakeIndexManagementClient stores objects by type and name. ILM policies are returned with a version incremented on
+// each update, as Elasticsearch does.
+type fakeIndexManagementClient struct {
+	objects  map[esv1.IndexManagementObjectType]map[string]map[string]interface{}
+	calls    []string
+	failing  map[string]bool
+	versions map[string]int
+}
+
+func newFakeClient() *fakeIndexManagementClient {
+	return &fakeIndexManagementClient{
+		objects:  map[esv1.IndexManagementObjectType]map[string]map[string]interface{}{},
+		failing:  map[string]bool{},
+		versions: map[string]int{},
+	}
+}
+
+func (f *fakeIndexManagementClient) get(typ esv1.IndexManagementObjectType, name string) (map[string]interface{}, error) {
+	obj, exists := f.objects[typ][name]
+	if !exists {
+		apiErr := esclient.FakeAPIError(404)
+		return nil, &apiErr
+	}
+	return obj, nil
+}
+
+func (f *fakeIndexManagementClient) upsert(typ esv1.IndexManagementObjectType, name string, obj map[string]interface{}) error {
+	key := objectKey(typ, name)
+	if f.failing[key] {
+		return errors.New("illegal_argument_exception")
+	}
+	f.calls = append(f.calls, "upsert "+key)
+	if f.objects[typ] == nil {
+		f.objects[typ] = map[string]map[string]interface{}{}
+	}
+	if typ == esv1.ILMPolicyType {
+		f.versions[key]++
+		obj = map[string]interface{}{"version": f.versions[key], "policy": obj}
+	}
+	f.objects[typ][name] = obj
+	return nil
+}
+
+func (f *fakeIndexManagementClient) delete(typ esv1.IndexManagementObjectType, name string) error {
+	f.calls = append(f.calls, "delete "+objectKey(typ, name))
+	delete(f.objects[typ], name)
+	return nil
+}
+
+func (f *fakeIndexManagementClient) GetILMPolicy(_ context.Context, name string) (map[string]interface{}, error) {
+	return f.get(esv1.ILMPolicyType, name)
+}
+
+func (f *fakeIndexManagementClient) UpsertILMPolicy(_ context.Context, name string, policy map[string]interface{}) error {
+	return f.upsert(esv1.ILMPolicyType, name, policy)
+}
+
+func (f *fakeIndexManagementClient) DeleteILMPolicy(_ context.Context, name string) error {
+	return f.delete(esv1.ILMPolicyType, name)
+}
+
+func (f *fakeIndexManagementClient) GetComponentTemplate(_ context.Context, name string) (map[string]interface{}, error) {
+	return f.get(esv1.ComponentTemplateType, name)
+}
+
+func (f *fakeIndexManagementClient) UpsertComponentTemplate(_ context.Context, name string, template map[string]interface{}) error {
+	return f.upsert(esv1.ComponentTemplateType, name, template)
+}
+
+func (f *fakeIndexManagementClient) DeleteComponentTemplate(_ context.Context, name string) error {
+	return f.delete(esv1.ComponentTemplateType, name)
+}
+
+func (f *fakeIndexManagementClient) GetIndexTemplate(_ context.Context, name string) (map[string]interface{}, error) {
+	return f.get(esv1.IndexTemplateType, name)
+}
+
+func (f *fakeIndexManagementClient) UpsertIndexTemplate(_ context.Context, name string, template map[string]interface{}) error {
+	return f.upsert(esv1.IndexTemplateType, name, template)
+}
+
+func (f *fakeIndexManagementClient) DeleteIndexTemplate(_ context.Context, name string) error {
+	return f.delete(esv1.IndexTemplateType, name)
+}
+
+func (f *fakeIndexManagementClient) GetIngestPipeline(_ context.Context, name string) (map[string]interface{}, error) {
+	return f.get(esv1.IngestPipelineType, name)
+}
+
+func (f *fakeIndexManagementClient) UpsertIngestPipeline(_ context.Context, name string, pipeline map[string]interface{}) error {
+	return f.upsert(esv1.IngestPipelineType, name, pipeline)
+}
+
+func (f *fakeIndexManagementClient) DeleteIngestPipeline(_ context.Context, name string) error {
+	return f.delete(esv1.IngestPipelineType, name)
+}
+
+var _ esclient.IndexManagementClient = &fakeIndexManagementClient{}
+
+func object(name string, definition map[string]interface{}) esv1.IndexManagementObject {
+	return esv1.IndexManagementObject{Name: name, Definition: &commonv1.Config{Data: definition}}
+}
+
+func sampleIndexManagement() esv1.IndexManagement {
+	return esv1.IndexManagement{
+		ILMPolicies: []esv1.IndexManagementObject{
+			object("logs", map[string]interface{}{"phases": map[string]interface{}{"delete": map[string]interface{}{"min_age": "30d"}}}),
+		},
+		ComponentTemplates: []esv1.IndexManagementObject{
+			object("logs-settings", map[string]interface{}{"template": map[string]interface{}{"settings": map[string]interface{}{"index.lifecycle.name": "logs"}}}),
+		},
+		IndexTemplates: []esv1.IndexManagementObject{
+			object("logs", map[string]interface{}{"index_patterns": []interface{}{"logs-*"}, "composed_of": []interface{}{"logs-settings"}}),
+		},
+		IngestPipelines: []esv1.IndexManagementObject{
+			object("logs", map[string]interface{}{"processors": []interface{}{}}),
+		},
+	}
+}
+
+func newES(indexManagement esv1.IndexManagement) esv1.Elasticsearch {
+	return esv1.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "es"},
+		Spec:       esv1.ElasticsearchSpec{Version: "7.15.0", IndexManagement: indexManagement},
+	}
+}
+
+// reconcile runs the reconciliation and returns the Elasticsearch resource, updated in place with the patched resource.
+func reconcile(t *testing.T, c k8s.Client, esClient esclient.IndexManagementClient, es esv1.Elasticsearch, v version.Version) ([]esv1.IndexManagementObjectStatus, esv1.Elasticsearch) {
+	t.Helper()
+	statuses, err := Reconcile(context.Background(), c, esClient, &es, v)
+	require.NoError(t, err)
+	var stored esv1.Elasticsearch
+	require.NoError(t, c.Get(context.Background(), k8s.ExtractNamespacedName(&es), &stored))
+	require.Equal(t, stored.ResourceVersion, es.ResourceVersion)
+	require.Equal(t, stored.Annotations[AppliedObjectsAnnotationName], es.Annotations[AppliedObjectsAnnotationName])
+	return statuses, es
+}
+
+func TestReconcile(t *testing.T) {
+	v7 := version.MustParse("7.15.0")
+
+	t.Run("nothing declared, nothing applied: no Elasticsearch call", func(t *testing.T) {
+		es := newES(esv1.IndexManagement{})
+		statuses, err := Reconcile(context.Background(), k8s.NewFakeClient(&es), nil, &es, v7)
+		require.NoError(t, err)
+		require.Nil(t, statuses)
+	})
+
+	t.Run("objects are applied in order, then left untouched", func(t *testing.T) {
+		es := newES(sampleIndexManagement())
+		c := k8s.NewFakeClient(&es)
+		esClient := newFakeClient()
+
+		statuses, es := reconcile(t, c, esClient, es, v7)
+		require.Equal(t, []string{
+			"upsert ILMPolicy/logs",
+			"upsert ComponentTemplate/logs-settings",
+			"upsert IndexTemplate/logs",
+			"upsert IngestPipeline/logs",
+		}, esClient.calls)
+		require.Len(t, statuses, 4)
+		for _, status := range statuses {
+			require.Equal(t, esv1.IndexManagementObjectReadyPhase, status.Phase)
+		}
+		require.Len(t, getAppliedObjects(es), 4)
+
+		esClient.calls = nil
+		_, _ = reconcile(t, c, esClient, es, v7)
+		require.Empty(t, esClient.calls)
+	})
+
+	t.Run("changed definitions are applied again", func(t *testing.T) {
+		es := newES(sampleIndexManagement())
+		c := k8s.NewFakeClient(&es)
+		esClient := newFakeClient()
+		_, es = reconcile(t, c, esClient, es, v7)
+
+		esClient.calls = nil
+		es.Spec.IndexManagement.IngestPipelines[0].Definition.Data["description"] = "logs pipeline"
+		_, _ = reconcile(t, c, esClient, es, v7)
+		require.Equal(t, []string{"upsert IngestPipeline/logs"}, esClient.calls)
+	})
+
+	t.Run("drift in Elasticsearch is detected", func(t *testing.T) {
+		es := newES(sampleIndexManagement())
+		c := k8s.NewFakeClient(&es)
+		esClient := newFakeClient()
+		_, es = reconcile(t, c, esClient, es, v7)
+
+		// the policy is updated and the pipeline deleted through the Elasticsearch API
+		esClient.calls = nil
+		require.NoError(t, esClient.UpsertILMPolicy(context.Background(), "logs", map[string]interface{}{"phases": map[string]interface{}{}}))
+		delete(esClient.objects[esv1.IngestPipelineType], "logs")
+		esClient.calls = nil
+
+		_, es = reconcile(t, c, esClient, es, v7)
+		require.Equal(t, []string{"upsert ILMPolicy/logs", "upsert IngestPipeline/logs"}, esClient.calls)
+		require.Equal(t, sampleIndexManagement().ILMPolicies[0].Definition.Data, esClient.objects[esv1.ILMPolicyType]["logs"]["policy"])
+
+		// the policy version has been incremented, but the new state is recorded
+		esClient.calls = nil
+		_, _ = reconcile(t, c, esClient, es, v7)
+		require.Empty(t, esClient.calls)
+	})
+
+	t.Run("undeclared objects are deleted in reverse order, objects not applied by the operator are left untouched", func(t *testing.T) {
+		es := newES(sampleIndexManagement())
+		c := k8s.NewFakeClient(&es)
+		esClient := newFakeClient()
+		require.NoError(t, esClient.UpsertIngestPipeline(context.Background(), "user-pipeline", map[string]interface{}{}))
+		_, es = reconcile(t, c, esClient, es, v7)
+
+		esClient.calls = nil
+		es.Spec.IndexManagement = esv1.IndexManagement{}
+		statuses, es := reconcile(t, c, esClient, es, v7)
+		require.Nil(t, statuses)
+		require.Equal(t, []string{
+			"delete IngestPipeline/logs",
+			"delete IndexTemplate/logs",
+			"delete ComponentTemplate/logs-settings",
+			"delete ILMPolicy/logs",
+		}, esClient.calls)
+		require.Contains(t, esClient.objects[esv1.IngestPipelineType], "user-pipeline")
+		require.NotContains(t, es.Annotations, AppliedObjectsAnnotationName)
+	})
+
+	t.Run("apply errors are reported in the status and retried", func(t *testing.T) {
+		es := newES(sampleIndexManagement())
+		c := k8s.NewFakeClient(&es)
+		esClient := newFakeClient()
+		esClient.failing[objectKey(esv1.IndexTemplateType, "logs")] = true
+
+		statuses, es := reconcile(t, c, esClient, es, v7)
+		require.Contains(t, statuses, esv1.IndexManagementObjectStatus{
+			Type:    esv1.IndexTemplateType,
+			Name:    "logs",
+			Phase:   esv1.IndexManagementObjectFailedPhase,
+			Message: "illegal_argument_exception",
+		})
+		// the other objects are still applied
+		require.Len(t, esClient.calls, 3)
+
+		esClient.calls = nil
+		esClient.failing = map[string]bool{}
+		statuses, _ = reconcile(t, c, esClient, es, v7)
+		require.Equal(t, []string{"upsert IndexTemplate/logs"}, esClient.calls)
+		for _, status := range statuses {
+			require.Equal(t, esv1.IndexManagementObjectReadyPhase, status.Phase, fmt.Sprintf("%s/%s", status.Type, status.Name))
+		}
+	})
+
+	t.Run("composable templates are skipped before 7.8.0", func(t *testing.T) {
+		es := newES(sampleIndexManagement())
+		c := k8s.NewFakeClient(&es)
+		esClient := newFakeClient()
+
+		statuses, _ := reconcile(t, c, esClient, es, version.MustParse("7.7.1"))
+		require.Equal(t, []string{"upsert ILMPolicy/logs", "upsert IngestPipeline/logs"}, esClient.calls)
+		require.Len(t, statuses, 2)
+	})
+}
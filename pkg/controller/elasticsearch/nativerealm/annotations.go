@@ -0,0 +1,57 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package nativerealm
+
+import (
+	"context"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/annotation"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+const (
+	// AppliedUsersAnnotationName holds the hashes of the native users which have been applied by the operator.
+	AppliedUsersAnnotationName = "elasticsearch.k8s.elastic.co/applied-native-users"
+	// AppliedRolesAnnotationName holds the hashes of the native roles which have been applied by the operator.
+	AppliedRolesAnnotationName = "elasticsearch.k8s.elastic.co/applied-native-roles"
+)
+
+// appliedHashes holds the hashes used to detect whether a user or a role must be applied again.
+type appliedHashes struct {
+	// Spec is the hash of the declared definition, when it was last applied.
+	Spec string `json:"spec"`
+	// Observed is the hash of the user or the role as returned by Elasticsearch once applied. It is empty if it could
+	// not be applied.
+	Observed string `json:"observed,omitempty"`
+}
+
+// appliedObjects maps user or role names to the hashes of the applied users or roles.
+type appliedObjects map[string]appliedHashes
+
+// getApplied returns the applied users or roles serialized in the given annotation of the Elasticsearch resource.
+// If the annotation does not exist or cannot be parsed the result is empty but not nil.
+func getApplied(es esv1.Elasticsearch, name string) appliedObjects {
+	applied := make(appliedObjects)
+	if err := annotation.GetJSON(&es, name, &applied); err != nil {
+		log.Error(err, "Ignoring invalid annotation", "namespace", es.Namespace, "es_name", es.Name, "annotation", name)
+		return make(appliedObjects)
+	}
+	return applied
+}
+
+// annotateWithApplied patches the annotations of the Elasticsearch resource which keep track of the applied users and
+// roles, if they have changed. es is updated with the patched resource.
+func annotateWithApplied(ctx context.Context, c k8s.Client, es *esv1.Elasticsearch, appliedByAnnotation map[string]appliedObjects) error {
+	values := make(map[string]string, len(appliedByAnnotation))
+	for name, applied := range appliedByAnnotation {
+		value, err := annotation.JSONValue(applied)
+		if err != nil {
+			return err
+		}
+		values[name] = value
+	}
+	return annotation.PatchAnnotations(ctx, c, es, values)
+}
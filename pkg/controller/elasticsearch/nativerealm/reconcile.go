@@ -0,0 +1,391 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package nativerealm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.elastic.co/apm"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	securityv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/security/v1alpha1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/hash"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/watches"
+	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	ulog "github.com/elastic/cloud-on-k8s/pkg/utils/log"
+)
+
+var log = ulog.Log.WithName("native-realm")
+
+// RefreshPeriod is the period at which the declared users and roles are reconciled again, to correct the changes made
+// through the Elasticsearch API.
+const RefreshPeriod = 1 * time.Minute
+
+// PasswordSecretsWatchName returns the name of the watch set on the Secrets which hold the passwords of the users
+// declared for the given Elasticsearch cluster.
+func PasswordSecretsWatchName(es types.NamespacedName) string {
+	return fmt.Sprintf("%s-%s-native-user-passwords", es.Namespace, es.Name)
+}
+
+// Reconcile ensures that the users and the roles declared by the ElasticsearchUser and ElasticsearchRole resources
+// referencing the given cluster exist in its native realm as declared. Roles are applied before users so that users can
+// be granted the declared roles. As for role mappings, a user or a role is applied again if its definition changed or
+// if it has been modified or deleted through the Elasticsearch API, and the password of an enabled user is changed if
+// it does not match the one of the referenced Secret. Users and roles previously applied by the operator but which are
+// not declared anymore are deleted, the ones created through the Elasticsearch API are left untouched. The applied
+// users and roles are tracked in annotations, es is updated with the patched resource.
+// The outcome is reported in the status of each resource.
+// A boolean is returned to indicate if a requeue should be scheduled after RefreshPeriod: the declared users and roles
+// are reconciled periodically, since their modification through the Elasticsearch API does not trigger any reconciliation.
+func Reconcile(
+	ctx context.Context,
+	c k8s.Client,
+	esClient esclient.NativeRealmClient,
+	es *esv1.Elasticsearch,
+	watched watches.DynamicWatches,
+) (bool, error) {
+	span, ctx := apm.StartSpan(ctx, "reconcile_native_realm", tracing.SpanTypeApp)
+	defer span.End()
+
+	users, roles, err := declared(ctx, c, *es)
+	if err != nil {
+		return true, err
+	}
+	esKey := k8s.ExtractNamespacedName(es)
+	if err := watches.WatchUserProvidedSecrets(esKey, watched, PasswordSecretsWatchName(esKey), passwordSecretNames(users)); err != nil {
+		return true, err
+	}
+
+	requeue := len(users) > 0 || len(roles) > 0
+	previousRoles := getApplied(*es, AppliedRolesAnnotationName)
+	previousUsers := getApplied(*es, AppliedUsersAnnotationName)
+	if !requeue && len(previousUsers) == 0 && len(previousRoles) == 0 {
+		// nothing to do, avoid any call to the Elasticsearch API
+		return false, nil
+	}
+
+	appliedRoles, errs := reconcileRoles(ctx, c, esClient, *es, roles, previousRoles)
+	appliedUsers, userErrs := reconcileUsers(ctx, c, esClient, *es, users, previousUsers)
+	errs = append(errs, userErrs...)
+
+	// users are deleted first as they may be granted the roles to delete
+	errs = append(errs, deleteUndeclared(ctx, *es, "user", previousUsers, appliedUsers, esClient.DeleteUser)...)
+	errs = append(errs, deleteUndeclared(ctx, *es, "role", previousRoles, appliedRoles, esClient.DeleteRole)...)
+
+	if err := annotateWithApplied(ctx, c, es, map[string]appliedObjects{
+		AppliedUsersAnnotationName: appliedUsers,
+		AppliedRolesAnnotationName: appliedRoles,
+	}); err != nil {
+		errs = append(errs, err)
+	}
+	return requeue, utilerrors.NewAggregate(errs)
+}
+
+// declared returns the users and the roles which reference the given cluster, sorted by resource name.
+func declared(ctx context.Context, c k8s.Client, es esv1.Elasticsearch) ([]securityv1alpha1.ElasticsearchUser, []securityv1alpha1.ElasticsearchRole, error) {
+	var userList securityv1alpha1.ElasticsearchUserList
+	if err := c.List(ctx, &userList, client.InNamespace(es.Namespace)); err != nil {
+		return nil, nil, err
+	}
+	var users []securityv1alpha1.ElasticsearchUser
+	for _, user := range userList.Items {
+		if user.Spec.ElasticsearchRef.Name == es.Name {
+			users = append(users, user)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Name < users[j].Name })
+
+	var roleList securityv1alpha1.ElasticsearchRoleList
+	if err := c.List(ctx, &roleList, client.InNamespace(es.Namespace)); err != nil {
+		return nil, nil, err
+	}
+	var roles []securityv1alpha1.ElasticsearchRole
+	for _, role := range roleList.Items {
+		if role.Spec.ElasticsearchRef.Name == es.Name {
+			roles = append(roles, role)
+		}
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+	return users, roles, nil
+}
+
+func passwordSecretNames(users []securityv1alpha1.ElasticsearchUser) []string {
+	names := make([]string, 0, len(users))
+	for _, user := range users {
+		names = append(names, user.Spec.PasswordSecretRef.SecretName)
+	}
+	return names
+}
+
+// reconcileRoles applies the given roles and updates their status. If several resources declare the same role, the
+// first one by resource name is applied and the others are reported as failed. Invalid resources, which may have been
+// admitted if the validating webhook is not available, are reported as failed without being applied.
+func reconcileRoles(
+	ctx context.Context,
+	c k8s.Client,
+	esClient esclient.NativeRealmClient,
+	es esv1.Elasticsearch,
+	roles []securityv1alpha1.ElasticsearchRole,
+	previouslyApplied appliedObjects,
+) (appliedObjects, []error) {
+	var errs []error
+	applied := make(appliedObjects, len(roles))
+	declaredBy := make(map[string]string, len(roles))
+	for i := range roles {
+		role := &roles[i]
+		name := role.RoleName()
+		err := role.ValidateCreate()
+		owner, duplicate := declaredBy[name]
+		switch {
+		case err != nil:
+			keepApplied(applied, previouslyApplied, name)
+		case duplicate:
+			err = fmt.Errorf("role %s is already declared by %s %s", name, securityv1alpha1.RoleKind, owner)
+		default:
+			declaredBy[name] = role.Name
+			applied[name], err = applyRole(ctx, es, esClient, *role, previouslyApplied[name])
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("while applying role %s: %w", name, err))
+		}
+		if err := updateStatus(c, role, &role.Status, newStatus(role.Generation, err)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return applied, errs
+}
+
+// applyRole creates or updates the given role if it does not match the previously applied one, and returns the hashes
+// to record.
+func applyRole(
+	ctx context.Context,
+	es esv1.Elasticsearch,
+	esClient esclient.NativeRealmClient,
+	role securityv1alpha1.ElasticsearchRole,
+	previous appliedHashes,
+) (appliedHashes, error) {
+	name := role.RoleName()
+	definition := map[string]interface{}{}
+	if role.Spec.Definition != nil && role.Spec.Definition.Data != nil {
+		definition = role.Spec.Definition.Data
+	}
+	// an empty observed hash forces the role to be applied again during the next reconciliation
+	failed := appliedHashes{Spec: hash.HashObject(definition)}
+
+	current, err := esClient.GetRole(ctx, name)
+	if err != nil && !esclient.IsNotFound(err) {
+		return failed, err
+	}
+	if current != nil && previous.Observed != "" &&
+		previous.Spec == failed.Spec && previous.Observed == hash.HashObject(current) {
+		// up-to-date
+		return previous, nil
+	}
+
+	log.Info("Applying role", "namespace", es.Namespace, "es_name", es.Name, "name", name)
+	if err := esClient.UpsertRole(ctx, name, definition); err != nil {
+		return failed, err
+	}
+	observed, err := esClient.GetRole(ctx, name)
+	if err != nil || observed == nil {
+		// the role has been applied but cannot be observed, try again later
+		return failed, nil
+	}
+	return appliedHashes{Spec: failed.Spec, Observed: hash.HashObject(observed)}, nil
+}
+
+// reconcileUsers applies the given users and updates their status. If several resources declare the same user, the
+// first one by resource name is applied and the others are reported as failed. Invalid resources, which may have been
+// admitted if the validating webhook is not available, are reported as failed without being applied.
+func reconcileUsers(
+	ctx context.Context,
+	c k8s.Client,
+	esClient esclient.NativeRealmClient,
+	es esv1.Elasticsearch,
+	users []securityv1alpha1.ElasticsearchUser,
+	previouslyApplied appliedObjects,
+) (appliedObjects, []error) {
+	var errs []error
+	applied := make(appliedObjects, len(users))
+	declaredBy := make(map[string]string, len(users))
+	for i := range users {
+		user := &users[i]
+		name := user.Username()
+		err := user.ValidateCreate()
+		owner, duplicate := declaredBy[name]
+		switch {
+		case err != nil:
+			keepApplied(applied, previouslyApplied, name)
+		case duplicate:
+			err = fmt.Errorf("user %s is already declared by %s %s", name, securityv1alpha1.UserKind, owner)
+		default:
+			declaredBy[name] = user.Name
+			var password string
+			password, err = getPassword(ctx, c, *user)
+			switch {
+			case err == nil:
+				applied[name], err = applyUser(ctx, es, esClient, *user, password, previouslyApplied[name])
+			case hasBeenApplied(previouslyApplied, name):
+				// do not delete the user while its password cannot be retrieved
+				applied[name] = previouslyApplied[name]
+			}
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("while applying user %s: %w", name, err))
+		}
+		if err := updateStatus(c, user, &user.Status, newStatus(user.Generation, err)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return applied, errs
+}
+
+func hasBeenApplied(applied appliedObjects, name string) bool {
+	_, exists := applied[name]
+	return exists
+}
+
+// keepApplied keeps tracking the given user or role if it has been previously applied, so that it is not deleted while
+// the resource declaring it is invalid, unless it is applied by another resource.
+func keepApplied(applied, previouslyApplied appliedObjects, name string) {
+	if hasBeenApplied(previouslyApplied, name) && !hasBeenApplied(applied, name) {
+		applied[name] = previouslyApplied[name]
+	}
+}
+
+// getPassword returns the password of the given user from the referenced Secret.
+func getPassword(ctx context.Context, c k8s.Client, user securityv1alpha1.ElasticsearchUser) (string, error) {
+	secretName := user.Spec.PasswordSecretRef.SecretName
+	var secret corev1.Secret
+	if err := c.Get(ctx, types.NamespacedName{Namespace: user.Namespace, Name: secretName}, &secret); err != nil {
+		return "", fmt.Errorf("while retrieving password secret %s: %w", secretName, err)
+	}
+	password := secret.Data[user.PasswordKey()]
+	if len(password) == 0 {
+		return "", fmt.Errorf("key %s not found in password secret %s", user.PasswordKey(), secretName)
+	}
+	return string(password), nil
+}
+
+// applyUser creates or updates the given user if it does not match the previously applied one, and returns the hashes
+// to record. The password is not part of the hashes: it is verified by authenticating as the user, which is only
+// possible if the user is enabled. The password of a disabled user is set again once its definition changes.
+func applyUser(
+	ctx context.Context,
+	es esv1.Elasticsearch,
+	esClient esclient.NativeRealmClient,
+	user securityv1alpha1.ElasticsearchUser,
+	password string,
+	previous appliedHashes,
+) (appliedHashes, error) {
+	name := user.Username()
+	definition := esclient.NativeUser{
+		Roles:    user.Spec.Roles,
+		FullName: user.Spec.FullName,
+		Email:    user.Spec.Email,
+		Enabled:  user.IsEnabled(),
+	}
+	if definition.Roles == nil {
+		definition.Roles = []string{}
+	}
+	if user.Spec.Metadata != nil {
+		definition.Metadata = user.Spec.Metadata.Data
+	}
+	// an empty observed hash forces the user to be applied again during the next reconciliation
+	failed := appliedHashes{Spec: hash.HashObject(definition)}
+
+	current, err := esClient.GetUser(ctx, name)
+	if err != nil && !esclient.IsNotFound(err) {
+		return failed, err
+	}
+	if current != nil && previous.Observed != "" &&
+		previous.Spec == failed.Spec && previous.Observed == hash.HashObject(*current) {
+		// up-to-date, except maybe the password
+		if !user.IsEnabled() {
+			return previous, nil
+		}
+		valid, err := esClient.Authenticate(ctx, esclient.BasicAuth{Name: name, Password: password})
+		if err != nil || valid {
+			return previous, err
+		}
+		log.Info("Changing password of user", "namespace", es.Namespace, "es_name", es.Name, "name", name)
+		return previous, esClient.ChangePassword(ctx, name, password)
+	}
+
+	log.Info("Applying user", "namespace", es.Namespace, "es_name", es.Name, "name", name)
+	definition.Password = password
+	if err := esClient.UpsertUser(ctx, name, definition); err != nil {
+		return failed, err
+	}
+	observed, err := esClient.GetUser(ctx, name)
+	if err != nil || observed == nil {
+		// the user has been applied but cannot be observed, try again later
+		return failed, nil
+	}
+	return appliedHashes{Spec: failed.Spec, Observed: hash.HashObject(*observed)}, nil
+}
+
+// deleteUndeclared deletes the users or the roles which have been previously applied but are not declared anymore.
+// The ones which cannot be deleted are kept in applied to retry the deletion during the next reconciliation.
+func deleteUndeclared(
+	ctx context.Context,
+	es esv1.Elasticsearch,
+	kind string,
+	previouslyApplied appliedObjects,
+	applied appliedObjects,
+	deleteFn func(ctx context.Context, name string) error,
+) []error {
+	undeclared := make([]string, 0, len(previouslyApplied))
+	for name := range previouslyApplied {
+		if _, declared := applied[name]; !declared {
+			undeclared = append(undeclared, name)
+		}
+	}
+	sort.Strings(undeclared)
+	var errs []error
+	for _, name := range undeclared {
+		log.Info("Deleting "+kind, "namespace", es.Namespace, "es_name", es.Name, "name", name)
+		if err := deleteFn(ctx, name); err != nil && !esclient.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("while deleting %s %s: %w", kind, name, err))
+			applied[name] = previouslyApplied[name]
+		}
+	}
+	return errs
+}
+
+// newStatus returns the status of a user or a role given the error which prevented it from being applied, if any.
+func newStatus(generation int64, err error) securityv1alpha1.NativeRealmStatus {
+	status := securityv1alpha1.NativeRealmStatus{Phase: securityv1alpha1.ReadyPhase, ObservedGeneration: generation}
+	if err != nil {
+		status.Phase = securityv1alpha1.FailedPhase
+		status.Message = err.Error()
+	}
+	return status
+}
+
+// updateStatus updates the status sub-resource of the given user or role, if it has changed.
+func updateStatus(c k8s.Client, obj client.Object, current *securityv1alpha1.NativeRealmStatus, expected securityv1alpha1.NativeRealmStatus) error {
+	if *current == expected {
+		return nil
+	}
+	*current = expected
+	err := common.UpdateStatus(c, obj)
+	if apierrors.IsConflict(err) {
+		// the resource has been updated in the meantime, which triggers a new reconciliation
+		return nil
+	}
+	return err
+}
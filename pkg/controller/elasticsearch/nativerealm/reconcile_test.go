@@ -0,0 +1,313 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package nativerealm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	securityv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/security/v1alpha1"
+	controllerscheme "github.com/elastic/cloud-on-k8s/pkg/controller/common/scheme"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/watches"
+	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+// fakeNativeRealmClient stores users, with their password, and roles by name.
+type fakeNativeRealmClient struct {
+	users   map[string]esclient.NativeUser
+	roles   map[string]map[string]interface{}
+	calls   []string
+	failing map[string]bool
+}
+
+func newFakeClient() *fakeNativeRealmClient {
+	return &fakeNativeRealmClient{
+		users:   map[string]esclient.NativeUser{},
+		roles:   map[string]map[string]interface{}{},
+		failing: map[string]bool{},
+	}
+}
+
+func notFound() error {
+	apiErr := esclient.FakeAPIError(404)
+	return &apiErr
+}
+
+func (f *fakeNativeRealmClient) GetUser(_ context.Context, name string) (*esclient.NativeUser, error) {
+	user, exists := f.users[name]
+	if !exists {
+		return nil, notFound()
+	}
+	// the password is never returned
+	user.Password = ""
+	return &user, nil
+}
+
+func (f *fakeNativeRealmClient) UpsertUser(_ context.Context, name string, user esclient.NativeUser) error {
+	if f.failing[name] {
+		return errors.New("illegal_argument_exception")
+	}
+	f.calls = append(f.calls, "upsert user "+name)
+	if user.Password == "" {
+		user.Password = f.users[name].Password
+	}
+	f.users[name] = user
+	return nil
+}
+
+func (f *fakeNativeRealmClient) ChangePassword(_ context.Context, name string, password string) error {
+	f.calls = append(f.calls, "change password "+name)
+	user := f.users[name]
+	user.Password = password
+	f.users[name] = user
+	return nil
+}
+
+func (f *fakeNativeRealmClient) DeleteUser(_ context.Context, name string) error {
+	f.calls = append(f.calls, "delete user "+name)
+	delete(f.users, name)
+	return nil
+}
+
+func (f *fakeNativeRealmClient) Authenticate(_ context.Context, credentials esclient.BasicAuth) (bool, error) {
+	user, exists := f.users[credentials.Name]
+	return exists && user.Enabled && user.Password == credentials.Password, nil
+}
+
+func (f *fakeNativeRealmClient) GetRole(_ context.Context, name string) (map[string]interface{}, error) {
+	role, exists := f.roles[name]
+	if !exists {
+		return nil, notFound()
+	}
+	return role, nil
+}
+
+func (f *fakeNativeRealmClient) UpsertRole(_ context.Context, name string, role map[string]interface{}) error {
+	if f.failing[name] {
+		return errors.New("illegal_argument_exception")
+	}
+	f.calls = append(f.calls, "upsert role "+name)
+	f.roles[name] = role
+	return nil
+}
+
+func (f *fakeNativeRealmClient) DeleteRole(_ context.Context, name string) error {
+	f.calls = append(f.calls, "delete role "+name)
+	delete(f.roles, name)
+	return nil
+}
+
+var _ esclient.NativeRealmClient = &fakeNativeRealmClient{}
+
+func newES() esv1.Elasticsearch {
+	return esv1.Elasticsearch{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "es"}}
+}
+
+func user(name string, esName string, roles ...string) *securityv1alpha1.ElasticsearchUser {
+	return &securityv1alpha1.ElasticsearchUser{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, Generation: 1},
+		Spec: securityv1alpha1.ElasticsearchUserSpec{
+			ElasticsearchRef:  securityv1alpha1.ElasticsearchRef{Name: esName},
+			PasswordSecretRef: securityv1alpha1.PasswordSecretRef{SecretName: name + "-password"},
+			Roles:             roles,
+		},
+	}
+}
+
+func role(name string, esName string, privilege string) *securityv1alpha1.ElasticsearchRole {
+	return &securityv1alpha1.ElasticsearchRole{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, Generation: 1},
+		Spec: securityv1alpha1.ElasticsearchRoleSpec{
+			ElasticsearchRef: securityv1alpha1.ElasticsearchRef{Name: esName},
+			Definition:       &commonv1.Config{Data: map[string]interface{}{"cluster": []interface{}{privilege}}},
+		},
+	}
+}
+
+func passwordSecret(userName string, password string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: userName + "-password"},
+		Data:       map[string][]byte{securityv1alpha1.DefaultPasswordKey: []byte(password)},
+	}
+}
+
+func newFixture(t *testing.T, objs ...runtime.Object) (k8s.Client, *fakeNativeRealmClient, esv1.Elasticsearch) {
+	t.Helper()
+	controllerscheme.SetupScheme()
+	es := newES()
+	return k8s.NewFakeClient(append(objs, &es)...), newFakeClient(), es
+}
+
+// reconcileES runs the reconciliation and returns the updated Elasticsearch resource.
+func reconcileES(t *testing.T, c k8s.Client, esClient esclient.NativeRealmClient, es esv1.Elasticsearch) esv1.Elasticsearch {
+	t.Helper()
+	_, err := Reconcile(context.Background(), c, esClient, &es, watches.NewDynamicWatches())
+	require.NoError(t, err)
+	var stored esv1.Elasticsearch
+	require.NoError(t, c.Get(context.Background(), k8s.ExtractNamespacedName(&es), &stored))
+	require.Equal(t, stored.ResourceVersion, es.ResourceVersion)
+	for _, name := range []string{AppliedUsersAnnotationName, AppliedRolesAnnotationName} {
+		require.Equal(t, stored.Annotations[name], es.Annotations[name])
+	}
+	return es
+}
+
+func userStatus(t *testing.T, c k8s.Client, name string) securityv1alpha1.NativeRealmStatus {
+	t.Helper()
+	var u securityv1alpha1.ElasticsearchUser
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: name}, &u))
+	return u.Status
+}
+
+func TestReconcile(t *testing.T) {
+	t.Run("nothing declared, nothing applied: no Elasticsearch call", func(t *testing.T) {
+		c, _, es := newFixture(t, user("other-cluster-user", "other-es"))
+		requeue, err := Reconcile(context.Background(), c, nil, &es, watches.NewDynamicWatches())
+		require.NoError(t, err)
+		require.False(t, requeue)
+	})
+
+	t.Run("roles then users are applied, then left untouched", func(t *testing.T) {
+		c, esClient, es := newFixture(t,
+			user("jacknich", "es", "monitoring"), passwordSecret("jacknich", "s3cr3t"),
+			role("monitoring", "es", "monitor"),
+			user("other-cluster-user", "other-es"),
+		)
+		es = reconcileES(t, c, esClient, es)
+		require.Equal(t, []string{"upsert role monitoring", "upsert user jacknich"}, esClient.calls)
+		require.Equal(t, esclient.NativeUser{Password: "s3cr3t", Roles: []string{"monitoring"}, Enabled: true}, esClient.users["jacknich"])
+		require.Len(t, getApplied(es, AppliedUsersAnnotationName), 1)
+		require.Len(t, getApplied(es, AppliedRolesAnnotationName), 1)
+		require.Equal(t, securityv1alpha1.NativeRealmStatus{Phase: securityv1alpha1.ReadyPhase, ObservedGeneration: 1}, userStatus(t, c, "jacknich"))
+
+		esClient.calls = nil
+		requeue, err := Reconcile(context.Background(), c, esClient, &es, watches.NewDynamicWatches())
+		require.NoError(t, err)
+		require.Empty(t, esClient.calls)
+		// the declared users and roles are reconciled periodically
+		require.True(t, requeue)
+	})
+
+	t.Run("changed definitions, drift in Elasticsearch and changed passwords are applied again", func(t *testing.T) {
+		c, esClient, es := newFixture(t,
+			user("jacknich", "es", "monitoring"), passwordSecret("jacknich", "s3cr3t"),
+			role("monitoring", "es", "monitor"),
+		)
+		es = reconcileES(t, c, esClient, es)
+
+		esClient.calls = nil
+		delete(esClient.roles, "monitoring")
+		var u securityv1alpha1.ElasticsearchUser
+		require.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "jacknich"}, &u))
+		u.Spec.FullName = "Jack Nicholson"
+		require.NoError(t, c.Update(context.Background(), &u))
+		es = reconcileES(t, c, esClient, es)
+		require.Equal(t, []string{"upsert role monitoring", "upsert user jacknich"}, esClient.calls)
+		require.Equal(t, "Jack Nicholson", esClient.users["jacknich"].FullName)
+
+		esClient.calls = nil
+		require.NoError(t, c.Update(context.Background(), passwordSecret("jacknich", "n3w-s3cr3t")))
+		_ = reconcileES(t, c, esClient, es)
+		require.Equal(t, []string{"change password jacknich"}, esClient.calls)
+		require.Equal(t, "n3w-s3cr3t", esClient.users["jacknich"].Password)
+	})
+
+	t.Run("undeclared users and roles are deleted, the ones not applied by the operator are left untouched", func(t *testing.T) {
+		u, r := user("jacknich", "es", "monitoring"), role("monitoring", "es", "monitor")
+		c, esClient, es := newFixture(t, u, passwordSecret("jacknich", "s3cr3t"), r)
+		require.NoError(t, esClient.UpsertUser(context.Background(), "api-user", esclient.NativeUser{}))
+		es = reconcileES(t, c, esClient, es)
+
+		esClient.calls = nil
+		require.NoError(t, c.Delete(context.Background(), u))
+		require.NoError(t, c.Delete(context.Background(), r))
+		es = reconcileES(t, c, esClient, es)
+		require.Equal(t, []string{"delete user jacknich", "delete role monitoring"}, esClient.calls)
+		require.Contains(t, esClient.users, "api-user")
+		require.NotContains(t, es.Annotations, AppliedUsersAnnotationName)
+		require.NotContains(t, es.Annotations, AppliedRolesAnnotationName)
+	})
+
+	t.Run("a user whose password cannot be retrieved is reported as failed but not deleted", func(t *testing.T) {
+		secret := passwordSecret("jacknich", "s3cr3t")
+		c, esClient, es := newFixture(t, user("jacknich", "es"), secret)
+		es = reconcileES(t, c, esClient, es)
+
+		esClient.calls = nil
+		require.NoError(t, c.Delete(context.Background(), secret))
+		_, err := Reconcile(context.Background(), c, esClient, &es, watches.NewDynamicWatches())
+		require.Error(t, err)
+		require.Empty(t, esClient.calls)
+		status := userStatus(t, c, "jacknich")
+		require.Equal(t, securityv1alpha1.FailedPhase, status.Phase)
+		require.Contains(t, status.Message, "jacknich-password")
+	})
+
+	t.Run("duplicate users are reported as failed", func(t *testing.T) {
+		duplicate := user("jacknich-duplicate", "es")
+		duplicate.Spec.Username = "jacknich"
+		c, esClient, es := newFixture(t, user("jacknich", "es"), passwordSecret("jacknich", "s3cr3t"), duplicate)
+		_, err := Reconcile(context.Background(), c, esClient, &es, watches.NewDynamicWatches())
+		require.Error(t, err)
+		require.Equal(t, []string{"upsert user jacknich"}, esClient.calls)
+		require.Equal(t, securityv1alpha1.ReadyPhase, userStatus(t, c, "jacknich").Phase)
+		require.Equal(t, securityv1alpha1.NativeRealmStatus{
+			Phase:              securityv1alpha1.FailedPhase,
+			Message:            "user jacknich is already declared by ElasticsearchUser jacknich",
+			ObservedGeneration: 1,
+		}, userStatus(t, c, "jacknich-duplicate"))
+	})
+
+	t.Run("errors are returned and the role applied again during the next reconciliation", func(t *testing.T) {
+		c, esClient, es := newFixture(t, role("monitoring", "es", "monitor"), role("viewer", "es", "monitor"))
+		esClient.failing["monitoring"] = true
+
+		_, err := Reconcile(context.Background(), c, esClient, &es, watches.NewDynamicWatches())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "illegal_argument_exception")
+		// the other roles are still applied
+		require.Equal(t, []string{"upsert role viewer"}, esClient.calls)
+
+		esClient.calls = nil
+		esClient.failing = map[string]bool{}
+		_ = reconcileES(t, c, esClient, es)
+		require.Equal(t, []string{"upsert role monitoring"}, esClient.calls)
+	})
+
+	t.Run("invalid users and roles are reported as failed but previously applied ones are not deleted", func(t *testing.T) {
+		reserved := user("reserved", "es")
+		reserved.Spec.Username = "elastic"
+		r := role("monitoring", "es", "monitor")
+		c, esClient, es := newFixture(t, reserved, passwordSecret("reserved", "s3cr3t"), r)
+		_, err := Reconcile(context.Background(), c, esClient, &es, watches.NewDynamicWatches())
+		require.Error(t, err)
+		require.Equal(t, []string{"upsert role monitoring"}, esClient.calls)
+		status := userStatus(t, c, "reserved")
+		require.Equal(t, securityv1alpha1.FailedPhase, status.Phase)
+		require.Contains(t, status.Message, "Username is reserved")
+
+		esClient.calls = nil
+		require.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "monitoring"}, r))
+		r.Spec.Definition = nil
+		require.NoError(t, c.Update(context.Background(), r))
+		_, err = Reconcile(context.Background(), c, esClient, &es, watches.NewDynamicWatches())
+		require.Error(t, err)
+		require.Empty(t, esClient.calls)
+		require.Contains(t, esClient.roles, "monitoring")
+		require.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "monitoring"}, r))
+		require.Equal(t, securityv1alpha1.FailedPhase, r.Status.Phase)
+		require.Contains(t, r.Status.Message, "definition is required")
+	})
+}
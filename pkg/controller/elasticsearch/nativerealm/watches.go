@@ -0,0 +1,46 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package nativerealm
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	securityv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/security/v1alpha1"
+)
+
+// WatchUsersAndRoles watches the specification of the ElasticsearchUser and ElasticsearchRole resources to reconcile
+// the Elasticsearch clusters they reference. On update, both the previously and the newly referenced clusters are
+// reconciled so that a user or a role is deleted from the cluster it does not reference anymore.
+func WatchUsersAndRoles(c controller.Controller) error {
+	if err := c.Watch(
+		&source.Kind{Type: &securityv1alpha1.ElasticsearchUser{}},
+		handler.EnqueueRequestsFromMapFunc(referencedElasticsearch),
+		predicate.GenerationChangedPredicate{},
+	); err != nil {
+		return err
+	}
+	return c.Watch(
+		&source.Kind{Type: &securityv1alpha1.ElasticsearchRole{}},
+		handler.EnqueueRequestsFromMapFunc(referencedElasticsearch),
+		predicate.GenerationChangedPredicate{},
+	)
+}
+
+// referencedElasticsearch maps a user or a role to the Elasticsearch cluster it references.
+func referencedElasticsearch(object client.Object) []reconcile.Request {
+	switch obj := object.(type) {
+	case *securityv1alpha1.ElasticsearchUser:
+		return []reconcile.Request{{NamespacedName: obj.ElasticsearchKey()}}
+	case *securityv1alpha1.ElasticsearchRole:
+		return []reconcile.Request{{NamespacedName: obj.ElasticsearchKey()}}
+	default:
+		return nil
+	}
+}
@@ -154,4 +154,5 @@ func (m *Manager) StopObserving(key types.NamespacedName) {
 		observer.Stop()
 		delete(m.observers, key)
 	}
+	deleteHealthMetrics(key)
 }
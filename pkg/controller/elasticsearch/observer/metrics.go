@@ -0,0 +1,51 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package observer
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/metrics"
+)
+
+var healthValues = []esv1.ElasticsearchHealth{
+	esv1.ElasticsearchGreenHealth,
+	esv1.ElasticsearchYellowHealth,
+	esv1.ElasticsearchRedHealth,
+	esv1.ElasticsearchUnknownHealth,
+}
+
+// HealthMetricsListener is an OnObservation listener that reports the observed health of the clusters as a metric.
+func HealthMetricsListener(cluster types.NamespacedName, _ State, current State) {
+	health := esv1.ElasticsearchUnknownHealth
+	if current.ClusterHealth != nil {
+		health = current.ClusterHealth.Status
+	}
+	for _, value := range healthValues {
+		gauge := metrics.ElasticsearchHealthGauge.With(healthLabels(cluster, value))
+		if value == health {
+			gauge.Set(1)
+		} else {
+			gauge.Set(0)
+		}
+	}
+}
+
+// deleteHealthMetrics removes the health metric of the given cluster.
+func deleteHealthMetrics(cluster types.NamespacedName) {
+	for _, value := range healthValues {
+		metrics.ElasticsearchHealthGauge.Delete(healthLabels(cluster, value))
+	}
+}
+
+func healthLabels(cluster types.NamespacedName, health esv1.ElasticsearchHealth) prometheus.Labels {
+	return prometheus.Labels{
+		metrics.NamespaceLabel: cluster.Namespace,
+		metrics.NameLabel:      cluster.Name,
+		metrics.HealthLabel:    string(health),
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package observer
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/metrics"
+)
+
+func TestHealthMetricsListener(t *testing.T) {
+	cluster := types.NamespacedName{Namespace: "ns", Name: "health-metrics"}
+	healthValue := func(health esv1.ElasticsearchHealth) float64 {
+		return testutil.ToFloat64(metrics.ElasticsearchHealthGauge.With(healthLabels(cluster, health)))
+	}
+
+	HealthMetricsListener(cluster, State{}, State{ClusterHealth: &esclient.Health{Status: esv1.ElasticsearchYellowHealth}})
+	require.Equal(t, float64(0), healthValue(esv1.ElasticsearchGreenHealth))
+	require.Equal(t, float64(1), healthValue(esv1.ElasticsearchYellowHealth))
+	require.Equal(t, float64(0), healthValue(esv1.ElasticsearchRedHealth))
+	require.Equal(t, float64(0), healthValue(esv1.ElasticsearchUnknownHealth))
+
+	// health could not be retrieved
+	HealthMetricsListener(cluster, State{}, State{})
+	require.Equal(t, float64(0), healthValue(esv1.ElasticsearchYellowHealth))
+	require.Equal(t, float64(1), healthValue(esv1.ElasticsearchUnknownHealth))
+
+	deleteHealthMetrics(cluster)
+	for _, health := range healthValues {
+		require.False(t, metrics.ElasticsearchHealthGauge.Delete(healthLabels(cluster, health)))
+	}
+}
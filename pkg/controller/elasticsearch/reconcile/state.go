@@ -5,16 +5,24 @@
 package reconcile
 
 import (
+	"fmt"
 	"reflect"
+	"sort"
+	"strings"
+	"time"
 
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
 	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/events"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/label"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/observer"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/sset"
 	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
 	ulog "github.com/elastic/cloud-on-k8s/pkg/utils/log"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var log = ulog.Log.WithName("elasticsearch-controller")
@@ -25,6 +33,8 @@ type State struct {
 	*events.Recorder
 	cluster esv1.Elasticsearch
 	status  esv1.ElasticsearchStatus
+	// versionUpgradeBlocker explains why the nodes are held at the running version, if they are
+	versionUpgradeBlocker string
 }
 
 // NewState creates a new reconcile state based on the given cluster
@@ -83,6 +93,8 @@ func (s *State) updateWithPhase(
 	} else if lowestVersion != nil {
 		s.status.Version = lowestVersion.String()
 	}
+	s.updateRunningDesiredVersion(lowestVersion)
+	s.status.NodeSets = s.nodeSetsStatus(resourcesState)
 
 	s.status.Health = esv1.ElasticsearchUnknownHealth
 	if observedState.ClusterHealth != nil && observedState.ClusterHealth.Status != "" {
@@ -154,6 +166,245 @@ func (s *State) UpdateElasticsearchInvalid(err error) {
 	s.AddEvent(corev1.EventTypeWarning, events.EventReasonValidation, err.Error())
 }
 
+// UpdateSnapshotRepositories updates the status of the snapshot repositories declared in the specification.
+func (s *State) UpdateSnapshotRepositories(statuses []esv1.SnapshotRepositoryStatus) *State {
+	s.status.SnapshotRepositories = statuses
+	return s
+}
+
+// UpdateIndexManagement updates the status of the index management objects declared in the specification.
+func (s *State) UpdateIndexManagement(statuses []esv1.IndexManagementObjectStatus) *State {
+	s.status.IndexManagement = statuses
+	return s
+}
+
+// UpdateStackConfigPolicies updates the status of the StackConfigPolicies applied to the cluster.
+func (s *State) UpdateStackConfigPolicies(status *commonv1.PoliciesStatus) *State {
+	s.status.StackConfigPolicies = status
+	return s
+}
+
+// UpdateUpgradeSnapshot updates the status of the snapshot taken before a version upgrade.
+func (s *State) UpdateUpgradeSnapshot(status *esv1.UpgradeSnapshotStatus) *State {
+	s.status.UpgradeSnapshot = status
+	return s
+}
+
+// UpdateDeprecationCheck updates the status of the deprecation check performed before a major version upgrade.
+func (s *State) UpdateDeprecationCheck(status *esv1.DeprecationCheckStatus) *State {
+	s.status.DeprecationCheck = status
+	return s
+}
+
+// UpdateDeferredChanges updates the list of the NodeSets whose rolling changes are deferred until their next
+// maintenance window.
+func (s *State) UpdateDeferredChanges(deferred []esv1.DeferredChangesStatus) *State {
+	s.status.DeferredChanges = deferred
+	return s
+}
+
+// UpdateTransportCertificates updates the status of the transport certificates, and records events describing the
+// rotations since the previous status.
+func (s *State) UpdateTransportCertificates(status *esv1.TransportCertificatesStatus) *State {
+	for _, event := range transportCertificatesEvents(s.status.TransportCertificates, status) {
+		s.AddEvent(event.EventType, event.Reason, event.Message)
+	}
+	s.status.TransportCertificates = status
+	return s
+}
+
+// transportCertificatesEvents returns the events describing the rotations of the transport certificates between the
+// previous and the current status.
+func transportCertificatesEvents(previous, current *esv1.TransportCertificatesStatus) []events.Event {
+	if previous == nil || current == nil {
+		return nil
+	}
+	var result []events.Event
+	if !previous.CAExpiration.Equal(&current.CAExpiration) {
+		result = append(result, events.Event{
+			EventType: corev1.EventTypeNormal,
+			Reason:    events.EventReasonStateChange,
+			Message:   fmt.Sprintf("Transport CA rotated, expires at %s", current.CAExpiration.UTC().Format(time.RFC3339)),
+		})
+	}
+	previousExpirations := make(map[string]metav1.Time, len(previous.Nodes))
+	for _, node := range previous.Nodes {
+		previousExpirations[node.Pod] = node.Expiration
+	}
+	var rotated []string
+	for _, node := range current.Nodes {
+		if expiration, exists := previousExpirations[node.Pod]; exists && !expiration.Equal(&node.Expiration) {
+			rotated = append(rotated, node.Pod)
+		}
+	}
+	if len(rotated) > 0 {
+		msg := fmt.Sprintf("Transport certificates rotated for Pods %s", strings.Join(rotated, ", "))
+		if current.NextRotation != nil {
+			msg = fmt.Sprintf("%s, next rotation at %s", msg, current.NextRotation.UTC().Format(time.RFC3339))
+		}
+		result = append(result, events.Event{EventType: corev1.EventTypeNormal, Reason: events.EventReasonStateChange, Message: msg})
+	}
+	if current.ForcedRotation != "" && current.ForcedRotation != previous.ForcedRotation {
+		result = append(result, events.Event{
+			EventType: corev1.EventTypeNormal,
+			Reason:    events.EventReasonStateChange,
+			Message:   fmt.Sprintf("Forced rotation %s of the transport certificates completed", current.ForcedRotation),
+		})
+	}
+	return result
+}
+
+// UpdateRemoteClusters updates the status of the connections to the remote clusters, and records an event for each
+// remote cluster which has been disconnected since the previous status.
+func (s *State) UpdateRemoteClusters(statuses []esv1.RemoteClusterStatus) *State {
+	previouslyConnected := make(map[string]bool, len(s.status.RemoteClusters))
+	for _, previous := range s.status.RemoteClusters {
+		previouslyConnected[previous.Name] = previous.Connected
+	}
+	for _, status := range statuses {
+		if previouslyConnected[status.Name] && !status.Connected {
+			s.AddEvent(corev1.EventTypeWarning, events.EventReasonUnhealthy, fmt.Sprintf("Remote cluster %s disconnected", status.Name))
+		}
+	}
+	s.status.RemoteClusters = statuses
+	return s
+}
+
+// UpdateFollowerIndices updates the status of the follower indices, and records an event for each follower index which
+// has been promoted or paused since the previous status.
+func (s *State) UpdateFollowerIndices(statuses []esv1.FollowerIndexStatus) *State {
+	previousPhases := make(map[string]esv1.FollowerIndexPhase, len(s.status.FollowerIndices))
+	for _, previous := range s.status.FollowerIndices {
+		previousPhases[previous.Name] = previous.Phase
+	}
+	for _, status := range statuses {
+		if previousPhases[status.Name] == status.Phase {
+			continue
+		}
+		//nolint:exhaustive
+		switch status.Phase {
+		case esv1.FollowerIndexPromotedPhase:
+			s.AddEvent(corev1.EventTypeNormal, events.EventReasonStateChange, fmt.Sprintf("Follower index %s promoted", status.Name))
+		case esv1.FollowerIndexPausedPhase:
+			s.AddEvent(corev1.EventTypeWarning, events.EventReasonUnhealthy, fmt.Sprintf("Replication of follower index %s paused", status.Name))
+		}
+	}
+	s.status.FollowerIndices = statuses
+	return s
+}
+
+// setCondition adds or updates a condition of the Elasticsearch status. The transition time is only updated when
+// the status of the condition changes.
+func (s *State) setCondition(conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&s.status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: s.cluster.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// updateRunningDesiredVersion updates the condition reporting whether all the nodes run the version declared in the
+// specification, based on the lowest version running. The condition is left untouched while the running version is
+// unknown, for example before the first Pod is created.
+func (s *State) updateRunningDesiredVersion(lowestVersion *version.Version) {
+	desiredVersion, err := version.Parse(s.cluster.Spec.Version)
+	switch {
+	case err != nil || lowestVersion == nil:
+		return
+	case lowestVersion.LT(desiredVersion):
+		s.setCondition(esv1.RunningDesiredVersionCondition, metav1.ConditionFalse, "Upgrading",
+			fmt.Sprintf("Upgrading from version %s to version %s", lowestVersion, desiredVersion))
+	default:
+		s.setCondition(esv1.RunningDesiredVersionCondition, metav1.ConditionTrue, "Running",
+			fmt.Sprintf("All nodes run version %s", desiredVersion))
+	}
+}
+
+// nodeSetsStatus returns the number of desired, ready and upgrading nodes of each NodeSet declared in the specification.
+func (s *State) nodeSetsStatus(resourcesState ResourcesState) []esv1.NodeSetStatus {
+	var statuses []esv1.NodeSetStatus //nolint:prealloc
+	for _, nodeSet := range s.cluster.Spec.NodeSets {
+		ssetName := esv1.StatefulSet(s.cluster.Name, nodeSet.Name)
+		status := esv1.NodeSetStatus{Name: nodeSet.Name, DesiredNodes: nodeSet.Count}
+		statefulSet, exists := resourcesState.StatefulSets.GetByName(ssetName)
+		for _, pod := range resourcesState.CurrentPods {
+			if pod.Labels[label.StatefulSetNameLabelName] != ssetName {
+				continue
+			}
+			if k8s.IsPodReady(pod) {
+				status.ReadyNodes++
+			}
+			if exists && statefulSet.Status.UpdateRevision != "" && sset.PodRevision(pod) != statefulSet.Status.UpdateRevision {
+				status.UpgradingNodes++
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// UpdateReachable updates the condition reporting whether the Elasticsearch API can be reached.
+func (s *State) UpdateReachable(reachable bool) *State {
+	if reachable {
+		s.setCondition(esv1.ElasticsearchIsReachableCondition, metav1.ConditionTrue, "Reachable", "Service has ready endpoints")
+	} else {
+		s.setCondition(esv1.ElasticsearchIsReachableCondition, metav1.ConditionFalse, "Unreachable", "Service has no ready endpoint")
+	}
+	return s
+}
+
+// BlockVersionUpgrade records that the nodes are held at the running version for the given reason, which is reported
+// by the UpgradeBlocked condition.
+func (s *State) BlockVersionUpgrade(reason string) *State {
+	s.versionUpgradeBlocker = reason
+	s.setCondition(esv1.UpgradeBlockedCondition, metav1.ConditionTrue, "VersionUpgradeBlocked", reason)
+	return s
+}
+
+// UpdateUpgradeBlocked updates the condition reporting whether some nodes cannot be restarted, given the Pods which
+// cannot be restarted indexed by the name of the predicates preventing their restart. A version upgrade blocked
+// during the same reconciliation is reported first.
+func (s *State) UpdateUpgradeBlocked(podsByPredicates map[string][]string) *State {
+	predicates := make([]string, 0, len(podsByPredicates))
+	for predicate := range podsByPredicates {
+		predicates = append(predicates, predicate)
+	}
+	sort.Strings(predicates)
+	reasons := make([]string, 0, len(predicates)+1)
+	if s.versionUpgradeBlocker != "" {
+		reasons = append(reasons, s.versionUpgradeBlocker)
+	}
+	for _, predicate := range predicates {
+		pods := append([]string{}, podsByPredicates[predicate]...)
+		sort.Strings(pods)
+		reasons = append(reasons, fmt.Sprintf("%s: %s", predicate, strings.Join(pods, ", ")))
+	}
+	switch {
+	case s.versionUpgradeBlocker != "":
+		s.setCondition(esv1.UpgradeBlockedCondition, metav1.ConditionTrue, "VersionUpgradeBlocked", strings.Join(reasons, "; "))
+	case len(predicates) > 0:
+		s.setCondition(esv1.UpgradeBlockedCondition, metav1.ConditionTrue, "PredicatesFailed", strings.Join(reasons, "; "))
+	default:
+		s.setCondition(esv1.UpgradeBlockedCondition, metav1.ConditionFalse, "NotBlocked", "")
+	}
+	return s
+}
+
+// UpdateReconciliationComplete updates the condition reporting whether the latest specification has been fully applied.
+func (s *State) UpdateReconciliationComplete(reconciled bool) *State {
+	switch {
+	case s.status.Phase == esv1.ElasticsearchResourceInvalid:
+		s.setCondition(esv1.ReconciliationCompleteCondition, metav1.ConditionFalse, "Invalid", "The specification is invalid")
+	case reconciled:
+		s.setCondition(esv1.ReconciliationCompleteCondition, metav1.ConditionTrue, "Reconciled", "")
+	default:
+		s.setCondition(esv1.ReconciliationCompleteCondition, metav1.ConditionFalse, "InProgress", "Changes are being applied")
+	}
+	return s
+}
+
 func (s *State) UpdateElasticsearchStatusPhase(orchPhase esv1.ElasticsearchOrchestrationPhase) {
 	s.status.Phase = orchPhase
 }
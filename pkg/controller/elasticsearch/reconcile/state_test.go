@@ -5,6 +5,7 @@
 package reconcile
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -353,6 +355,128 @@ func TestState_UpdateElasticsearchMigrating(t *testing.T) {
 	}
 }
 
+func TestState_UpdateTransportCertificates(t *testing.T) {
+	caExpiration := metav1.NewTime(time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC))
+	certExpiration := metav1.NewTime(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	rotatedCertExpiration := metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	nextRotation := metav1.NewTime(time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC))
+	previous := &esv1.TransportCertificatesStatus{
+		CAExpiration: caExpiration,
+		Nodes: []esv1.NodeTransportCertificateStatus{
+			{Pod: "es-default-0", Expiration: certExpiration},
+			{Pod: "es-default-1", Expiration: certExpiration},
+		},
+	}
+	tests := []struct {
+		name       string
+		previous   *esv1.TransportCertificatesStatus
+		current    *esv1.TransportCertificatesStatus
+		wantEvents []events.Event
+	}{
+		{
+			name:     "no previous status",
+			previous: nil,
+			current:  previous,
+		},
+		{
+			name:     "no change",
+			previous: previous,
+			current:  previous.DeepCopy(),
+		},
+		{
+			name:     "new Pod",
+			previous: previous,
+			current: &esv1.TransportCertificatesStatus{
+				CAExpiration: caExpiration,
+				Nodes: append(previous.DeepCopy().Nodes,
+					esv1.NodeTransportCertificateStatus{Pod: "es-default-2", Expiration: rotatedCertExpiration}),
+			},
+		},
+		{
+			name:     "rotated CA and certificates",
+			previous: previous,
+			current: &esv1.TransportCertificatesStatus{
+				CAExpiration: metav1.NewTime(time.Date(2032, 1, 1, 0, 0, 0, 0, time.UTC)),
+				NextRotation: &nextRotation,
+				Nodes: []esv1.NodeTransportCertificateStatus{
+					{Pod: "es-default-0", Expiration: rotatedCertExpiration},
+					{Pod: "es-default-1", Expiration: rotatedCertExpiration},
+				},
+			},
+			wantEvents: []events.Event{
+				{EventType: corev1.EventTypeNormal, Reason: events.EventReasonStateChange, Message: "Transport CA rotated, expires at 2032-01-01T00:00:00Z"},
+				{EventType: corev1.EventTypeNormal, Reason: events.EventReasonStateChange, Message: "Transport certificates rotated for Pods es-default-0, es-default-1, next rotation at 2022-12-01T00:00:00Z"},
+			},
+		},
+		{
+			name:     "forced rotation completed",
+			previous: previous,
+			current: &esv1.TransportCertificatesStatus{
+				CAExpiration:   caExpiration,
+				ForcedRotation: "1634567890",
+				Nodes: []esv1.NodeTransportCertificateStatus{
+					{Pod: "es-default-0", Expiration: rotatedCertExpiration},
+					{Pod: "es-default-1", Expiration: certExpiration},
+				},
+			},
+			wantEvents: []events.Event{
+				{EventType: corev1.EventTypeNormal, Reason: events.EventReasonStateChange, Message: "Transport certificates rotated for Pods es-default-0"},
+				{EventType: corev1.EventTypeNormal, Reason: events.EventReasonStateChange, Message: "Forced rotation 1634567890 of the transport certificates completed"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := esv1.Elasticsearch{Status: esv1.ElasticsearchStatus{TransportCertificates: tt.previous}}
+			s := NewState(es)
+			s.UpdateTransportCertificates(tt.current)
+			assert.Equal(t, tt.current, s.status.TransportCertificates)
+			assert.ElementsMatch(t, tt.wantEvents, s.Recorder.Events())
+		})
+	}
+}
+
+func TestState_UpdateRemoteClusters(t *testing.T) {
+	es := esv1.Elasticsearch{Status: esv1.ElasticsearchStatus{RemoteClusters: []esv1.RemoteClusterStatus{
+		{Name: "eu-west", Mode: esv1.ProxyRemoteClusterMode, Connected: true, ConnectedNodes: 18},
+		{Name: "us-east", Mode: esv1.SniffRemoteClusterMode, Connected: false},
+		{Name: "ap-south", Mode: esv1.SniffRemoteClusterMode, Connected: true, ConnectedNodes: 3},
+	}}}
+	statuses := []esv1.RemoteClusterStatus{
+		{Name: "ap-south", Mode: esv1.SniffRemoteClusterMode, Connected: true, ConnectedNodes: 3},
+		{Name: "eu-west", Mode: esv1.ProxyRemoteClusterMode, Connected: false},
+		{Name: "us-east", Mode: esv1.SniffRemoteClusterMode, Connected: false},
+		{Name: "us-west", Mode: esv1.SniffRemoteClusterMode, Connected: false},
+	}
+	s := NewState(es)
+	s.UpdateRemoteClusters(statuses)
+	assert.Equal(t, statuses, s.status.RemoteClusters)
+	assert.Equal(t, []events.Event{
+		{EventType: corev1.EventTypeWarning, Reason: events.EventReasonUnhealthy, Message: "Remote cluster eu-west disconnected"},
+	}, s.Recorder.Events())
+}
+
+func TestState_UpdateFollowerIndices(t *testing.T) {
+	es := esv1.Elasticsearch{Status: esv1.ElasticsearchStatus{FollowerIndices: []esv1.FollowerIndexStatus{
+		{Name: "orders-copy", Phase: esv1.FollowerIndexFollowingPhase},
+		{Name: "customers-copy", Phase: esv1.FollowerIndexFollowingPhase},
+		{Name: "logs-copy", Phase: esv1.FollowerIndexPausedPhase},
+	}}}
+	statuses := []esv1.FollowerIndexStatus{
+		{Name: "orders-copy", Phase: esv1.FollowerIndexPromotedPhase},
+		{Name: "customers-copy", Phase: esv1.FollowerIndexPausedPhase, OperationsBehind: 12},
+		{Name: "logs-copy", Phase: esv1.FollowerIndexPausedPhase},
+		{Name: "metrics-copy", Phase: esv1.FollowerIndexFollowingPhase},
+	}
+	s := NewState(es)
+	s.UpdateFollowerIndices(statuses)
+	assert.Equal(t, statuses, s.status.FollowerIndices)
+	assert.Equal(t, []events.Event{
+		{EventType: corev1.EventTypeNormal, Reason: events.EventReasonStateChange, Message: "Follower index orders-copy promoted"},
+		{EventType: corev1.EventTypeWarning, Reason: events.EventReasonUnhealthy, Message: "Replication of follower index customers-copy paused"},
+	}, s.Recorder.Events())
+}
+
 func TestState_fetchMinRunningVersion(t *testing.T) {
 	v770 := version.MustParse("7.7.0")
 	ssetWithVersion := func(value string) appsv1.StatefulSet {
@@ -416,3 +540,127 @@ func TestState_fetchMinRunningVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestState_Conditions(t *testing.T) {
+	es := esv1.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{Name: "es", Generation: 3},
+		Spec:       esv1.ElasticsearchSpec{Version: "7.15.0"},
+	}
+	podWithVersion := func(value string) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{label.VersionLabelName: value}}}
+	}
+	conditionOf := func(s *State, conditionType string) metav1.Condition {
+		condition := meta.FindStatusCondition(s.status.Conditions, conditionType)
+		assert.NotNil(t, condition)
+		return metav1.Condition{Type: condition.Type, Status: condition.Status, ObservedGeneration: condition.ObservedGeneration,
+			Reason: condition.Reason, Message: condition.Message}
+	}
+
+	s := NewState(es)
+	s.UpdateReachable(false)
+	s.UpdateUpgradeBlocked(map[string][]string{
+		"require_started_replica": {"es-default-2", "es-default-1"},
+		"one_master_at_a_time":    {"es-master-1"},
+	})
+	s.UpdateElasticsearchState(ResourcesState{
+		AllPods: []corev1.Pod{podWithVersion("7.14.2"), podWithVersion("7.15.0")},
+	}, observer.State{})
+	s.UpdateReconciliationComplete(false)
+	assert.Equal(t, metav1.Condition{Type: esv1.ElasticsearchIsReachableCondition, Status: metav1.ConditionFalse,
+		ObservedGeneration: 3, Reason: "Unreachable", Message: "Service has no ready endpoint"},
+		conditionOf(s, esv1.ElasticsearchIsReachableCondition))
+	assert.Equal(t, metav1.Condition{Type: esv1.UpgradeBlockedCondition, Status: metav1.ConditionTrue,
+		ObservedGeneration: 3, Reason: "PredicatesFailed",
+		Message: "one_master_at_a_time: es-master-1; require_started_replica: es-default-1, es-default-2"},
+		conditionOf(s, esv1.UpgradeBlockedCondition))
+	assert.Equal(t, metav1.Condition{Type: esv1.RunningDesiredVersionCondition, Status: metav1.ConditionFalse,
+		ObservedGeneration: 3, Reason: "Upgrading", Message: "Upgrading from version 7.14.2 to version 7.15.0"},
+		conditionOf(s, esv1.RunningDesiredVersionCondition))
+	assert.Equal(t, metav1.Condition{Type: esv1.ReconciliationCompleteCondition, Status: metav1.ConditionFalse,
+		ObservedGeneration: 3, Reason: "InProgress", Message: "Changes are being applied"},
+		conditionOf(s, esv1.ReconciliationCompleteCondition))
+
+	// the conditions are updated once the upgrade is over
+	_, updated := s.Apply()
+	s = NewState(*updated)
+	s.UpdateReachable(true)
+	s.UpdateUpgradeBlocked(nil)
+	s.UpdateElasticsearchState(ResourcesState{
+		AllPods: []corev1.Pod{podWithVersion("7.15.0"), podWithVersion("7.15.0")},
+	}, observer.State{})
+	s.UpdateReconciliationComplete(true)
+	assert.Equal(t, metav1.ConditionTrue, conditionOf(s, esv1.ElasticsearchIsReachableCondition).Status)
+	assert.Equal(t, metav1.Condition{Type: esv1.UpgradeBlockedCondition, Status: metav1.ConditionFalse,
+		ObservedGeneration: 3, Reason: "NotBlocked"},
+		conditionOf(s, esv1.UpgradeBlockedCondition))
+	assert.Equal(t, metav1.Condition{Type: esv1.RunningDesiredVersionCondition, Status: metav1.ConditionTrue,
+		ObservedGeneration: 3, Reason: "Running", Message: "All nodes run version 7.15.0"},
+		conditionOf(s, esv1.RunningDesiredVersionCondition))
+	assert.Equal(t, metav1.Condition{Type: esv1.ReconciliationCompleteCondition, Status: metav1.ConditionTrue,
+		ObservedGeneration: 3, Reason: "Reconciled"},
+		conditionOf(s, esv1.ReconciliationCompleteCondition))
+
+	// a version upgrade held at the running version is reported before the failed predicates
+	s = NewState(*updated)
+	s.BlockVersionUpgrade("Upgrade to version 8.0.0 blocked by critical deprecation issues: a, b")
+	s.UpdateUpgradeBlocked(map[string][]string{"one_master_at_a_time": {"es-master-1"}})
+	assert.Equal(t, metav1.Condition{Type: esv1.UpgradeBlockedCondition, Status: metav1.ConditionTrue,
+		ObservedGeneration: 3, Reason: "VersionUpgradeBlocked",
+		Message: "Upgrade to version 8.0.0 blocked by critical deprecation issues: a, b; one_master_at_a_time: es-master-1"},
+		conditionOf(s, esv1.UpgradeBlockedCondition))
+	s.UpdateUpgradeBlocked(nil)
+	assert.Equal(t, metav1.Condition{Type: esv1.UpgradeBlockedCondition, Status: metav1.ConditionTrue,
+		ObservedGeneration: 3, Reason: "VersionUpgradeBlocked",
+		Message: "Upgrade to version 8.0.0 blocked by critical deprecation issues: a, b"},
+		conditionOf(s, esv1.UpgradeBlockedCondition))
+
+	// an invalid specification is never reconciled
+	s.UpdateElasticsearchInvalid(errors.New("invalid version"))
+	s.UpdateReconciliationComplete(true)
+	assert.Equal(t, "Invalid", conditionOf(s, esv1.ReconciliationCompleteCondition).Reason)
+}
+
+func TestState_nodeSetsStatus(t *testing.T) {
+	pod := func(name, ssetName, revision string, ready bool) corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{
+				label.StatefulSetNameLabelName:  ssetName,
+				appsv1.StatefulSetRevisionLabel: revision,
+			}},
+			Status: corev1.PodStatus{Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: status},
+				{Type: corev1.ContainersReady, Status: status},
+			}},
+		}
+	}
+	es := esv1.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{Name: "es"},
+		Spec: esv1.ElasticsearchSpec{NodeSets: []esv1.NodeSet{
+			{Name: "master", Count: 3},
+			{Name: "data", Count: 2},
+			{Name: "ingest", Count: 1},
+		}},
+	}
+	resourcesState := ResourcesState{
+		CurrentPods: []corev1.Pod{
+			pod("es-es-master-0", "es-es-master", "rev-1", true),
+			pod("es-es-master-1", "es-es-master", "rev-2", true),
+			pod("es-es-master-2", "es-es-master", "rev-2", false),
+			pod("es-es-data-0", "es-es-data", "rev-1", true),
+		},
+		StatefulSets: []appsv1.StatefulSet{
+			{ObjectMeta: metav1.ObjectMeta{Name: "es-es-master"}, Status: appsv1.StatefulSetStatus{UpdateRevision: "rev-2"}},
+			// no update revision yet: the Pods are not considered as upgrading
+			{ObjectMeta: metav1.ObjectMeta{Name: "es-es-data"}},
+		},
+	}
+	assert.Equal(t, []esv1.NodeSetStatus{
+		{Name: "master", DesiredNodes: 3, ReadyNodes: 2, UpgradingNodes: 1},
+		{Name: "data", DesiredNodes: 2, ReadyNodes: 1},
+		{Name: "ingest", DesiredNodes: 1},
+	}, NewState(es).nodeSetsStatus(resourcesState))
+}
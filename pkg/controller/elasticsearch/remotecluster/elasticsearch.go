@@ -7,11 +7,13 @@ package remotecluster
 import (
 	"context"
 	"sort"
+	"strconv"
 
 	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/events"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/license"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
 	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/services"
 	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
@@ -19,6 +21,7 @@ import (
 	"go.elastic.co/apm"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
 )
 
 var log = ulog.Log.WithName("remotecluster")
@@ -74,6 +77,14 @@ func updateSettingsInternal(
 	esClient esclient.Client,
 	es esv1.Elasticsearch,
 ) (requeue bool, err error) {
+	esVersion, err := lowestRunningVersion(es)
+	if err != nil {
+		return true, err
+	}
+	// connection modes are only supported from 7.7.0, older versions only support the seeds of the sniff mode:
+	// all the nodes must support them during an upgrade, since the settings are applied to the whole cluster
+	connectionModes := esVersion.GTE(version.From(7, 7, 0))
+
 	remoteClustersInAnnotation := getRemoteClustersInAnnotation(es)
 
 	// Retrieve the remote clusters currently declared in Elasticsearch
@@ -106,15 +117,21 @@ func updateSettingsInternal(
 	for name, remoteCluster := range remoteClustersInSpec {
 		remoteClustersToUpdate = append(remoteClustersToUpdate, name)
 		// Declare remote cluster in ES
-		seedHosts := []string{services.ExternalTransportServiceHost(remoteCluster.ElasticsearchRef.NamespacedName())}
-		remoteClustersToApply[name] = esclient.RemoteCluster{Seeds: seedHosts}
+		remoteClustersToApply[name] = remoteClusterSettings(remoteCluster, connectionModes, remoteClustersInEs[name])
 		// Ensure this cluster is tracked in the annotation
 		remoteClustersInAnnotation[name] = struct{}{}
 	}
 
 	// RemoteClusters to remove from Elasticsearch
 	for _, name := range remoteClustersToDelete {
-		remoteClustersToApply[name] = esclient.RemoteCluster{Seeds: nil}
+		deleted := esclient.RemoteCluster{Seeds: nil}
+		if remoteClustersInEs[name].RemoteClusterOptions != nil {
+			deleted.RemoteClusterOptions = &esclient.RemoteClusterOptions{}
+		}
+		if connectionModes {
+			deleted.RemoteClusterConnectionMode = &esclient.RemoteClusterConnectionMode{}
+		}
+		remoteClustersToApply[name] = deleted
 	}
 
 	// Update the annotation
@@ -140,15 +157,80 @@ func updateSettingsInternal(
 	return requeue, nil
 }
 
-// getRemoteClustersInElasticsearch returns all the remote clusters currently declared in Elasticsearch
-func getRemoteClustersInElasticsearch(esClient esclient.Client) (map[string]struct{}, error) {
-	remoteClustersInEs := make(map[string]struct{})
+// lowestRunningVersion returns the lowest version of the running Elasticsearch nodes as reported in the status, or the
+// version from the specification if the status does not report any version yet.
+func lowestRunningVersion(es esv1.Elasticsearch) (version.Version, error) {
+	if es.Status.Version != "" {
+		return version.Parse(es.Status.Version)
+	}
+	return version.Parse(es.Spec.Version)
+}
+
+// remoteClusterSettings returns the settings of the given remote cluster. The settings of the connection mode which is
+// not used are reset, so that the mode of an existing remote cluster can be changed, as well as the options which are
+// currently set in Elasticsearch but not in the specification.
+func remoteClusterSettings(remoteCluster esv1.RemoteCluster, connectionModes bool, current esclient.RemoteCluster) esclient.RemoteCluster {
+	addresses := []string{services.ExternalTransportServiceHost(remoteCluster.ElasticsearchRef.NamespacedName())}
+	if remoteCluster.External != nil {
+		addresses = remoteCluster.External.Addresses
+	}
+	options := remoteClusterOptions(remoteCluster, current.RemoteClusterOptions)
+	if !connectionModes {
+		return esclient.RemoteCluster{Seeds: addresses, RemoteClusterOptions: options}
+	}
+	mode := remoteCluster.ModeOrDefault()
+	settings := esclient.RemoteCluster{
+		RemoteClusterOptions:        options,
+		RemoteClusterConnectionMode: &esclient.RemoteClusterConnectionMode{Mode: pointer.StringPtr(string(mode))},
+	}
+	var nodeConnections *string
+	if remoteCluster.NodeConnections != nil {
+		nodeConnections = pointer.StringPtr(strconv.Itoa(int(*remoteCluster.NodeConnections)))
+	}
+	switch mode {
+	case esv1.ProxyRemoteClusterMode:
+		if len(addresses) > 0 {
+			settings.ProxyAddress = pointer.StringPtr(addresses[0])
+		}
+		if remoteCluster.ServerName != "" {
+			settings.ServerName = pointer.StringPtr(remoteCluster.ServerName)
+		}
+		settings.ProxySocketConnections = nodeConnections
+	case esv1.SniffRemoteClusterMode:
+		settings.Seeds = addresses
+		settings.NodeConnections = nodeConnections
+	}
+	return settings
+}
+
+// remoteClusterOptions returns the connection options of the given remote cluster. Nil is returned if no option is set
+// in the specification nor currently in Elasticsearch, as there is nothing to reset.
+func remoteClusterOptions(remoteCluster esv1.RemoteCluster, current *esclient.RemoteClusterOptions) *esclient.RemoteClusterOptions {
+	var options esclient.RemoteClusterOptions
+	if remoteCluster.SkipUnavailable != nil {
+		options.SkipUnavailable = pointer.StringPtr(strconv.FormatBool(*remoteCluster.SkipUnavailable))
+	}
+	if remoteCluster.Compress != nil {
+		options.Transport.Compress = pointer.StringPtr(strconv.FormatBool(*remoteCluster.Compress))
+	}
+	if remoteCluster.PingSchedule != "" {
+		options.Transport.PingSchedule = pointer.StringPtr(remoteCluster.PingSchedule)
+	}
+	if options == (esclient.RemoteClusterOptions{}) && current == nil {
+		return nil
+	}
+	return &options
+}
+
+// getRemoteClustersInElasticsearch returns all the remote clusters currently declared in Elasticsearch, with their settings
+func getRemoteClustersInElasticsearch(esClient esclient.Client) (map[string]esclient.RemoteCluster, error) {
+	remoteClustersInEs := make(map[string]esclient.RemoteCluster)
 	remoteClusterSettings, err := esClient.GetRemoteClusterSettings(context.Background())
 	if err != nil {
 		return remoteClustersInEs, err
 	}
-	for remoteClusterName := range remoteClusterSettings.PersistentSettings.Cluster.RemoteClusters {
-		remoteClustersInEs[remoteClusterName] = struct{}{}
+	for remoteClusterName, remoteCluster := range remoteClusterSettings.PersistentSettings.Cluster.RemoteClusters {
+		remoteClustersInEs[remoteClusterName] = remoteCluster
 	}
 	return remoteClustersInEs, nil
 }
@@ -158,7 +240,7 @@ func getRemoteClustersInElasticsearch(esClient esclient.Client) (map[string]stru
 func getRemoteClustersInSpec(es esv1.Elasticsearch) map[string]esv1.RemoteCluster {
 	remoteClusters := make(map[string]esv1.RemoteCluster)
 	for _, remoteCluster := range es.Spec.RemoteClusters {
-		if !remoteCluster.ElasticsearchRef.IsDefined() {
+		if !remoteCluster.IsDefined() {
 			continue
 		}
 		remoteCluster.ElasticsearchRef = remoteCluster.ElasticsearchRef.WithDefaultNamespace(es.Namespace)
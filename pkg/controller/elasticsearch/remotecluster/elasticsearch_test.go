@@ -17,6 +17,7 @@ import (
 	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 func Test_getCurrentRemoteClusters(t *testing.T) {
@@ -103,11 +104,25 @@ func newEsWithRemoteClusters(
 			Annotations: annotations,
 		},
 		Spec: esv1.ElasticsearchSpec{
+			// connection modes are not supported before 7.7.0
+			Version:        "7.6.0",
 			RemoteClusters: remoteClusters,
 		},
 	}
 }
 
+// withVersion sets the version of the given Elasticsearch resource.
+func withVersion(es *esv1.Elasticsearch, version string) *esv1.Elasticsearch {
+	es.Spec.Version = version
+	return es
+}
+
+// withRunningVersion sets the lowest version of the running nodes of the given Elasticsearch resource.
+func withRunningVersion(es *esv1.Elasticsearch, version string) *esv1.Elasticsearch {
+	es.Status.Version = version
+	return es
+}
+
 type fakeLicenseChecker struct {
 	enterpriseFeaturesEnabled bool
 }
@@ -463,6 +478,80 @@ func TestUpdateSettings(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Upgrade to a version supporting connection modes in progress",
+			args: args{
+				esClient:       &fakeESClient{existingSettings: emptySettings},
+				licenseChecker: &fakeLicenseChecker{true},
+				es: withRunningVersion(withVersion(newEsWithRemoteClusters(
+					"ns1",
+					"es1",
+					nil,
+					esv1.RemoteCluster{
+						Name:             "ns2-es2",
+						ElasticsearchRef: commonv1.ObjectSelector{Name: "es2", Namespace: "ns2"},
+						Mode:             esv1.SniffRemoteClusterMode,
+					},
+				), "7.15.0"), "7.6.0"),
+			},
+			wantAnnotation: "ns2-es2",
+			wantEsCalled:   true,
+			wantSettings: esclient.RemoteClustersSettings{
+				PersistentSettings: &esclient.SettingsGroup{
+					Cluster: esclient.RemoteClusters{
+						RemoteClusters: map[string]esclient.RemoteCluster{
+							"ns2-es2": {Seeds: []string{"es2-es-transport.ns2.svc:9300"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Create an external remote cluster in proxy mode and remove a previously managed one",
+			args: args{
+				esClient: &fakeESClient{existingSettings: esclient.RemoteClustersSettings{
+					PersistentSettings: &esclient.SettingsGroup{
+						Cluster: esclient.RemoteClusters{
+							RemoteClusters: map[string]esclient.RemoteCluster{
+								"ns1-es2": {Seeds: []string{"es2-es-transport.ns1.svc:9300"}},
+							},
+						},
+					},
+				}},
+				licenseChecker: &fakeLicenseChecker{true},
+				es: withVersion(newEsWithRemoteClusters(
+					"ns1",
+					"es1",
+					map[string]string{ManagedRemoteClustersAnnotationName: "ns1-es2"},
+					esv1.RemoteCluster{
+						Name: "eu-west",
+						External: &esv1.ExternalRemoteCluster{
+							Addresses:    []string{"es.eu-west.example.com:9300"},
+							CASecretName: "eu-west-ca",
+						},
+						Mode:       esv1.ProxyRemoteClusterMode,
+						ServerName: "es.eu-west.example.com",
+					},
+				), "7.15.0"),
+			},
+			wantRequeue:    true,
+			wantEsCalled:   true,
+			wantAnnotation: "eu-west,ns1-es2",
+			wantSettings: esclient.RemoteClustersSettings{
+				PersistentSettings: &esclient.SettingsGroup{
+					Cluster: esclient.RemoteClusters{
+						RemoteClusters: map[string]esclient.RemoteCluster{
+							"eu-west": {RemoteClusterConnectionMode: &esclient.RemoteClusterConnectionMode{
+								Mode:         pointer.StringPtr("proxy"),
+								ProxyAddress: pointer.StringPtr("es.eu-west.example.com:9300"),
+								ServerName:   pointer.StringPtr("es.eu-west.example.com"),
+							}},
+							"ns1-es2": {RemoteClusterConnectionMode: &esclient.RemoteClusterConnectionMode{}},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -499,3 +588,144 @@ func TestUpdateSettings(t *testing.T) {
 		})
 	}
 }
+
+func Test_remoteClusterSettings(t *testing.T) {
+	tests := []struct {
+		name            string
+		remoteCluster   esv1.RemoteCluster
+		connectionModes bool
+		current         esclient.RemoteCluster
+		want            esclient.RemoteCluster
+	}{
+		{
+			name:          "Elasticsearch reference without connection modes",
+			remoteCluster: esv1.RemoteCluster{Name: "ns1-es2", ElasticsearchRef: commonv1.ObjectSelector{Name: "es2", Namespace: "ns1"}},
+			want:          esclient.RemoteCluster{Seeds: []string{"es2-es-transport.ns1.svc:9300"}},
+		},
+		{
+			name:            "Elasticsearch reference in sniff mode",
+			remoteCluster:   esv1.RemoteCluster{Name: "ns1-es2", ElasticsearchRef: commonv1.ObjectSelector{Name: "es2", Namespace: "ns1"}},
+			connectionModes: true,
+			want: esclient.RemoteCluster{
+				Seeds:                       []string{"es2-es-transport.ns1.svc:9300"},
+				RemoteClusterConnectionMode: &esclient.RemoteClusterConnectionMode{Mode: pointer.StringPtr("sniff")},
+			},
+		},
+		{
+			name: "Elasticsearch reference in proxy mode",
+			remoteCluster: esv1.RemoteCluster{
+				Name:             "ns1-es2",
+				ElasticsearchRef: commonv1.ObjectSelector{Name: "es2", Namespace: "ns1"},
+				Mode:             esv1.ProxyRemoteClusterMode,
+			},
+			connectionModes: true,
+			want: esclient.RemoteCluster{
+				RemoteClusterConnectionMode: &esclient.RemoteClusterConnectionMode{
+					Mode:         pointer.StringPtr("proxy"),
+					ProxyAddress: pointer.StringPtr("es2-es-transport.ns1.svc:9300"),
+				},
+			},
+		},
+		{
+			name: "External cluster in sniff mode",
+			remoteCluster: esv1.RemoteCluster{
+				Name: "eu-west",
+				External: &esv1.ExternalRemoteCluster{
+					Addresses: []string{"10.0.0.1:9300", "10.0.0.2:9300"},
+				},
+			},
+			connectionModes: true,
+			want: esclient.RemoteCluster{
+				Seeds:                       []string{"10.0.0.1:9300", "10.0.0.2:9300"},
+				RemoteClusterConnectionMode: &esclient.RemoteClusterConnectionMode{Mode: pointer.StringPtr("sniff")},
+			},
+		},
+		{
+			name: "External cluster in proxy mode",
+			remoteCluster: esv1.RemoteCluster{
+				Name:       "eu-west",
+				External:   &esv1.ExternalRemoteCluster{Addresses: []string{"es.eu-west.example.com:9300"}},
+				Mode:       esv1.ProxyRemoteClusterMode,
+				ServerName: "es.eu-west.example.com",
+			},
+			connectionModes: true,
+			want: esclient.RemoteCluster{
+				RemoteClusterConnectionMode: &esclient.RemoteClusterConnectionMode{
+					Mode:         pointer.StringPtr("proxy"),
+					ProxyAddress: pointer.StringPtr("es.eu-west.example.com:9300"),
+					ServerName:   pointer.StringPtr("es.eu-west.example.com"),
+				},
+			},
+		},
+		{
+			name: "Connection options without connection modes",
+			remoteCluster: esv1.RemoteCluster{
+				Name:             "ns1-es2",
+				ElasticsearchRef: commonv1.ObjectSelector{Name: "es2", Namespace: "ns1"},
+				Compress:         pointer.BoolPtr(true),
+				PingSchedule:     "30s",
+				SkipUnavailable:  pointer.BoolPtr(false),
+			},
+			want: esclient.RemoteCluster{
+				Seeds: []string{"es2-es-transport.ns1.svc:9300"},
+				RemoteClusterOptions: &esclient.RemoteClusterOptions{
+					SkipUnavailable: pointer.StringPtr("false"),
+					Transport: esclient.RemoteClusterTransportOptions{
+						Compress:     pointer.StringPtr("true"),
+						PingSchedule: pointer.StringPtr("30s"),
+					},
+				},
+			},
+		},
+		{
+			name:          "Connection options removed from the specification are reset",
+			remoteCluster: esv1.RemoteCluster{Name: "ns1-es2", ElasticsearchRef: commonv1.ObjectSelector{Name: "es2", Namespace: "ns1"}},
+			current: esclient.RemoteCluster{
+				Seeds:                []string{"es2-es-transport.ns1.svc:9300"},
+				RemoteClusterOptions: &esclient.RemoteClusterOptions{SkipUnavailable: pointer.StringPtr("true")},
+			},
+			want: esclient.RemoteCluster{
+				Seeds:                []string{"es2-es-transport.ns1.svc:9300"},
+				RemoteClusterOptions: &esclient.RemoteClusterOptions{},
+			},
+		},
+		{
+			name: "Node connections in sniff mode",
+			remoteCluster: esv1.RemoteCluster{
+				Name:            "eu-west",
+				External:        &esv1.ExternalRemoteCluster{Addresses: []string{"10.0.0.1:9300"}},
+				NodeConnections: pointer.Int32(5),
+			},
+			connectionModes: true,
+			want: esclient.RemoteCluster{
+				Seeds: []string{"10.0.0.1:9300"},
+				RemoteClusterConnectionMode: &esclient.RemoteClusterConnectionMode{
+					Mode:            pointer.StringPtr("sniff"),
+					NodeConnections: pointer.StringPtr("5"),
+				},
+			},
+		},
+		{
+			name: "Node connections in proxy mode",
+			remoteCluster: esv1.RemoteCluster{
+				Name:            "eu-west",
+				External:        &esv1.ExternalRemoteCluster{Addresses: []string{"es.eu-west.example.com:9300"}},
+				Mode:            esv1.ProxyRemoteClusterMode,
+				NodeConnections: pointer.Int32(18),
+			},
+			connectionModes: true,
+			want: esclient.RemoteCluster{
+				RemoteClusterConnectionMode: &esclient.RemoteClusterConnectionMode{
+					Mode:                   pointer.StringPtr("proxy"),
+					ProxyAddress:           pointer.StringPtr("es.eu-west.example.com:9300"),
+					ProxySocketConnections: pointer.StringPtr("18"),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, remoteClusterSettings(tt.remoteCluster, tt.connectionModes, tt.current))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package remotecluster
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
+	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	"go.elastic.co/apm"
+)
+
+// StatusRefreshPeriod is the period at which the status of the connections to the remote clusters is refreshed, as
+// the connections may be lost without any change to the Kubernetes resources.
+const StatusRefreshPeriod = 1 * time.Minute
+
+// Status returns the status of the connections to the remote clusters declared in the specification, retrieved from
+// the remote cluster info API. Nil is returned if no remote cluster is declared.
+func Status(ctx context.Context, esClient esclient.Client, es esv1.Elasticsearch) ([]esv1.RemoteClusterStatus, error) {
+	span, _ := apm.StartSpan(ctx, "remote_clusters_status", tracing.SpanTypeApp)
+	defer span.End()
+
+	remoteClustersInSpec := getRemoteClustersInSpec(es)
+	if len(remoteClustersInSpec) == 0 {
+		return nil, nil
+	}
+	info, err := esClient.GetRemoteClustersInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]esv1.RemoteClusterStatus, 0, len(remoteClustersInSpec))
+	for name, remoteCluster := range remoteClustersInSpec {
+		status := esv1.RemoteClusterStatus{Name: name, Mode: remoteCluster.ModeOrDefault()}
+		if remoteInfo, exists := info[name]; exists {
+			// the mode is not reported before 7.7.0, in which case the remote cluster is connected in sniff mode
+			if remoteInfo.Mode != "" {
+				status.Mode = esv1.RemoteClusterMode(remoteInfo.Mode)
+			}
+			status.Connected = remoteInfo.Connected
+			status.ConnectedNodes = remoteInfo.NumNodesConnected
+			if status.Mode == esv1.ProxyRemoteClusterMode {
+				status.ConnectedNodes = remoteInfo.NumProxySocketsConnected
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses, nil
+}
@@ -0,0 +1,91 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package remotecluster
+
+import (
+	"context"
+	"testing"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRemoteInfoESClient struct {
+	esclient.Client
+	info   esclient.RemoteClustersInfo
+	called bool
+}
+
+func (f *fakeRemoteInfoESClient) GetRemoteClustersInfo(_ context.Context) (esclient.RemoteClustersInfo, error) {
+	f.called = true
+	return f.info, nil
+}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		es         *esv1.Elasticsearch
+		info       esclient.RemoteClustersInfo
+		want       []esv1.RemoteClusterStatus
+		wantCalled bool
+	}{
+		{
+			name: "no remote clusters",
+			es:   newEsWithRemoteClusters("ns1", "es1", nil),
+		},
+		{
+			name: "remote clusters connected, disconnected or not declared yet",
+			es: withVersion(newEsWithRemoteClusters("ns1", "es1", nil,
+				esv1.RemoteCluster{Name: "ns1-es2", ElasticsearchRef: commonv1.ObjectSelector{Name: "es2"}},
+				esv1.RemoteCluster{
+					Name:     "eu-west",
+					External: &esv1.ExternalRemoteCluster{Addresses: []string{"es.eu-west.example.com:9300"}},
+					Mode:     esv1.ProxyRemoteClusterMode,
+				},
+				esv1.RemoteCluster{
+					Name:     "us-east",
+					External: &esv1.ExternalRemoteCluster{Addresses: []string{"es.us-east.example.com:9300"}},
+					Mode:     esv1.ProxyRemoteClusterMode,
+				},
+			), "7.15.0"),
+			info: esclient.RemoteClustersInfo{
+				"ns1-es2": {Connected: true, Mode: "sniff", NumNodesConnected: 3},
+				"eu-west": {Connected: true, Mode: "proxy", NumProxySocketsConnected: 18},
+				"custom":  {Connected: true, Mode: "sniff", NumNodesConnected: 1},
+			},
+			want: []esv1.RemoteClusterStatus{
+				{Name: "eu-west", Mode: esv1.ProxyRemoteClusterMode, Connected: true, ConnectedNodes: 18},
+				{Name: "ns1-es2", Mode: esv1.SniffRemoteClusterMode, Connected: true, ConnectedNodes: 3},
+				{Name: "us-east", Mode: esv1.ProxyRemoteClusterMode, Connected: false, ConnectedNodes: 0},
+			},
+			wantCalled: true,
+		},
+		{
+			name: "mode not reported before 7.7.0",
+			es: newEsWithRemoteClusters("ns1", "es1", nil,
+				esv1.RemoteCluster{Name: "ns1-es2", ElasticsearchRef: commonv1.ObjectSelector{Name: "es2"}},
+			),
+			info: esclient.RemoteClustersInfo{
+				"ns1-es2": {Connected: false},
+			},
+			want: []esv1.RemoteClusterStatus{
+				{Name: "ns1-es2", Mode: esv1.SniffRemoteClusterMode, Connected: false, ConnectedNodes: 0},
+			},
+			wantCalled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			esClient := &fakeRemoteInfoESClient{info: tt.info}
+			got, err := Status(context.Background(), esClient, *tt.es)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantCalled, esClient.called)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package rolemapping
+
+import (
+	"context"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/annotation"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+// AppliedRoleMappingsAnnotationName holds the hashes of the role mappings which have been applied by the operator.
+const AppliedRoleMappingsAnnotationName = "elasticsearch.k8s.elastic.co/applied-role-mappings"
+
+// appliedHashes holds the hashes used to detect whether a role mapping must be applied again.
+type appliedHashes struct {
+	// Spec is the hash of the declared definition, when it was last applied.
+	Spec string `json:"spec"`
+	// Observed is the hash of the role mapping as returned by Elasticsearch once applied. It is empty if the role
+	// mapping could not be applied.
+	Observed string `json:"observed,omitempty"`
+}
+
+// appliedRoleMappings maps role mapping names to the hashes of the applied role mappings.
+type appliedRoleMappings map[string]appliedHashes
+
+// getAppliedRoleMappings returns the applied role mappings serialized in the annotation of the Elasticsearch resource.
+// If the annotation does not exist or cannot be parsed the result is empty but not nil.
+func getAppliedRoleMappings(es esv1.Elasticsearch) appliedRoleMappings {
+	applied := make(appliedRoleMappings)
+	if err := annotation.GetJSON(&es, AppliedRoleMappingsAnnotationName, &applied); err != nil {
+		log.Error(err, "Ignoring invalid annotation", "namespace", es.Namespace, "es_name", es.Name, "annotation", AppliedRoleMappingsAnnotationName)
+		return make(appliedRoleMappings)
+	}
+	return applied
+}
+
+// annotateWithAppliedRoleMappings patches the annotation of the Elasticsearch resource which keeps track of the
+// applied role mappings, if it has changed. es is updated with the patched resource.
+func annotateWithAppliedRoleMappings(ctx context.Context, c k8s.Client, es *esv1.Elasticsearch, applied appliedRoleMappings) error {
+	value, err := annotation.JSONValue(applied)
+	if err != nil {
+		return err
+	}
+	return annotation.PatchAnnotations(ctx, c, es, map[string]string{AppliedRoleMappingsAnnotationName: value})
+}
@@ -0,0 +1,115 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package rolemapping
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.elastic.co/apm"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	policyv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/stackconfigpolicy/v1alpha1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/hash"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
+	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	ulog "github.com/elastic/cloud-on-k8s/pkg/utils/log"
+)
+
+var log = ulog.Log.WithName("role-mappings")
+
+// Reconcile ensures that the given role mappings, enforced by StackConfigPolicies, exist in Elasticsearch as declared.
+// As for index management objects, a role mapping is applied again if its definition changed or if it has been
+// modified or deleted through the Elasticsearch API. Role mappings previously applied by the operator but which are not
+// declared anymore are deleted, the ones created through the Elasticsearch API are left untouched. The applied role
+// mappings are tracked in an annotation, es is updated with the patched resource.
+func Reconcile(
+	ctx context.Context,
+	c k8s.Client,
+	esClient esclient.RoleMappingClient,
+	es *esv1.Elasticsearch,
+	declared []policyv1alpha1.RoleMapping,
+) error {
+	span, ctx := apm.StartSpan(ctx, "reconcile_role_mappings", tracing.SpanTypeApp)
+	defer span.End()
+
+	previouslyApplied := getAppliedRoleMappings(*es)
+	if len(declared) == 0 && len(previouslyApplied) == 0 {
+		// nothing to do, avoid any call to the Elasticsearch API
+		return nil
+	}
+
+	var errs []error
+	applied := make(appliedRoleMappings, len(declared))
+	for _, roleMapping := range declared {
+		hashes, err := apply(ctx, *es, esClient, roleMapping, previouslyApplied[roleMapping.Name])
+		applied[roleMapping.Name] = hashes
+		if err != nil {
+			errs = append(errs, fmt.Errorf("while applying role mapping %s: %w", roleMapping.Name, err))
+		}
+	}
+
+	undeclared := make([]string, 0, len(previouslyApplied))
+	for name := range previouslyApplied {
+		if _, declared := applied[name]; !declared {
+			undeclared = append(undeclared, name)
+		}
+	}
+	sort.Strings(undeclared)
+	for _, name := range undeclared {
+		log.Info("Deleting role mapping", "namespace", es.Namespace, "es_name", es.Name, "name", name)
+		if err := esClient.DeleteRoleMapping(ctx, name); err != nil && !esclient.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("while deleting role mapping %s: %w", name, err))
+			// keep track of the role mapping to retry the deletion
+			applied[name] = previouslyApplied[name]
+		}
+	}
+
+	if err := annotateWithAppliedRoleMappings(ctx, c, es, applied); err != nil {
+		errs = append(errs, err)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// apply creates or updates the given role mapping if it does not match the previously applied one, and returns the
+// hashes to record.
+func apply(
+	ctx context.Context,
+	es esv1.Elasticsearch,
+	esClient esclient.RoleMappingClient,
+	roleMapping policyv1alpha1.RoleMapping,
+	previous appliedHashes,
+) (appliedHashes, error) {
+	definition := map[string]interface{}{}
+	if roleMapping.Definition != nil && roleMapping.Definition.Data != nil {
+		definition = roleMapping.Definition.Data
+	}
+	// an empty observed hash forces the role mapping to be applied again during the next reconciliation
+	failed := appliedHashes{Spec: hash.HashObject(definition)}
+
+	current, err := esClient.GetRoleMapping(ctx, roleMapping.Name)
+	if err != nil && !esclient.IsNotFound(err) {
+		return failed, err
+	}
+	if current != nil && previous.Observed != "" &&
+		previous.Spec == failed.Spec && previous.Observed == hash.HashObject(current) {
+		// up-to-date
+		return previous, nil
+	}
+
+	log.Info("Applying role mapping", "namespace", es.Namespace, "es_name", es.Name, "name", roleMapping.Name)
+	if err := esClient.UpsertRoleMapping(ctx, roleMapping.Name, definition); err != nil {
+		return failed, err
+	}
+	observed, err := esClient.GetRoleMapping(ctx, roleMapping.Name)
+	if err != nil || observed == nil {
+		// the role mapping has been applied but cannot be observed, try again later
+		return failed, nil
+	}
+	return appliedHashes{Spec: failed.Spec, Observed: hash.HashObject(observed)}, nil
+}
@@ -0,0 +1,155 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package rolemapping
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	policyv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/stackconfigpolicy/v1alpha1"
+	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+// fakeRoleMappingClient stores role mappings by name.
+type fakeRoleMappingClient struct {
+	roleMappings map[string]map[string]interface{}
+	calls        []string
+	failing      map[string]bool
+}
+
+func newFakeClient() *fakeRoleMappingClient {
+	return &fakeRoleMappingClient{
+		roleMappings: map[string]map[string]interface{}{},
+		failing:      map[string]bool{},
+	}
+}
+
+func (f *fakeRoleMappingClient) GetRoleMapping(_ context.Context, name string) (map[string]interface{}, error) {
+	roleMapping, exists := f.roleMappings[name]
+	if !exists {
+		apiErr := esclient.FakeAPIError(404)
+		return nil, &apiErr
+	}
+	return roleMapping, nil
+}
+
+func (f *fakeRoleMappingClient) UpsertRoleMapping(_ context.Context, name string, roleMapping map[string]interface{}) error {
+	if f.failing[name] {
+		return errors.New("illegal_argument_exception")
+	}
+	f.calls = append(f.calls, "upsert "+name)
+	f.roleMappings[name] = roleMapping
+	return nil
+}
+
+func (f *fakeRoleMappingClient) DeleteRoleMapping(_ context.Context, name string) error {
+	f.calls = append(f.calls, "delete "+name)
+	delete(f.roleMappings, name)
+	return nil
+}
+
+var _ esclient.RoleMappingClient = &fakeRoleMappingClient{}
+
+func roleMapping(name string, role string) policyv1alpha1.RoleMapping {
+	return policyv1alpha1.RoleMapping{
+		Name: name,
+		Definition: &commonv1.Config{Data: map[string]interface{}{
+			"enabled": true,
+			"roles":   []interface{}{role},
+			"rules":   map[string]interface{}{"field": map[string]interface{}{"groups": "cn=" + name}},
+		}},
+	}
+}
+
+func newES() esv1.Elasticsearch {
+	return esv1.Elasticsearch{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "es"}}
+}
+
+// reconcile runs the reconciliation and returns the updated Elasticsearch resource.
+func reconcile(t *testing.T, c k8s.Client, esClient esclient.RoleMappingClient, es esv1.Elasticsearch, declared []policyv1alpha1.RoleMapping) esv1.Elasticsearch {
+	t.Helper()
+	require.NoError(t, Reconcile(context.Background(), c, esClient, &es, declared))
+	var stored esv1.Elasticsearch
+	require.NoError(t, c.Get(context.Background(), k8s.ExtractNamespacedName(&es), &stored))
+	require.Equal(t, stored.ResourceVersion, es.ResourceVersion)
+	require.Equal(t, stored.Annotations[AppliedRoleMappingsAnnotationName], es.Annotations[AppliedRoleMappingsAnnotationName])
+	return es
+}
+
+func TestReconcile(t *testing.T) {
+	declared := []policyv1alpha1.RoleMapping{roleMapping("admins", "superuser"), roleMapping("viewers", "viewer")}
+
+	t.Run("nothing declared, nothing applied: no Elasticsearch call", func(t *testing.T) {
+		es := newES()
+		require.NoError(t, Reconcile(context.Background(), k8s.NewFakeClient(&es), nil, &es, nil))
+	})
+
+	t.Run("role mappings are applied, then left untouched", func(t *testing.T) {
+		es := newES()
+		c := k8s.NewFakeClient(&es)
+		esClient := newFakeClient()
+
+		es = reconcile(t, c, esClient, es, declared)
+		require.Equal(t, []string{"upsert admins", "upsert viewers"}, esClient.calls)
+		require.Len(t, getAppliedRoleMappings(es), 2)
+
+		esClient.calls = nil
+		_ = reconcile(t, c, esClient, es, declared)
+		require.Empty(t, esClient.calls)
+	})
+
+	t.Run("changed definitions and drift in Elasticsearch are applied again", func(t *testing.T) {
+		es := newES()
+		c := k8s.NewFakeClient(&es)
+		esClient := newFakeClient()
+		es = reconcile(t, c, esClient, es, declared)
+
+		esClient.calls = nil
+		delete(esClient.roleMappings, "viewers")
+		changed := []policyv1alpha1.RoleMapping{roleMapping("admins", "admin"), roleMapping("viewers", "viewer")}
+		_ = reconcile(t, c, esClient, es, changed)
+		require.Equal(t, []string{"upsert admins", "upsert viewers"}, esClient.calls)
+		require.Equal(t, []interface{}{"admin"}, esClient.roleMappings["admins"]["roles"])
+	})
+
+	t.Run("undeclared role mappings are deleted, role mappings not applied by the operator are left untouched", func(t *testing.T) {
+		es := newES()
+		c := k8s.NewFakeClient(&es)
+		esClient := newFakeClient()
+		require.NoError(t, esClient.UpsertRoleMapping(context.Background(), "user-mapping", map[string]interface{}{}))
+		es = reconcile(t, c, esClient, es, declared)
+
+		esClient.calls = nil
+		es = reconcile(t, c, esClient, es, nil)
+		require.Equal(t, []string{"delete admins", "delete viewers"}, esClient.calls)
+		require.Contains(t, esClient.roleMappings, "user-mapping")
+		require.NotContains(t, es.Annotations, AppliedRoleMappingsAnnotationName)
+	})
+
+	t.Run("errors are returned and the role mapping applied again during the next reconciliation", func(t *testing.T) {
+		es := newES()
+		c := k8s.NewFakeClient(&es)
+		esClient := newFakeClient()
+		esClient.failing["admins"] = true
+
+		err := Reconcile(context.Background(), c, esClient, &es, declared)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "illegal_argument_exception")
+		// the other role mappings are still applied
+		require.Equal(t, []string{"upsert viewers"}, esClient.calls)
+
+		esClient.calls = nil
+		esClient.failing = map[string]bool{}
+		_ = reconcile(t, c, esClient, es, declared)
+		require.Equal(t, []string{"upsert admins"}, esClient.calls)
+	})
+}
@@ -25,7 +25,8 @@ const nodeAttrK8sNodeName = "k8s_node_name"
 var nodeAttrNodeName = fmt.Sprintf("%s.%s", esv1.NodeAttr, nodeAttrK8sNodeName)
 
 // NewMergedESConfig merges user provided Elasticsearch configuration with configuration derived from the given
-// parameters. The user provided config overrides have precedence over the ECK config.
+// parameters. The user provided config overrides have precedence over the ECK config. The configuration enforced by
+// StackConfigPolicies, if any, has precedence over both: user provided settings which conflict with it are discarded.
 func NewMergedESConfig(
 	clusterName string,
 	ver version.Version,
@@ -33,6 +34,7 @@ func NewMergedESConfig(
 	httpConfig commonv1.HTTPConfig,
 	userConfig commonv1.Config,
 	monitoringConfig commonv1.Config,
+	policyConfig *common.CanonicalConfig,
 ) (CanonicalConfig, error) {
 	userCfg, err := common.NewCanonicalConfigFrom(userConfig.Data)
 	if err != nil {
@@ -51,6 +53,9 @@ func NewMergedESConfig(
 	if err != nil {
 		return CanonicalConfig{}, err
 	}
+	if err := config.OverrideWith(policyConfig); err != nil {
+		return CanonicalConfig{}, err
+	}
 	return CanonicalConfig{config}, nil
 }
 
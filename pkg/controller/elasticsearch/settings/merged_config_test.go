@@ -10,6 +10,7 @@ import (
 
 	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
 	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	common "github.com/elastic/cloud-on-k8s/pkg/controller/common/settings"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
@@ -40,6 +41,7 @@ func TestNewMergedESConfig(t *testing.T) {
 		version  string
 		ipFamily corev1.IPFamily
 		cfgData  map[string]interface{}
+		policy   map[string]interface{}
 		assert   func(cfg CanonicalConfig)
 	}{
 		{
@@ -208,11 +210,38 @@ func TestNewMergedESConfig(t *testing.T) {
 				require.Equal(t, "[${POD_IP}]", esCfg.Network.PublishHost)
 			},
 		},
+		{
+			name:     "policy configuration has precedence over the user configuration",
+			version:  "7.15.0",
+			ipFamily: corev1.IPv4Protocol,
+			cfgData: map[string]interface{}{
+				"indices.memory.index_buffer_size": "10%",
+				"node.attr.zones":                  []interface{}{"a", "b"},
+				"node.store.allow_mmap":            false,
+			},
+			policy: map[string]interface{}{
+				"indices.memory.index_buffer_size": "20%",
+				"node.attr.zones":                  []interface{}{"c"},
+			},
+			assert: func(cfg CanonicalConfig) {
+				var esCfg map[string]interface{}
+				require.NoError(t, cfg.CanonicalConfig.Unpack(&esCfg))
+				require.Equal(t, map[string]interface{}{"index_buffer_size": "20%"}, esCfg["indices"].(map[string]interface{})["memory"])
+				node := esCfg["node"].(map[string]interface{})
+				require.Equal(t, []interface{}{"c"}, node["attr"].(map[string]interface{})["zones"])
+				require.Equal(t, false, node["store"].(map[string]interface{})["allow_mmap"])
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ver, err := version.Parse(tt.version)
 			require.NoError(t, err)
+			var policyCfg *common.CanonicalConfig
+			if tt.policy != nil {
+				policyCfg, err = common.NewCanonicalConfigFrom(tt.policy)
+				require.NoError(t, err)
+			}
 			cfg, err := NewMergedESConfig(
 				"clusterName",
 				ver,
@@ -220,6 +249,7 @@ func TestNewMergedESConfig(t *testing.T) {
 				commonv1.HTTPConfig{},
 				commonv1.Config{Data: tt.cfgData},
 				commonv1.Config{},
+				policyCfg,
 			)
 			require.NoError(t, err)
 			tt.assert(cfg)
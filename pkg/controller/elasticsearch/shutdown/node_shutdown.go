@@ -0,0 +1,220 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/go-logr/logr"
+
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/stringsutil"
+)
+
+// MinVersion is the minimum version of Elasticsearch supporting the node shutdown API.
+var MinVersion = version.From(7, 15, 0)
+
+// IsSupported returns true if all the nodes of a cluster running the given minimum version support the node
+// shutdown API.
+func IsSupported(minVersion version.Version) bool {
+	return minVersion.GTE(MinVersion)
+}
+
+// ErrNodeNotFound is returned when the status of the shutdown of a node which is not a member of the cluster is
+// requested: the node cannot be prepared for the shutdown.
+var ErrNodeNotFound = errors.New("node is not a member of the cluster")
+
+// Client captures the Elasticsearch API calls required to manage node shutdowns.
+type Client interface {
+	esclient.ShutdownClient
+	// GetNodes returns the nodes of the cluster, to map the names of the nodes to their id.
+	GetNodes(ctx context.Context) (esclient.Nodes, error)
+}
+
+// NodeShutdownStatus is the status of the shutdown of a node, along with an explanation if the node is not ready to
+// be shut down.
+type NodeShutdownStatus struct {
+	Status      esclient.ShutdownStatus
+	Explanation string
+}
+
+// NodeShutdown manages the shutdown records of a given type, registered by the operator for the nodes of a cluster.
+// Shutdown records of another type or registered with another reason are never modified.
+// The shutdown records and the ids of the nodes are requested from Elasticsearch only once, at first call, and
+// requested again after a shutdown record has been added or deleted.
+type NodeShutdown struct {
+	c            Client
+	shutdownType esclient.ShutdownType
+	reason       string
+	log          logr.Logger
+
+	once      *sync.Once
+	nodeIDs   map[string]string
+	shutdowns map[string]esclient.NodeShutdown
+}
+
+// NewNodeShutdown returns a NodeShutdown managing the shutdown records of the given type and reason.
+func NewNodeShutdown(c Client, shutdownType esclient.ShutdownType, reason string, log logr.Logger) *NodeShutdown {
+	return &NodeShutdown{
+		c:            c,
+		shutdownType: shutdownType,
+		reason:       reason,
+		log:          log,
+		once:         &sync.Once{},
+	}
+}
+
+// initialize requests Elasticsearch for the ids of the nodes and their shutdown records, only once.
+func (ns *NodeShutdown) initialize(ctx context.Context) error {
+	var err error
+	ns.once.Do(func() {
+		err = ns.load(ctx)
+	})
+	return err
+}
+
+func (ns *NodeShutdown) load(ctx context.Context) error {
+	nodes, err := ns.c.GetNodes(ctx)
+	if err != nil {
+		return err
+	}
+	ns.nodeIDs = make(map[string]string, len(nodes.Nodes))
+	for id, node := range nodes.Nodes {
+		ns.nodeIDs[node.Name] = id
+	}
+	response, err := ns.c.GetShutdown(ctx)
+	if err != nil {
+		return err
+	}
+	ns.shutdowns = make(map[string]esclient.NodeShutdown, len(response.Nodes))
+	for _, shutdown := range response.Nodes {
+		ns.shutdowns[shutdown.NodeID] = shutdown
+	}
+	return nil
+}
+
+// invalidate ensures the shutdown records are requested again from Elasticsearch on next call.
+func (ns *NodeShutdown) invalidate() {
+	ns.once = &sync.Once{}
+}
+
+// owned returns true if the given shutdown record has been registered by this NodeShutdown.
+func (ns *NodeShutdown) owned(shutdown esclient.NodeShutdown) bool {
+	return shutdown.Is(ns.shutdownType) && shutdown.Reason == ns.reason
+}
+
+// Request registers a shutdown record for each of the given nodes, if not already registered. Nodes which are not
+// members of the cluster are ignored, as well as nodes with a shutdown record of another type, which must be deleted
+// first by the operation which registered it.
+func (ns *NodeShutdown) Request(ctx context.Context, nodeNames []string) error {
+	if err := ns.initialize(ctx); err != nil {
+		return err
+	}
+	updated := false
+	defer func() {
+		if updated {
+			ns.invalidate()
+		}
+	}()
+	for _, name := range nodeNames {
+		id, exists := ns.nodeIDs[name]
+		if !exists {
+			ns.log.V(1).Info("Node not in the cluster, skipping shutdown request", "node", name, "type", ns.shutdownType)
+			continue
+		}
+		if shutdown, exists := ns.shutdowns[id]; exists {
+			if !shutdown.Is(ns.shutdownType) {
+				ns.log.Info("Node already being shut down for another reason, skipping shutdown request",
+					"node", name, "type", ns.shutdownType, "existing_type", shutdown.Type, "existing_reason", shutdown.Reason)
+			}
+			continue
+		}
+		ns.log.Info("Requesting node shutdown", "node", name, "node_id", id, "type", ns.shutdownType)
+		if err := ns.c.PutShutdown(ctx, id, ns.shutdownType, ns.reason); err != nil {
+			return fmt.Errorf("while requesting the shutdown of node %s: %w", name, err)
+		}
+		updated = true
+	}
+	return nil
+}
+
+// Clear deletes the shutdown records registered for the given nodes, if any.
+func (ns *NodeShutdown) Clear(ctx context.Context, nodeNames []string) error {
+	if err := ns.initialize(ctx); err != nil {
+		return err
+	}
+	var ids []string
+	for _, name := range nodeNames {
+		if id, exists := ns.nodeIDs[name]; exists {
+			ids = append(ids, id)
+		}
+	}
+	return ns.deleteOwned(ctx, func(shutdown esclient.NodeShutdown) bool {
+		return stringsutil.StringInSlice(shutdown.NodeID, ids)
+	})
+}
+
+// ReconcileShutdowns registers a shutdown record for each of the given nodes, and deletes the shutdown records
+// registered for any other node, including the nodes which are not members of the cluster anymore.
+func (ns *NodeShutdown) ReconcileShutdowns(ctx context.Context, nodeNames []string) error {
+	if err := ns.Request(ctx, nodeNames); err != nil {
+		return err
+	}
+	if err := ns.initialize(ctx); err != nil {
+		return err
+	}
+	ids := make([]string, 0, len(nodeNames))
+	for _, name := range nodeNames {
+		if id, exists := ns.nodeIDs[name]; exists {
+			ids = append(ids, id)
+		}
+	}
+	return ns.deleteOwned(ctx, func(shutdown esclient.NodeShutdown) bool {
+		return !stringsutil.StringInSlice(shutdown.NodeID, ids)
+	})
+}
+
+// deleteOwned deletes the shutdown records registered by this NodeShutdown which match the given predicate.
+func (ns *NodeShutdown) deleteOwned(ctx context.Context, matches func(esclient.NodeShutdown) bool) error {
+	updated := false
+	defer func() {
+		if updated {
+			ns.invalidate()
+		}
+	}()
+	for id, shutdown := range ns.shutdowns {
+		if !ns.owned(shutdown) || !matches(shutdown) {
+			continue
+		}
+		ns.log.Info("Deleting node shutdown", "node_id", id, "type", ns.shutdownType)
+		if err := ns.c.DeleteShutdown(ctx, id); err != nil {
+			return fmt.Errorf("while deleting the shutdown of node %s: %w", id, err)
+		}
+		updated = true
+	}
+	return nil
+}
+
+// ShutdownStatus returns the status of the shutdown of the given node. The status is NOT_STARTED if no shutdown record
+// of the managed type is registered for the node. ErrNodeNotFound is returned if the node is not a member of the
+// cluster.
+func (ns *NodeShutdown) ShutdownStatus(ctx context.Context, nodeName string) (NodeShutdownStatus, error) {
+	if err := ns.initialize(ctx); err != nil {
+		return NodeShutdownStatus{}, err
+	}
+	id, exists := ns.nodeIDs[nodeName]
+	if !exists {
+		return NodeShutdownStatus{}, fmt.Errorf("%w: %s", ErrNodeNotFound, nodeName)
+	}
+	shutdown, exists := ns.shutdowns[id]
+	if !exists || !shutdown.Is(ns.shutdownType) {
+		return NodeShutdownStatus{Status: esclient.ShutdownNotStarted}, nil
+	}
+	return NodeShutdownStatus{Status: shutdown.Status, Explanation: shutdown.ShardMigration.Explanation}, nil
+}
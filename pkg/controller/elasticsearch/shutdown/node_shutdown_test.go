@@ -0,0 +1,158 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	ulog "github.com/elastic/cloud-on-k8s/pkg/utils/log"
+)
+
+const testReason = "test"
+
+// fakeClient keeps the shutdown records in memory, and counts the requests to retrieve them.
+type fakeClient struct {
+	nodes     esclient.Nodes
+	shutdowns map[string]esclient.NodeShutdown
+	getCalls  int
+}
+
+func newFakeClient(nodeIDs map[string]string, shutdowns ...esclient.NodeShutdown) *fakeClient {
+	c := &fakeClient{
+		nodes:     esclient.Nodes{Nodes: map[string]esclient.Node{}},
+		shutdowns: map[string]esclient.NodeShutdown{},
+	}
+	for name, id := range nodeIDs {
+		c.nodes.Nodes[id] = esclient.Node{Name: name}
+	}
+	for _, s := range shutdowns {
+		c.shutdowns[s.NodeID] = s
+	}
+	return c
+}
+
+func (f *fakeClient) GetNodes(_ context.Context) (esclient.Nodes, error) {
+	return f.nodes, nil
+}
+
+func (f *fakeClient) GetShutdown(_ context.Context) (esclient.ShutdownResponse, error) {
+	f.getCalls++
+	var response esclient.ShutdownResponse
+	for _, s := range f.shutdowns {
+		response.Nodes = append(response.Nodes, s)
+	}
+	return response, nil
+}
+
+func (f *fakeClient) PutShutdown(_ context.Context, nodeID string, shutdownType esclient.ShutdownType, reason string) error {
+	f.shutdowns[nodeID] = esclient.NodeShutdown{
+		NodeID: nodeID,
+		Type:   string(shutdownType),
+		Reason: reason,
+		Status: esclient.ShutdownInProgress,
+	}
+	return nil
+}
+
+func (f *fakeClient) DeleteShutdown(_ context.Context, nodeID string) error {
+	delete(f.shutdowns, nodeID)
+	return nil
+}
+
+// shutdownNodes returns the ids of the nodes with a shutdown record, sorted.
+func (f *fakeClient) shutdownNodes() []string {
+	ids := make([]string, 0, len(f.shutdowns))
+	for id := range f.shutdowns {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestIsSupported(t *testing.T) {
+	require.False(t, IsSupported(version.MustParse("6.8.0")))
+	require.False(t, IsSupported(version.MustParse("7.14.2")))
+	require.True(t, IsSupported(version.MustParse("7.15.0")))
+	require.True(t, IsSupported(version.MustParse("8.0.0")))
+}
+
+func TestNodeShutdown_Request(t *testing.T) {
+	c := newFakeClient(
+		map[string]string{"node-0": "id-0", "node-1": "id-1", "node-2": "id-2"},
+		// registered for another operation
+		esclient.NodeShutdown{NodeID: "id-2", Type: "RESTART", Reason: "other", Status: esclient.ShutdownComplete},
+	)
+	ns := NewNodeShutdown(c, esclient.Remove, testReason, ulog.Log)
+
+	require.NoError(t, ns.Request(context.Background(), []string{"node-0", "node-2", "node-not-in-cluster"}))
+	require.Equal(t, []string{"id-0", "id-2"}, c.shutdownNodes())
+	// the record of the other operation is not modified
+	require.Equal(t, "other", c.shutdowns["id-2"].Reason)
+
+	// records are requested again after an update
+	status, err := ns.ShutdownStatus(context.Background(), "node-0")
+	require.NoError(t, err)
+	require.Equal(t, NodeShutdownStatus{Status: esclient.ShutdownInProgress}, status)
+	require.Equal(t, 2, c.getCalls)
+
+	// but not more than once
+	status, err = ns.ShutdownStatus(context.Background(), "node-1")
+	require.NoError(t, err)
+	require.Equal(t, NodeShutdownStatus{Status: esclient.ShutdownNotStarted}, status)
+	status, err = ns.ShutdownStatus(context.Background(), "node-2")
+	require.NoError(t, err)
+	require.Equal(t, NodeShutdownStatus{Status: esclient.ShutdownNotStarted}, status)
+	require.Equal(t, 2, c.getCalls)
+
+	_, err = ns.ShutdownStatus(context.Background(), "node-not-in-cluster")
+	require.True(t, errors.Is(err, ErrNodeNotFound))
+}
+
+func TestNodeShutdown_ReconcileShutdowns(t *testing.T) {
+	c := newFakeClient(
+		map[string]string{"node-0": "id-0", "node-1": "id-1", "node-2": "id-2"},
+		esclient.NodeShutdown{NodeID: "id-0", Type: "REMOVE", Reason: testReason, Status: esclient.ShutdownStalled,
+			ShardMigration: esclient.ShardMigration{Status: esclient.ShutdownStalled, Explanation: "cannot move"}},
+		// not leaving anymore
+		esclient.NodeShutdown{NodeID: "id-1", Type: "REMOVE", Reason: testReason, Status: esclient.ShutdownInProgress},
+		// already removed from the cluster
+		esclient.NodeShutdown{NodeID: "id-3", Type: "REMOVE", Reason: testReason, Status: esclient.ShutdownComplete},
+		// registered for another operation
+		esclient.NodeShutdown{NodeID: "id-4", Type: "REMOVE", Reason: "other", Status: esclient.ShutdownComplete},
+	)
+	ns := NewNodeShutdown(c, esclient.Remove, testReason, ulog.Log)
+
+	require.NoError(t, ns.ReconcileShutdowns(context.Background(), []string{"node-0", "node-2"}))
+	require.Equal(t, []string{"id-0", "id-2", "id-4"}, c.shutdownNodes())
+
+	status, err := ns.ShutdownStatus(context.Background(), "node-0")
+	require.NoError(t, err)
+	require.Equal(t, NodeShutdownStatus{Status: esclient.ShutdownStalled, Explanation: "cannot move"}, status)
+
+	// no more leaving nodes
+	require.NoError(t, ns.ReconcileShutdowns(context.Background(), nil))
+	require.Equal(t, []string{"id-4"}, c.shutdownNodes())
+}
+
+func TestNodeShutdown_Clear(t *testing.T) {
+	c := newFakeClient(
+		map[string]string{"node-0": "id-0", "node-1": "id-1"},
+		esclient.NodeShutdown{NodeID: "id-0", Type: "RESTART", Reason: testReason, Status: esclient.ShutdownComplete},
+		esclient.NodeShutdown{NodeID: "id-1", Type: "RESTART", Reason: testReason, Status: esclient.ShutdownComplete},
+		// node restarting, not back in the cluster yet
+		esclient.NodeShutdown{NodeID: "id-2", Type: "RESTART", Reason: testReason, Status: esclient.ShutdownComplete},
+	)
+	ns := NewNodeShutdown(c, esclient.Restart, testReason, ulog.Log)
+
+	require.NoError(t, ns.Clear(context.Background(), []string{"node-0", "node-2"}))
+	require.Equal(t, []string{"id-1", "id-2"}, c.shutdownNodes())
+}
@@ -0,0 +1,30 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package snapshot
+
+import (
+	"context"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/annotation"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/set"
+)
+
+const (
+	// ManagedRepositoriesAnnotationName holds the list of the snapshot repositories which have been created by the operator.
+	ManagedRepositoriesAnnotationName = "elasticsearch.k8s.elastic.co/managed-snapshot-repositories"
+	// ManagedPoliciesAnnotationName holds the list of the snapshot lifecycle policies which have been created by the operator.
+	ManagedPoliciesAnnotationName = "elasticsearch.k8s.elastic.co/managed-snapshot-policies"
+)
+
+// annotateWithManagedNames patches the annotations of the Elasticsearch resource which keep track of the managed
+// repositories and policies, if one of them has changed. es is updated with the patched resource.
+func annotateWithManagedNames(ctx context.Context, c k8s.Client, es *esv1.Elasticsearch, repositories, policies set.StringSet) error {
+	return annotation.PatchAnnotations(ctx, c, es, map[string]string{
+		ManagedRepositoriesAnnotationName: annotation.NamesValue(repositories),
+		ManagedPoliciesAnnotationName:     annotation.NamesValue(policies),
+	})
+}
@@ -0,0 +1,279 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"go.elastic.co/apm"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/annotation"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	ulog "github.com/elastic/cloud-on-k8s/pkg/utils/log"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/set"
+)
+
+var (
+	log = ulog.Log.WithName("snapshot")
+
+	// SnapshotLifecycleManagementMinVersion is the first version of Elasticsearch which supports SLM policies.
+	SnapshotLifecycleManagementMinVersion = version.From(7, 4, 0)
+)
+
+// Reconcile ensures that the snapshot repositories and the snapshot lifecycle policies declared in the Elasticsearch
+// specification exist in Elasticsearch, and are up-to-date. Repositories and policies previously created by the
+// operator but which are not declared anymore are deleted. Repositories and policies created through the
+// Elasticsearch API are left untouched: the ones managed by the operator are tracked in annotations, es is updated with
+// the patched resource. The status of each repository declared in the specification is returned.
+func Reconcile(
+	ctx context.Context,
+	c k8s.Client,
+	esClient esclient.SnapshotClient,
+	es *esv1.Elasticsearch,
+	v version.Version,
+) ([]esv1.SnapshotRepositoryStatus, error) {
+	span, ctx := apm.StartSpan(ctx, "reconcile_snapshots", tracing.SpanTypeApp)
+	defer span.End()
+
+	managedRepositories := annotation.GetNames(es, ManagedRepositoriesAnnotationName)
+	managedPolicies := annotation.GetNames(es, ManagedPoliciesAnnotationName)
+	if !es.Spec.Snapshots.IsDefined() && len(managedRepositories) == 0 && len(managedPolicies) == 0 {
+		// nothing to do, avoid any call to the Elasticsearch API
+		return nil, nil
+	}
+
+	slmSupported := v.GTE(SnapshotLifecycleManagementMinVersion)
+
+	// track the repositories and policies before they are created
+	for _, repository := range es.Spec.Snapshots.Repositories {
+		managedRepositories[repository.Name] = struct{}{}
+	}
+	if slmSupported {
+		for _, policy := range es.Spec.Snapshots.Policies {
+			managedPolicies[policy.Name] = struct{}{}
+		}
+	}
+	if err := annotateWithManagedNames(ctx, c, es, managedRepositories, managedPolicies); err != nil {
+		return nil, err
+	}
+
+	var errs []error
+
+	statuses, err := reconcileRepositories(ctx, esClient, *es)
+	if err != nil {
+		return nil, err
+	}
+
+	if slmSupported {
+		if err := reconcilePolicies(ctx, esClient, *es); err != nil {
+			errs = append(errs, err)
+		}
+	} else if len(es.Spec.Snapshots.Policies) > 0 {
+		log.Info("Snapshot lifecycle policies are not supported by the running version of Elasticsearch, skipping",
+			"namespace", es.Namespace, "es_name", es.Name, "version", v.String())
+	}
+
+	// policies may reference repositories, delete them first
+	if slmSupported {
+		deleted, err := deleteUndeclared(ctx, managedPolicies, policyNames(*es), esClient.DeleteSnapshotLifecyclePolicy)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		removeAll(managedPolicies, deleted)
+	}
+	deleted, err := deleteUndeclared(ctx, managedRepositories, repositoryNames(*es), esClient.DeleteSnapshotRepository)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	removeAll(managedRepositories, deleted)
+
+	if err := annotateWithManagedNames(ctx, c, es, managedRepositories, managedPolicies); err != nil {
+		errs = append(errs, err)
+	}
+
+	return statuses, utilerrors.NewAggregate(errs)
+}
+
+// reconcileRepositories creates or updates the snapshot repositories declared in the specification.
+// Errors while applying a repository are reported in its status rather than returned, so that a single misconfigured
+// repository does not prevent the others from being applied.
+func reconcileRepositories(
+	ctx context.Context,
+	esClient esclient.SnapshotClient,
+	es esv1.Elasticsearch,
+) ([]esv1.SnapshotRepositoryStatus, error) {
+	if len(es.Spec.Snapshots.Repositories) == 0 {
+		return nil, nil
+	}
+
+	current, err := esClient.GetSnapshotRepositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]esv1.SnapshotRepositoryStatus, 0, len(es.Spec.Snapshots.Repositories))
+	for _, repository := range es.Spec.Snapshots.Repositories {
+		status := esv1.SnapshotRepositoryStatus{Name: repository.Name, Phase: esv1.SnapshotRepositoryReadyPhase}
+		expected := expectedRepository(repository)
+		if actual, exists := current[repository.Name]; !exists || !repositoryUpToDate(expected, actual) {
+			log.Info("Updating snapshot repository", "namespace", es.Namespace, "es_name", es.Name, "repository", repository.Name)
+			if err := esClient.UpsertSnapshotRepository(ctx, repository.Name, expected); err != nil {
+				status.Phase = esv1.SnapshotRepositoryFailedPhase
+				status.Message = err.Error()
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// reconcilePolicies creates or updates the snapshot lifecycle policies declared in the specification.
+func reconcilePolicies(ctx context.Context, esClient esclient.SnapshotClient, es esv1.Elasticsearch) error {
+	if len(es.Spec.Snapshots.Policies) == 0 {
+		return nil
+	}
+
+	current, err := esClient.GetSnapshotLifecyclePolicies(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, policy := range es.Spec.Snapshots.Policies {
+		expected := expectedPolicy(policy)
+		if actual, exists := current[policy.Name]; exists && policyUpToDate(expected, actual.Policy) {
+			continue
+		}
+		log.Info("Updating snapshot lifecycle policy", "namespace", es.Namespace, "es_name", es.Name, "policy", policy.Name)
+		if err := esClient.UpsertSnapshotLifecyclePolicy(ctx, policy.Name, expected); err != nil {
+			errs = append(errs, fmt.Errorf("while updating snapshot lifecycle policy %s: %w", policy.Name, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// deleteUndeclared deletes the managed objects which are not declared anymore, and returns the names of the ones
+// that have been deleted or do not exist anymore.
+func deleteUndeclared(
+	ctx context.Context,
+	managed, declared set.StringSet,
+	deleteFn func(context.Context, string) error,
+) ([]string, error) {
+	var deleted []string
+	var errs []error
+	for name := range managed {
+		if _, isDeclared := declared[name]; isDeclared {
+			continue
+		}
+		if err := deleteFn(ctx, name); err != nil && !esclient.IsNotFound(err) {
+			errs = append(errs, err)
+			continue
+		}
+		deleted = append(deleted, name)
+	}
+	return deleted, utilerrors.NewAggregate(errs)
+}
+
+func removeAll(names set.StringSet, toRemove []string) {
+	for _, name := range toRemove {
+		delete(names, name)
+	}
+}
+
+func repositoryNames(es esv1.Elasticsearch) set.StringSet {
+	names := make(set.StringSet, len(es.Spec.Snapshots.Repositories))
+	for _, repository := range es.Spec.Snapshots.Repositories {
+		names[repository.Name] = struct{}{}
+	}
+	return names
+}
+
+func policyNames(es esv1.Elasticsearch) set.StringSet {
+	names := make(set.StringSet, len(es.Spec.Snapshots.Policies))
+	for _, policy := range es.Spec.Snapshots.Policies {
+		names[policy.Name] = struct{}{}
+	}
+	return names
+}
+
+func expectedRepository(repository esv1.SnapshotRepository) esclient.SnapshotRepository {
+	expected := esclient.SnapshotRepository{Type: repository.Type}
+	if repository.Settings != nil {
+		expected.Settings = repository.Settings.Data
+	}
+	return expected
+}
+
+func expectedPolicy(policy esv1.SnapshotLifecyclePolicy) esclient.SnapshotLifecyclePolicy {
+	expected := esclient.SnapshotLifecyclePolicy{
+		Name:       policy.SnapshotNameOrDefault(),
+		Schedule:   policy.Schedule,
+		Repository: policy.Repository,
+	}
+	if policy.Config != nil {
+		expected.Config = policy.Config.Data
+	}
+	if policy.Retention != nil {
+		expected.Retention = &esclient.SnapshotLifecycleRetention{
+			ExpireAfter: policy.Retention.ExpireAfter,
+			MinCount:    policy.Retention.MinCount,
+			MaxCount:    policy.Retention.MaxCount,
+		}
+	}
+	return expected
+}
+
+// repositoryUpToDate compares the expected repository with the one returned by Elasticsearch.
+// Elasticsearch returns all the settings as strings, values are therefore compared using their string representation.
+func repositoryUpToDate(expected, actual esclient.SnapshotRepository) bool {
+	return expected.Type == actual.Type && reflect.DeepEqual(flatten(expected.Settings), flatten(actual.Settings))
+}
+
+// policyUpToDate compares the expected policy with the one returned by Elasticsearch.
+func policyUpToDate(expected, actual esclient.SnapshotLifecyclePolicy) bool {
+	return reflect.DeepEqual(flattenObject(expected), flattenObject(actual))
+}
+
+// flattenObject returns the flattened JSON representation of the given object.
+func flattenObject(obj interface{}) map[string]string {
+	bytes, err := json.Marshal(obj)
+	if err != nil {
+		return nil
+	}
+	var untyped map[string]interface{}
+	if err := json.Unmarshal(bytes, &untyped); err != nil {
+		return nil
+	}
+	return flatten(untyped)
+}
+
+// flatten returns a map of dotted keys to the string representation of the corresponding leaf values.
+func flatten(m map[string]interface{}) map[string]string {
+	flattened := make(map[string]string)
+	flattenInto("", m, flattened)
+	return flattened
+}
+
+func flattenInto(prefix string, m map[string]interface{}, out map[string]string) {
+	for k, v := range m {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if nested, isMap := v.(map[string]interface{}); isMap {
+			flattenInto(key, nested, out)
+			continue
+		}
+		out[key] = fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	esclient "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/client"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/pointer"
+)
+
+type fakeSnapshotClient struct {
+	repositories esclient.SnapshotRepositories
+	policies     esclient.SnapshotLifecyclePolicies
+	upserted     []string
+	deleted      []string
+	failingRepos map[string]bool
+}
+
+func newFakeSnapshotClient() *fakeSnapshotClient {
+	return &fakeSnapshotClient{
+		repositories: esclient.SnapshotRepositories{},
+		policies:     esclient.SnapshotLifecyclePolicies{},
+		failingRepos: map[string]bool{},
+	}
+}
+
+func (f *fakeSnapshotClient) GetSnapshotRepositories(_ context.Context) (esclient.SnapshotRepositories, error) {
+	return f.repositories, nil
+}
+
+func (f *fakeSnapshotClient) UpsertSnapshotRepository(_ context.Context, name string, repository esclient.SnapshotRepository) error {
+	if f.failingRepos[name] {
+		return errors.New("repository verification exception")
+	}
+	f.upserted = append(f.upserted, "repository/"+name)
+	// Elasticsearch returns settings as strings
+	settings := map[string]interface{}{}
+	for k, v := range flatten(repository.Settings) {
+		settings[k] = v
+	}
+	f.repositories[name] = esclient.SnapshotRepository{Type: repository.Type, Settings: settings}
+	return nil
+}
+
+func (f *fakeSnapshotClient) DeleteSnapshotRepository(_ context.Context, name string) error {
+	f.deleted = append(f.deleted, "repository/"+name)
+	delete(f.repositories, name)
+	return nil
+}
+
+func (f *fakeSnapshotClient) CreateSnapshot(_ context.Context, _ string, _ string) error {
+	return errors.New("not implemented")
+}
+
+func (f *fakeSnapshotClient) GetSnapshot(_ context.Context, _ string, _ string) (esclient.Snapshot, error) {
+	return esclient.Snapshot{}, errors.New("not implemented")
+}
+
+func (f *fakeSnapshotClient) GetSnapshotLifecyclePolicies(_ context.Context) (esclient.SnapshotLifecyclePolicies, error) {
+	return f.policies, nil
+}
+
+func (f *fakeSnapshotClient) UpsertSnapshotLifecyclePolicy(_ context.Context, name string, policy esclient.SnapshotLifecyclePolicy) error {
+	f.upserted = append(f.upserted, "policy/"+name)
+	f.policies[name] = esclient.SnapshotLifecyclePolicyResponse{Version: 1, Policy: policy}
+	return nil
+}
+
+func (f *fakeSnapshotClient) DeleteSnapshotLifecyclePolicy(_ context.Context, name string) error {
+	f.deleted = append(f.deleted, "policy/"+name)
+	delete(f.policies, name)
+	return nil
+}
+
+var _ esclient.SnapshotClient = &fakeSnapshotClient{}
+
+func s3Repository(name string) esv1.SnapshotRepository {
+	return esv1.SnapshotRepository{
+		Name: name,
+		Type: "s3",
+		Settings: &commonv1.Config{Data: map[string]interface{}{
+			"bucket":   "my-bucket",
+			"compress": true,
+			"client":   "default",
+		}},
+	}
+}
+
+func nightlyPolicy(name, repository string) esv1.SnapshotLifecyclePolicy {
+	return esv1.SnapshotLifecyclePolicy{
+		Name:       name,
+		Schedule:   "0 30 1 * * ?",
+		Repository: repository,
+		Config:     &commonv1.Config{Data: map[string]interface{}{"indices": []interface{}{"*"}}},
+		Retention:  &esv1.SnapshotRetention{ExpireAfter: "30d", MinCount: pointer.Int32(5)},
+	}
+}
+
+func newES(annotations map[string]string, snapshots esv1.Snapshots) esv1.Elasticsearch {
+	return esv1.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "es", Annotations: annotations},
+		Spec:       esv1.ElasticsearchSpec{Version: "7.15.0", Snapshots: snapshots},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	v7 := version.MustParse("7.15.0")
+
+	t.Run("nothing declared, nothing managed: no Elasticsearch call", func(t *testing.T) {
+		es := newES(nil, esv1.Snapshots{})
+		statuses, err := Reconcile(context.Background(), k8s.NewFakeClient(&es), nil, &es, v7)
+		require.NoError(t, err)
+		require.Nil(t, statuses)
+	})
+
+	t.Run("create, leave in place, update on drift, then delete", func(t *testing.T) {
+		es := newES(nil, esv1.Snapshots{
+			Repositories: []esv1.SnapshotRepository{s3Repository("repo")},
+			Policies:     []esv1.SnapshotLifecyclePolicy{nightlyPolicy("nightly", "repo")},
+		})
+		c := k8s.NewFakeClient(&es)
+		esClient := newFakeSnapshotClient()
+		// an existing repository created by the user through the API
+		esClient.repositories["user-repo"] = esclient.SnapshotRepository{Type: "fs"}
+
+		statuses, err := Reconcile(context.Background(), c, esClient, &es, v7)
+		require.NoError(t, err)
+		require.Equal(t, []esv1.SnapshotRepositoryStatus{{Name: "repo", Phase: esv1.SnapshotRepositoryReadyPhase}}, statuses)
+		require.Equal(t, []string{"repository/repo", "policy/nightly"}, esClient.upserted)
+		require.Equal(t, "<nightly-{now/d}>", esClient.policies["nightly"].Policy.Name)
+
+		// the Elasticsearch resource is updated in place
+		var stored esv1.Elasticsearch
+		require.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "es"}, &stored))
+		require.Equal(t, stored.ResourceVersion, es.ResourceVersion)
+		require.Equal(t, "repo", stored.Annotations[ManagedRepositoriesAnnotationName])
+		require.Equal(t, "nightly", stored.Annotations[ManagedPoliciesAnnotationName])
+		require.Equal(t, stored.Annotations, es.Annotations)
+
+		// second reconciliation: nothing changes
+		esClient.upserted = nil
+		_, err = Reconcile(context.Background(), c, esClient, &es, v7)
+		require.NoError(t, err)
+		require.Empty(t, esClient.upserted)
+
+		// drift in Elasticsearch is corrected
+		esClient.repositories["repo"] = esclient.SnapshotRepository{Type: "s3", Settings: map[string]interface{}{"bucket": "other"}}
+		_, err = Reconcile(context.Background(), c, esClient, &es, v7)
+		require.NoError(t, err)
+		require.Equal(t, []string{"repository/repo"}, esClient.upserted)
+
+		// removing everything from the spec deletes the managed objects only
+		es.Spec.Snapshots = esv1.Snapshots{}
+		_, err = Reconcile(context.Background(), c, esClient, &es, v7)
+		require.NoError(t, err)
+		require.Equal(t, []string{"policy/nightly", "repository/repo"}, esClient.deleted)
+		require.Contains(t, esClient.repositories, "user-repo")
+
+		stored = esv1.Elasticsearch{}
+		require.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "es"}, &stored))
+		require.NotContains(t, stored.Annotations, ManagedRepositoriesAnnotationName)
+		require.NotContains(t, stored.Annotations, ManagedPoliciesAnnotationName)
+	})
+
+	t.Run("repository errors are reported in the status", func(t *testing.T) {
+		es := newES(nil, esv1.Snapshots{
+			Repositories: []esv1.SnapshotRepository{s3Repository("broken"), s3Repository("repo")},
+		})
+		esClient := newFakeSnapshotClient()
+		esClient.failingRepos["broken"] = true
+		statuses, err := Reconcile(context.Background(), k8s.NewFakeClient(&es), esClient, &es, v7)
+		require.NoError(t, err)
+		require.Equal(t, []esv1.SnapshotRepositoryStatus{
+			{Name: "broken", Phase: esv1.SnapshotRepositoryFailedPhase, Message: "repository verification exception"},
+			{Name: "repo", Phase: esv1.SnapshotRepositoryReadyPhase},
+		}, statuses)
+	})
+
+	t.Run("policies are ignored if not supported", func(t *testing.T) {
+		es := newES(nil, esv1.Snapshots{
+			Policies: []esv1.SnapshotLifecyclePolicy{nightlyPolicy("nightly", "repo")},
+		})
+		esClient := newFakeSnapshotClient()
+		_, err := Reconcile(context.Background(), k8s.NewFakeClient(&es), esClient, &es, version.MustParse("7.3.0"))
+		require.NoError(t, err)
+		require.Empty(t, esClient.upserted)
+	})
+}
+
+func Test_repositoryUpToDate(t *testing.T) {
+	expected := expectedRepository(s3Repository("repo"))
+	assert.True(t, repositoryUpToDate(expected, esclient.SnapshotRepository{
+		Type:     "s3",
+		Settings: map[string]interface{}{"bucket": "my-bucket", "compress": "true", "client": "default"},
+	}))
+	assert.False(t, repositoryUpToDate(expected, esclient.SnapshotRepository{
+		Type:     "s3",
+		Settings: map[string]interface{}{"bucket": "my-bucket", "compress": "false", "client": "default"},
+	}))
+	assert.False(t, repositoryUpToDate(expected, esclient.SnapshotRepository{
+		Type:     "gcs",
+		Settings: map[string]interface{}{"bucket": "my-bucket", "compress": "true", "client": "default"},
+	}))
+}
+
+func Test_policyUpToDate(t *testing.T) {
+	expected := expectedPolicy(nightlyPolicy("nightly", "repo"))
+	actual := esclient.SnapshotLifecyclePolicy{
+		Name:       "<nightly-{now/d}>",
+		Schedule:   "0 30 1 * * ?",
+		Repository: "repo",
+		Config:     map[string]interface{}{"indices": []interface{}{"*"}},
+		Retention:  &esclient.SnapshotLifecycleRetention{ExpireAfter: "30d", MinCount: pointer.Int32(5)},
+	}
+	assert.True(t, policyUpToDate(expected, actual))
+	actual.Schedule = "0 0 * * * ?"
+	assert.False(t, policyUpToDate(expected, actual))
+}
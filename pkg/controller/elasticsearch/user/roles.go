@@ -38,6 +38,9 @@ const (
 	// data to the monitoring Elasticsearch cluster when Stack Monitoring is enabled
 	StackMonitoringUserRole = "eck_stack_mon_user_role"
 
+	// LogstashUserRole is the name of the role used by Logstash instances to connect to Elasticsearch.
+	LogstashUserRole = "eck_logstash_user_role"
+
 	// V70 indicates version 7.0
 	V70 = "v70"
 
@@ -121,6 +124,17 @@ var (
 				},
 			},
 		},
+		// LogstashUserRole grants the privileges required by the Elasticsearch output plugin of Logstash.
+		// See: https://www.elastic.co/guide/en/logstash/current/ls-security.html#ls-http-auth-basic.
+		LogstashUserRole: esclient.Role{
+			Cluster: []string{"monitor", "manage_index_templates", "manage_ilm", "read_ilm"},
+			Indices: []esclient.IndexRole{
+				{
+					Names:      []string{"logstash", "logstash-*", "ecs-logstash", "ecs-logstash-*"},
+					Privileges: []string{"write", "create", "create_index", "manage", "manage_ilm"},
+				},
+			},
+		},
 	}
 )
 
@@ -10,7 +10,9 @@ import (
 	"strings"
 
 	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/autoscaling/elasticsearch/status"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
 	"github.com/elastic/cloud-on-k8s/pkg/utils/set"
 	"github.com/elastic/cloud-on-k8s/pkg/utils/stringsutil"
 	corev1 "k8s.io/api/core/v1"
@@ -32,8 +34,11 @@ var (
 	minStorage = resource.MustParse("0")
 )
 
+// PolicyPathBuilder helps to compute the path used in validation error fields of an autoscaling policy.
+type PolicyPathBuilder func(index int, child string, moreChildren ...string) *field.Path
+
 func validAutoscalingConfiguration(es esv1.Elasticsearch) field.ErrorList {
-	if !es.IsAutoscalingDefined() {
+	if !es.IsAutoscalingAnnotationSet() {
 		return nil
 	}
 	proposedVer, err := version.Parse(es.Spec.Version)
@@ -57,18 +62,23 @@ func validAutoscalingConfiguration(es esv1.Elasticsearch) field.ErrorList {
 	}
 
 	// Attempt to unmarshall the proposed autoscaling spec.
-	autoscalingSpecification, err := es.GetAutoscalingSpecification()
+	autoscalingSpecification, err := es.GetAutoscalingSpecificationFromAnnotation()
 	if err != nil {
 		errs = append(errs, field.Invalid(
 			field.NewPath("metadata").Child("annotations", esv1.ElasticsearchAutoscalingSpecAnnotationName),
-			es.AutoscalingSpec(),
+			es.AutoscalingAnnotation(),
 			err.Error(),
 		))
 		return errs
 	}
 
+	return ValidateAutoscalingSpecification(autoscalingSpecPath, autoscalingSpecification)
+}
+
+// ValidateAutoscalingSpecification validates an autoscaling specification against the Elasticsearch cluster it applies to.
+func ValidateAutoscalingSpecification(policyPathBuilder PolicyPathBuilder, autoscalingSpecification esv1.AutoscalingSpec) field.ErrorList {
 	// Validate the autoscaling policies
-	errs = append(errs, validateAutoscalingPolicies(autoscalingSpecification.AutoscalingPolicySpecs)...)
+	errs := ValidateAutoscalingPolicies(policyPathBuilder, autoscalingSpecification.AutoscalingPolicySpecs)
 	if len(errs) > 0 {
 		// We may have policies with duplicated set of roles, it may make it hard to validate further the autoscaling spec.
 		return errs
@@ -97,7 +107,7 @@ func validAutoscalingConfiguration(es esv1.Elasticsearch) field.ErrorList {
 			// No nodeSet matches this autoscaling policy
 			errs = append(
 				errs,
-				field.Invalid(autoscalingSpecPath(i, "roles"),
+				field.Invalid(policyPathBuilder(i, "roles"),
 					policy.Roles,
 					"roles must be used in at least one nodeSet"),
 			)
@@ -122,19 +132,20 @@ func validAutoscalingConfiguration(es esv1.Elasticsearch) field.ErrorList {
 	return errs
 }
 
-func validateAutoscalingPolicies(autoscalingPolicies esv1.AutoscalingPolicySpecs) field.ErrorList {
+// ValidateAutoscalingPolicies validates the autoscaling policies, regardless of the Elasticsearch cluster they apply to.
+func ValidateAutoscalingPolicies(policyPathBuilder PolicyPathBuilder, autoscalingPolicies esv1.AutoscalingPolicySpecs) field.ErrorList {
 	var errs field.ErrorList
 	policyNames := set.Make()
 	rolesSet := make([][]string, 0, len(autoscalingPolicies))
 	for i, autoscalingSpec := range autoscalingPolicies {
 		// The name field is mandatory.
 		if len(autoscalingSpec.Name) == 0 {
-			errs = append(errs, field.Required(autoscalingSpecPath(i, "name"), "name is mandatory"))
+			errs = append(errs, field.Required(policyPathBuilder(i, "name"), "name is mandatory"))
 		} else {
 			if policyNames.Has(autoscalingSpec.Name) {
 				errs = append(
 					errs,
-					field.Invalid(autoscalingSpecPath(i, "name"), autoscalingSpec.Name, "policy is duplicated"),
+					field.Invalid(policyPathBuilder(i, "name"), autoscalingSpec.Name, "policy is duplicated"),
 				)
 			}
 			policyNames.Add(autoscalingSpec.Name)
@@ -142,14 +153,14 @@ func validateAutoscalingPolicies(autoscalingPolicies esv1.AutoscalingPolicySpecs
 
 		// Validate the set of roles managed by this autoscaling policy.
 		if autoscalingSpec.Roles == nil {
-			errs = append(errs, field.Required(autoscalingSpecPath(i, "roles"), "roles field is mandatory"))
+			errs = append(errs, field.Required(policyPathBuilder(i, "roles"), "roles field is mandatory"))
 		} else {
 			if containsStringSlice(rolesSet, autoscalingSpec.Roles) {
 				// A set of roles must be unique across all the autoscaling policies.
 				errs = append(
 					errs,
 					field.Invalid(
-						autoscalingSpecPath(i, "name"),
+						policyPathBuilder(i, "name"),
 						strings.Join(autoscalingSpec.Roles, ","),
 						"roles set is duplicated"),
 				)
@@ -163,7 +174,7 @@ func validateAutoscalingPolicies(autoscalingPolicies esv1.AutoscalingPolicySpecs
 			errs = append(
 				errs,
 				field.Invalid(
-					autoscalingSpecPath(i, "name"), strings.Join(autoscalingSpec.Roles, ","),
+					policyPathBuilder(i, "name"), strings.Join(autoscalingSpec.Roles, ","),
 					"ML nodes must be in a dedicated autoscaling policy"),
 			)
 		}
@@ -172,7 +183,7 @@ func validateAutoscalingPolicies(autoscalingPolicies esv1.AutoscalingPolicySpecs
 			errs = append(
 				errs,
 				field.Invalid(
-					autoscalingSpecPath(i, "resources", "nodeCount", "min"),
+					policyPathBuilder(i, "resources", "nodeCount", "min"),
 					autoscalingSpec.NodeCountRange.Min,
 					"min count must be equal or greater than 0",
 				),
@@ -183,7 +194,7 @@ func validateAutoscalingPolicies(autoscalingPolicies esv1.AutoscalingPolicySpecs
 			errs = append(
 				errs,
 				field.Invalid(
-					autoscalingSpecPath(i, "resources", "nodeCount", "max"),
+					policyPathBuilder(i, "resources", "nodeCount", "max"),
 					autoscalingSpec.NodeCountRange.Max,
 					"max count must be greater than 0"),
 			)
@@ -192,25 +203,25 @@ func validateAutoscalingPolicies(autoscalingPolicies esv1.AutoscalingPolicySpecs
 		if !(autoscalingSpec.NodeCountRange.Max >= autoscalingSpec.NodeCountRange.Min) {
 			errs = append(
 				errs,
-				field.Invalid(autoscalingSpecPath(i, "resources", "nodeCount", "max"),
+				field.Invalid(policyPathBuilder(i, "resources", "nodeCount", "max"),
 					autoscalingSpec.NodeCountRange.Max,
 					"max node count must be an integer greater or equal than the min node count"),
 			)
 		}
 
 		// Validate CPU
-		errs = validateQuantities(errs, autoscalingSpec.CPURange, i, "cpu", minCPU)
+		errs = validateQuantities(errs, policyPathBuilder, autoscalingSpec.CPURange, i, "cpu", minCPU)
 
 		// Validate Memory
-		errs = validateQuantities(errs, autoscalingSpec.MemoryRange, i, "memory", minMemory)
+		errs = validateQuantities(errs, policyPathBuilder, autoscalingSpec.MemoryRange, i, "memory", minMemory)
 
 		// Validate storage
-		errs = validateQuantities(errs, autoscalingSpec.StorageRange, i, "storage", minStorage)
+		errs = validateQuantities(errs, policyPathBuilder, autoscalingSpec.StorageRange, i, "storage", minStorage)
 	}
 	return errs
 }
 
-// autoscalingSpecPath helps to compute the path used in validation error fields.
+// autoscalingSpecPath helps to compute the path used in validation error fields of the deprecated autoscaling annotation.
 func autoscalingSpecPath(index int, child string, moreChildren ...string) *field.Path {
 	return field.NewPath("metadata").
 		Child("annotations", `"`+esv1.ElasticsearchAutoscalingSpecAnnotationName+`"`).
@@ -221,6 +232,7 @@ func autoscalingSpecPath(index int, child string, moreChildren ...string) *field
 // validateQuantities ensures that a quantity range is valid.
 func validateQuantities(
 	errs field.ErrorList,
+	policyPathBuilder PolicyPathBuilder,
 	quantityRange *esv1.QuantityRange,
 	index int,
 	resource string,
@@ -235,7 +247,7 @@ func validateQuantities(
 		quantityErrs = append(
 			quantityErrs,
 			field.Required(
-				autoscalingSpecPath(index, "minAllowed", resource),
+				policyPathBuilder(index, "minAllowed", resource),
 				fmt.Sprintf("min quantity must be greater than %s", minQuantity.String())),
 		)
 	}
@@ -245,7 +257,7 @@ func validateQuantities(
 		quantityErrs = append(
 			quantityErrs,
 			field.Required(
-				autoscalingSpecPath(index, "minAllowed", resource),
+				policyPathBuilder(index, "minAllowed", resource),
 				"min quantity must be greater than 0"),
 		)
 	}
@@ -254,13 +266,27 @@ func validateQuantities(
 		quantityErrs = append(
 			quantityErrs,
 			field.Invalid(
-				autoscalingSpecPath(index, "maxAllowed", resource), quantityRange.Max.String(),
+				policyPathBuilder(index, "maxAllowed", resource), quantityRange.Max.String(),
 				"max quantity must be greater or equal than min quantity"),
 		)
 	}
 	return append(errs, quantityErrs...)
 }
 
+// isAutoscaled returns true if the Elasticsearch cluster is managed by an ElasticsearchAutoscaler, or if it still holds
+// the deprecated autoscaling annotation.
+func isAutoscaled(c k8s.Client, es esv1.Elasticsearch) bool {
+	if es.IsAutoscalingAnnotationSet() {
+		return true
+	}
+	esa, err := status.GetElasticsearchAutoscaler(c, es)
+	if err != nil {
+		log.Error(err, "Error while retrieving the Elasticsearch autoscaler", "namespace", es.Namespace, "es_name", es.Name)
+		return false
+	}
+	return esa != nil
+}
+
 // containsStringSlice returns true if a slice of strings is included in a slice of slices of strings.
 func containsStringSlice(slices [][]string, slice []string) bool {
 	set1 := set.Make(slice...)
@@ -8,34 +8,50 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"time"
 
 	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
 	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
 	stackmon "github.com/elastic/cloud-on-k8s/pkg/controller/common/stackmon/validations"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
 	esversion "github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/version"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/chrono"
 	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
 	ulog "github.com/elastic/cloud-on-k8s/pkg/utils/log"
 	netutil "github.com/elastic/cloud-on-k8s/pkg/utils/net"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 var log = ulog.Log.WithName("es-validation")
 
 const (
+	autoFollowPatternNameMsg = "the name of an auto-follow pattern cannot contain a comma"
 	autoscalingVersionMsg    = "autoscaling is not available in this version of Elasticsearch"
 	cfgInvalidMsg            = "Configuration invalid"
 	duplicateNodeSets        = "NodeSet names must be unique"
 	invalidNamesErrMsg       = "Elasticsearch configuration would generate resources with invalid names"
 	invalidSanIPErrMsg       = "Invalid SAN IP address. Must be a valid IPv4 address"
+	maintenanceDurationMsg   = "maintenance window duration must be positive"
+	maintenanceScheduleMsg   = "maintenance window schedule is never activated in the next 5 years"
 	masterRequiredMsg        = "Elasticsearch needs to have at least one master node"
 	mixedRoleConfigMsg       = "Detected a combination of node.roles and %s. Use only node.roles"
 	noDowngradesMsg          = "Downgrades are not supported"
 	nodeRolesInOldVersionMsg = "node.roles setting is not available in this version of Elasticsearch"
 	parseStoredVersionErrMsg = "Cannot parse current Elasticsearch version. String format must be {major}.{minor}.{patch}[-{label}]"
+	slmVersionMsg            = "snapshot lifecycle policies are not available in this version of Elasticsearch"
+	composableTemplatesMsg   = "component and composable index templates are not available in this version of Elasticsearch"
+	objectDefinitionMsg      = "index management objects must have a definition"
 	parseVersionErrMsg       = "Cannot parse Elasticsearch version. String format must be {major}.{minor}.{patch}[-{label}]"
 	pvcImmutableErrMsg       = "volume claim templates can only have their storage requests increased, if the storage class allows volume expansion. Any other change is forbidden"
+	policyRepositoryMsg      = "snapshot lifecycle policies must reference a repository"
 	pvcNotMountedErrMsg      = "volume claim declared but volume not mounted in any container. Note that the Elasticsearch data volume should be named 'elasticsearch-data'"
+	nodeConnectionsMsg       = "the number of node connections of remote clusters cannot be set in this version of Elasticsearch"
+	proxyModeVersionMsg      = "the proxy mode of remote clusters is not available in this version of Elasticsearch"
+	proxyAddressMsg          = "a single address is expected for a remote cluster in proxy mode"
+	remoteClusterRefMsg      = "a remote cluster must reference either an Elasticsearch cluster or an external cluster"
+	replicatedRemoteMsg      = "cross-cluster replication must reference a remote cluster declared in the specification"
+	serverNameMsg            = "a server name can only be set for a remote cluster in proxy mode"
 	unsupportedConfigErrMsg  = "Configuration setting is reserved for internal use. User-configured use is unsupported"
 	unsupportedUpgradeMsg    = "Unsupported version upgrade path. Check the Elasticsearch documentation for supported upgrade paths."
 	unsupportedVersionMsg    = "Unsupported version"
@@ -44,15 +60,24 @@ const (
 type validation func(esv1.Elasticsearch) field.ErrorList
 
 // validations are the validation funcs that apply to creates or updates
-var validations = []validation{
-	noUnknownFields,
-	validName,
-	hasCorrectNodeRoles,
-	supportedVersion,
-	validSanIP,
-	validAutoscalingConfiguration,
-	validPVCNaming,
-	validMonitoring,
+func validations(k8sClient k8s.Client) []validation {
+	return []validation{
+		noUnknownFields,
+		validName,
+		func(es esv1.Elasticsearch) field.ErrorList {
+			return hasCorrectNodeRoles(es, isAutoscaled(k8sClient, es))
+		},
+		supportedVersion,
+		validSanIP,
+		validAutoscalingConfiguration,
+		validPVCNaming,
+		validMonitoring,
+		validSnapshots,
+		validIndexManagement,
+		validUpdateStrategy,
+		validRemoteClusters,
+		validCrossClusterReplication,
+	}
 }
 
 type updateValidation func(esv1.Elasticsearch, esv1.Elasticsearch) field.ErrorList
@@ -107,9 +132,9 @@ func supportedVersion(es esv1.Elasticsearch) field.ErrorList {
 
 // hasCorrectNodeRoles checks whether Elasticsearch node roles are correctly configured.
 // The rules are:
-// There must be at least one master node.
+// There must be at least one master node, unless the cluster is autoscaled: the count of the master NodeSets may not be set yet.
 // node.roles are only supported on Elasticsearch 7.9.0 and above
-func hasCorrectNodeRoles(es esv1.Elasticsearch) field.ErrorList {
+func hasCorrectNodeRoles(es esv1.Elasticsearch, autoscaled bool) field.ErrorList {
 	v, err := version.Parse(es.Spec.Version)
 	if err != nil {
 		return field.ErrorList{field.Invalid(field.NewPath("spec").Child("version"), es.Spec.Version, parseVersionErrMsg)}
@@ -145,7 +170,7 @@ func hasCorrectNodeRoles(es esv1.Elasticsearch) field.ErrorList {
 		}
 
 		// Check if this nodeSet has the master role. If autoscaling is enabled the count value in the NodeSet might not be initially set.
-		seenMaster = seenMaster || (cfg.Node.HasRole(esv1.MasterRole) && !cfg.Node.HasRole(esv1.VotingOnlyRole) && ns.Count > 0) || es.IsAutoscalingDefined()
+		seenMaster = seenMaster || (cfg.Node.HasRole(esv1.MasterRole) && !cfg.Node.HasRole(esv1.VotingOnlyRole) && ns.Count > 0) || autoscaled
 	}
 
 	if !seenMaster {
@@ -276,3 +301,220 @@ func validUpgradePath(current, proposed esv1.Elasticsearch) field.ErrorList {
 func validMonitoring(es esv1.Elasticsearch) field.ErrorList {
 	return stackmon.Validate(&es, es.Spec.Version)
 }
+
+// validSnapshots checks that snapshot repository and policy names are unique, and that snapshot lifecycle policies
+// are only declared with a version of Elasticsearch which supports them.
+func validSnapshots(es esv1.Elasticsearch) field.ErrorList {
+	var errs field.ErrorList
+	snapshotsPath := field.NewPath("spec").Child("snapshots")
+
+	repositories := make(map[string]struct{}, len(es.Spec.Snapshots.Repositories))
+	for i, repository := range es.Spec.Snapshots.Repositories {
+		if _, found := repositories[repository.Name]; found {
+			errs = append(errs, field.Duplicate(snapshotsPath.Child("repositories").Index(i).Child("name"), repository.Name))
+		}
+		repositories[repository.Name] = struct{}{}
+	}
+
+	if len(es.Spec.Snapshots.Policies) == 0 {
+		return errs
+	}
+
+	v, err := version.Parse(es.Spec.Version)
+	if err != nil {
+		return append(errs, field.Invalid(field.NewPath("spec").Child("version"), es.Spec.Version, parseVersionErrMsg))
+	}
+	if !v.GTE(version.From(7, 4, 0)) {
+		errs = append(errs, field.Invalid(snapshotsPath.Child("policies"), len(es.Spec.Snapshots.Policies), slmVersionMsg))
+	}
+
+	policies := make(map[string]struct{}, len(es.Spec.Snapshots.Policies))
+	for i, policy := range es.Spec.Snapshots.Policies {
+		policyPath := snapshotsPath.Child("policies").Index(i)
+		if _, found := policies[policy.Name]; found {
+			errs = append(errs, field.Duplicate(policyPath.Child("name"), policy.Name))
+		}
+		policies[policy.Name] = struct{}{}
+		if policy.Repository == "" {
+			errs = append(errs, field.Required(policyPath.Child("repository"), policyRepositoryMsg))
+		}
+	}
+	return errs
+}
+
+// validIndexManagement checks that index management objects have a unique name for each type and a definition, and
+// that component and index templates are only declared with a version of Elasticsearch which supports them.
+func validIndexManagement(es esv1.Elasticsearch) field.ErrorList {
+	var errs field.ErrorList
+	spec := es.Spec.IndexManagement
+	indexManagementPath := field.NewPath("spec").Child("indexManagement")
+
+	for _, objects := range []struct {
+		field   string
+		objects []esv1.IndexManagementObject
+	}{
+		{field: "ilmPolicies", objects: spec.ILMPolicies},
+		{field: "componentTemplates", objects: spec.ComponentTemplates},
+		{field: "indexTemplates", objects: spec.IndexTemplates},
+		{field: "ingestPipelines", objects: spec.IngestPipelines},
+	} {
+		names := make(map[string]struct{}, len(objects.objects))
+		for i, obj := range objects.objects {
+			objPath := indexManagementPath.Child(objects.field).Index(i)
+			if _, found := names[obj.Name]; found {
+				errs = append(errs, field.Duplicate(objPath.Child("name"), obj.Name))
+			}
+			names[obj.Name] = struct{}{}
+			if obj.Definition == nil {
+				errs = append(errs, field.Required(objPath.Child("definition"), objectDefinitionMsg))
+			}
+		}
+	}
+
+	if len(spec.ComponentTemplates) == 0 && len(spec.IndexTemplates) == 0 {
+		return errs
+	}
+	v, err := version.Parse(es.Spec.Version)
+	if err != nil {
+		return append(errs, field.Invalid(field.NewPath("spec").Child("version"), es.Spec.Version, parseVersionErrMsg))
+	}
+	if !v.GTE(version.From(7, 8, 0)) {
+		if len(spec.ComponentTemplates) > 0 {
+			errs = append(errs, field.Invalid(indexManagementPath.Child("componentTemplates"), len(spec.ComponentTemplates), composableTemplatesMsg))
+		}
+		if len(spec.IndexTemplates) > 0 {
+			errs = append(errs, field.Invalid(indexManagementPath.Child("indexTemplates"), len(spec.IndexTemplates), composableTemplatesMsg))
+		}
+	}
+	return errs
+}
+
+// validUpdateStrategy checks that the maintenance windows of the cluster and of the NodeSets have a valid schedule, which
+// is activated at least once, and a positive duration.
+func validUpdateStrategy(es esv1.Elasticsearch) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validMaintenanceWindow(es.Spec.UpdateStrategy.MaintenanceWindow,
+		field.NewPath("spec").Child("updateStrategy", "maintenanceWindow"))...)
+	for i, nodeSet := range es.Spec.NodeSets {
+		if nodeSet.UpdateStrategy == nil {
+			continue
+		}
+		errs = append(errs, validMaintenanceWindow(nodeSet.UpdateStrategy.MaintenanceWindow,
+			field.NewPath("spec").Child("nodeSets").Index(i).Child("updateStrategy", "maintenanceWindow"))...)
+	}
+	return errs
+}
+
+func validMaintenanceWindow(window *esv1.MaintenanceWindow, path *field.Path) field.ErrorList {
+	if window == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	schedule, err := chrono.ParseSchedule(window.Schedule)
+	switch {
+	case err != nil:
+		errs = append(errs, field.Invalid(path.Child("schedule"), window.Schedule, err.Error()))
+	case schedule.Next(time.Now()).IsZero():
+		// such as the 31st of February
+		errs = append(errs, field.Invalid(path.Child("schedule"), window.Schedule, maintenanceScheduleMsg))
+	}
+	if window.Duration.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("duration"), window.Duration.String(), maintenanceDurationMsg))
+	}
+	return errs
+}
+
+// validRemoteClusters checks that remote clusters have a unique name and reference either an Elasticsearch cluster or
+// an external cluster, and that the proxy mode and the number of node connections are only used with a version of
+// Elasticsearch which supports them.
+func validRemoteClusters(es esv1.Elasticsearch) field.ErrorList {
+	var errs field.ErrorList
+	remoteClustersPath := field.NewPath("spec").Child("remoteClusters")
+	names := make(map[string]struct{}, len(es.Spec.RemoteClusters))
+	proxyMode, nodeConnections := false, false
+	for i, remoteCluster := range es.Spec.RemoteClusters {
+		nodeConnections = nodeConnections || remoteCluster.NodeConnections != nil
+		remoteClusterPath := remoteClustersPath.Index(i)
+		if _, found := names[remoteCluster.Name]; found {
+			errs = append(errs, field.Duplicate(remoteClusterPath.Child("name"), remoteCluster.Name))
+		}
+		names[remoteCluster.Name] = struct{}{}
+		if remoteCluster.ElasticsearchRef.IsDefined() && remoteCluster.External != nil {
+			errs = append(errs, field.Invalid(remoteClusterPath.Child("external"), remoteCluster.Name, remoteClusterRefMsg))
+		}
+		if remoteCluster.External != nil && remoteCluster.External.CASecretName != "" {
+			// the name of the remote cluster is used as a label value to track the copy of its CA
+			for _, msg := range utilvalidation.IsValidLabelValue(remoteCluster.Name) {
+				errs = append(errs, field.Invalid(remoteClusterPath.Child("name"), remoteCluster.Name, msg))
+			}
+		}
+		if remoteCluster.ModeOrDefault() != esv1.ProxyRemoteClusterMode {
+			if remoteCluster.ServerName != "" {
+				errs = append(errs, field.Invalid(remoteClusterPath.Child("serverName"), remoteCluster.ServerName, serverNameMsg))
+			}
+			continue
+		}
+		proxyMode = true
+		if remoteCluster.External != nil && len(remoteCluster.External.Addresses) != 1 {
+			errs = append(errs, field.Invalid(remoteClusterPath.Child("external", "addresses"), remoteCluster.External.Addresses, proxyAddressMsg))
+		}
+	}
+
+	if !proxyMode && !nodeConnections {
+		return errs
+	}
+	v, err := version.Parse(es.Spec.Version)
+	if err != nil {
+		return append(errs, field.Invalid(field.NewPath("spec").Child("version"), es.Spec.Version, parseVersionErrMsg))
+	}
+	if v.GTE(version.From(7, 7, 0)) {
+		return errs
+	}
+	if proxyMode {
+		errs = append(errs, field.Invalid(remoteClustersPath, es.Spec.Version, proxyModeVersionMsg))
+	}
+	if nodeConnections {
+		errs = append(errs, field.Invalid(remoteClustersPath, es.Spec.Version, nodeConnectionsMsg))
+	}
+	return errs
+}
+
+// validCrossClusterReplication checks that auto-follow patterns and follower indices have a unique name and reference a
+// remote cluster declared in the specification.
+func validCrossClusterReplication(es esv1.Elasticsearch) field.ErrorList {
+	var errs field.ErrorList
+	ccrPath := field.NewPath("spec").Child("crossClusterReplication")
+	remoteClusters := make(map[string]struct{}, len(es.Spec.RemoteClusters))
+	for _, remoteCluster := range es.Spec.RemoteClusters {
+		remoteClusters[remoteCluster.Name] = struct{}{}
+	}
+
+	patterns := make(map[string]struct{}, len(es.Spec.CrossClusterReplication.AutoFollowPatterns))
+	for i, pattern := range es.Spec.CrossClusterReplication.AutoFollowPatterns {
+		patternPath := ccrPath.Child("autoFollowPatterns").Index(i)
+		if _, found := patterns[pattern.Name]; found {
+			errs = append(errs, field.Duplicate(patternPath.Child("name"), pattern.Name))
+		}
+		patterns[pattern.Name] = struct{}{}
+		// the names of the managed auto-follow patterns are tracked in a comma-separated annotation
+		if strings.Contains(pattern.Name, ",") {
+			errs = append(errs, field.Invalid(patternPath.Child("name"), pattern.Name, autoFollowPatternNameMsg))
+		}
+		if _, found := remoteClusters[pattern.RemoteCluster]; !found {
+			errs = append(errs, field.Invalid(patternPath.Child("remoteCluster"), pattern.RemoteCluster, replicatedRemoteMsg))
+		}
+	}
+
+	indices := make(map[string]struct{}, len(es.Spec.CrossClusterReplication.FollowerIndices))
+	for i, index := range es.Spec.CrossClusterReplication.FollowerIndices {
+		indexPath := ccrPath.Child("followerIndices").Index(i)
+		if _, found := indices[index.Name]; found {
+			errs = append(errs, field.Duplicate(indexPath.Child("name"), index.Name))
+		}
+		indices[index.Name] = struct{}{}
+		if _, found := remoteClusters[index.RemoteCluster]; !found {
+			errs = append(errs, field.Invalid(indexPath.Child("remoteCluster"), index.RemoteCluster, replicatedRemoteMsg))
+		}
+	}
+	return errs
+}
@@ -6,9 +6,15 @@ package validation
 
 import (
 	"testing"
+	"time"
 
+	autoscalingv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/autoscaling/v1alpha1"
 	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
 	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	controllerscheme "github.com/elastic/cloud-on-k8s/pkg/controller/common/scheme"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/pointer"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -158,7 +164,7 @@ func Test_hasCorrectNodeRoles(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := hasCorrectNodeRoles(tt.es)
+			result := hasCorrectNodeRoles(tt.es, false)
 			hasErrors := len(result) > 0
 			if tt.expectErrors != hasErrors {
 				t.Errorf("expectedErrors=%t hasErrors=%t result=%+v", tt.expectErrors, hasErrors, result)
@@ -167,6 +173,33 @@ func Test_hasCorrectNodeRoles(t *testing.T) {
 	}
 }
 
+func TestValidateElasticsearch_autoscaledMasterTier(t *testing.T) {
+	controllerscheme.SetupScheme()
+	cluster := es("7.15.0")
+	cluster.Spec.NodeSets = []esv1.NodeSet{
+		{Name: "master", Count: 0, Config: &commonv1.Config{Data: map[string]interface{}{esv1.NodeRoles: []string{"master"}}}},
+		{Name: "data", Count: 0, Config: &commonv1.Config{Data: map[string]interface{}{esv1.NodeRoles: []string{"data"}}}},
+	}
+	autoscaler := autoscalingv1alpha1.ElasticsearchAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "autoscaler"},
+		Spec: autoscalingv1alpha1.ElasticsearchAutoscalerSpec{
+			ElasticsearchRef: autoscalingv1alpha1.ElasticsearchRef{Name: "foo"},
+			AutoscalingPolicySpecs: esv1.AutoscalingPolicySpecs{
+				{NamedAutoscalingPolicy: esv1.NamedAutoscalingPolicy{Name: "master", AutoscalingPolicy: esv1.AutoscalingPolicy{Roles: []string{"master"}}}},
+				{NamedAutoscalingPolicy: esv1.NamedAutoscalingPolicy{Name: "data", AutoscalingPolicy: esv1.AutoscalingPolicy{Roles: []string{"data"}}}},
+			},
+		},
+	}
+
+	// the master NodeSet has no node, and no autoscaler sets its count
+	err := ValidateElasticsearch(k8s.NewFakeClient(), cluster)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), masterRequiredMsg)
+
+	// the count of the master NodeSet is managed by the autoscaler
+	require.NoError(t, ValidateElasticsearch(k8s.NewFakeClient(&autoscaler), cluster))
+}
+
 func Test_supportedVersion(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -486,6 +519,378 @@ func Test_autoscalingValidation(t *testing.T) {
 	}
 }
 
+func Test_validSnapshots(t *testing.T) {
+	repository := func(name string) esv1.SnapshotRepository {
+		return esv1.SnapshotRepository{Name: name, Type: "fs"}
+	}
+	policy := func(name, repository string) esv1.SnapshotLifecyclePolicy {
+		return esv1.SnapshotLifecyclePolicy{Name: name, Schedule: "0 30 1 * * ?", Repository: repository}
+	}
+	tests := []struct {
+		name         string
+		version      string
+		snapshots    esv1.Snapshots
+		expectErrors bool
+	}{
+		{
+			name:    "no snapshots",
+			version: "6.8.0",
+		},
+		{
+			name:    "valid repositories and policies",
+			version: "7.15.0",
+			snapshots: esv1.Snapshots{
+				Repositories: []esv1.SnapshotRepository{repository("a"), repository("b")},
+				Policies:     []esv1.SnapshotLifecyclePolicy{policy("nightly", "a"), policy("weekly", "b")},
+			},
+		},
+		{
+			name:    "repositories without policies are supported in 6.x",
+			version: "6.8.0",
+			snapshots: esv1.Snapshots{
+				Repositories: []esv1.SnapshotRepository{repository("a")},
+			},
+		},
+		{
+			name:    "policies are not supported before 7.4.0",
+			version: "7.3.2",
+			snapshots: esv1.Snapshots{
+				Repositories: []esv1.SnapshotRepository{repository("a")},
+				Policies:     []esv1.SnapshotLifecyclePolicy{policy("nightly", "a")},
+			},
+			expectErrors: true,
+		},
+		{
+			name:    "duplicate repositories",
+			version: "7.15.0",
+			snapshots: esv1.Snapshots{
+				Repositories: []esv1.SnapshotRepository{repository("a"), repository("a")},
+			},
+			expectErrors: true,
+		},
+		{
+			name:    "duplicate policies",
+			version: "7.15.0",
+			snapshots: esv1.Snapshots{
+				Repositories: []esv1.SnapshotRepository{repository("a")},
+				Policies:     []esv1.SnapshotLifecyclePolicy{policy("nightly", "a"), policy("nightly", "a")},
+			},
+			expectErrors: true,
+		},
+		{
+			name:    "policy without repository",
+			version: "7.15.0",
+			snapshots: esv1.Snapshots{
+				Policies: []esv1.SnapshotLifecyclePolicy{policy("nightly", "")},
+			},
+			expectErrors: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := es(tt.version)
+			es.Spec.Snapshots = tt.snapshots
+			actual := validSnapshots(es)
+			actualErrors := len(actual) > 0
+			if tt.expectErrors != actualErrors {
+				t.Errorf("failed validSnapshots(). Name: %v, actual %v, wanted: %v, value: %v", tt.name, actual, tt.expectErrors, tt.snapshots)
+			}
+		})
+	}
+}
+
+func Test_validIndexManagement(t *testing.T) {
+	object := func(name string) esv1.IndexManagementObject {
+		return esv1.IndexManagementObject{Name: name, Definition: &commonv1.Config{Data: map[string]interface{}{}}}
+	}
+	tests := []struct {
+		name            string
+		version         string
+		indexManagement esv1.IndexManagement
+		expectErrors    bool
+	}{
+		{
+			name:    "no objects",
+			version: "6.8.0",
+		},
+		{
+			name:    "valid objects",
+			version: "7.15.0",
+			indexManagement: esv1.IndexManagement{
+				ILMPolicies:        []esv1.IndexManagementObject{object("logs"), object("metrics")},
+				ComponentTemplates: []esv1.IndexManagementObject{object("logs")},
+				IndexTemplates:     []esv1.IndexManagementObject{object("logs")},
+				IngestPipelines:    []esv1.IndexManagementObject{object("logs")},
+			},
+		},
+		{
+			name:    "ILM policies and ingest pipelines are supported in 6.x",
+			version: "6.8.0",
+			indexManagement: esv1.IndexManagement{
+				ILMPolicies:     []esv1.IndexManagementObject{object("logs")},
+				IngestPipelines: []esv1.IndexManagementObject{object("logs")},
+			},
+		},
+		{
+			name:    "component templates are not supported before 7.8.0",
+			version: "7.7.1",
+			indexManagement: esv1.IndexManagement{
+				ComponentTemplates: []esv1.IndexManagementObject{object("logs")},
+			},
+			expectErrors: true,
+		},
+		{
+			name:    "index templates are not supported before 7.8.0",
+			version: "7.7.1",
+			indexManagement: esv1.IndexManagement{
+				IndexTemplates: []esv1.IndexManagementObject{object("logs")},
+			},
+			expectErrors: true,
+		},
+		{
+			name:    "duplicate names",
+			version: "7.15.0",
+			indexManagement: esv1.IndexManagement{
+				IngestPipelines: []esv1.IndexManagementObject{object("logs"), object("logs")},
+			},
+			expectErrors: true,
+		},
+		{
+			name:    "missing definition",
+			version: "7.15.0",
+			indexManagement: esv1.IndexManagement{
+				ILMPolicies: []esv1.IndexManagementObject{{Name: "logs"}},
+			},
+			expectErrors: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := es(tt.version)
+			es.Spec.IndexManagement = tt.indexManagement
+			actual := validIndexManagement(es)
+			actualErrors := len(actual) > 0
+			if tt.expectErrors != actualErrors {
+				t.Errorf("failed validIndexManagement(). Name: %v, actual %v, wanted: %v, value: %v", tt.name, actual, tt.expectErrors, tt.indexManagement)
+			}
+		})
+	}
+}
+
+func Test_validUpdateStrategy(t *testing.T) {
+	window := func(schedule string, duration time.Duration) *esv1.MaintenanceWindow {
+		return &esv1.MaintenanceWindow{Schedule: schedule, Duration: metav1.Duration{Duration: duration}}
+	}
+	tests := []struct {
+		name           string
+		updateStrategy esv1.UpdateStrategy
+		nodeSets       []esv1.NodeSet
+		expectErrors   bool
+	}{
+		{
+			name: "no maintenance window",
+		},
+		{
+			name:           "valid maintenance windows",
+			updateStrategy: esv1.UpdateStrategy{MaintenanceWindow: window("0 22 * * 1-5", 4*time.Hour)},
+			nodeSets: []esv1.NodeSet{
+				{Name: "hot", UpdateStrategy: &esv1.NodeSetUpdateStrategy{MaintenanceWindow: window("@daily", time.Hour)}},
+				{Name: "cold", UpdateStrategy: &esv1.NodeSetUpdateStrategy{ChangeBudget: esv1.NodeSetChangeBudget{MaxUnavailable: pointer.Int32(2)}}},
+			},
+		},
+		{
+			name:           "invalid schedule",
+			updateStrategy: esv1.UpdateStrategy{MaintenanceWindow: window("0 0 1 * * ?", 4*time.Hour)},
+			expectErrors:   true,
+		},
+		{
+			name:           "schedule never activated",
+			updateStrategy: esv1.UpdateStrategy{MaintenanceWindow: window("0 0 31 2 *", 4*time.Hour)},
+			expectErrors:   true,
+		},
+		{
+			name: "invalid duration in a node set",
+			nodeSets: []esv1.NodeSet{
+				{Name: "hot", UpdateStrategy: &esv1.NodeSetUpdateStrategy{MaintenanceWindow: window("0 22 * * *", 0)}},
+			},
+			expectErrors: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := es("7.15.0")
+			es.Spec.UpdateStrategy = tt.updateStrategy
+			es.Spec.NodeSets = tt.nodeSets
+			actual := validUpdateStrategy(es)
+			actualErrors := len(actual) > 0
+			if tt.expectErrors != actualErrors {
+				t.Errorf("failed validUpdateStrategy(). Name: %v, actual %v, wanted: %v", tt.name, actual, tt.expectErrors)
+			}
+		})
+	}
+}
+
+func Test_validRemoteClusters(t *testing.T) {
+	external := func(addresses ...string) *esv1.ExternalRemoteCluster {
+		return &esv1.ExternalRemoteCluster{Addresses: addresses, CASecretName: "remote-ca"}
+	}
+	tests := []struct {
+		name           string
+		version        string
+		remoteClusters []esv1.RemoteCluster
+		expectErrors   bool
+	}{
+		{
+			name: "no remote clusters",
+		},
+		{
+			name:    "valid remote clusters",
+			version: "7.15.0",
+			remoteClusters: []esv1.RemoteCluster{
+				{Name: "local", ElasticsearchRef: commonv1.ObjectSelector{Name: "es2"}},
+				{Name: "eu-west", External: external("10.0.0.1:9300", "10.0.0.2:9300"), NodeConnections: pointer.Int32(2)},
+				{Name: "us-east", External: external("es.us-east.example.com:9300"), Mode: esv1.ProxyRemoteClusterMode, ServerName: "es.us-east.example.com"},
+			},
+		},
+		{
+			name:    "duplicate names",
+			version: "7.15.0",
+			remoteClusters: []esv1.RemoteCluster{
+				{Name: "eu-west", ElasticsearchRef: commonv1.ObjectSelector{Name: "es2"}},
+				{Name: "eu-west", External: external("10.0.0.1:9300")},
+			},
+			expectErrors: true,
+		},
+		{
+			name:    "both a reference and an external cluster",
+			version: "7.15.0",
+			remoteClusters: []esv1.RemoteCluster{
+				{Name: "eu-west", ElasticsearchRef: commonv1.ObjectSelector{Name: "es2"}, External: external("10.0.0.1:9300")},
+			},
+			expectErrors: true,
+		},
+		{
+			name:    "several addresses in proxy mode",
+			version: "7.15.0",
+			remoteClusters: []esv1.RemoteCluster{
+				{Name: "eu-west", External: external("10.0.0.1:9300", "10.0.0.2:9300"), Mode: esv1.ProxyRemoteClusterMode},
+			},
+			expectErrors: true,
+		},
+		{
+			name:    "server name in sniff mode",
+			version: "7.15.0",
+			remoteClusters: []esv1.RemoteCluster{
+				{Name: "eu-west", External: external("10.0.0.1:9300"), ServerName: "es.eu-west.example.com"},
+			},
+			expectErrors: true,
+		},
+		{
+			name:    "node connections not supported",
+			version: "7.6.2",
+			remoteClusters: []esv1.RemoteCluster{
+				{Name: "eu-west", External: external("10.0.0.1:9300"), NodeConnections: pointer.Int32(5)},
+			},
+			expectErrors: true,
+		},
+		{
+			name:    "proxy mode not supported",
+			version: "7.6.2",
+			remoteClusters: []esv1.RemoteCluster{
+				{Name: "eu-west", External: external("es.eu-west.example.com:9300"), Mode: esv1.ProxyRemoteClusterMode},
+			},
+			expectErrors: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := es(tt.version)
+			es.Spec.RemoteClusters = tt.remoteClusters
+			actual := validRemoteClusters(es)
+			actualErrors := len(actual) > 0
+			if tt.expectErrors != actualErrors {
+				t.Errorf("failed validRemoteClusters(). Name: %v, actual %v, wanted: %v", tt.name, actual, tt.expectErrors)
+			}
+		})
+	}
+}
+
+func Test_validCrossClusterReplication(t *testing.T) {
+	remoteClusters := []esv1.RemoteCluster{{Name: "eu-west", ElasticsearchRef: commonv1.ObjectSelector{Name: "es2"}}}
+	tests := []struct {
+		name         string
+		ccr          esv1.CrossClusterReplication
+		expectErrors bool
+	}{
+		{
+			name: "no cross-cluster replication",
+		},
+		{
+			name: "valid auto-follow patterns and follower indices",
+			ccr: esv1.CrossClusterReplication{
+				AutoFollowPatterns: []esv1.AutoFollowPattern{{Name: "logs", RemoteCluster: "eu-west", LeaderIndexPatterns: []string{"logs-*"}}},
+				FollowerIndices: []esv1.FollowerIndex{
+					{Name: "orders-copy", RemoteCluster: "eu-west", LeaderIndex: "orders"},
+					{Name: "customers-copy", RemoteCluster: "eu-west", LeaderIndex: "customers", Promote: true},
+				},
+			},
+		},
+		{
+			name: "duplicate auto-follow patterns",
+			ccr: esv1.CrossClusterReplication{
+				AutoFollowPatterns: []esv1.AutoFollowPattern{
+					{Name: "logs", RemoteCluster: "eu-west", LeaderIndexPatterns: []string{"logs-*"}},
+					{Name: "logs", RemoteCluster: "eu-west", LeaderIndexPatterns: []string{"metrics-*"}},
+				},
+			},
+			expectErrors: true,
+		},
+		{
+			name: "comma in the name of an auto-follow pattern",
+			ccr: esv1.CrossClusterReplication{
+				AutoFollowPatterns: []esv1.AutoFollowPattern{{Name: "logs,metrics", RemoteCluster: "eu-west", LeaderIndexPatterns: []string{"logs-*"}}},
+			},
+			expectErrors: true,
+		},
+		{
+			name: "auto-follow pattern referencing an unknown remote cluster",
+			ccr: esv1.CrossClusterReplication{
+				AutoFollowPatterns: []esv1.AutoFollowPattern{{Name: "logs", RemoteCluster: "us-east", LeaderIndexPatterns: []string{"logs-*"}}},
+			},
+			expectErrors: true,
+		},
+		{
+			name: "duplicate follower indices",
+			ccr: esv1.CrossClusterReplication{
+				FollowerIndices: []esv1.FollowerIndex{
+					{Name: "orders-copy", RemoteCluster: "eu-west", LeaderIndex: "orders"},
+					{Name: "orders-copy", RemoteCluster: "eu-west", LeaderIndex: "customers"},
+				},
+			},
+			expectErrors: true,
+		},
+		{
+			name: "follower index referencing an unknown remote cluster",
+			ccr: esv1.CrossClusterReplication{
+				FollowerIndices: []esv1.FollowerIndex{{Name: "orders-copy", RemoteCluster: "us-east", LeaderIndex: "orders"}},
+			},
+			expectErrors: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := es("7.15.0")
+			es.Spec.RemoteClusters = remoteClusters
+			es.Spec.CrossClusterReplication = tt.ccr
+			actual := validCrossClusterReplication(es)
+			actualErrors := len(actual) > 0
+			if tt.expectErrors != actualErrors {
+				t.Errorf("failed validCrossClusterReplication(). Name: %v, actual %v, wanted: %v", tt.name, actual, tt.expectErrors)
+			}
+		})
+	}
+}
+
 // es returns an es fixture at a given version
 func es(v string) esv1.Elasticsearch {
 	return esv1.Elasticsearch{
@@ -53,7 +53,7 @@ func (wh *validatingWebhook) InjectDecoder(d *admission.Decoder) error {
 
 func (wh *validatingWebhook) validateCreate(es esv1.Elasticsearch) error {
 	eslog.V(1).Info("validate create", "name", es.Name)
-	return ValidateElasticsearch(es)
+	return ValidateElasticsearch(wh.client, es)
 }
 
 func (wh *validatingWebhook) validateUpdate(prev esv1.Elasticsearch, curr esv1.Elasticsearch) error {
@@ -70,7 +70,7 @@ func (wh *validatingWebhook) validateUpdate(prev esv1.Elasticsearch, curr esv1.E
 			schema.GroupKind{Group: "elasticsearch.k8s.elastic.co", Kind: esv1.Kind},
 			curr.Name, errs)
 	}
-	return ValidateElasticsearch(curr)
+	return ValidateElasticsearch(wh.client, curr)
 }
 
 func (wh *validatingWebhook) Handle(_ context.Context, req admission.Request) admission.Response {
@@ -103,8 +103,8 @@ func (wh *validatingWebhook) Handle(_ context.Context, req admission.Request) ad
 	return admission.Allowed("")
 }
 
-func ValidateElasticsearch(es esv1.Elasticsearch) error {
-	errs := check(es, validations)
+func ValidateElasticsearch(k8sClient k8s.Client, es esv1.Elasticsearch) error {
+	errs := check(es, validations(k8sClient))
 	if len(errs) > 0 {
 		return apierrors.NewInvalid(
 			schema.GroupKind{Group: "elasticsearch.k8s.elastic.co", Kind: esv1.Kind},
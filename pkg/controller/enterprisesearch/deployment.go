@@ -9,9 +9,14 @@ import (
 
 	"go.elastic.co/apm"
 	appsv1 "k8s.io/api/apps/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
 	entv1 "github.com/elastic/cloud-on-k8s/pkg/apis/enterprisesearch/v1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/deployment"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/hpa"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/networkpolicy"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/pdb"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
 	"github.com/elastic/cloud-on-k8s/pkg/utils/maps"
 )
@@ -20,12 +25,45 @@ func (r *ReconcileEnterpriseSearch) reconcileDeployment(
 	ctx context.Context,
 	ent entv1.EnterpriseSearch,
 	configHash string,
-) (appsv1.Deployment, error) {
+) (appsv1.Deployment, *commonv1.AutoscalingStatus, error) {
 	span, _ := apm.StartSpan(ctx, "reconcile_deployment", tracing.SpanTypeApp)
 	defer span.End()
 
-	deploy := deployment.New(r.deploymentParams(ent, configHash))
-	return deployment.Reconcile(r.K8sClient(), deploy, &ent)
+	params := r.deploymentParams(ent, configHash)
+	reconciled, err := deployment.Reconcile(r.K8sClient(), deployment.New(params), &ent)
+	if err != nil {
+		return reconciled, nil, err
+	}
+
+	autoscalingStatus, err := hpa.Reconcile(r.K8sClient(), hpa.Params{
+		Owner:      &ent,
+		Spec:       ent.Spec.Autoscaling,
+		Deployment: params.Name,
+		Labels:     params.Labels,
+	})
+	if err != nil {
+		return reconciled, nil, err
+	}
+
+	err = pdb.Reconcile(r.K8sClient(), pdb.Params{
+		Owner:    &ent,
+		Template: ent.Spec.PodDisruptionBudget,
+		Name:     PodDisruptionBudgetName(ent.Name),
+		Selector: params.Selector,
+		Replicas: hpa.MaxReplicas(ent.Spec.Autoscaling, ent.Spec.Count),
+	})
+	if err != nil {
+		return reconciled, nil, err
+	}
+
+	return reconciled, autoscalingStatus, networkpolicy.Reconcile(r.K8sClient(), networkpolicy.Params{
+		Owner:       &ent,
+		Enabled:     r.NetworkPoliciesEnabled(ent.GetNetworkPolicySettings()),
+		Name:        networkpolicy.Name(entv1.Namer, ent.Name),
+		Labels:      params.Selector,
+		PodSelector: params.Selector,
+		Ingress:     []networkingv1.NetworkPolicyIngressRule{networkpolicy.FromOperator(r.OperatorNamespace, HTTPPort)},
+	})
 }
 
 func (r *ReconcileEnterpriseSearch) deploymentParams(ent entv1.EnterpriseSearch, configHash string) deployment.Params {
@@ -45,5 +83,6 @@ func (r *ReconcileEnterpriseSearch) deploymentParams(ent entv1.EnterpriseSearch,
 		Labels:          deploymentLabels,
 		PodTemplateSpec: podSpec,
 		Strategy:        appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType},
+		Autoscaled:      ent.Spec.Autoscaling != nil,
 	}
 }
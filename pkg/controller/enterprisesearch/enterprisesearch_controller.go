@@ -11,6 +11,7 @@ import (
 	"reflect"
 	"sync/atomic"
 
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
 	entv1 "github.com/elastic/cloud-on-k8s/pkg/apis/enterprisesearch/v1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/association"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common"
@@ -28,7 +29,10 @@ import (
 	ulog "github.com/elastic/cloud-on-k8s/pkg/utils/log"
 	"go.elastic.co/apm"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -84,6 +88,30 @@ func addWatches(c controller.Controller, r *ReconcileEnterpriseSearch) error {
 		return err
 	}
 
+	// Watch PodDisruptionBudgets
+	if err := c.Watch(&source.Kind{Type: &policyv1beta1.PodDisruptionBudget{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &entv1.EnterpriseSearch{},
+	}); err != nil {
+		return err
+	}
+
+	// Watch NetworkPolicies
+	if err := c.Watch(&source.Kind{Type: &networkingv1.NetworkPolicy{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &entv1.EnterpriseSearch{},
+	}); err != nil {
+		return err
+	}
+
+	// Watch HorizontalPodAutoscalers, to report their status
+	if err := c.Watch(&source.Kind{Type: &autoscalingv2beta2.HorizontalPodAutoscaler{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &entv1.EnterpriseSearch{},
+	}); err != nil {
+		return err
+	}
+
 	// Watch Pods, to ensure `status.version` and version upgrades are correctly reconciled on any change.
 	// Watching Deployments only may lead to missing some events.
 	if err := watches.WatchPods(c, EnterpriseSearchNameLabelName); err != nil {
@@ -249,12 +277,12 @@ func (r *ReconcileEnterpriseSearch) doReconcile(ctx context.Context, ent entv1.E
 		return reconcile.Result{}, fmt.Errorf("build config hash: %w", err)
 	}
 
-	deploy, err := r.reconcileDeployment(ctx, ent, configHash)
+	deploy, autoscalingStatus, err := r.reconcileDeployment(ctx, ent, configHash)
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("reconcile deployment: %w", err)
 	}
 
-	err = r.updateStatus(ent, deploy, svc.Name)
+	err = r.updateStatus(ent, deploy, autoscalingStatus, svc.Name)
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("updating status: %w", err)
 	}
@@ -275,7 +303,12 @@ func (r *ReconcileEnterpriseSearch) validate(ctx context.Context, ent *entv1.Ent
 	return nil
 }
 
-func (r *ReconcileEnterpriseSearch) updateStatus(ent entv1.EnterpriseSearch, deploy appsv1.Deployment, svcName string) error {
+func (r *ReconcileEnterpriseSearch) updateStatus(
+	ent entv1.EnterpriseSearch,
+	deploy appsv1.Deployment,
+	autoscalingStatus *commonv1.AutoscalingStatus,
+	svcName string,
+) error {
 	pods, err := k8s.PodsMatchingLabels(r.K8sClient(), ent.Namespace, map[string]string{EnterpriseSearchNameLabelName: ent.Name})
 	if err != nil {
 		return err
@@ -284,6 +317,7 @@ func (r *ReconcileEnterpriseSearch) updateStatus(ent entv1.EnterpriseSearch, dep
 	if err != nil {
 		return err
 	}
+	deploymentStatus.Autoscaling = autoscalingStatus
 	newStatus := entv1.EnterpriseSearchStatus{
 		DeploymentStatus: deploymentStatus,
 		ExternalService:  svcName,
@@ -502,7 +502,7 @@ func TestReconcileEnterpriseSearch_updateStatus(t *testing.T) {
 				Client:   c,
 				recorder: fakeRecorder,
 			}
-			err := r.updateStatus(tt.ent, tt.deploy, tt.svcName)
+			err := r.updateStatus(tt.ent, tt.deploy, nil, tt.svcName)
 			require.NoError(t, err)
 
 			require.Equal(t, tt.wantStatusUpdateCalled, c.updateCalled)
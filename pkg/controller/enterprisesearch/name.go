@@ -9,6 +9,7 @@ import entv1 "github.com/elastic/cloud-on-k8s/pkg/apis/enterprisesearch/v1"
 const (
 	httpServiceSuffix = "http"
 	configSuffix      = "config"
+	pdbSuffix         = "default"
 )
 
 func HTTPServiceName(entName string) string {
@@ -22,3 +23,7 @@ func DeploymentName(entName string) string {
 func ConfigName(entName string) string {
 	return entv1.Namer.Suffix(entName, configSuffix)
 }
+
+func PodDisruptionBudgetName(entName string) string {
+	return entv1.Namer.Suffix(entName, pdbSuffix)
+}
@@ -0,0 +1,198 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/association"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/net"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/stringsutil"
+)
+
+// DefaultKibanaClientTimeout is the default timeout value for Kibana requests.
+var DefaultKibanaClientTimeout = 1 * time.Minute
+
+// BasicAuth contains credentials for an Elasticsearch user authenticating against Kibana.
+type BasicAuth struct {
+	Name     string
+	Password string
+}
+
+// Client captures the information needed to interact with Kibana via HTTP.
+type Client interface {
+	StatusClient
+	SpacesClient
+	FleetClient
+	// Close idle connections in the underlying http client.
+	Close()
+}
+
+// NewKibanaClient creates a new client for the Kibana instance reachable at the given URL.
+//
+// If dialer is not nil, it will be used to create new TCP connections.
+func NewKibanaClient(
+	dialer net.Dialer,
+	kbURL string,
+	kbUser BasicAuth,
+	caCerts []*x509.Certificate,
+	timeout time.Duration,
+) Client {
+	return &baseClient{
+		Endpoint: kbURL,
+		User:     kbUser,
+		HTTP:     common.HTTPClient(dialer, caCerts, timeout),
+	}
+}
+
+// NewKibanaClientForAssociation creates a new client for the Kibana instance reachable at the given URL. It
+// authenticates with the Elasticsearch user of the given association, which must be configured.
+func NewKibanaClientForAssociation(
+	c k8s.Client,
+	dialer net.Dialer,
+	assoc commonv1.Association,
+	kbURL string,
+	caCerts []*x509.Certificate,
+) (Client, error) {
+	if !assoc.AssociationConf().IsConfigured() {
+		return nil, fmt.Errorf("association with %s is not configured yet", assoc.AssociationRef().NamespacedName())
+	}
+	username, password, err := association.ElasticsearchAuthSettings(c, assoc)
+	if err != nil {
+		return nil, err
+	}
+	return NewKibanaClient(dialer, kbURL, BasicAuth{Name: username, Password: password}, caCerts, DefaultKibanaClientTimeout), nil
+}
+
+type baseClient struct {
+	User     BasicAuth
+	HTTP     *http.Client
+	Endpoint string
+}
+
+// Close idle connections in the underlying http client.
+func (c *baseClient) Close() {
+	if c.HTTP != nil {
+		c.HTTP.CloseIdleConnections()
+	}
+}
+
+func (c *baseClient) get(ctx context.Context, pathWithQuery string, out interface{}) error {
+	return c.request(ctx, http.MethodGet, pathWithQuery, nil, out)
+}
+
+func (c *baseClient) put(ctx context.Context, pathWithQuery string, in, out interface{}) error {
+	return c.request(ctx, http.MethodPut, pathWithQuery, in, out)
+}
+
+func (c *baseClient) post(ctx context.Context, pathWithQuery string, in, out interface{}) error {
+	return c.request(ctx, http.MethodPost, pathWithQuery, in, out)
+}
+
+// request performs a new http request
+//
+// if requestObj is not nil, it's marshalled as JSON and used as the request body
+// if responseObj is not nil, it should be a pointer to a struct. The response body will be unmarshalled from JSON
+// into this struct if the status code of the response is 2xx.
+func (c *baseClient) request(ctx context.Context, method string, pathWithQuery string, requestObj, responseObj interface{}) error {
+	resp, err := c.doRequest(ctx, method, pathWithQuery, requestObj)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newAPIError(resp)
+	}
+
+	if responseObj != nil {
+		return json.NewDecoder(resp.Body).Decode(responseObj)
+	}
+	return nil
+}
+
+// doRequest sends a new http request and returns the response, whatever its status code. The caller is responsible
+// for closing the response body.
+func (c *baseClient) doRequest(ctx context.Context, method string, pathWithQuery string, requestObj interface{}) (*http.Response, error) {
+	var body io.Reader = http.NoBody
+	if requestObj != nil {
+		outData, err := json.Marshal(requestObj)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewBuffer(outData)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, stringsutil.Concat(c.Endpoint, pathWithQuery), body)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json; charset=utf-8")
+	// required by Kibana for any request which is not a GET
+	request.Header.Set("kbn-xsrf", "true")
+	if c.User != (BasicAuth{}) {
+		request.SetBasicAuth(c.User.Name, c.User.Password)
+	}
+
+	return c.HTTP.Do(request)
+}
+
+// APIError is a non 2xx response from the Kibana API.
+type APIError struct {
+	StatusCode int
+	Status     string
+	Message    string
+}
+
+// errorResponse is the body of a Kibana API error response.
+type errorResponse struct {
+	StatusCode int    `json:"statusCode"`
+	Error      string `json:"error"`
+	Message    string `json:"message"`
+}
+
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode, Status: resp.Status, Message: "unknown"}
+	// Kibana has a detailed error message in the response body
+	var errMsg errorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&errMsg); err == nil && errMsg.Message != "" {
+		apiErr.Message = errMsg.Message
+	}
+	return apiErr
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Status, e.Message)
+}
+
+// IsNotFound checks whether the error was an HTTP 404 error.
+func IsNotFound(err error) bool {
+	return isHTTPError(err, http.StatusNotFound)
+}
+
+// IsConflict checks whether the error was an HTTP 409 error.
+func IsConflict(err error) bool {
+	return isHTTPError(err, http.StatusConflict)
+}
+
+func isHTTPError(err error, statusCode int) bool {
+	apiErr := new(APIError)
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == statusCode
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_request(t *testing.T) {
+	c := NewMockClient(func(req *http.Request) *http.Response {
+		require.Equal(t, "true", req.Header.Get("kbn-xsrf"))
+		require.Equal(t, "application/json; charset=utf-8", req.Header.Get("Content-Type"))
+		user, password, ok := req.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "elastic-internal", user)
+		require.Equal(t, "secret", password)
+		return NewMockResponse(200, req, `{"id":"dev","name":"Dev"}`)
+	}).(*baseClient)
+	c.User = BasicAuth{Name: "elastic-internal", Password: "secret"}
+
+	space, err := c.GetSpace(context.Background(), "dev")
+	require.NoError(t, err)
+	require.Equal(t, Space{ID: "dev", Name: "Dev"}, space)
+}
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		name         string
+		statusCode   int
+		body         string
+		wantMessage  string
+		wantNotFound bool
+		wantConflict bool
+	}{
+		{
+			name:         "not found",
+			statusCode:   404,
+			body:         `{"statusCode":404,"error":"Not Found","message":"Saved object [space/dev] not found"}`,
+			wantMessage:  "Not Found: Saved object [space/dev] not found",
+			wantNotFound: true,
+		},
+		{
+			name:         "conflict",
+			statusCode:   409,
+			body:         `{"statusCode":409,"error":"Conflict","message":"A space with the identifier dev already exists."}`,
+			wantMessage:  "Conflict: A space with the identifier dev already exists.",
+			wantConflict: true,
+		},
+		{
+			name:        "no error message",
+			statusCode:  500,
+			body:        `not json`,
+			wantMessage: "Internal Server Error: unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewMockClient(func(req *http.Request) *http.Response {
+				return NewMockResponse(tt.statusCode, req, tt.body)
+			})
+			_, err := c.GetSpace(context.Background(), "dev")
+			require.Error(t, err)
+			apiErr := new(APIError)
+			require.True(t, errors.As(err, &apiErr))
+			require.Equal(t, tt.wantMessage, err.Error())
+			require.Equal(t, tt.wantNotFound, IsNotFound(err))
+			require.Equal(t, tt.wantConflict, IsConflict(err))
+		})
+	}
+}
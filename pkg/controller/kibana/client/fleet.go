@@ -0,0 +1,160 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// AgentPolicy is a Fleet agent policy.
+type AgentPolicy struct {
+	ID                   string   `json:"id,omitempty"`
+	Name                 string   `json:"name"`
+	Namespace            string   `json:"namespace"`
+	Description          string   `json:"description,omitempty"`
+	MonitoringEnabled    []string `json:"monitoring_enabled"`
+	IsDefaultFleetServer bool     `json:"is_default_fleet_server,omitempty"`
+}
+
+// PackagePolicy is a Fleet package policy, in the simplified format of the Fleet API.
+type PackagePolicy struct {
+	ID          string           `json:"id,omitempty"`
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Namespace   string           `json:"namespace"`
+	PolicyID    string           `json:"policy_id"`
+	Package     PackageReference `json:"package"`
+	// Inputs and Vars are maps in the simplified format used to create or update a package policy, but are returned
+	// as lists by Kibana.
+	Inputs interface{} `json:"inputs,omitempty"`
+	Vars   interface{} `json:"vars,omitempty"`
+}
+
+// PackageReference identifies an integration package.
+type PackageReference struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// EnrollmentAPIKey is an enrollment token used by Elastic Agents to enroll in Fleet with a given agent policy.
+type EnrollmentAPIKey struct {
+	ID       string `json:"id"`
+	APIKey   string `json:"api_key"`
+	PolicyID string `json:"policy_id"`
+	Active   bool   `json:"active"`
+}
+
+type FleetClient interface {
+	// SetupFleet initializes Fleet, which is required before managing any Fleet object.
+	SetupFleet(ctx context.Context) error
+	// GetAgentPolicy returns the agent policy with the given ID.
+	GetAgentPolicy(ctx context.Context, id string) (AgentPolicy, error)
+	// CreateAgentPolicy creates the given agent policy.
+	CreateAgentPolicy(ctx context.Context, policy AgentPolicy) error
+	// UpdateAgentPolicy updates the agent policy with the ID of the given agent policy.
+	UpdateAgentPolicy(ctx context.Context, policy AgentPolicy) error
+	// DeleteAgentPolicy deletes the agent policy with the given ID.
+	DeleteAgentPolicy(ctx context.Context, id string) error
+	// InstallPackage installs the given version of an integration package, if not already installed.
+	InstallPackage(ctx context.Context, pkg PackageReference) error
+	// GetPackagePolicy returns the package policy with the given ID.
+	GetPackagePolicy(ctx context.Context, id string) (PackagePolicy, error)
+	// CreatePackagePolicy creates the given package policy.
+	CreatePackagePolicy(ctx context.Context, policy PackagePolicy) error
+	// UpdatePackagePolicy updates the package policy with the ID of the given package policy.
+	UpdatePackagePolicy(ctx context.Context, policy PackagePolicy) error
+	// DeletePackagePolicy deletes the package policy with the given ID.
+	DeletePackagePolicy(ctx context.Context, id string) error
+	// GetEnrollmentAPIKeys returns the enrollment API keys of the agent policy with the given ID.
+	GetEnrollmentAPIKeys(ctx context.Context, policyID string) ([]EnrollmentAPIKey, error)
+}
+
+func (c *baseClient) SetupFleet(ctx context.Context) error {
+	return c.post(ctx, "/api/fleet/setup", nil, nil)
+}
+
+func (c *baseClient) GetAgentPolicy(ctx context.Context, id string) (AgentPolicy, error) {
+	var response struct {
+		Item AgentPolicy `json:"item"`
+	}
+	err := c.get(ctx, fmt.Sprintf("/api/fleet/agent_policies/%s", url.PathEscape(id)), &response)
+	return response.Item, err
+}
+
+func (c *baseClient) CreateAgentPolicy(ctx context.Context, policy AgentPolicy) error {
+	return c.post(ctx, "/api/fleet/agent_policies", policy, nil)
+}
+
+func (c *baseClient) UpdateAgentPolicy(ctx context.Context, policy AgentPolicy) error {
+	id := policy.ID
+	// the ID is part of the path, it cannot be updated
+	policy.ID = ""
+	return c.put(ctx, fmt.Sprintf("/api/fleet/agent_policies/%s", url.PathEscape(id)), policy, nil)
+}
+
+func (c *baseClient) DeleteAgentPolicy(ctx context.Context, id string) error {
+	request := struct {
+		AgentPolicyID string `json:"agentPolicyId"`
+	}{AgentPolicyID: id}
+	return c.post(ctx, "/api/fleet/agent_policies/delete", request, nil)
+}
+
+func (c *baseClient) InstallPackage(ctx context.Context, pkg PackageReference) error {
+	return c.post(ctx, fmt.Sprintf("/api/fleet/epm/packages/%s/%s", url.PathEscape(pkg.Name), url.PathEscape(pkg.Version)), nil, nil)
+}
+
+func (c *baseClient) GetPackagePolicy(ctx context.Context, id string) (PackagePolicy, error) {
+	var response struct {
+		Item PackagePolicy `json:"item"`
+	}
+	err := c.get(ctx, fmt.Sprintf("/api/fleet/package_policies/%s", url.PathEscape(id)), &response)
+	return response.Item, err
+}
+
+func (c *baseClient) CreatePackagePolicy(ctx context.Context, policy PackagePolicy) error {
+	return c.post(ctx, "/api/fleet/package_policies", policy, nil)
+}
+
+func (c *baseClient) UpdatePackagePolicy(ctx context.Context, policy PackagePolicy) error {
+	id := policy.ID
+	// the ID is part of the path, it cannot be updated
+	policy.ID = ""
+	return c.put(ctx, fmt.Sprintf("/api/fleet/package_policies/%s", url.PathEscape(id)), policy, nil)
+}
+
+func (c *baseClient) DeletePackagePolicy(ctx context.Context, id string) error {
+	request := struct {
+		PackagePolicyIDs []string `json:"packagePolicyIds"`
+	}{PackagePolicyIDs: []string{id}}
+	return c.post(ctx, "/api/fleet/package_policies/delete", request, nil)
+}
+
+func (c *baseClient) GetEnrollmentAPIKeys(ctx context.Context, policyID string) ([]EnrollmentAPIKey, error) {
+	// Kibana 8.x returns the keys in items, earlier versions in list
+	var response struct {
+		Items []EnrollmentAPIKey `json:"items"`
+		List  []EnrollmentAPIKey `json:"list"`
+	}
+	query := url.Values{}
+	query.Set("perPage", "100")
+	query.Set("kuery", fmt.Sprintf("policy_id:%q", policyID))
+	if err := c.get(ctx, "/api/fleet/enrollment_api_keys?"+query.Encode(), &response); err != nil {
+		return nil, err
+	}
+	keys := response.Items
+	if len(keys) == 0 {
+		keys = response.List
+	}
+	// filter on the policy ID in case the query is not supported
+	filtered := make([]EnrollmentAPIKey, 0, len(keys))
+	for _, key := range keys {
+		if key.PolicyID == policyID {
+			filtered = append(filtered, key)
+		}
+	}
+	return filtered, nil
+}
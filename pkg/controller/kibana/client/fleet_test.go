@@ -0,0 +1,112 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetAgentPolicy(t *testing.T) {
+	c := NewMockClient(func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodGet, req.Method)
+		require.Equal(t, "/api/fleet/agent_policies/eck-agent", req.URL.Path)
+		return NewMockResponse(200, req, `{"item":{"id":"eck-agent","name":"ECK Agent","namespace":"default",
+			"monitoring_enabled":["logs"],"revision":3,"status":"active"}}`)
+	})
+	policy, err := c.GetAgentPolicy(context.Background(), "eck-agent")
+	require.NoError(t, err)
+	require.Equal(t, AgentPolicy{ID: "eck-agent", Name: "ECK Agent", Namespace: "default", MonitoringEnabled: []string{"logs"}}, policy)
+}
+
+func TestClient_UpdateAgentPolicy(t *testing.T) {
+	c := NewMockClient(func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodPut, req.Method)
+		require.Equal(t, "/api/fleet/agent_policies/eck-agent", req.URL.Path)
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"name":"ECK Agent","namespace":"default","monitoring_enabled":[]}`, string(body))
+		return NewMockResponse(200, req, `{}`)
+	})
+	require.NoError(t, c.UpdateAgentPolicy(context.Background(),
+		AgentPolicy{ID: "eck-agent", Name: "ECK Agent", Namespace: "default", MonitoringEnabled: []string{}}))
+}
+
+func TestClient_CreatePackagePolicy(t *testing.T) {
+	c := NewMockClient(func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodPost, req.Method)
+		require.Equal(t, "/api/fleet/package_policies", req.URL.Path)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		require.Equal(t, map[string]interface{}{
+			"id":        "system-1",
+			"name":      "system-1",
+			"namespace": "default",
+			"policy_id": "eck-agent",
+			"package":   map[string]interface{}{"name": "system", "version": "1.13.0"},
+			"inputs":    map[string]interface{}{"system-system/metrics": map[string]interface{}{"enabled": true}},
+		}, body)
+		return NewMockResponse(200, req, `{}`)
+	})
+	require.NoError(t, c.CreatePackagePolicy(context.Background(), PackagePolicy{
+		ID:        "system-1",
+		Name:      "system-1",
+		Namespace: "default",
+		PolicyID:  "eck-agent",
+		Package:   PackageReference{Name: "system", Version: "1.13.0"},
+		Inputs:    map[string]interface{}{"system-system/metrics": map[string]interface{}{"enabled": true}},
+	}))
+}
+
+func TestClient_DeletePackagePolicy(t *testing.T) {
+	c := NewMockClient(func(req *http.Request) *http.Response {
+		require.Equal(t, http.MethodPost, req.Method)
+		require.Equal(t, "/api/fleet/package_policies/delete", req.URL.Path)
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"packagePolicyIds":["system-1"]}`, string(body))
+		return NewMockResponse(200, req, `[{"id":"system-1","success":true}]`)
+	})
+	require.NoError(t, c.DeletePackagePolicy(context.Background(), "system-1"))
+}
+
+func TestClient_GetEnrollmentAPIKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "keys in items",
+			body: `{"items":[
+				{"id":"1","api_key":"key1","policy_id":"eck-agent","active":true},
+				{"id":"2","api_key":"key2","policy_id":"other","active":true}
+			]}`,
+		},
+		{
+			name: "keys in list",
+			body: `{"list":[
+				{"id":"1","api_key":"key1","policy_id":"eck-agent","active":true},
+				{"id":"2","api_key":"key2","policy_id":"other","active":true}
+			]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewMockClient(func(req *http.Request) *http.Response {
+				require.Equal(t, "/api/fleet/enrollment_api_keys", req.URL.Path)
+				require.Equal(t, `policy_id:"eck-agent"`, req.URL.Query().Get("kuery"))
+				return NewMockResponse(200, req, tt.body)
+			})
+			keys, err := c.GetEnrollmentAPIKeys(context.Background(), "eck-agent")
+			require.NoError(t, err)
+			require.Equal(t, []EnrollmentAPIKey{{ID: "1", APIKey: "key1", PolicyID: "eck-agent", Active: true}}, keys)
+		})
+	}
+}
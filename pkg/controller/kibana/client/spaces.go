@@ -0,0 +1,44 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Space is a Kibana space.
+type Space struct {
+	ID               string   `json:"id"`
+	Name             string   `json:"name"`
+	Description      string   `json:"description,omitempty"`
+	Color            string   `json:"color,omitempty"`
+	Initials         string   `json:"initials,omitempty"`
+	DisabledFeatures []string `json:"disabledFeatures"`
+}
+
+type SpacesClient interface {
+	// GetSpace returns the space with the given ID.
+	GetSpace(ctx context.Context, id string) (Space, error)
+	// CreateSpace creates the given space.
+	CreateSpace(ctx context.Context, space Space) error
+	// UpdateSpace updates the space with the ID of the given space.
+	UpdateSpace(ctx context.Context, space Space) error
+}
+
+func (c *baseClient) GetSpace(ctx context.Context, id string) (Space, error) {
+	var space Space
+	err := c.get(ctx, fmt.Sprintf("/api/spaces/space/%s", url.PathEscape(id)), &space)
+	return space, err
+}
+
+func (c *baseClient) CreateSpace(ctx context.Context, space Space) error {
+	return c.post(ctx, "/api/spaces/space", space, nil)
+}
+
+func (c *baseClient) UpdateSpace(ctx context.Context, space Space) error {
+	return c.put(ctx, fmt.Sprintf("/api/spaces/space/%s", url.PathEscape(space.ID)), space, nil)
+}
@@ -0,0 +1,133 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// StatusLevel is the level of Kibana or of one of its plugins, as reported by the Kibana status API.
+type StatusLevel string
+
+const (
+	AvailableLevel   StatusLevel = "available"
+	DegradedLevel    StatusLevel = "degraded"
+	UnavailableLevel StatusLevel = "unavailable"
+	CriticalLevel    StatusLevel = "critical"
+)
+
+// legacyLevels maps the states reported by Kibana before 8.0 to the corresponding levels.
+var legacyLevels = map[string]StatusLevel{
+	"green":  AvailableLevel,
+	"yellow": DegradedLevel,
+	"red":    UnavailableLevel,
+}
+
+// Status is the status of Kibana.
+type Status struct {
+	// Version is the version of the Kibana instance which responded.
+	Version string
+	// Level is the overall level of Kibana.
+	Level StatusLevel
+	// Summary describes the overall level.
+	Summary string
+	// DegradedPlugins are the sorted names of the plugins which are not available.
+	DegradedPlugins []string
+}
+
+// statusResponse is the response of the Kibana status API. Kibana 8.0 and above report levels for the overall status
+// and for each plugin, while older versions report a state for the overall status and a flat list of statuses.
+type statusResponse struct {
+	Version struct {
+		Number string `json:"number"`
+	} `json:"version"`
+	Status struct {
+		Overall struct {
+			Level    StatusLevel `json:"level"`
+			Summary  string      `json:"summary"`
+			State    string      `json:"state"`
+			Nickname string      `json:"nickname"`
+		} `json:"overall"`
+		Plugins map[string]struct {
+			Level StatusLevel `json:"level"`
+		} `json:"plugins"`
+		Statuses []struct {
+			ID    string `json:"id"`
+			State string `json:"state"`
+		} `json:"statuses"`
+	} `json:"status"`
+}
+
+type StatusClient interface {
+	// GetStatus returns the status of Kibana.
+	GetStatus(ctx context.Context) (Status, error)
+}
+
+func (c *baseClient) GetStatus(ctx context.Context) (Status, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/api/status", nil)
+	if err != nil {
+		return Status{}, err
+	}
+	defer resp.Body.Close()
+
+	// Kibana responds with a 503 along with the status details if it is unavailable
+	if (resp.StatusCode < 200 || resp.StatusCode >= 300) && resp.StatusCode != http.StatusServiceUnavailable {
+		return Status{}, newAPIError(resp)
+	}
+	var statusResp statusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&statusResp); err != nil {
+		return Status{}, err
+	}
+	return statusResp.toStatus(), nil
+}
+
+func (r statusResponse) toStatus() Status {
+	overall := r.Status.Overall
+	if overall.Level == "" {
+		// Kibana < 8.0
+		status := Status{Version: r.Version.Number, Level: legacyLevel(overall.State), Summary: overall.Nickname}
+		for _, s := range r.Status.Statuses {
+			if legacyLevel(s.State) != AvailableLevel {
+				status.DegradedPlugins = append(status.DegradedPlugins, legacyPluginName(s.ID))
+			}
+		}
+		sort.Strings(status.DegradedPlugins)
+		return status
+	}
+
+	status := Status{Version: r.Version.Number, Level: overall.Level, Summary: overall.Summary}
+	for name, plugin := range r.Status.Plugins {
+		if plugin.Level != AvailableLevel {
+			status.DegradedPlugins = append(status.DegradedPlugins, name)
+		}
+	}
+	sort.Strings(status.DegradedPlugins)
+	return status
+}
+
+// legacyLevel returns the level corresponding to a state reported by Kibana before 8.0. Unknown states such as
+// "uninitialized" are considered unavailable.
+func legacyLevel(state string) StatusLevel {
+	if level, exists := legacyLevels[state]; exists {
+		return level
+	}
+	return UnavailableLevel
+}
+
+// legacyPluginName extracts the plugin name from a status ID reported by Kibana before 8.0 (eg. "plugin:security@7.10.0").
+func legacyPluginName(id string) string {
+	name := id
+	if i := strings.Index(name, ":"); i >= 0 {
+		name = name[i+1:]
+	}
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
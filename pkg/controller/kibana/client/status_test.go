@@ -0,0 +1,79 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       Status
+		wantErr    bool
+	}{
+		{
+			name:       "8.x available",
+			statusCode: 200,
+			body: `{"version":{"number":"8.1.0"},"status":{"overall":{"level":"available","summary":"All services are available"},
+				"plugins":{"security":{"level":"available"},"fleet":{"level":"available"}}}}`,
+			want: Status{Version: "8.1.0", Level: AvailableLevel, Summary: "All services are available"},
+		},
+		{
+			name:       "8.x degraded",
+			statusCode: 200,
+			body: `{"status":{"overall":{"level":"degraded","summary":"2 services are degraded"},
+				"plugins":{"security":{"level":"available"},"reporting":{"level":"degraded"},"fleet":{"level":"unavailable"}}}}`,
+			want: Status{Level: DegradedLevel, Summary: "2 services are degraded", DegradedPlugins: []string{"fleet", "reporting"}},
+		},
+		{
+			name:       "8.x unavailable with a 503",
+			statusCode: 503,
+			body:       `{"status":{"overall":{"level":"unavailable","summary":"Waiting for Elasticsearch"},"plugins":{}}}`,
+			want:       Status{Level: UnavailableLevel, Summary: "Waiting for Elasticsearch"},
+		},
+		{
+			name:       "7.x green",
+			statusCode: 200,
+			body: `{"version":{"number":"7.10.0"},"status":{"overall":{"state":"green","nickname":"Looking good"},
+				"statuses":[{"id":"core:elasticsearch@7.10.0","state":"green"},{"id":"plugin:security@7.10.0","state":"green"}]}}`,
+			want: Status{Version: "7.10.0", Level: AvailableLevel, Summary: "Looking good"},
+		},
+		{
+			name:       "7.x red",
+			statusCode: 200,
+			body: `{"status":{"overall":{"state":"red","nickname":"Danger Will Robinson! Danger!"},
+				"statuses":[{"id":"plugin:security@7.10.0","state":"red"},{"id":"plugin:reporting@7.10.0","state":"uninitialized"}]}}`,
+			want: Status{Level: UnavailableLevel, Summary: "Danger Will Robinson! Danger!", DegradedPlugins: []string{"reporting", "security"}},
+		},
+		{
+			name:       "unauthorized",
+			statusCode: 401,
+			body:       `{"statusCode":401,"error":"Unauthorized","message":"Unauthorized"}`,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewMockClient(func(req *http.Request) *http.Response {
+				require.Equal(t, "/api/status", req.URL.Path)
+				return NewMockResponse(tt.statusCode, req, tt.body)
+			})
+			got, err := c.GetStatus(context.Background())
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -76,7 +76,8 @@ type CanonicalConfig struct {
 }
 
 // NewConfigSettings returns the Kibana configuration settings for the given Kibana resource.
-func NewConfigSettings(ctx context.Context, client k8s.Client, kb kbv1.Kibana, v version.Version, ipFamily corev1.IPFamily) (CanonicalConfig, error) {
+// The settings enforced by StackConfigPolicies in policyConfig, which may be nil, take precedence over all the others.
+func NewConfigSettings(ctx context.Context, client k8s.Client, kb kbv1.Kibana, v version.Version, ipFamily corev1.IPFamily, policyConfig *settings.CanonicalConfig) (CanonicalConfig, error) {
 	span, _ := apm.StartSpan(ctx, "new_config_settings", tracing.SpanTypeApp)
 	defer span.End()
 
@@ -121,6 +122,9 @@ func NewConfigSettings(ctx context.Context, client k8s.Client, kb kbv1.Kibana, v
 			userSettings); err != nil {
 			return CanonicalConfig{}, err
 		}
+		if err := cfg.OverrideWith(policyConfig); err != nil {
+			return CanonicalConfig{}, err
+		}
 		return CanonicalConfig{cfg}, nil
 	}
 
@@ -148,6 +152,9 @@ func NewConfigSettings(ctx context.Context, client k8s.Client, kb kbv1.Kibana, v
 	if err != nil {
 		return CanonicalConfig{}, err
 	}
+	if err := cfg.OverrideWith(policyConfig); err != nil {
+		return CanonicalConfig{}, err
+	}
 
 	return CanonicalConfig{cfg}, nil
 }
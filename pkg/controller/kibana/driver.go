@@ -13,9 +13,11 @@ import (
 	"go.elastic.co/apm"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/association"
@@ -26,8 +28,11 @@ import (
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/deployment"
 	driver2 "github.com/elastic/cloud-on-k8s/pkg/controller/common/driver"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/events"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/hpa"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/keystore"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/networkpolicy"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/operator"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/pdb"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/reconciler"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
@@ -35,7 +40,9 @@ import (
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/watches"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/kibana/network"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/kibana/stackmon"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/stackconfigpolicy"
 	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/net"
 )
 
 // minSupportedVersion is the minimum version of Kibana supported by ECK. Currently this is set to version 6.8.0.
@@ -134,7 +141,14 @@ func (d *driver) Reconcile(
 		return results // will eventually retry
 	}
 
-	kbSettings, err := NewConfigSettings(ctx, d.client, *kb, d.version, d.ipFamily)
+	// settings enforced by the StackConfigPolicies selecting this Kibana instance
+	policySettings, err := stackconfigpolicy.ForKibana(d.client, params.OperatorNamespace, *kb)
+	if err != nil {
+		return results.WithError(err)
+	}
+	state.Kibana.Status.StackConfigPolicies = policySettings.Status()
+
+	kbSettings, err := NewConfigSettings(ctx, d.client, *kb, d.version, d.ipFamily, policySettings.Config)
 	if err != nil {
 		return results.WithError(err)
 	}
@@ -157,8 +171,44 @@ func (d *driver) Reconcile(
 		return results.WithError(err)
 	}
 
-	expectedDp := deployment.New(deploymentParams)
-	reconciledDp, err := deployment.Reconcile(d.client, expectedDp, kb)
+	// the migration Pod created by reconcileUpgrade must be reachable by the operator
+	if err := d.reconcileNetworkPolicies(kb, params, deploymentParams.Selector); err != nil {
+		return results.WithError(err)
+	}
+
+	holdBack, err := d.reconcileUpgrade(ctx, kb, &deploymentParams, params.Dialer)
+	if err != nil {
+		return results.WithError(err)
+	}
+	var reconciledDp appsv1.Deployment
+	if holdBack {
+		// the saved objects are being migrated by a single Pod of the new version
+		results.WithResult(reconcile.Result{RequeueAfter: migrationPollingInterval})
+		reconciledDp, err = d.currentDeployment(ctx, deploymentParams)
+	} else {
+		reconciledDp, err = deployment.Reconcile(d.client, deployment.New(deploymentParams), kb)
+	}
+	if err != nil {
+		return results.WithError(err)
+	}
+
+	autoscalingStatus, err := hpa.Reconcile(d.client, hpa.Params{
+		Owner:      kb,
+		Spec:       kb.Spec.Autoscaling,
+		Deployment: deploymentParams.Name,
+		Labels:     deploymentParams.Labels,
+	})
+	if err != nil {
+		return results.WithError(err)
+	}
+
+	err = pdb.Reconcile(d.client, pdb.Params{
+		Owner:    kb,
+		Template: kb.Spec.PodDisruptionBudget,
+		Name:     kbv1.PodDisruptionBudget(kb.Name),
+		Selector: deploymentParams.Selector,
+		Replicas: hpa.MaxReplicas(kb.Spec.Autoscaling, kb.Spec.Count),
+	})
 	if err != nil {
 		return results.WithError(err)
 	}
@@ -171,14 +221,88 @@ func (d *driver) Reconcile(
 	if err != nil {
 		return results.WithError(err)
 	}
+	deploymentStatus.Autoscaling = autoscalingStatus
 	state.Kibana.Status.DeploymentStatus = deploymentStatus
 
+	return results.WithResults(d.reconcileThroughAPI(ctx, state, params.Dialer))
+}
+
+// reconcileNetworkPolicies reconciles the NetworkPolicies allowing the operator to call the Kibana API of the Pods
+// matching the given selector, and of the Pod migrating the saved objects during a rolling upgrade, which is not
+// selected by the Deployment.
+func (d *driver) reconcileNetworkPolicies(kb *kbv1.Kibana, params operator.Parameters, selector map[string]string) error {
+	enabled := params.NetworkPoliciesEnabled(kb.GetNetworkPolicySettings())
+	ingress := []networkingv1.NetworkPolicyIngressRule{networkpolicy.FromOperator(params.OperatorNamespace, network.HTTPPort)}
+	if err := networkpolicy.Reconcile(d.client, networkpolicy.Params{
+		Owner:       kb,
+		Enabled:     enabled,
+		Name:        networkpolicy.Name(kbv1.KBNamer, kb.Name),
+		Labels:      selector,
+		PodSelector: selector,
+		Ingress:     ingress,
+	}); err != nil {
+		return err
+	}
+	return networkpolicy.Reconcile(d.client, networkpolicy.Params{
+		Owner:       kb,
+		Enabled:     enabled,
+		Name:        migrationNetworkPolicyName(kb.Name),
+		Labels:      selector,
+		PodSelector: map[string]string{KibanaNameLabelName: kb.Name, KibanaMigrationLabelName: "true"},
+		Ingress:     ingress,
+	})
+}
+
+// reconcileThroughAPI retrieves the status of Kibana and reconciles the spaces and Fleet policies declared in the Kibana
+// specification through the Kibana API. It does nothing until Kibana is associated with an Elasticsearch cluster, which
+// provides the credentials to call the API, and at least one Kibana instance is available. The status is retrieved as
+// the user of the association, whereas the spaces and Fleet policies are managed as the operator user of Elasticsearch.
+func (d *driver) reconcileThroughAPI(ctx context.Context, state *State, dialer net.Dialer) *reconciler.Results {
+	results := reconciler.NewResult(ctx)
+	kb := state.Kibana
+	if !kb.Spec.ElasticsearchRef.IsDefined() || kb.Status.AvailableNodes == 0 {
+		state.Kibana.Status.ApplicationStatus = nil
+		return results
+	}
+
+	// the status of Kibana is not reflected by any Kubernetes resource, poll it periodically
+	results.WithResult(reconcile.Result{RequeueAfter: applicationStatusPollingInterval})
+
+	state.Kibana.Status.ApplicationStatus = d.applicationStatus(ctx, *kb, dialer)
+	state.Kibana.Status.Health = applicationHealth(kb.Status.Health, state.Kibana.Status.ApplicationStatus)
+
+	if !hasManagedObjects(*kb) {
+		return results
+	}
+	kbClient, err := newKibanaClient(d.client, dialer, *kb)
+	if err != nil {
+		return results.WithError(err)
+	}
+	defer kbClient.Close()
+	if err := reconcileManagedObjects(ctx, d.client, kbClient, kb); err != nil {
+		k8s.EmitErrorEvent(d.Recorder(), err, kb, events.EventReconciliationError, "Spaces and Fleet policies reconciliation error: %v", err)
+		return results.WithError(err)
+	}
 	return results
 }
 
+// applicationStatus returns the status reported by the Kibana status API. The status is unknown if the API cannot be
+// called, for example until the association is configured, in which case the health of Kibana is derived from its
+// Deployment only and the API is called again at the next poll.
+func (d *driver) applicationStatus(ctx context.Context, kb kbv1.Kibana, dialer net.Dialer) *kbv1.ApplicationStatus {
+	statusClient, err := newKibanaStatusClient(d.client, dialer, kb)
+	if err != nil {
+		log.Info("Cannot create a client for the Kibana API", "namespace", kb.Namespace, "kibana_name", kb.Name, "error", err.Error())
+		return unknownApplicationStatus(err)
+	}
+	defer statusClient.Close()
+	return getApplicationStatus(ctx, statusClient)
+}
+
 // getStrategyType decides which deployment strategy (RollingUpdate or Recreate) to use based on whether the version
 // upgrade is in progress. Kibana does not support a smooth rolling upgrade from one version to another:
-// running multiple versions simultaneously may lead to concurrency bugs and data corruption.
+// running multiple versions simultaneously may lead to concurrency bugs and data corruption, unless the saved objects
+// were already migrated to the new version by a single instance (see reconcileUpgrade).
 func (d *driver) getStrategyType(kb *kbv1.Kibana) (appsv1.DeploymentStrategyType, error) {
 	var pods corev1.PodList
 	var labels client.MatchingLabels = map[string]string{KibanaNameLabelName: kb.Name}
@@ -192,6 +316,10 @@ func (d *driver) getStrategyType(kb *kbv1.Kibana) (appsv1.DeploymentStrategyType
 		// to be safe, we assume the Kibana version has changed when operator was offline and use Recreate,
 		// otherwise we may run into data corruption/data loss.
 		if !ok || ver != kb.Spec.Version {
+			if upgrade := kb.Status.Upgrade; upgrade != nil && upgrade.TargetVersion == kb.Spec.Version &&
+				upgrade.Phase == kbv1.UpgradeRollingOut {
+				return appsv1.RollingUpdateDeploymentStrategyType, nil
+			}
 			return appsv1.RecreateDeploymentStrategyType, nil
 		}
 	}
@@ -275,6 +403,7 @@ func (d *driver) deploymentParams(kb *kbv1.Kibana) (deployment.Params, error) {
 		Labels:          NewLabels(kb.Name),
 		PodTemplateSpec: kibanaPodSpec,
 		Strategy:        appsv1.DeploymentStrategy{Type: strategyType},
+		Autoscaled:      kb.Spec.Autoscaling != nil,
 	}, nil
 }
 
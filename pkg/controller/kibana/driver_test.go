@@ -75,6 +75,7 @@ func Test_getStrategyType(t *testing.T) {
 		name            string
 		expectedKbName  string
 		expectedVersion string
+		upgradeStatus   *kbv1.UpgradeStatus
 		initialObjects  []runtime.Object
 		clientError     bool
 		wantErr         bool
@@ -147,6 +148,22 @@ func Test_getStrategyType(t *testing.T) {
 			wantErr:      false,
 			wantStrategy: appsv1.RecreateDeploymentStrategyType,
 		},
+		{
+			name:            "Version mismatch - saved objects migrated",
+			expectedVersion: "7.15.0",
+			expectedKbName:  "test",
+			upgradeStatus:   &kbv1.UpgradeStatus{TargetVersion: "7.15.0", Phase: kbv1.UpgradeRollingOut},
+			initialObjects:  append(getPods("test", 1, "7.15.0"), getPods("test", 2, "7.14.0")...),
+			wantStrategy:    appsv1.RollingUpdateDeploymentStrategyType,
+		},
+		{
+			name:            "Version mismatch - saved objects migrated to another version",
+			expectedVersion: "7.16.0",
+			expectedKbName:  "test",
+			upgradeStatus:   &kbv1.UpgradeStatus{TargetVersion: "7.15.0", Phase: kbv1.UpgradeRollingOut},
+			initialObjects:  append(getPods("test", 1, "7.15.0"), getPods("test", 2, "7.14.0")...),
+			wantStrategy:    appsv1.RecreateDeploymentStrategyType,
+		},
 		{
 			name:            "Version label missing (operator upgrade case), should assume spec changed",
 			expectedVersion: "7.5.0",
@@ -174,6 +191,7 @@ func Test_getStrategyType(t *testing.T) {
 			kb := kibanaFixture()
 			kb.Name = tt.expectedKbName
 			kb.Spec.Version = tt.expectedVersion
+			kb.Status.Upgrade = tt.upgradeStatus
 
 			client := k8s.NewFakeClient(tt.initialObjects...)
 			if tt.clientError {
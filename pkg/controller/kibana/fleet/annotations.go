@@ -0,0 +1,49 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package fleet
+
+import (
+	"context"
+
+	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/annotation"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+// AppliedAgentPoliciesAnnotationName holds the agent policies which have been applied by the operator.
+const AppliedAgentPoliciesAnnotationName = "kibana.k8s.elastic.co/applied-agent-policies"
+
+// appliedAgentPolicy keeps track of an agent policy applied by the operator.
+type appliedAgentPolicy struct {
+	// Hash of the declared agent policy, including its package policies, when it was last applied. It is empty if the
+	// agent policy could not be applied.
+	Hash string `json:"hash,omitempty"`
+	// PackagePolicies are the IDs of the package policies applied with the agent policy.
+	PackagePolicies []string `json:"packagePolicies,omitempty"`
+}
+
+// appliedAgentPolicies maps agent policy IDs to the applied agent policies.
+type appliedAgentPolicies map[string]appliedAgentPolicy
+
+// getAppliedAgentPolicies returns the applied agent policies serialized in the annotation of the Kibana resource.
+// If the annotation does not exist or cannot be parsed the result is empty but not nil.
+func getAppliedAgentPolicies(kb kbv1.Kibana) appliedAgentPolicies {
+	applied := make(appliedAgentPolicies)
+	if err := annotation.GetJSON(&kb, AppliedAgentPoliciesAnnotationName, &applied); err != nil {
+		log.Error(err, "Ignoring invalid annotation", "namespace", kb.Namespace, "kibana_name", kb.Name, "annotation", AppliedAgentPoliciesAnnotationName)
+		return make(appliedAgentPolicies)
+	}
+	return applied
+}
+
+// annotateWithAppliedAgentPolicies patches the annotation of the Kibana resource which keeps track of the applied
+// agent policies, if it has changed. kb is updated with the patched resource.
+func annotateWithAppliedAgentPolicies(ctx context.Context, c k8s.Client, kb *kbv1.Kibana, applied appliedAgentPolicies) error {
+	value, err := annotation.JSONValue(applied)
+	if err != nil {
+		return err
+	}
+	return annotation.PatchAnnotations(ctx, c, kb, map[string]string{AppliedAgentPoliciesAnnotationName: value})
+}
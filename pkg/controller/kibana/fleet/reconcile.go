@@ -0,0 +1,213 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package fleet
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.elastic.co/apm"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/hash"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
+	kbclient "github.com/elastic/cloud-on-k8s/pkg/controller/kibana/client"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	ulog "github.com/elastic/cloud-on-k8s/pkg/utils/log"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/stringsutil"
+)
+
+// DefaultNamespace is the default data stream namespace of the agent policies.
+const DefaultNamespace = "default"
+
+var log = ulog.Log.WithName("kibana-fleet")
+
+// Reconcile ensures that the agent policies declared in the Kibana specification, and their package policies, exist
+// in Fleet as declared. An agent policy is applied again if its definition changed or if it has been deleted through
+// the Kibana API. Agent and package policies previously applied by the operator but which are not declared anymore are
+// deleted, the ones created through the Kibana API are left untouched. The applied policies are tracked in an
+// annotation, kb is updated with the patched resource.
+func Reconcile(ctx context.Context, c k8s.Client, kbClient kbclient.FleetClient, kb *kbv1.Kibana) error {
+	span, ctx := apm.StartSpan(ctx, "reconcile_fleet_policies", tracing.SpanTypeApp)
+	defer span.End()
+
+	var declared []kbv1.AgentPolicy
+	if kb.Spec.Fleet != nil {
+		declared = kb.Spec.Fleet.AgentPolicies
+	}
+	previouslyApplied := getAppliedAgentPolicies(*kb)
+	if len(declared) == 0 && len(previouslyApplied) == 0 {
+		// nothing to do, avoid any call to the Kibana API
+		return nil
+	}
+
+	if len(declared) > 0 {
+		if err := kbClient.SetupFleet(ctx); err != nil {
+			return fmt.Errorf("while setting up Fleet: %w", err)
+		}
+	}
+
+	var errs []error
+	applied := make(appliedAgentPolicies, len(declared))
+	for _, policy := range declared {
+		appliedPolicy, err := apply(ctx, kbClient, *kb, policy, previouslyApplied[policy.ID])
+		applied[policy.ID] = appliedPolicy
+		if err != nil {
+			errs = append(errs, fmt.Errorf("while applying agent policy %s: %w", policy.ID, err))
+		}
+	}
+
+	undeclared := make([]string, 0, len(previouslyApplied))
+	for id := range previouslyApplied {
+		if _, declared := applied[id]; !declared {
+			undeclared = append(undeclared, id)
+		}
+	}
+	sort.Strings(undeclared)
+	for _, id := range undeclared {
+		// package policies of the agent policy are deleted along with it
+		log.Info("Deleting agent policy", "namespace", kb.Namespace, "kibana_name", kb.Name, "id", id)
+		if err := kbClient.DeleteAgentPolicy(ctx, id); err != nil && !kbclient.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("while deleting agent policy %s: %w", id, err))
+			// keep track of the agent policy to retry the deletion
+			applied[id] = previouslyApplied[id]
+		}
+	}
+
+	if err := annotateWithAppliedAgentPolicies(ctx, c, kb, applied); err != nil {
+		errs = append(errs, err)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// apply creates or updates the given agent policy and its package policies if the policy changed since it was
+// previously applied, or if it does not exist anymore. It returns the applied agent policy to record.
+func apply(
+	ctx context.Context,
+	kbClient kbclient.FleetClient,
+	kb kbv1.Kibana,
+	policy kbv1.AgentPolicy,
+	previous appliedAgentPolicy,
+) (appliedAgentPolicy, error) {
+	packagePolicyIDs := make([]string, 0, len(policy.PackagePolicies))
+	for _, packagePolicy := range policy.PackagePolicies {
+		packagePolicyIDs = append(packagePolicyIDs, packagePolicy.ID)
+	}
+	// an empty hash forces the agent policy to be applied again during the next reconciliation, keep track of all
+	// the package policies which may have been applied to delete them later if needed
+	failed := appliedAgentPolicy{PackagePolicies: union(previous.PackagePolicies, packagePolicyIDs)}
+	specHash := hash.HashObject(policy)
+
+	_, err := kbClient.GetAgentPolicy(ctx, policy.ID)
+	if err != nil && !kbclient.IsNotFound(err) {
+		return failed, err
+	}
+	exists := err == nil
+	if exists && previous.Hash == specHash {
+		// up-to-date
+		return previous, nil
+	}
+
+	log.Info("Applying agent policy", "namespace", kb.Namespace, "kibana_name", kb.Name, "id", policy.ID)
+	expected := expectedAgentPolicy(policy)
+	if exists {
+		err = kbClient.UpdateAgentPolicy(ctx, expected)
+	} else {
+		err = kbClient.CreateAgentPolicy(ctx, expected)
+	}
+	if err != nil {
+		return failed, err
+	}
+
+	for _, packagePolicy := range policy.PackagePolicies {
+		if err := applyPackagePolicy(ctx, kbClient, expectedPackagePolicy(policy, packagePolicy)); err != nil {
+			return failed, fmt.Errorf("while applying package policy %s: %w", packagePolicy.ID, err)
+		}
+	}
+
+	for _, id := range previous.PackagePolicies {
+		if stringsutil.StringInSlice(id, packagePolicyIDs) {
+			continue
+		}
+		log.Info("Deleting package policy", "namespace", kb.Namespace, "kibana_name", kb.Name, "id", id)
+		if err := kbClient.DeletePackagePolicy(ctx, id); err != nil && !kbclient.IsNotFound(err) {
+			return failed, fmt.Errorf("while deleting package policy %s: %w", id, err)
+		}
+	}
+
+	return appliedAgentPolicy{Hash: specHash, PackagePolicies: packagePolicyIDs}, nil
+}
+
+// applyPackagePolicy installs the package of the given package policy, then creates or updates the package policy.
+func applyPackagePolicy(ctx context.Context, kbClient kbclient.FleetClient, packagePolicy kbclient.PackagePolicy) error {
+	if err := kbClient.InstallPackage(ctx, packagePolicy.Package); err != nil {
+		return fmt.Errorf("while installing package %s-%s: %w", packagePolicy.Package.Name, packagePolicy.Package.Version, err)
+	}
+	_, err := kbClient.GetPackagePolicy(ctx, packagePolicy.ID)
+	switch {
+	case kbclient.IsNotFound(err):
+		return kbClient.CreatePackagePolicy(ctx, packagePolicy)
+	case err != nil:
+		return err
+	}
+	return kbClient.UpdatePackagePolicy(ctx, packagePolicy)
+}
+
+func expectedAgentPolicy(policy kbv1.AgentPolicy) kbclient.AgentPolicy {
+	namespace := policy.Namespace
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	monitoringEnabled := policy.MonitoringEnabled
+	if monitoringEnabled == nil {
+		monitoringEnabled = []string{}
+	}
+	return kbclient.AgentPolicy{
+		ID:                   policy.ID,
+		Name:                 policy.Name,
+		Namespace:            namespace,
+		Description:          policy.Description,
+		MonitoringEnabled:    monitoringEnabled,
+		IsDefaultFleetServer: policy.IsDefaultFleetServer,
+	}
+}
+
+func expectedPackagePolicy(policy kbv1.AgentPolicy, packagePolicy kbv1.PackagePolicy) kbclient.PackagePolicy {
+	namespace := packagePolicy.Namespace
+	if namespace == "" {
+		namespace = expectedAgentPolicy(policy).Namespace
+	}
+	expected := kbclient.PackagePolicy{
+		ID:          packagePolicy.ID,
+		Name:        packagePolicy.Name,
+		Description: packagePolicy.Description,
+		Namespace:   namespace,
+		PolicyID:    policy.ID,
+		Package: kbclient.PackageReference{
+			Name:    packagePolicy.Package.Name,
+			Version: packagePolicy.Package.Version,
+		},
+	}
+	if packagePolicy.Inputs != nil && packagePolicy.Inputs.Data != nil {
+		expected.Inputs = packagePolicy.Inputs.Data
+	}
+	if packagePolicy.Vars != nil && packagePolicy.Vars.Data != nil {
+		expected.Vars = packagePolicy.Vars.Data
+	}
+	return expected
+}
+
+// union returns the IDs of both slices, without duplicates.
+func union(a, b []string) []string {
+	result := make([]string, 0, len(a)+len(b))
+	for _, id := range append(append([]string{}, a...), b...) {
+		if !stringsutil.StringInSlice(id, result) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
@@ -0,0 +1,227 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package fleet
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
+	kbclient "github.com/elastic/cloud-on-k8s/pkg/controller/kibana/client"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+// fakeFleetClient stores agent and package policies by ID.
+type fakeFleetClient struct {
+	agentPolicies   map[string]kbclient.AgentPolicy
+	packagePolicies map[string]kbclient.PackagePolicy
+	calls           []string
+	failing         map[string]bool
+}
+
+func newFakeClient() *fakeFleetClient {
+	return &fakeFleetClient{
+		agentPolicies:   map[string]kbclient.AgentPolicy{},
+		packagePolicies: map[string]kbclient.PackagePolicy{},
+		failing:         map[string]bool{},
+	}
+}
+
+func notFound() error {
+	return &kbclient.APIError{StatusCode: 404}
+}
+
+func (f *fakeFleetClient) SetupFleet(_ context.Context) error {
+	return nil
+}
+
+func (f *fakeFleetClient) GetAgentPolicy(_ context.Context, id string) (kbclient.AgentPolicy, error) {
+	policy, exists := f.agentPolicies[id]
+	if !exists {
+		return kbclient.AgentPolicy{}, notFound()
+	}
+	return policy, nil
+}
+
+func (f *fakeFleetClient) CreateAgentPolicy(_ context.Context, policy kbclient.AgentPolicy) error {
+	if f.failing[policy.ID] {
+		return errors.New("bad request")
+	}
+	f.calls = append(f.calls, "create agent policy "+policy.ID)
+	f.agentPolicies[policy.ID] = policy
+	return nil
+}
+
+func (f *fakeFleetClient) UpdateAgentPolicy(_ context.Context, policy kbclient.AgentPolicy) error {
+	f.calls = append(f.calls, "update agent policy "+policy.ID)
+	f.agentPolicies[policy.ID] = policy
+	return nil
+}
+
+func (f *fakeFleetClient) DeleteAgentPolicy(_ context.Context, id string) error {
+	f.calls = append(f.calls, "delete agent policy "+id)
+	delete(f.agentPolicies, id)
+	for packagePolicyID, packagePolicy := range f.packagePolicies {
+		if packagePolicy.PolicyID == id {
+			delete(f.packagePolicies, packagePolicyID)
+		}
+	}
+	return nil
+}
+
+func (f *fakeFleetClient) InstallPackage(_ context.Context, pkg kbclient.PackageReference) error {
+	f.calls = append(f.calls, "install package "+pkg.Name)
+	return nil
+}
+
+func (f *fakeFleetClient) GetPackagePolicy(_ context.Context, id string) (kbclient.PackagePolicy, error) {
+	policy, exists := f.packagePolicies[id]
+	if !exists {
+		return kbclient.PackagePolicy{}, notFound()
+	}
+	return policy, nil
+}
+
+func (f *fakeFleetClient) CreatePackagePolicy(_ context.Context, policy kbclient.PackagePolicy) error {
+	f.calls = append(f.calls, "create package policy "+policy.ID)
+	f.packagePolicies[policy.ID] = policy
+	return nil
+}
+
+func (f *fakeFleetClient) UpdatePackagePolicy(_ context.Context, policy kbclient.PackagePolicy) error {
+	f.calls = append(f.calls, "update package policy "+policy.ID)
+	f.packagePolicies[policy.ID] = policy
+	return nil
+}
+
+func (f *fakeFleetClient) DeletePackagePolicy(_ context.Context, id string) error {
+	f.calls = append(f.calls, "delete package policy "+id)
+	delete(f.packagePolicies, id)
+	return nil
+}
+
+func (f *fakeFleetClient) GetEnrollmentAPIKeys(_ context.Context, _ string) ([]kbclient.EnrollmentAPIKey, error) {
+	return nil, nil
+}
+
+var _ kbclient.FleetClient = &fakeFleetClient{}
+
+func agentPolicy(id string, packagePolicies ...kbv1.PackagePolicy) kbv1.AgentPolicy {
+	return kbv1.AgentPolicy{ID: id, Name: id, PackagePolicies: packagePolicies}
+}
+
+func packagePolicy(id string) kbv1.PackagePolicy {
+	return kbv1.PackagePolicy{
+		ID:      id,
+		Name:    id,
+		Package: kbv1.PackageReference{Name: "system", Version: "1.13.0"},
+		Inputs:  &commonv1.Config{Data: map[string]interface{}{"system-system/metrics": map[string]interface{}{"enabled": true}}},
+	}
+}
+
+func newKibana() kbv1.Kibana {
+	return kbv1.Kibana{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kb"}}
+}
+
+// reconcile runs the reconciliation with the given agent policies and returns the Kibana resource, updated in place
+// with the patched resource.
+func reconcile(t *testing.T, c k8s.Client, kbClient kbclient.FleetClient, kb kbv1.Kibana, declared ...kbv1.AgentPolicy) kbv1.Kibana {
+	t.Helper()
+	kb.Spec.Fleet = &kbv1.FleetSpec{AgentPolicies: declared}
+	require.NoError(t, Reconcile(context.Background(), c, kbClient, &kb))
+	var stored kbv1.Kibana
+	require.NoError(t, c.Get(context.Background(), k8s.ExtractNamespacedName(&kb), &stored))
+	require.Equal(t, stored.ResourceVersion, kb.ResourceVersion)
+	require.Equal(t, stored.Annotations[AppliedAgentPoliciesAnnotationName], kb.Annotations[AppliedAgentPoliciesAnnotationName])
+	return kb
+}
+
+func TestReconcile(t *testing.T) {
+	kb := newKibana()
+	c := k8s.NewFakeClient(&kb)
+	kbClient := newFakeClient()
+
+	// nothing declared: no call to Kibana, no annotation
+	kb = reconcile(t, c, kbClient, kb)
+	require.Empty(t, kbClient.calls)
+	require.NotContains(t, kb.Annotations, AppliedAgentPoliciesAnnotationName)
+
+	// create the declared policies
+	kb = reconcile(t, c, kbClient, kb, agentPolicy("agents", packagePolicy("system-1")), agentPolicy("fleet-server"))
+	require.Equal(t, []string{
+		"create agent policy agents", "install package system", "create package policy system-1",
+		"create agent policy fleet-server",
+	}, kbClient.calls)
+	require.Equal(t, kbclient.AgentPolicy{ID: "agents", Name: "agents", Namespace: "default", MonitoringEnabled: []string{}},
+		kbClient.agentPolicies["agents"])
+	require.Equal(t, kbclient.PackagePolicy{
+		ID:        "system-1",
+		Name:      "system-1",
+		Namespace: "default",
+		PolicyID:  "agents",
+		Package:   kbclient.PackageReference{Name: "system", Version: "1.13.0"},
+		Inputs:    map[string]interface{}{"system-system/metrics": map[string]interface{}{"enabled": true}},
+	}, kbClient.packagePolicies["system-1"])
+	require.Contains(t, kb.Annotations, AppliedAgentPoliciesAnnotationName)
+
+	// nothing changed: no update
+	kbClient.calls = nil
+	kb = reconcile(t, c, kbClient, kb, agentPolicy("agents", packagePolicy("system-1")), agentPolicy("fleet-server"))
+	require.Empty(t, kbClient.calls)
+
+	// agent policy deleted through the Kibana API: created again
+	delete(kbClient.agentPolicies, "fleet-server")
+	kb = reconcile(t, c, kbClient, kb, agentPolicy("agents", packagePolicy("system-1")), agentPolicy("fleet-server"))
+	require.Equal(t, []string{"create agent policy fleet-server"}, kbClient.calls)
+
+	// package policy replaced by another one
+	kbClient.calls = nil
+	kb = reconcile(t, c, kbClient, kb, agentPolicy("agents", packagePolicy("system-2")), agentPolicy("fleet-server"))
+	require.Equal(t, []string{
+		"update agent policy agents", "install package system", "create package policy system-2",
+		"delete package policy system-1",
+	}, kbClient.calls)
+
+	// agent policy not declared anymore: deleted
+	kbClient.calls = nil
+	kb = reconcile(t, c, kbClient, kb, agentPolicy("agents", packagePolicy("system-2")))
+	require.Equal(t, []string{"delete agent policy fleet-server"}, kbClient.calls)
+	applied := getAppliedAgentPolicies(kb)
+	require.NotContains(t, applied, "fleet-server")
+	require.Equal(t, []string{"system-2"}, applied["agents"].PackagePolicies)
+
+	// nothing declared anymore: all deleted, annotation removed
+	kbClient.calls = nil
+	kb = reconcile(t, c, kbClient, kb)
+	require.Equal(t, []string{"delete agent policy agents"}, kbClient.calls)
+	require.Empty(t, kbClient.agentPolicies)
+	require.Empty(t, kbClient.packagePolicies)
+	require.NotContains(t, kb.Annotations, AppliedAgentPoliciesAnnotationName)
+}
+
+func TestReconcile_Error(t *testing.T) {
+	kb := newKibana()
+	c := k8s.NewFakeClient(&kb)
+	kbClient := newFakeClient()
+	kbClient.failing["agents"] = true
+
+	kb.Spec.Fleet = &kbv1.FleetSpec{AgentPolicies: []kbv1.AgentPolicy{agentPolicy("agents"), agentPolicy("fleet-server")}}
+	require.Error(t, Reconcile(context.Background(), c, kbClient, &kb))
+	// other policies are still applied
+	require.Contains(t, kbClient.agentPolicies, "fleet-server")
+
+	// the failed policy is recorded with an empty hash to be applied again
+	var updated kbv1.Kibana
+	require.NoError(t, c.Get(context.Background(), k8s.ExtractNamespacedName(&kb), &updated))
+	applied := getAppliedAgentPolicies(updated)
+	require.Contains(t, applied, "agents")
+	require.Empty(t, applied["agents"].Hash)
+	require.NotEmpty(t, applied["fleet-server"].Hash)
+}
@@ -0,0 +1,83 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package kibana
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
+	kbclient "github.com/elastic/cloud-on-k8s/pkg/controller/kibana/client"
+)
+
+const (
+	// applicationStatusPollingInterval is the interval at which the status of Kibana is retrieved from its API.
+	applicationStatusPollingInterval = 1 * time.Minute
+	// applicationStatusTimeout is the timeout of the requests retrieving the status of Kibana.
+	applicationStatusTimeout = 10 * time.Second
+)
+
+// getApplicationStatus returns the status reported by the Kibana status API. Kibana is considered unavailable if its
+// status cannot be retrieved.
+func getApplicationStatus(ctx context.Context, kbClient kbclient.StatusClient) *kbv1.ApplicationStatus {
+	ctx, cancel := context.WithTimeout(ctx, applicationStatusTimeout)
+	defer cancel()
+
+	status, err := kbClient.GetStatus(ctx)
+	if err != nil {
+		return &kbv1.ApplicationStatus{
+			Level:   kbv1.ApplicationUnavailable,
+			Summary: fmt.Sprintf("Failed to retrieve the Kibana status: %v", err),
+		}
+	}
+	return &kbv1.ApplicationStatus{
+		Level:           kbv1.ApplicationStatusLevel(status.Level),
+		Summary:         status.Summary,
+		DegradedPlugins: status.DegradedPlugins,
+	}
+}
+
+// unknownApplicationStatus returns the status of Kibana when the Kibana status API cannot be called.
+func unknownApplicationStatus(err error) *kbv1.ApplicationStatus {
+	return &kbv1.ApplicationStatus{
+		Level:   kbv1.ApplicationUnknown,
+		Summary: fmt.Sprintf("Failed to create a client for the Kibana API: %v", err),
+	}
+}
+
+// applicationHealth returns the health of Kibana derived from the health of its Deployment and from the status
+// reported by the Kibana API, if any.
+func applicationHealth(deploymentHealth commonv1.DeploymentHealth, status *kbv1.ApplicationStatus) commonv1.DeploymentHealth {
+	if status == nil || deploymentHealth == commonv1.RedHealth {
+		return deploymentHealth
+	}
+	switch status.Level {
+	case kbv1.ApplicationAvailable, kbv1.ApplicationUnknown:
+		return deploymentHealth
+	case kbv1.ApplicationDegraded:
+		return commonv1.YellowHealth
+	default:
+		return commonv1.RedHealth
+	}
+}
+
+// applicationStatusChangeMessage returns the message of the event to emit when the level reported by the Kibana API
+// changes to a level other than available, if any. No event is emitted when the level is unknown.
+func applicationStatusChangeMessage(previous, current *kbv1.ApplicationStatus) (string, bool) {
+	if current == nil || current.Level == kbv1.ApplicationAvailable || current.Level == kbv1.ApplicationUnknown {
+		return "", false
+	}
+	if previous != nil && previous.Level == current.Level {
+		return "", false
+	}
+	msg := fmt.Sprintf("Kibana status is %s: %s", current.Level, current.Summary)
+	if len(current.DegradedPlugins) > 0 {
+		msg = fmt.Sprintf("%s (degraded plugins: %s)", msg, strings.Join(current.DegradedPlugins, ", "))
+	}
+	return msg, true
+}
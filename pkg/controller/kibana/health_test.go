@@ -0,0 +1,173 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package kibana
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
+	kbclient "github.com/elastic/cloud-on-k8s/pkg/controller/kibana/client"
+)
+
+func Test_getApplicationStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       kbv1.ApplicationStatus
+	}{
+		{
+			name:       "degraded",
+			statusCode: 200,
+			body:       `{"status":{"overall":{"level":"degraded","summary":"1 service is degraded"},"plugins":{"reporting":{"level":"degraded"}}}}`,
+			want: kbv1.ApplicationStatus{
+				Level:           kbv1.ApplicationDegraded,
+				Summary:         "1 service is degraded",
+				DegradedPlugins: []string{"reporting"},
+			},
+		},
+		{
+			name:       "status cannot be retrieved",
+			statusCode: 500,
+			body:       `{"statusCode":500,"error":"Internal Server Error","message":"boom"}`,
+			want: kbv1.ApplicationStatus{
+				Level:   kbv1.ApplicationUnavailable,
+				Summary: "Failed to retrieve the Kibana status: Internal Server Error: boom",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kbClient := kbclient.NewMockClient(func(req *http.Request) *http.Response {
+				return kbclient.NewMockResponse(tt.statusCode, req, tt.body)
+			})
+			require.Equal(t, &tt.want, getApplicationStatus(context.Background(), kbClient))
+		})
+	}
+}
+
+func Test_applicationHealth(t *testing.T) {
+	tests := []struct {
+		name             string
+		deploymentHealth commonv1.DeploymentHealth
+		status           *kbv1.ApplicationStatus
+		want             commonv1.DeploymentHealth
+	}{
+		{
+			name:             "no application status",
+			deploymentHealth: commonv1.GreenHealth,
+			want:             commonv1.GreenHealth,
+		},
+		{
+			name:             "available",
+			deploymentHealth: commonv1.GreenHealth,
+			status:           &kbv1.ApplicationStatus{Level: kbv1.ApplicationAvailable},
+			want:             commonv1.GreenHealth,
+		},
+		{
+			name:             "degraded",
+			deploymentHealth: commonv1.GreenHealth,
+			status:           &kbv1.ApplicationStatus{Level: kbv1.ApplicationDegraded},
+			want:             commonv1.YellowHealth,
+		},
+		{
+			name:             "unavailable",
+			deploymentHealth: commonv1.GreenHealth,
+			status:           &kbv1.ApplicationStatus{Level: kbv1.ApplicationUnavailable},
+			want:             commonv1.RedHealth,
+		},
+		{
+			name:             "critical",
+			deploymentHealth: commonv1.GreenHealth,
+			status:           &kbv1.ApplicationStatus{Level: kbv1.ApplicationCritical},
+			want:             commonv1.RedHealth,
+		},
+		{
+			name:             "unknown",
+			deploymentHealth: commonv1.YellowHealth,
+			status:           &kbv1.ApplicationStatus{Level: kbv1.ApplicationUnknown},
+			want:             commonv1.YellowHealth,
+		},
+		{
+			name:             "red Deployment",
+			deploymentHealth: commonv1.RedHealth,
+			status:           &kbv1.ApplicationStatus{Level: kbv1.ApplicationAvailable},
+			want:             commonv1.RedHealth,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, applicationHealth(tt.deploymentHealth, tt.status))
+		})
+	}
+}
+
+func Test_applicationStatusChangeMessage(t *testing.T) {
+	available := &kbv1.ApplicationStatus{Level: kbv1.ApplicationAvailable}
+	degraded := &kbv1.ApplicationStatus{
+		Level:           kbv1.ApplicationDegraded,
+		Summary:         "2 services are degraded",
+		DegradedPlugins: []string{"fleet", "reporting"},
+	}
+	tests := []struct {
+		name      string
+		previous  *kbv1.ApplicationStatus
+		current   *kbv1.ApplicationStatus
+		wantMsg   string
+		wantEvent bool
+	}{
+		{
+			name:      "no application status",
+			previous:  available,
+			current:   nil,
+			wantEvent: false,
+		},
+		{
+			name:      "available",
+			previous:  degraded,
+			current:   available,
+			wantEvent: false,
+		},
+		{
+			name:      "degraded",
+			previous:  available,
+			current:   degraded,
+			wantMsg:   "Kibana status is degraded: 2 services are degraded (degraded plugins: fleet, reporting)",
+			wantEvent: true,
+		},
+		{
+			name:      "first status",
+			previous:  nil,
+			current:   &kbv1.ApplicationStatus{Level: kbv1.ApplicationUnavailable, Summary: "Waiting for Elasticsearch"},
+			wantMsg:   "Kibana status is unavailable: Waiting for Elasticsearch",
+			wantEvent: true,
+		},
+		{
+			name:      "unknown",
+			previous:  available,
+			current:   unknownApplicationStatus(errors.New("association with default/es is not configured yet")),
+			wantEvent: false,
+		},
+		{
+			name:      "same level",
+			previous:  degraded,
+			current:   degraded,
+			wantEvent: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, event := applicationStatusChangeMessage(tt.previous, tt.current)
+			require.Equal(t, tt.wantEvent, event)
+			require.Equal(t, tt.wantMsg, msg)
+		})
+	}
+}
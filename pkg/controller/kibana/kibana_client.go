@@ -0,0 +1,115 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package kibana
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/association"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/certificates"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/user"
+	kbclient "github.com/elastic/cloud-on-k8s/pkg/controller/kibana/client"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/kibana/network"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	netutil "github.com/elastic/cloud-on-k8s/pkg/utils/net"
+)
+
+// newKibanaStatusClient returns a client to retrieve the status of Kibana through its HTTP Service. It authenticates
+// with the Elasticsearch user of the association of Kibana, which is granted the kibana_system role: this user can
+// retrieve the status of Kibana, but has no privileges on the spaces nor on Fleet.
+func newKibanaStatusClient(c k8s.Client, dialer netutil.Dialer, kb kbv1.Kibana) (kbclient.Client, error) {
+	url, err := serviceURL(c, kb)
+	if err != nil {
+		return nil, err
+	}
+	caCerts, err := kibanaCACerts(c, kb)
+	if err != nil {
+		return nil, err
+	}
+	return kbclient.NewKibanaClientForAssociation(c, dialer, kb.EsAssociation(), url, caCerts)
+}
+
+// newPodStatusClient returns a client to retrieve the status of the given Kibana Pod, authenticating with the
+// Elasticsearch user of the association of Kibana.
+func newPodStatusClient(c k8s.Client, dialer netutil.Dialer, kb kbv1.Kibana, pod corev1.Pod) (kbclient.Client, error) {
+	if pod.Status.PodIP == "" {
+		return nil, fmt.Errorf("no IP assigned to Pod %s/%s yet", pod.Namespace, pod.Name)
+	}
+	url := fmt.Sprintf("%s://%s", kb.Spec.HTTP.Protocol(), net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(network.HTTPPort)))
+	caCerts, err := kibanaCACerts(c, kb)
+	if err != nil {
+		return nil, err
+	}
+	return kbclient.NewKibanaClientForAssociation(c, dialer, kb.EsAssociation(), url, caCerts)
+}
+
+// newKibanaClient returns a client to manage the spaces and the Fleet policies of Kibana through its HTTP Service. It
+// authenticates with the operator user of the Elasticsearch cluster referenced by Kibana, which has the privileges to
+// manage them.
+func newKibanaClient(c k8s.Client, dialer netutil.Dialer, kb kbv1.Kibana) (kbclient.Client, error) {
+	url, err := serviceURL(c, kb)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the operator user Secret of the referenced Elasticsearch cluster
+	esRef := kb.EsAssociation().AssociationRef()
+	var controllerUserSecret corev1.Secret
+	key := types.NamespacedName{Namespace: esRef.Namespace, Name: esv1.InternalUsersSecret(esRef.Name)}
+	if err := c.Get(context.Background(), key, &controllerUserSecret); err != nil {
+		return nil, err
+	}
+	password, ok := controllerUserSecret.Data[user.ControllerUserName]
+	if !ok {
+		return nil, fmt.Errorf("controller user %s not found in Secret %s/%s", user.ControllerUserName, key.Namespace, key.Name)
+	}
+
+	caCerts, err := kibanaCACerts(c, kb)
+	if err != nil {
+		return nil, err
+	}
+	return kbclient.NewKibanaClient(
+		dialer,
+		url,
+		kbclient.BasicAuth{Name: user.ControllerUserName, Password: string(password)},
+		caCerts,
+		kbclient.DefaultKibanaClientTimeout,
+	), nil
+}
+
+// serviceURL returns the URL of the HTTP Service of the given Kibana.
+func serviceURL(c k8s.Client, kb kbv1.Kibana) (string, error) {
+	return association.ServiceURL(
+		c,
+		types.NamespacedName{Namespace: kb.Namespace, Name: kbv1.HTTPService(kb.Name)},
+		kb.Spec.HTTP.Protocol(),
+	)
+}
+
+// kibanaCACerts returns the certificates to trust to call the Kibana API, if TLS is enabled.
+func kibanaCACerts(c k8s.Client, kb kbv1.Kibana) ([]*x509.Certificate, error) {
+	if !kb.Spec.HTTP.TLS.Enabled() {
+		return nil, nil
+	}
+	var caSecret corev1.Secret
+	key := types.NamespacedName{Namespace: kb.Namespace, Name: certificates.PublicCertsSecretName(kbv1.KBNamer, kb.Name)}
+	if err := c.Get(context.Background(), key, &caSecret); err != nil {
+		return nil, err
+	}
+	trustedCerts, ok := caSecret.Data[certificates.CertFileName]
+	if !ok {
+		return nil, fmt.Errorf("%s not found in Secret %s/%s", certificates.CertFileName, key.Namespace, key.Name)
+	}
+	return certificates.ParsePEMCerts(trustedCerts)
+}
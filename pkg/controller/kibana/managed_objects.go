@@ -0,0 +1,40 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package kibana
+
+import (
+	"context"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
+	kbclient "github.com/elastic/cloud-on-k8s/pkg/controller/kibana/client"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/kibana/fleet"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/kibana/spaces"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+// hasManagedObjects returns true if spaces or Fleet policies are declared in the Kibana specification, or if Fleet
+// policies previously applied by the operator may need to be deleted.
+func hasManagedObjects(kb kbv1.Kibana) bool {
+	if len(kb.Spec.Spaces) > 0 {
+		return true
+	}
+	if kb.Spec.Fleet != nil && len(kb.Spec.Fleet.AgentPolicies) > 0 {
+		return true
+	}
+	_, exists := kb.Annotations[fleet.AppliedAgentPoliciesAnnotationName]
+	return exists
+}
+
+// reconcileManagedObjects reconciles the spaces and Fleet policies declared in the Kibana specification through the
+// Kibana API. The given client must be authenticated as a user with the privileges to manage them. kb is updated with
+// the resource patched to keep track of the applied Fleet policies.
+func reconcileManagedObjects(ctx context.Context, c k8s.Client, kbClient kbclient.Client, kb *kbv1.Kibana) error {
+	return utilerrors.NewAggregate([]error{
+		spaces.Reconcile(ctx, kbClient, *kb),
+		fleet.Reconcile(ctx, c, kbClient, kb),
+	})
+}
@@ -0,0 +1,121 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package kibana
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/watches"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/user"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/kibana/fleet"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+func Test_hasManagedObjects(t *testing.T) {
+	tests := []struct {
+		name string
+		kb   kbv1.Kibana
+		want bool
+	}{
+		{
+			name: "nothing declared",
+			kb:   kbv1.Kibana{},
+			want: false,
+		},
+		{
+			name: "empty Fleet spec",
+			kb:   kbv1.Kibana{Spec: kbv1.KibanaSpec{Fleet: &kbv1.FleetSpec{}}},
+			want: false,
+		},
+		{
+			name: "spaces declared",
+			kb:   kbv1.Kibana{Spec: kbv1.KibanaSpec{Spaces: []kbv1.Space{{ID: "dev", Name: "Dev"}}}},
+			want: true,
+		},
+		{
+			name: "agent policies declared",
+			kb: kbv1.Kibana{Spec: kbv1.KibanaSpec{Fleet: &kbv1.FleetSpec{
+				AgentPolicies: []kbv1.AgentPolicy{{ID: "agents", Name: "Agents"}},
+			}}},
+			want: true,
+		},
+		{
+			name: "agent policies previously applied",
+			kb: kbv1.Kibana{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{fleet.AppliedAgentPoliciesAnnotationName: `{"agents":{"hash":"123"}}`},
+			}},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, hasManagedObjects(tt.kb))
+		})
+	}
+}
+
+func Test_reconcileThroughAPI(t *testing.T) {
+	kb := upgradeFixture("", nil)
+	kb.Spec.Spaces = []kbv1.Space{{ID: "dev", Name: "Dev"}}
+	kb.Status.AvailableNodes = 1
+
+	// a Kibana API which only grants the operator user the privileges on spaces, as the kibana_system role does not
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, _, _ := r.BasicAuth()
+		calls = append(calls, fmt.Sprintf("%s %s %s", username, r.Method, r.URL.Path))
+		switch {
+		case r.URL.Path == "/api/status":
+			_, _ = w.Write([]byte(`{"version":{"number":"7.15.0"},"status":{"overall":{"level":"available","summary":"All services are available"}}}`))
+		case username != user.ControllerUserName:
+			w.WriteHeader(http.StatusForbidden)
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	c := k8s.NewFakeClient(
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: kbv1.HTTPService("test")},
+			Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 5601}}},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-auth"},
+			Data:       map[string][]byte{"kibana-user": []byte("kibana-password")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: esv1.InternalUsersSecret("es")},
+			Data:       map[string][]byte{user.ControllerUserName: []byte("operator-password")},
+		},
+	)
+	d, err := newDriver(c, watches.NewDynamicWatches(), record.NewFakeRecorder(100), kb, corev1.IPv4Protocol)
+	require.NoError(t, err)
+	state := NewState(reconcile.Request{}, kb)
+
+	results := d.reconcileThroughAPI(context.Background(), &state, serverDialer{server: server})
+	_, err = results.Aggregate()
+	require.NoError(t, err)
+	require.Equal(t, kbv1.ApplicationAvailable, state.Kibana.Status.ApplicationStatus.Level)
+	require.Equal(t, []string{
+		"kibana-user GET /api/status",
+		"elastic-internal GET /api/spaces/space/dev",
+		"elastic-internal POST /api/spaces/space",
+	}, calls)
+}
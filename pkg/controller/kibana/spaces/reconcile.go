@@ -0,0 +1,86 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package spaces
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"go.elastic.co/apm"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
+	kbclient "github.com/elastic/cloud-on-k8s/pkg/controller/kibana/client"
+	ulog "github.com/elastic/cloud-on-k8s/pkg/utils/log"
+)
+
+var log = ulog.Log.WithName("kibana-spaces")
+
+// Reconcile ensures that the spaces declared in the Kibana specification exist in Kibana as declared. A space is
+// updated if it has been modified through the Kibana API. Spaces are never deleted by the operator, to preserve the
+// saved objects they contain.
+func Reconcile(ctx context.Context, kbClient kbclient.SpacesClient, kb kbv1.Kibana) error {
+	span, ctx := apm.StartSpan(ctx, "reconcile_spaces", tracing.SpanTypeApp)
+	defer span.End()
+
+	var errs []error
+	for _, space := range kb.Spec.Spaces {
+		if err := apply(ctx, kbClient, kb, expected(space)); err != nil {
+			errs = append(errs, fmt.Errorf("while applying space %s: %w", space.ID, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// expected returns the space as expected to be returned by the Kibana API.
+func expected(space kbv1.Space) kbclient.Space {
+	disabledFeatures := space.DisabledFeatures
+	if disabledFeatures == nil {
+		disabledFeatures = []string{}
+	}
+	return kbclient.Space{
+		ID:               space.ID,
+		Name:             space.Name,
+		Description:      space.Description,
+		Color:            space.Color,
+		Initials:         space.Initials,
+		DisabledFeatures: disabledFeatures,
+	}
+}
+
+// apply creates or updates the given space if it does not match the current one.
+func apply(ctx context.Context, kbClient kbclient.SpacesClient, kb kbv1.Kibana, space kbclient.Space) error {
+	current, err := kbClient.GetSpace(ctx, space.ID)
+	switch {
+	case kbclient.IsNotFound(err):
+		log.Info("Creating space", "namespace", kb.Namespace, "kibana_name", kb.Name, "space", space.ID)
+		return kbClient.CreateSpace(ctx, space)
+	case err != nil:
+		return err
+	}
+
+	if isUpToDate(space, current) {
+		return nil
+	}
+	log.Info("Updating space", "namespace", kb.Namespace, "kibana_name", kb.Name, "space", space.ID)
+	return kbClient.UpdateSpace(ctx, space)
+}
+
+// isUpToDate returns true if the current space matches the expected one. Color and initials are generated by Kibana
+// if not specified.
+func isUpToDate(expected, current kbclient.Space) bool {
+	if expected.Color == "" {
+		current.Color = ""
+	}
+	if expected.Initials == "" {
+		current.Initials = ""
+	}
+	if current.DisabledFeatures == nil {
+		current.DisabledFeatures = []string{}
+	}
+	return reflect.DeepEqual(expected, current)
+}
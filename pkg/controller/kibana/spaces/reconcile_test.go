@@ -0,0 +1,130 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package spaces
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
+	kbclient "github.com/elastic/cloud-on-k8s/pkg/controller/kibana/client"
+)
+
+// fakeSpacesClient stores spaces by ID.
+type fakeSpacesClient struct {
+	spaces  map[string]kbclient.Space
+	calls   []string
+	failing map[string]bool
+}
+
+func newFakeClient(spaces ...kbclient.Space) *fakeSpacesClient {
+	f := &fakeSpacesClient{spaces: map[string]kbclient.Space{}, failing: map[string]bool{}}
+	for _, space := range spaces {
+		f.spaces[space.ID] = space
+	}
+	return f
+}
+
+func (f *fakeSpacesClient) GetSpace(_ context.Context, id string) (kbclient.Space, error) {
+	space, exists := f.spaces[id]
+	if !exists {
+		return kbclient.Space{}, &kbclient.APIError{StatusCode: 404}
+	}
+	return space, nil
+}
+
+func (f *fakeSpacesClient) CreateSpace(_ context.Context, space kbclient.Space) error {
+	if f.failing[space.ID] {
+		return errors.New("bad request")
+	}
+	f.calls = append(f.calls, "create "+space.ID)
+	f.spaces[space.ID] = space
+	return nil
+}
+
+func (f *fakeSpacesClient) UpdateSpace(_ context.Context, space kbclient.Space) error {
+	f.calls = append(f.calls, "update "+space.ID)
+	f.spaces[space.ID] = space
+	return nil
+}
+
+var _ kbclient.SpacesClient = &fakeSpacesClient{}
+
+func newKibana(spaces ...kbv1.Space) kbv1.Kibana {
+	return kbv1.Kibana{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kb"},
+		Spec:       kbv1.KibanaSpec{Spaces: spaces},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	dev := kbv1.Space{ID: "dev", Name: "Dev", DisabledFeatures: []string{"canvas"}}
+	prod := kbv1.Space{ID: "prod", Name: "Prod", Description: "Production"}
+	tests := []struct {
+		name      string
+		client    *fakeSpacesClient
+		kb        kbv1.Kibana
+		wantCalls []string
+		wantErr   bool
+	}{
+		{
+			name:   "no spaces",
+			client: newFakeClient(),
+			kb:     newKibana(),
+		},
+		{
+			name:      "create spaces",
+			client:    newFakeClient(),
+			kb:        newKibana(dev, prod),
+			wantCalls: []string{"create dev", "create prod"},
+		},
+		{
+			name: "spaces up-to-date, with color and initials generated by Kibana",
+			client: newFakeClient(
+				kbclient.Space{ID: "dev", Name: "Dev", Color: "#aabbcc", Initials: "D", DisabledFeatures: []string{"canvas"}},
+				kbclient.Space{ID: "prod", Name: "Prod", Description: "Production", Color: "#ccbbaa", Initials: "P"},
+			),
+			kb: newKibana(dev, prod),
+		},
+		{
+			name: "update a space modified in Kibana",
+			client: newFakeClient(
+				kbclient.Space{ID: "dev", Name: "Dev", DisabledFeatures: []string{}},
+				kbclient.Space{ID: "prod", Name: "Prod", Description: "Production"},
+			),
+			kb:        newKibana(dev, prod),
+			wantCalls: []string{"update dev"},
+		},
+		{
+			name: "spaces not declared anymore are not deleted",
+			client: newFakeClient(
+				kbclient.Space{ID: "dev", Name: "Dev", DisabledFeatures: []string{"canvas"}},
+			),
+			kb: newKibana(),
+		},
+		{
+			name: "continue on error",
+			client: func() *fakeSpacesClient {
+				f := newFakeClient()
+				f.failing["dev"] = true
+				return f
+			}(),
+			kb:        newKibana(dev, prod),
+			wantCalls: []string{"create prod"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Reconcile(context.Background(), tt.client, tt.kb)
+			require.Equal(t, tt.wantErr, err != nil)
+			require.Equal(t, tt.wantCalls, tt.client.calls)
+		})
+	}
+}
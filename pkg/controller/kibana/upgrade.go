@@ -0,0 +1,234 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package kibana
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/deployment"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/events"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	kbclient "github.com/elastic/cloud-on-k8s/pkg/controller/kibana/client"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/maps"
+	netutil "github.com/elastic/cloud-on-k8s/pkg/utils/net"
+)
+
+const (
+	// KibanaMigrationLabelName is the label set on the Pod migrating the saved objects during a rolling upgrade.
+	KibanaMigrationLabelName = "kibana.k8s.elastic.co/saved-objects-migration"
+
+	// migrationPollingInterval is the interval at which the progress of the saved objects migration is checked.
+	migrationPollingInterval = 10 * time.Second
+	// migrationTimeout is the time after which a saved objects migration which did not complete is considered failed.
+	migrationTimeout = 1 * time.Hour
+	// migrationMaxRestarts is the number of restarts of the Kibana container after which a saved objects migration is
+	// considered failed.
+	migrationMaxRestarts = 3
+)
+
+// rollingUpgradeMinVersion is the minimum version of Kibana supporting rolling upgrades: saved objects migrations are
+// performed by a single instance while the instances of the previous version can no longer write to the saved objects
+// indices starting with this version.
+var rollingUpgradeMinVersion = version.From(7, 12, 0)
+
+// migrationPodName returns the name of the Pod migrating the saved objects during a rolling upgrade.
+func migrationPodName(kbName string) string {
+	return kbv1.KBNamer.Suffix(kbName, "migration")
+}
+
+// migrationNetworkPolicyName returns the name of the NetworkPolicy allowing the operator to retrieve the status of the
+// Pod migrating the saved objects.
+func migrationNetworkPolicyName(kbName string) string {
+	return kbv1.KBNamer.Suffix(kbName, "migration", "network-policy")
+}
+
+// reconcileUpgrade tracks the progress of a version upgrade in the status of the given Kibana and returns true if the
+// Deployment must not be updated yet.
+// With the Rolling upgrade strategy, a single Pod of the new version is first created to migrate the saved objects, the
+// Deployment being held back until the migration completes. The strategy of the given Deployment parameters is then
+// updated to roll out the new version, or to recreate all the Pods if the migration failed.
+func (d *driver) reconcileUpgrade(ctx context.Context, kb *kbv1.Kibana, params *deployment.Params, dialer netutil.Dialer) (bool, error) {
+	var pods corev1.PodList
+	if err := d.client.List(ctx, &pods, client.InNamespace(kb.Namespace), client.MatchingLabels{KibanaNameLabelName: kb.Name}); err != nil {
+		return false, err
+	}
+	var migrationPod *corev1.Pod
+	var runningVersions []string
+	for i, pod := range pods.Items {
+		if pod.Labels[KibanaMigrationLabelName] == "true" {
+			migrationPod = &pods.Items[i]
+			continue
+		}
+		if v := pod.Labels[KibanaVersionLabelName]; v != kb.Spec.Version {
+			runningVersions = append(runningVersions, v)
+		}
+	}
+
+	if len(runningVersions) == 0 {
+		// no upgrade in progress
+		kb.Status.Upgrade = nil
+		return false, d.deleteMigrationPod(ctx, migrationPod)
+	}
+
+	upgrade := kb.Status.Upgrade
+	if upgrade == nil || upgrade.TargetVersion != kb.Spec.Version {
+		upgrade = &kbv1.UpgradeStatus{TargetVersion: kb.Spec.Version, Phase: kbv1.UpgradeRecreating}
+		if supportsRollingUpgrade(*kb, runningVersions) {
+			upgrade.Phase = kbv1.UpgradeMigratingSavedObjects
+		}
+		kb.Status.Upgrade = upgrade
+	}
+	if upgrade.Phase != kbv1.UpgradeMigratingSavedObjects {
+		return false, d.deleteMigrationPod(ctx, migrationPod)
+	}
+
+	if migrationPod != nil && migrationPod.Labels[KibanaVersionLabelName] != kb.Spec.Version {
+		// leftover of a previous upgrade, wait for its deletion to create a new one
+		return true, d.deleteMigrationPod(ctx, migrationPod)
+	}
+	if migrationPod == nil {
+		return true, d.createMigrationPod(ctx, kb, params.PodTemplateSpec)
+	}
+
+	if reason, failed := migrationFailed(*migrationPod, time.Now()); failed {
+		upgrade.Phase = kbv1.UpgradeRecreating
+		upgrade.Message = fmt.Sprintf("Saved objects migration failed: %s", reason)
+		params.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+		d.Recorder().Event(kb, corev1.EventTypeWarning, events.EventReasonUnexpected,
+			fmt.Sprintf("%s, falling back to recreating all Kibana instances", upgrade.Message))
+		return false, d.deleteMigrationPod(ctx, migrationPod)
+	}
+
+	status, err := d.migrationPodStatus(ctx, *kb, *migrationPod, dialer)
+	if err != nil {
+		log.V(1).Info("Saved objects migration in progress", "namespace", kb.Namespace, "kibana_name", kb.Name, "error", err.Error())
+		return true, nil
+	}
+	if !migrationCompleted(*kb, status) {
+		log.V(1).Info("Saved objects migration in progress", "namespace", kb.Namespace, "kibana_name", kb.Name, "level", status.Level)
+		return true, nil
+	}
+
+	upgrade.Phase = kbv1.UpgradeRollingOut
+	upgrade.Message = ""
+	params.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType}
+	d.Recorder().Event(kb, corev1.EventTypeNormal, events.EventReasonUpgraded,
+		fmt.Sprintf("Saved objects migrated to version %s, rolling out the remaining Kibana instances", kb.Spec.Version))
+	return false, d.deleteMigrationPod(ctx, migrationPod)
+}
+
+// supportsRollingUpgrade returns true if Kibana can be upgraded from the given running versions to the specified version
+// with the Rolling upgrade strategy. Credentials to call the Kibana API are provided by the Elasticsearch association.
+func supportsRollingUpgrade(kb kbv1.Kibana, runningVersions []string) bool {
+	if kb.Spec.UpgradeStrategy != kbv1.RollingUpgradeStrategy || !kb.Spec.ElasticsearchRef.IsDefined() {
+		return false
+	}
+	target, err := version.Parse(kb.Spec.Version)
+	if err != nil || target.LT(rollingUpgradeMinVersion) {
+		return false
+	}
+	for _, v := range runningVersions {
+		// a missing version label means the Pod was created by a previous version of the operator
+		running, err := version.Parse(v)
+		if err != nil || running.LT(rollingUpgradeMinVersion) || running.GT(target) {
+			return false
+		}
+	}
+	return true
+}
+
+// migrationFailed returns the reason why the saved objects migration performed by the given Pod is considered failed,
+// if any.
+func migrationFailed(pod corev1.Pod, now time.Time) (string, bool) {
+	if pod.Status.Phase == corev1.PodFailed {
+		return fmt.Sprintf("Pod %s failed", pod.Name), true
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == kbv1.KibanaContainerName && status.RestartCount >= migrationMaxRestarts {
+			return fmt.Sprintf("Kibana container of Pod %s restarted %d times", pod.Name, status.RestartCount), true
+		}
+	}
+	if now.Sub(pod.CreationTimestamp.Time) > migrationTimeout {
+		return fmt.Sprintf("not completed after %s", migrationTimeout), true
+	}
+	return "", false
+}
+
+// migrationCompleted returns true if the given status reports that the specified version of Kibana is running, which
+// implies that the saved objects were successfully migrated.
+func migrationCompleted(kb kbv1.Kibana, status kbclient.Status) bool {
+	if status.Version != kb.Spec.Version {
+		return false
+	}
+	return status.Level == kbclient.AvailableLevel || status.Level == kbclient.DegradedLevel
+}
+
+// migrationPodStatus returns the status reported by the Kibana API of the given Pod.
+func (d *driver) migrationPodStatus(ctx context.Context, kb kbv1.Kibana, pod corev1.Pod, dialer netutil.Dialer) (kbclient.Status, error) {
+	kbClient, err := newPodStatusClient(d.client, dialer, kb, pod)
+	if err != nil {
+		return kbclient.Status{}, err
+	}
+	defer kbClient.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, applicationStatusTimeout)
+	defer cancel()
+	return kbClient.GetStatus(ctx)
+}
+
+// createMigrationPod creates the Pod migrating the saved objects from the Pod template of the new version.
+// The Pod is not labelled with the type of resource, so that it is not selected by the HTTP Service, the
+// PodDisruptionBudget and the Deployment of Kibana. It keeps the name of Kibana in its labels, to be found during the
+// next reconciliations and to be allowed to connect to Elasticsearch by the NetworkPolicies of the association. It is
+// selected by its own NetworkPolicy to allow the operator to retrieve its status (see reconcileNetworkPolicies).
+func (d *driver) createMigrationPod(ctx context.Context, kb *kbv1.Kibana, podTemplate corev1.PodTemplateSpec) error {
+	pod := corev1.Pod{
+		ObjectMeta: *podTemplate.ObjectMeta.DeepCopy(),
+		Spec:       *podTemplate.Spec.DeepCopy(),
+	}
+	pod.Name = migrationPodName(kb.Name)
+	pod.Namespace = kb.Namespace
+	pod.Labels = maps.Merge(pod.Labels, map[string]string{KibanaMigrationLabelName: "true"})
+	delete(pod.Labels, common.TypeLabelName)
+	if err := controllerutil.SetControllerReference(kb, &pod, scheme.Scheme); err != nil {
+		return err
+	}
+	log.Info("Creating Pod to migrate the saved objects", "namespace", kb.Namespace, "kibana_name", kb.Name, "version", kb.Spec.Version)
+	if err := d.client.Create(ctx, &pod); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// deleteMigrationPod deletes the given Pod migrating the saved objects, if not nil.
+func (d *driver) deleteMigrationPod(ctx context.Context, pod *corev1.Pod) error {
+	if pod == nil || !pod.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	opts := client.Preconditions{UID: &pod.UID, ResourceVersion: &pod.ResourceVersion}
+	if err := d.client.Delete(ctx, pod, opts); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// currentDeployment returns the Deployment of the given Kibana as it exists, when it must not be updated.
+func (d *driver) currentDeployment(ctx context.Context, params deployment.Params) (appsv1.Deployment, error) {
+	var dep appsv1.Deployment
+	err := d.client.Get(ctx, types.NamespacedName{Namespace: params.Namespace, Name: params.Name}, &dep)
+	return dep, err
+}
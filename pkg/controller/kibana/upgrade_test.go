@@ -0,0 +1,364 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package kibana
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/deployment"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/networkpolicy"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/operator"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/watches"
+	kbclient "github.com/elastic/cloud-on-k8s/pkg/controller/kibana/client"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/kibana/network"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/maps"
+)
+
+// serverDialer connects to the given server whatever the requested address.
+type serverDialer struct {
+	server *httptest.Server
+}
+
+func (d serverDialer) DialContext(ctx context.Context, network, _ string) (net.Conn, error) {
+	return (&net.Dialer{}).DialContext(ctx, network, d.server.Listener.Addr().String())
+}
+
+func upgradeFixture(strategy kbv1.UpgradeStrategyType, upgrade *kbv1.UpgradeStatus) *kbv1.Kibana {
+	kb := kibanaFixture()
+	kb.Spec.Version = "7.15.0"
+	kb.Spec.UpgradeStrategy = strategy
+	kb.Spec.HTTP.TLS.SelfSignedCertificate = &commonv1.SelfSignedCertificate{Disabled: true}
+	kb.Status.Upgrade = upgrade
+	return kb
+}
+
+func kibanaPod(name, version string, migration bool) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			Labels:            map[string]string{KibanaNameLabelName: "test", KibanaVersionLabelName: version},
+			CreationTimestamp: metav1.Now(),
+		},
+		Status: corev1.PodStatus{PodIP: "10.0.0.1"},
+	}
+	if migration {
+		pod.Labels[KibanaMigrationLabelName] = "true"
+	}
+	return pod
+}
+
+func Test_reconcileUpgrade(t *testing.T) {
+	migrationPod := migrationPodName("test")
+	failedMigrationPod := kibanaPod(migrationPod, "7.15.0", true)
+	failedMigrationPod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: kbv1.KibanaContainerName, RestartCount: 3}}
+	authSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-auth"},
+		Data:       map[string][]byte{"kibana-user": []byte("password")},
+	}
+
+	tests := []struct {
+		name             string
+		kb               *kbv1.Kibana
+		objects          []runtime.Object
+		statusBody       string
+		wantHoldBack     bool
+		wantUpgrade      *kbv1.UpgradeStatus
+		wantStrategy     appsv1.DeploymentStrategyType
+		wantMigrationPod bool
+	}{
+		{
+			name:         "no upgrade in progress",
+			kb:           upgradeFixture(kbv1.RollingUpgradeStrategy, &kbv1.UpgradeStatus{TargetVersion: "7.15.0", Phase: kbv1.UpgradeRollingOut}),
+			objects:      []runtime.Object{kibanaPod("pod-1", "7.15.0", false), kibanaPod(migrationPod, "7.15.0", true)},
+			wantHoldBack: false,
+			wantUpgrade:  nil,
+			wantStrategy: appsv1.RollingUpdateDeploymentStrategyType,
+		},
+		{
+			name:         "Recreate upgrade strategy",
+			kb:           upgradeFixture("", nil),
+			objects:      []runtime.Object{kibanaPod("pod-1", "7.14.0", false)},
+			wantHoldBack: false,
+			wantUpgrade:  &kbv1.UpgradeStatus{TargetVersion: "7.15.0", Phase: kbv1.UpgradeRecreating},
+			wantStrategy: appsv1.RecreateDeploymentStrategyType,
+		},
+		{
+			name:         "Rolling upgrade strategy from a version which does not support it",
+			kb:           upgradeFixture(kbv1.RollingUpgradeStrategy, nil),
+			objects:      []runtime.Object{kibanaPod("pod-1", "7.11.0", false)},
+			wantHoldBack: false,
+			wantUpgrade:  &kbv1.UpgradeStatus{TargetVersion: "7.15.0", Phase: kbv1.UpgradeRecreating},
+			wantStrategy: appsv1.RecreateDeploymentStrategyType,
+		},
+		{
+			name:             "Rolling upgrade strategy: create the migration Pod",
+			kb:               upgradeFixture(kbv1.RollingUpgradeStrategy, nil),
+			objects:          []runtime.Object{kibanaPod("pod-1", "7.14.0", false)},
+			wantHoldBack:     true,
+			wantUpgrade:      &kbv1.UpgradeStatus{TargetVersion: "7.15.0", Phase: kbv1.UpgradeMigratingSavedObjects},
+			wantStrategy:     appsv1.RecreateDeploymentStrategyType,
+			wantMigrationPod: true,
+		},
+		{
+			name: "Rolling upgrade strategy: migration in progress",
+			kb:   upgradeFixture(kbv1.RollingUpgradeStrategy, &kbv1.UpgradeStatus{TargetVersion: "7.15.0", Phase: kbv1.UpgradeMigratingSavedObjects}),
+			objects: []runtime.Object{
+				kibanaPod("pod-1", "7.14.0", false), kibanaPod(migrationPod, "7.15.0", true), authSecret,
+			},
+			statusBody:       `{"version":{"number":"7.15.0"},"status":{"overall":{"state":"red"}}}`,
+			wantHoldBack:     true,
+			wantUpgrade:      &kbv1.UpgradeStatus{TargetVersion: "7.15.0", Phase: kbv1.UpgradeMigratingSavedObjects},
+			wantStrategy:     appsv1.RecreateDeploymentStrategyType,
+			wantMigrationPod: true,
+		},
+		{
+			name: "Rolling upgrade strategy: migration completed",
+			kb:   upgradeFixture(kbv1.RollingUpgradeStrategy, &kbv1.UpgradeStatus{TargetVersion: "7.15.0", Phase: kbv1.UpgradeMigratingSavedObjects}),
+			objects: []runtime.Object{
+				kibanaPod("pod-1", "7.14.0", false), kibanaPod(migrationPod, "7.15.0", true), authSecret,
+			},
+			statusBody:   `{"version":{"number":"7.15.0"},"status":{"overall":{"state":"green"}}}`,
+			wantHoldBack: false,
+			wantUpgrade:  &kbv1.UpgradeStatus{TargetVersion: "7.15.0", Phase: kbv1.UpgradeRollingOut},
+			wantStrategy: appsv1.RollingUpdateDeploymentStrategyType,
+		},
+		{
+			name:         "Rolling upgrade strategy: migration failed",
+			kb:           upgradeFixture(kbv1.RollingUpgradeStrategy, &kbv1.UpgradeStatus{TargetVersion: "7.15.0", Phase: kbv1.UpgradeMigratingSavedObjects}),
+			objects:      []runtime.Object{kibanaPod("pod-1", "7.14.0", false), failedMigrationPod},
+			wantHoldBack: false,
+			wantUpgrade: &kbv1.UpgradeStatus{
+				TargetVersion: "7.15.0",
+				Phase:         kbv1.UpgradeRecreating,
+				Message:       fmt.Sprintf("Saved objects migration failed: Kibana container of Pod %s restarted 3 times", migrationPod),
+			},
+			wantStrategy: appsv1.RecreateDeploymentStrategyType,
+		},
+		{
+			name: "Rolling upgrade strategy: delete the migration Pod of a previous upgrade",
+			kb:   upgradeFixture(kbv1.RollingUpgradeStrategy, nil),
+			objects: []runtime.Object{
+				kibanaPod("pod-1", "7.14.0", false), kibanaPod(migrationPod, "7.14.1", true),
+			},
+			wantHoldBack: true,
+			wantUpgrade:  &kbv1.UpgradeStatus{TargetVersion: "7.15.0", Phase: kbv1.UpgradeMigratingSavedObjects},
+			wantStrategy: appsv1.RecreateDeploymentStrategyType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tt.statusBody))
+			}))
+			defer server.Close()
+
+			c := k8s.NewFakeClient(tt.objects...)
+			d, err := newDriver(c, watches.NewDynamicWatches(), record.NewFakeRecorder(100), tt.kb, corev1.IPv4Protocol)
+			require.NoError(t, err)
+			params := deployment.Params{
+				PodTemplateSpec: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{
+					Labels: maps.Merge(NewLabels("test"), map[string]string{KibanaVersionLabelName: "7.15.0"}),
+				}},
+				Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			}
+			if tt.kb.Status.Upgrade == nil || tt.kb.Status.Upgrade.Phase == kbv1.UpgradeRollingOut {
+				params.Strategy.Type, err = d.getStrategyType(tt.kb)
+				require.NoError(t, err)
+			}
+
+			holdBack, err := d.reconcileUpgrade(context.Background(), tt.kb, &params, serverDialer{server: server})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantHoldBack, holdBack)
+			require.Equal(t, tt.wantUpgrade, tt.kb.Status.Upgrade)
+			require.Equal(t, tt.wantStrategy, params.Strategy.Type)
+
+			var pod corev1.Pod
+			err = c.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: migrationPod}, &pod)
+			if !tt.wantMigrationPod {
+				require.True(t, apierrors.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "7.15.0", pod.Labels[KibanaVersionLabelName])
+			require.Equal(t, "true", pod.Labels[KibanaMigrationLabelName])
+			// the migration Pod is not selected by the HTTP Service and the PodDisruptionBudget
+			require.Equal(t, "test", pod.Labels[KibanaNameLabelName])
+			require.False(t, labels.SelectorFromSet(NewService(*tt.kb).Spec.Selector).Matches(labels.Set(pod.Labels)))
+		})
+	}
+}
+
+func Test_supportsRollingUpgrade(t *testing.T) {
+	tests := []struct {
+		name            string
+		kb              *kbv1.Kibana
+		runningVersions []string
+		want            bool
+	}{
+		{
+			name:            "Recreate upgrade strategy",
+			kb:              upgradeFixture(kbv1.RecreateUpgradeStrategy, nil),
+			runningVersions: []string{"7.14.0"},
+			want:            false,
+		},
+		{
+			name:            "Rolling upgrade strategy",
+			kb:              upgradeFixture(kbv1.RollingUpgradeStrategy, nil),
+			runningVersions: []string{"7.14.0", "7.12.0"},
+			want:            true,
+		},
+		{
+			name:            "running version too old",
+			kb:              upgradeFixture(kbv1.RollingUpgradeStrategy, nil),
+			runningVersions: []string{"7.14.0", "7.11.2"},
+			want:            false,
+		},
+		{
+			name:            "version label missing",
+			kb:              upgradeFixture(kbv1.RollingUpgradeStrategy, nil),
+			runningVersions: []string{""},
+			want:            false,
+		},
+		{
+			name:            "downgrade",
+			kb:              upgradeFixture(kbv1.RollingUpgradeStrategy, nil),
+			runningVersions: []string{"7.16.0"},
+			want:            false,
+		},
+		{
+			name: "no Elasticsearch association",
+			kb: func() *kbv1.Kibana {
+				kb := upgradeFixture(kbv1.RollingUpgradeStrategy, nil)
+				kb.Spec.ElasticsearchRef = commonv1.ObjectSelector{}
+				return kb
+			}(),
+			runningVersions: []string{"7.14.0"},
+			want:            false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, supportsRollingUpgrade(*tt.kb, tt.runningVersions))
+		})
+	}
+}
+
+func Test_migrationFailed(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name       string
+		pod        corev1.Pod
+		wantFailed bool
+	}{
+		{
+			name:       "running",
+			pod:        *kibanaPod("pod", "7.15.0", true),
+			wantFailed: false,
+		},
+		{
+			name: "Pod failed",
+			pod: func() corev1.Pod {
+				pod := kibanaPod("pod", "7.15.0", true)
+				pod.Status.Phase = corev1.PodFailed
+				return *pod
+			}(),
+			wantFailed: true,
+		},
+		{
+			name: "Kibana container restarted",
+			pod: func() corev1.Pod {
+				pod := kibanaPod("pod", "7.15.0", true)
+				pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: kbv1.KibanaContainerName, RestartCount: 3}}
+				return *pod
+			}(),
+			wantFailed: true,
+		},
+		{
+			name: "timeout",
+			pod: func() corev1.Pod {
+				pod := kibanaPod("pod", "7.15.0", true)
+				pod.CreationTimestamp = metav1.NewTime(now.Add(-2 * time.Hour))
+				return *pod
+			}(),
+			wantFailed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, failed := migrationFailed(tt.pod, now)
+			require.Equal(t, tt.wantFailed, failed)
+		})
+	}
+}
+
+func Test_migrationCompleted(t *testing.T) {
+	kb := upgradeFixture(kbv1.RollingUpgradeStrategy, nil)
+	require.True(t, migrationCompleted(*kb, kbclient.Status{Version: "7.15.0", Level: kbclient.AvailableLevel}))
+	require.True(t, migrationCompleted(*kb, kbclient.Status{Version: "7.15.0", Level: kbclient.DegradedLevel}))
+	require.False(t, migrationCompleted(*kb, kbclient.Status{Version: "7.15.0", Level: kbclient.UnavailableLevel}))
+	// response of an instance of the previous version
+	require.False(t, migrationCompleted(*kb, kbclient.Status{Version: "7.14.0", Level: kbclient.AvailableLevel}))
+}
+
+func Test_reconcileUpgrade_networkPolicies(t *testing.T) {
+	kb := upgradeFixture(kbv1.RollingUpgradeStrategy, nil)
+	c := k8s.NewFakeClient(kb, kibanaPod("pod-1", "7.14.0", false))
+	d, err := newDriver(c, watches.NewDynamicWatches(), record.NewFakeRecorder(100), kb, corev1.IPv4Protocol)
+	require.NoError(t, err)
+	params := deployment.Params{
+		PodTemplateSpec: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{
+			Labels: maps.Merge(NewLabels("test"), map[string]string{KibanaVersionLabelName: "7.15.0"}),
+		}},
+		Selector: NewLabels("test"),
+		Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+	}
+
+	// the migration Pod is created
+	holdBack, err := d.reconcileUpgrade(context.Background(), kb, &params, nil)
+	require.NoError(t, err)
+	require.True(t, holdBack)
+	var pod corev1.Pod
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: migrationPodName("test")}, &pod))
+
+	// the operator is allowed to retrieve its status in a namespace denying the traffic by default
+	operatorParams := operator.Parameters{OperatorNamespace: "elastic-system", NetworkPoliciesSupported: true, ManageNetworkPolicies: true}
+	require.NoError(t, d.reconcileNetworkPolicies(kb, operatorParams, params.Selector))
+	var policies networkingv1.NetworkPolicyList
+	require.NoError(t, c.List(context.Background(), &policies))
+	var allowed bool
+	for _, policy := range policies.Items {
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+		require.NoError(t, err)
+		if !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		require.Equal(t, []networkingv1.NetworkPolicyIngressRule{
+			networkpolicy.FromOperator("elastic-system", network.HTTPPort),
+		}, policy.Spec.Ingress)
+		allowed = true
+	}
+	require.True(t, allowed, "the migration Pod must be selected by a NetworkPolicy")
+}
@@ -0,0 +1,92 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package logstash
+
+import (
+	"hash"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/reconciler"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/settings"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
+)
+
+// reconcileConfig reconciles the Secret holding the Logstash settings and pipelines, and writes its content to the
+// given hash so that Pods are rotated on configuration changes.
+func reconcileConfig(params Params, configHash hash.Hash) *reconciler.Results {
+	defer tracing.Span(&params.Context)()
+	results := reconciler.NewResult(params.Context)
+
+	cfgBytes, err := buildConfig(params)
+	if err != nil {
+		return results.WithError(err)
+	}
+
+	pipelinesBytes, err := buildPipelines(params)
+	if err != nil {
+		return results.WithError(err)
+	}
+
+	cfgData := map[string][]byte{
+		ConfigFileName: cfgBytes,
+	}
+	// keep the default pipelines of the Logstash image if none are specified
+	if pipelinesBytes != nil {
+		cfgData[PipelinesFileName] = pipelinesBytes
+	}
+
+	expected := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: params.Logstash.Namespace,
+			Name:      ConfigSecretName(params.Logstash.Name),
+			Labels:    common.AddCredentialsLabel(NewLabels(params.Logstash)),
+		},
+		Data: cfgData,
+	}
+
+	if _, err = reconciler.ReconcileSecret(params.Client, expected, &params.Logstash); err != nil {
+		return results.WithError(err)
+	}
+
+	_, _ = configHash.Write(cfgBytes)
+	_, _ = configHash.Write(pipelinesBytes)
+
+	return results
+}
+
+// buildConfig renders the logstash.yml settings file from the operator defaults and the user provided configuration.
+func buildConfig(params Params) ([]byte, error) {
+	cfg, err := settings.NewCanonicalConfigFrom(map[string]interface{}{
+		// expose the Logstash API outside of the Pod, for readiness checks and monitoring
+		"api.http.host": "0.0.0.0",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// get user config from `config` or `configRef`
+	userConfig, err := getUserConfig(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cfg.MergeWith(userConfig); err != nil {
+		return nil, err
+	}
+
+	return cfg.Render()
+}
+
+// getUserConfig extracts the config either from the spec `config` field or from the Secret referenced by spec
+// `configRef` field.
+func getUserConfig(params Params) (*settings.CanonicalConfig, error) {
+	if params.Logstash.Spec.Config != nil {
+		return settings.NewCanonicalConfigFrom(params.Logstash.Spec.Config.Data)
+	}
+	return common.ParseConfigRef(params, &params.Logstash, params.Logstash.Spec.ConfigRef, ConfigFileName)
+}
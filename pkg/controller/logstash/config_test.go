@@ -0,0 +1,177 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package logstash
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	logstashv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/logstash/v1alpha1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/watches"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+func Test_buildConfig(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		runtimeObjs []runtime.Object
+		logstash    logstashv1alpha1.Logstash
+		want        string
+		wantErr     bool
+	}{
+		{
+			name:     "no user config",
+			logstash: sampleLogstash(),
+			want: `api:
+  http:
+    host: 0.0.0.0
+`,
+		},
+		{
+			name: "inline user config",
+			logstash: withSpec(sampleLogstash(), func(spec *logstashv1alpha1.LogstashSpec) {
+				spec.Config = &commonv1.Config{Data: map[string]interface{}{"pipeline.workers": 4}}
+			}),
+			want: `api:
+  http:
+    host: 0.0.0.0
+pipeline:
+  workers: 4
+`,
+		},
+		{
+			name:        "with configRef",
+			runtimeObjs: []runtime.Object{secretWithData("cfg", ConfigFileName, "log.level: debug")},
+			logstash: withSpec(sampleLogstash(), func(spec *logstashv1alpha1.LogstashSpec) {
+				spec.ConfigRef = &commonv1.ConfigSource{SecretRef: commonv1.SecretRef{SecretName: "cfg"}}
+			}),
+			want: `api:
+  http:
+    host: 0.0.0.0
+log:
+  level: debug
+`,
+		},
+		{
+			name: "non existing configRef",
+			logstash: withSpec(sampleLogstash(), func(spec *logstashv1alpha1.LogstashSpec) {
+				spec.ConfigRef = &commonv1.ConfigSource{SecretRef: commonv1.SecretRef{SecretName: "cfg"}}
+			}),
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildConfig(testParams(tt.logstash, tt.runtimeObjs...))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func Test_buildPipelines(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		runtimeObjs []runtime.Object
+		logstash    logstashv1alpha1.Logstash
+		want        string
+		wantErr     bool
+	}{
+		{
+			name:     "no pipelines",
+			logstash: sampleLogstash(),
+			want:     "",
+		},
+		{
+			name: "inline pipelines",
+			logstash: withSpec(sampleLogstash(), func(spec *logstashv1alpha1.LogstashSpec) {
+				spec.Pipelines = []commonv1.Config{
+					{Data: map[string]interface{}{"pipeline.id": "main", "config.string": "input { beats {} }"}},
+				}
+			}),
+			want: `- config.string: input { beats {} }
+  pipeline.id: main
+`,
+		},
+		{
+			name:        "with pipelinesRef",
+			runtimeObjs: []runtime.Object{secretWithData("pipelines", PipelinesFileName, "- pipeline.id: main\n  path.config: /tmp/main.conf\n")},
+			logstash: withSpec(sampleLogstash(), func(spec *logstashv1alpha1.LogstashSpec) {
+				spec.PipelinesRef = &commonv1.ConfigSource{SecretRef: commonv1.SecretRef{SecretName: "pipelines"}}
+			}),
+			want: `- path.config: /tmp/main.conf
+  pipeline.id: main
+`,
+		},
+		{
+			name:        "pipelinesRef with missing key",
+			runtimeObjs: []runtime.Object{secretWithData("pipelines", "other.yml", "- pipeline.id: main\n")},
+			logstash: withSpec(sampleLogstash(), func(spec *logstashv1alpha1.LogstashSpec) {
+				spec.PipelinesRef = &commonv1.ConfigSource{SecretRef: commonv1.SecretRef{SecretName: "pipelines"}}
+			}),
+			wantErr: true,
+		},
+		{
+			name: "non existing pipelinesRef",
+			logstash: withSpec(sampleLogstash(), func(spec *logstashv1alpha1.LogstashSpec) {
+				spec.PipelinesRef = &commonv1.ConfigSource{SecretRef: commonv1.SecretRef{SecretName: "pipelines"}}
+			}),
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			params := testParams(tt.logstash, tt.runtimeObjs...)
+			got, err := buildPipelines(params)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, string(got))
+			if tt.logstash.Spec.PipelinesRef != nil {
+				require.Len(t, params.Watches.Secrets.Registrations(), 1)
+			}
+		})
+	}
+}
+
+func sampleLogstash() logstashv1alpha1.Logstash {
+	return logstashv1alpha1.Logstash{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ls"},
+		Spec:       logstashv1alpha1.LogstashSpec{Version: "8.0.0", Count: 1},
+	}
+}
+
+func withSpec(ls logstashv1alpha1.Logstash, f func(spec *logstashv1alpha1.LogstashSpec)) logstashv1alpha1.Logstash {
+	f(&ls.Spec)
+	return ls
+}
+
+func secretWithData(name, key, data string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+		Data:       map[string][]byte{key: []byte(data)},
+	}
+}
+
+func testParams(ls logstashv1alpha1.Logstash, runtimeObjs ...runtime.Object) Params {
+	return Params{
+		Context:       context.Background(),
+		Client:        k8s.NewFakeClient(runtimeObjs...),
+		EventRecorder: record.NewFakeRecorder(10),
+		Watches:       watches.NewDynamicWatches(),
+		Logstash:      ls,
+	}
+}
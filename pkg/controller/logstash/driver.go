@@ -0,0 +1,115 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package logstash
+
+import (
+	"context"
+	"crypto/sha256"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	logstashv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/logstash/v1alpha1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/association"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common"
+	commonassociation "github.com/elastic/cloud-on-k8s/pkg/controller/common/association"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/defaults"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/keystore"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/operator"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/reconciler"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/watches"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/log"
+)
+
+type Params struct {
+	Context context.Context
+
+	Client        k8s.Client
+	EventRecorder record.EventRecorder
+	Watches       watches.DynamicWatches
+
+	Logstash logstashv1alpha1.Logstash
+
+	OperatorParams operator.Parameters
+}
+
+func (p Params) K8sClient() k8s.Client {
+	return p.Client
+}
+
+func (p Params) Recorder() record.EventRecorder {
+	return p.EventRecorder
+}
+
+func (p Params) DynamicWatches() watches.DynamicWatches {
+	return p.Watches
+}
+
+func (p *Params) Logger() logr.Logger {
+	return log.FromContext(p.Context)
+}
+
+func internalReconcile(params Params) *reconciler.Results {
+	defer tracing.Span(&params.Context)()
+	results := reconciler.NewResult(params.Context)
+
+	logstashVersion, err := version.Parse(params.Logstash.Spec.Version)
+	if err != nil {
+		return results.WithError(err)
+	}
+	if !association.AllowVersion(logstashVersion, &params.Logstash, params.Logger(), params.EventRecorder) {
+		return results // will eventually retry
+	}
+
+	if _, err := common.ReconcileService(params.Context, params.Client, newAPIService(params.Logstash), &params.Logstash); err != nil {
+		return results.WithError(err)
+	}
+
+	configHash := sha256.New224()
+	if res := reconcileConfig(params, configHash); res.HasError() {
+		return results.WithResults(res)
+	}
+
+	// we need to deref the secret here (if any) to include it in the configHash otherwise Logstash will not be rolled on content changes
+	if err := commonassociation.WriteAssocsToConfigHash(params.Client, params.Logstash.GetAssociations(), configHash); err != nil {
+		return results.WithError(err)
+	}
+
+	keystoreResources, err := keystore.NewResources(
+		params,
+		&params.Logstash,
+		Namer,
+		NewLabels(params.Logstash),
+		keystoreInitContainerParameters,
+	)
+	if err != nil {
+		return results.WithError(err)
+	}
+
+	podTemplate := buildPodTemplate(params, keystoreResources, configHash)
+	return results.WithResults(reconcileStatefulSet(params, podTemplate))
+}
+
+// newAPIService returns the headless Service governing the Logstash StatefulSet, which exposes the Logstash API.
+func newAPIService(logstash logstashv1alpha1.Logstash) *corev1.Service {
+	svc := corev1.Service{}
+	svc.ObjectMeta.Namespace = logstash.Namespace
+	svc.ObjectMeta.Name = APIServiceName(logstash.Name)
+	svc.Spec.ClusterIP = corev1.ClusterIPNone
+
+	labels := NewLabels(logstash)
+	ports := []corev1.ServicePort{
+		{
+			Name:     "http",
+			Protocol: corev1.ProtocolTCP,
+			Port:     APIPort,
+		},
+	}
+	return defaults.SetServiceDefaults(&svc, labels, labels, ports)
+}
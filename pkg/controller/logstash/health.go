@@ -0,0 +1,33 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package logstash
+
+import (
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	logstashv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/logstash/v1alpha1"
+)
+
+// CalculateHealth returns health of Logstash based on association status, desired count and ready count.
+func CalculateHealth(associations []commonv1.Association, ready, desired int32) logstashv1alpha1.LogstashHealth {
+	for _, assoc := range associations {
+		if assoc.AssociationConf().IsConfigured() {
+			statusMap := assoc.AssociationStatusMap(assoc.AssociationType())
+			if !statusMap.AllEstablished() {
+				return logstashv1alpha1.LogstashRedHealth
+			}
+		}
+	}
+
+	switch {
+	case ready == 0:
+		return logstashv1alpha1.LogstashRedHealth
+	case ready == desired:
+		return logstashv1alpha1.LogstashGreenHealth
+	case ready > 0:
+		return logstashv1alpha1.LogstashYellowHealth
+	default:
+		return logstashv1alpha1.LogstashRedHealth
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package logstash
+
+import common_name "github.com/elastic/cloud-on-k8s/pkg/controller/common/name"
+
+const apiServiceSuffix = "api"
+
+// Namer is a Namer that is configured with the defaults for resources related to a Logstash resource.
+var Namer = common_name.NewNamer("ls")
+
+func ConfigSecretName(name string) string {
+	return Namer.Suffix(name, "config")
+}
+
+func Name(name string) string {
+	return Namer.Suffix(name)
+}
+
+func APIServiceName(name string) string {
+	return Namer.Suffix(name, apiServiceSuffix)
+}
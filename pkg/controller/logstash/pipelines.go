@@ -0,0 +1,88 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package logstash
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/events"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/watches"
+)
+
+// PipelinesRefWatchName returns the name of the watch registered on the secret referenced in `pipelinesRef`.
+func PipelinesRefWatchName(resource types.NamespacedName) string {
+	return fmt.Sprintf("%s-%s-pipelinesref", resource.Namespace, resource.Name)
+}
+
+// buildPipelines renders the pipelines.yml file from the spec `pipelines` field or from the Secret referenced by
+// the spec `pipelinesRef` field. It returns nil if no pipelines are specified.
+func buildPipelines(params Params) ([]byte, error) {
+	pipelines, err := getUserPipelines(params)
+	if err != nil {
+		return nil, err
+	}
+	if pipelines == nil {
+		return nil, nil
+	}
+	return yaml.Marshal(pipelines)
+}
+
+func getUserPipelines(params Params) ([]map[string]interface{}, error) {
+	if len(params.Logstash.Spec.Pipelines) > 0 {
+		pipelines := make([]map[string]interface{}, 0, len(params.Logstash.Spec.Pipelines))
+		for _, p := range params.Logstash.Spec.Pipelines {
+			pipelines = append(pipelines, p.Data)
+		}
+		return pipelines, nil
+	}
+	return parsePipelinesRef(params)
+}
+
+// parsePipelinesRef retrieves the content of the secret referenced in `pipelinesRef`, sets up dynamic watches for that
+// secret, and parses the secret content into a list of pipelines.
+func parsePipelinesRef(params Params) ([]map[string]interface{}, error) {
+	logstash := params.Logstash
+	logstashNsn := types.NamespacedName{Namespace: logstash.Namespace, Name: logstash.Name}
+	pipelinesRef := logstash.Spec.PipelinesRef
+
+	// ensure watches match the referenced secret
+	var secretNames []string
+	if pipelinesRef != nil && pipelinesRef.SecretName != "" {
+		secretNames = append(secretNames, pipelinesRef.SecretName)
+	}
+	if err := watches.WatchUserProvidedSecrets(logstashNsn, params.Watches, PipelinesRefWatchName(logstashNsn), secretNames); err != nil {
+		return nil, err
+	}
+
+	if len(secretNames) == 0 {
+		// no secret referenced, nothing to do
+		return nil, nil
+	}
+
+	var secret corev1.Secret
+	if err := params.Client.Get(context.Background(), types.NamespacedName{Namespace: logstash.Namespace, Name: pipelinesRef.SecretName}, &secret); err != nil {
+		// the secret may not exist (yet) in the cache, let's explicitly error out and retry later
+		return nil, err
+	}
+	data, exists := secret.Data[PipelinesFileName]
+	if !exists {
+		msg := fmt.Sprintf("unable to parse pipelinesRef secret %s/%s: missing key %s", logstash.Namespace, pipelinesRef.SecretName, PipelinesFileName)
+		params.EventRecorder.Event(&params.Logstash, corev1.EventTypeWarning, events.EventReasonUnexpected, msg)
+		return nil, errors.New(msg)
+	}
+	var pipelines []map[string]interface{}
+	if err := yaml.Unmarshal(data, &pipelines); err != nil {
+		msg := fmt.Sprintf("unable to parse %s in pipelinesRef secret %s/%s", PipelinesFileName, logstash.Namespace, pipelinesRef.SecretName)
+		params.EventRecorder.Event(&params.Logstash, corev1.EventTypeWarning, events.EventReasonUnexpected, msg)
+		return nil, errors.Wrap(err, msg)
+	}
+	return pipelines, nil
+}
@@ -0,0 +1,259 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package logstash
+
+import (
+	"fmt"
+	"hash"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	logstashv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/logstash/v1alpha1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/container"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/defaults"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/keystore"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/volume"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/maps"
+)
+
+const (
+	CAFileName = "ca.crt"
+
+	ContainerName = "logstash"
+
+	// APIPort is the port of the Logstash monitoring API.
+	APIPort int32 = 9600
+
+	// ConfigVolumeName is the name of the writable volume holding the Logstash settings directory.
+	ConfigVolumeName = "config"
+	ConfigMountPath  = "/usr/share/logstash/config"
+	ConfigFileName   = "logstash.yml"
+
+	PipelinesFileName = "pipelines.yml"
+
+	// ConfigSecretVolumeName is the name of the volume holding the operator managed settings and pipelines files.
+	ConfigSecretVolumeName = "elastic-internal-logstash-config"
+	ConfigSecretMountPath  = "/mnt/elastic-internal/logstash-config"
+
+	// InitConfigContainerName is the name of the init container preparing the Logstash settings directory.
+	InitConfigContainerName = "elastic-internal-init-config"
+	InitConfigMountPath     = "/mnt/elastic-internal/logstash-config-local"
+
+	// DataVolumeName is the name of the volume holding the Logstash data directory, which includes persistent
+	// queues. A volume claim template with that name takes precedence over the default emptyDir volume.
+	DataVolumeName = "logstash-data"
+	DataMountPath  = "/usr/share/logstash/data"
+
+	// ConfigChecksumLabel is a label used to store Logstash config checksum.
+	ConfigChecksumLabel = "logstash.k8s.elastic.co/config-checksum"
+
+	// VersionLabelName is a label used to track the version of a Logstash Pod.
+	VersionLabelName = "logstash.k8s.elastic.co/version"
+)
+
+var (
+	defaultResources = corev1.ResourceRequirements{
+		Limits: map[corev1.ResourceName]resource.Quantity{
+			corev1.ResourceMemory: resource.MustParse("2Gi"),
+			corev1.ResourceCPU:    resource.MustParse("1000m"),
+		},
+		Requests: map[corev1.ResourceName]resource.Quantity{
+			corev1.ResourceMemory: resource.MustParse("2Gi"),
+			corev1.ResourceCPU:    resource.MustParse("1000m"),
+		},
+	}
+
+	// keystoreInitContainerParameters creates the Logstash keystore in the settings directory, which is the only
+	// location Logstash looks for it.
+	keystoreInitContainerParameters = keystore.InitContainerParameters{
+		KeystoreCreateCommand:         "echo 'y' | /usr/share/logstash/bin/logstash-keystore create",
+		KeystoreAddCommand:            `cat "$filename" | /usr/share/logstash/bin/logstash-keystore add "$key"`,
+		SecureSettingsVolumeMountPath: keystore.SecureSettingsVolumeMountPath,
+		KeystoreVolumePath:            ConfigMountPath,
+		Resources:                     defaultResources,
+	}
+
+	// initConfigScript copies the default settings files of the Logstash image to the writable settings directory,
+	// then overrides them with the files managed by the operator.
+	initConfigScript = fmt.Sprintf(`#!/usr/bin/env bash
+set -eu
+cp -fr %[1]s/* %[2]s/
+cp -f %[3]s/* %[2]s/
+echo "Logstash configuration successfully prepared."
+`, ConfigMountPath, InitConfigMountPath, ConfigSecretMountPath)
+)
+
+func buildPodTemplate(params Params, keystoreResources *keystore.Resources, configHash hash.Hash) corev1.PodTemplateSpec {
+	defer tracing.Span(&params.Context)()
+	spec := &params.Logstash.Spec
+
+	configVolume := volume.NewEmptyDirVolume(ConfigVolumeName, ConfigMountPath)
+	vols := []volume.VolumeLike{
+		configVolume,
+		// volume with the operator managed logstash.yml and pipelines.yml files
+		volume.NewSecretVolumeWithMountPath(
+			ConfigSecretName(params.Logstash.Name),
+			ConfigSecretVolumeName,
+			ConfigSecretMountPath,
+		),
+	}
+	// all volumes with CAs of direct associations
+	vols = append(vols, getVolumesFromAssociations(params.Logstash.GetAssociations())...)
+
+	volumes := make([]corev1.Volume, 0, len(vols))
+	volumeMounts := make([]corev1.VolumeMount, 0, len(vols))
+	for _, v := range vols {
+		volumes = append(volumes, v.Volume())
+		volumeMounts = append(volumeMounts, v.VolumeMount())
+	}
+
+	// the data volume is either provided by a volume claim template, or an emptyDir
+	dataVolume := volume.NewEmptyDirVolume(DataVolumeName, DataMountPath)
+	if !hasDataVolumeClaim(params.Logstash) {
+		volumes = append(volumes, dataVolume.Volume())
+	}
+	volumeMounts = append(volumeMounts, dataVolume.VolumeMount())
+
+	initContainers := []corev1.Container{initConfigContainer(configVolume)}
+	if keystoreResources != nil {
+		_, _ = configHash.Write([]byte(keystoreResources.Version))
+		volumes = append(volumes, keystoreResources.Volume)
+		initContainers = append(initContainers, keystoreResources.InitContainer)
+	}
+
+	labels := maps.Merge(NewLabels(params.Logstash), map[string]string{
+		ConfigChecksumLabel: fmt.Sprintf("%x", configHash.Sum(nil)),
+		VersionLabelName:    spec.Version})
+
+	builder := defaults.NewPodTemplateBuilder(spec.PodTemplate, ContainerName).
+		WithLabels(labels).
+		WithResources(defaultResources).
+		WithDockerImage(spec.Image, container.ImageRepository(container.LogstashImage, spec.Version)).
+		WithPorts([]corev1.ContainerPort{{Name: "http", ContainerPort: APIPort, Protocol: corev1.ProtocolTCP}}).
+		WithReadinessProbe(readinessProbe()).
+		WithEnv(associationsEnvVars(params.Logstash)...).
+		WithVolumes(volumes...).
+		WithVolumeMounts(volumeMounts...).
+		WithInitContainers(initContainers...).
+		WithInitContainerDefaults()
+
+	return builder.PodTemplate
+}
+
+// initConfigContainer returns the init container which prepares the writable Logstash settings directory.
+// The settings volume is mounted at a different location than in the main container so that the default settings
+// files of the image can be copied to it.
+func initConfigContainer(configVolume volume.EmptyDirVolume) corev1.Container {
+	privileged := false
+	mount := configVolume.VolumeMount()
+	mount.MountPath = InitConfigMountPath
+	return corev1.Container{
+		// Image will be inherited from pod template defaults
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Name:            InitConfigContainerName,
+		SecurityContext: &corev1.SecurityContext{
+			Privileged: &privileged,
+		},
+		Command:      []string{"/usr/bin/env", "bash", "-c", initConfigScript},
+		VolumeMounts: []corev1.VolumeMount{mount},
+		Resources:    defaultResources,
+	}
+}
+
+func readinessProbe() corev1.Probe {
+	return corev1.Probe{
+		FailureThreshold:    3,
+		InitialDelaySeconds: 30,
+		PeriodSeconds:       10,
+		SuccessThreshold:    1,
+		TimeoutSeconds:      5,
+		Handler: corev1.Handler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Port:   intstr.FromInt(int(APIPort)),
+				Path:   "/",
+				Scheme: corev1.URISchemeHTTP,
+			},
+		},
+	}
+}
+
+func hasDataVolumeClaim(logstash logstashv1alpha1.Logstash) bool {
+	for _, claim := range logstash.Spec.VolumeClaimTemplates {
+		if claim.Name == DataVolumeName {
+			return true
+		}
+	}
+	return false
+}
+
+// associationsEnvVars returns the environment variables exposing the connection settings of the Elasticsearch
+// clusters to the Logstash pipelines. Each variable is prefixed with the uppercased cluster name of the reference.
+func associationsEnvVars(logstash logstashv1alpha1.Logstash) []corev1.EnvVar {
+	var envVars []corev1.EnvVar //nolint:prealloc
+	for _, assoc := range logstash.GetAssociations() {
+		assocConf := assoc.AssociationConf()
+		if !assocConf.IsConfigured() {
+			continue
+		}
+		cluster, found := logstash.ElasticsearchCluster(assoc)
+		if !found {
+			continue
+		}
+		prefix := strings.ToUpper(cluster.ClusterName)
+
+		envVars = append(envVars, corev1.EnvVar{Name: prefix + "_ES_HOSTS", Value: assocConf.GetURL()})
+		if !assocConf.NoAuthRequired() {
+			envVars = append(envVars,
+				corev1.EnvVar{Name: prefix + "_ES_USER", Value: assocConf.GetAuthSecretKey()},
+				corev1.EnvVar{Name: prefix + "_ES_PASSWORD", ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: assocConf.GetAuthSecretName()},
+						Key:                  assocConf.GetAuthSecretKey(),
+					},
+				}},
+			)
+		}
+		if assocConf.GetCACertProvided() {
+			envVars = append(envVars, corev1.EnvVar{
+				Name:  prefix + "_ES_SSL_CERTIFICATE_AUTHORITY",
+				Value: path.Join(certificatesDir(assoc), CAFileName),
+			})
+		}
+	}
+	return envVars
+}
+
+func getVolumesFromAssociations(associations []commonv1.Association) []volume.VolumeLike {
+	var vols []volume.VolumeLike //nolint:prealloc
+	for i, assoc := range associations {
+		if !assoc.AssociationConf().CAIsConfigured() {
+			// skip as there is no volume to mount if association has no CA configured
+			continue
+		}
+		caSecretName := assoc.AssociationConf().GetCASecretName()
+		vols = append(vols, volume.NewSecretVolumeWithMountPath(
+			caSecretName,
+			fmt.Sprintf("%s-certs-%d", assoc.AssociationType(), i),
+			certificatesDir(assoc),
+		))
+	}
+	return vols
+}
+
+func certificatesDir(association commonv1.Association) string {
+	ref := association.AssociationRef()
+	return fmt.Sprintf(
+		"/mnt/elastic-internal/%s-association/%s/%s/certs",
+		association.AssociationType(),
+		ref.Namespace,
+		ref.Name,
+	)
+}
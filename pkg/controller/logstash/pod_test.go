@@ -0,0 +1,121 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package logstash
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	logstashv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/logstash/v1alpha1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/keystore"
+)
+
+func Test_buildPodTemplate(t *testing.T) {
+	for _, tt := range []struct {
+		name              string
+		logstash          logstashv1alpha1.Logstash
+		keystoreResources *keystore.Resources
+		wantDataEmptyDir  bool
+		wantInitNames     []string
+	}{
+		{
+			name:             "default data volume",
+			logstash:         sampleLogstash(),
+			wantDataEmptyDir: true,
+			wantInitNames:    []string{InitConfigContainerName},
+		},
+		{
+			name: "data volume claim template",
+			logstash: withSpec(sampleLogstash(), func(spec *logstashv1alpha1.LogstashSpec) {
+				spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{
+					{ObjectMeta: metav1.ObjectMeta{Name: DataVolumeName}},
+				}
+			}),
+			wantDataEmptyDir: false,
+			wantInitNames:    []string{InitConfigContainerName},
+		},
+		{
+			name:     "with keystore",
+			logstash: sampleLogstash(),
+			keystoreResources: &keystore.Resources{
+				InitContainer: corev1.Container{Name: "elastic-internal-init-keystore"},
+				Volume:        corev1.Volume{Name: "elastic-internal-secure-settings"},
+				Version:       "1",
+			},
+			wantDataEmptyDir: true,
+			wantInitNames:    []string{InitConfigContainerName, "elastic-internal-init-keystore"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			podTemplate := buildPodTemplate(testParams(tt.logstash), tt.keystoreResources, sha256.New224())
+
+			var hasDataVolume bool
+			for _, v := range podTemplate.Spec.Volumes {
+				if v.Name == DataVolumeName {
+					hasDataVolume = true
+					require.NotNil(t, v.EmptyDir)
+				}
+			}
+			require.Equal(t, tt.wantDataEmptyDir, hasDataVolume)
+
+			require.Len(t, podTemplate.Spec.Containers, 1)
+			var hasDataMount bool
+			for _, m := range podTemplate.Spec.Containers[0].VolumeMounts {
+				if m.Name == DataVolumeName {
+					hasDataMount = true
+					require.Equal(t, DataMountPath, m.MountPath)
+				}
+			}
+			require.True(t, hasDataMount)
+
+			initNames := make([]string, 0, len(podTemplate.Spec.InitContainers))
+			for _, c := range podTemplate.Spec.InitContainers {
+				initNames = append(initNames, c.Name)
+			}
+			require.Equal(t, tt.wantInitNames, initNames)
+
+			// the init config container mounts the settings volume at a different path than the main container
+			for _, m := range podTemplate.Spec.InitContainers[0].VolumeMounts {
+				if m.Name == ConfigVolumeName {
+					require.Equal(t, InitConfigMountPath, m.MountPath)
+				}
+			}
+		})
+	}
+}
+
+func Test_associationsEnvVars(t *testing.T) {
+	ls := withSpec(sampleLogstash(), func(spec *logstashv1alpha1.LogstashSpec) {
+		spec.ElasticsearchRefs = []logstashv1alpha1.ElasticsearchCluster{
+			{ObjectSelector: commonv1.ObjectSelector{Name: "es1"}, ClusterName: "prod"},
+			{ObjectSelector: commonv1.ObjectSelector{Name: "es2"}, ClusterName: "unconfigured"},
+		}
+	})
+	associations := ls.GetAssociations()
+	associations[0].SetAssociationConf(&commonv1.AssociationConf{
+		AuthSecretName: "ls-prod-user",
+		AuthSecretKey:  "ns-ls-logstash-user",
+		CACertProvided: true,
+		CASecretName:   "ls-es-ca",
+		URL:            "https://es1-es-http.ns.svc:9200",
+	})
+
+	require.Equal(t, []corev1.EnvVar{
+		{Name: "PROD_ES_HOSTS", Value: "https://es1-es-http.ns.svc:9200"},
+		{Name: "PROD_ES_USER", Value: "ns-ls-logstash-user"},
+		{Name: "PROD_ES_PASSWORD", ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "ls-prod-user"},
+				Key:                  "ns-ls-logstash-user",
+			},
+		}},
+		{Name: "PROD_ES_SSL_CERTIFICATE_AUTHORITY", Value: "/mnt/elastic-internal/elasticsearch-association/ns/es1/certs/ca.crt"},
+	}, associationsEnvVars(ls))
+}
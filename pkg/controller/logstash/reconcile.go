@@ -0,0 +1,72 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package logstash
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/reconciler"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/statefulset"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+func reconcileStatefulSet(params Params, podTemplate corev1.PodTemplateSpec) *reconciler.Results {
+	defer tracing.Span(&params.Context)()
+	results := reconciler.NewResult(params.Context)
+
+	expected := statefulset.New(statefulset.Params{
+		Name:                 Name(params.Logstash.Name),
+		Namespace:            params.Logstash.Namespace,
+		ServiceName:          APIServiceName(params.Logstash.Name),
+		Selector:             NewLabels(params.Logstash),
+		Labels:               NewLabels(params.Logstash),
+		PodTemplateSpec:      podTemplate,
+		VolumeClaimTemplates: params.Logstash.Spec.VolumeClaimTemplates,
+		Replicas:             params.Logstash.Spec.Count,
+		// Logstash instances do not depend on each other, they can be created and deleted in parallel
+		PodManagementPolicy: appsv1.ParallelPodManagement,
+		UpdateStrategy:      params.Logstash.Spec.UpdateStrategy,
+	})
+	if err := controllerutil.SetControllerReference(&params.Logstash, &expected, scheme.Scheme); err != nil {
+		return results.WithError(err)
+	}
+
+	reconciled, err := statefulset.Reconcile(params.Client, expected, &params.Logstash)
+	if err != nil {
+		return results.WithError(err)
+	}
+
+	err = updateStatus(params, reconciled.Status.ReadyReplicas, reconciled.Status.Replicas)
+	if err != nil && apierrors.IsConflict(err) {
+		params.Logger().V(1).Info("Conflict while updating status")
+		return results.WithResult(reconcile.Result{Requeue: true})
+	}
+
+	return results.WithError(err)
+}
+
+func updateStatus(params Params, ready, desired int32) error {
+	logstash := params.Logstash
+
+	pods, err := k8s.PodsMatchingLabels(params.Client, logstash.Namespace, map[string]string{NameLabelName: logstash.Name})
+	if err != nil {
+		return err
+	}
+	logstash.Status.AvailableNodes = ready
+	logstash.Status.ExpectedNodes = desired
+	logstash.Status.Health = CalculateHealth(logstash.GetAssociations(), ready, desired)
+	logstash.Status.Version = common.LowestVersionFromPods(logstash.Status.Version, pods, VersionLabelName)
+
+	return params.Client.Status().Update(context.Background(), &logstash)
+}
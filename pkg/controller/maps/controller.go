@@ -12,6 +12,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
 	emsv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/maps/v1alpha1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/association"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common"
@@ -21,8 +22,11 @@ import (
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/deployment"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/driver"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/events"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/hpa"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/license"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/networkpolicy"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/operator"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/pdb"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/reconciler"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
@@ -32,7 +36,10 @@ import (
 	"github.com/elastic/cloud-on-k8s/pkg/utils/maps"
 	"go.elastic.co/apm"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -86,6 +93,30 @@ func addWatches(c controller.Controller, r *ReconcileMapsServer) error {
 		return err
 	}
 
+	// Watch PodDisruptionBudgets
+	if err := c.Watch(&source.Kind{Type: &policyv1beta1.PodDisruptionBudget{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &emsv1alpha1.ElasticMapsServer{},
+	}); err != nil {
+		return err
+	}
+
+	// Watch NetworkPolicies
+	if err := c.Watch(&source.Kind{Type: &networkingv1.NetworkPolicy{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &emsv1alpha1.ElasticMapsServer{},
+	}); err != nil {
+		return err
+	}
+
+	// Watch HorizontalPodAutoscalers, to report their status
+	if err := c.Watch(&source.Kind{Type: &autoscalingv2beta2.HorizontalPodAutoscaler{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &emsv1alpha1.ElasticMapsServer{},
+	}); err != nil {
+		return err
+	}
+
 	// Watch Pods, to ensure `status.version` and version upgrades are correctly reconciled on any change.
 	// Watching Deployments only may lead to missing some events.
 	if err := watches.WatchPods(c, NameLabelName); err != nil {
@@ -262,12 +293,12 @@ func (r *ReconcileMapsServer) doReconcile(ctx context.Context, ems emsv1alpha1.E
 		return reconcile.Result{}, fmt.Errorf("build config hash: %w", err)
 	}
 
-	deploy, err := r.reconcileDeployment(ctx, ems, configHash)
+	deploy, autoscalingStatus, err := r.reconcileDeployment(ctx, ems, configHash)
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("reconcile deployment: %w", err)
 	}
 
-	err = r.updateStatus(ems, deploy)
+	err = r.updateStatus(ems, deploy, autoscalingStatus)
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("updating status: %w", err)
 	}
@@ -347,12 +378,45 @@ func (r *ReconcileMapsServer) reconcileDeployment(
 	ctx context.Context,
 	ems emsv1alpha1.ElasticMapsServer,
 	configHash string,
-) (appsv1.Deployment, error) {
+) (appsv1.Deployment, *commonv1.AutoscalingStatus, error) {
 	span, _ := apm.StartSpan(ctx, "reconcile_deployment", tracing.SpanTypeApp)
 	defer span.End()
 
-	deploy := deployment.New(r.deploymentParams(ems, configHash))
-	return deployment.Reconcile(r.K8sClient(), deploy, &ems)
+	params := r.deploymentParams(ems, configHash)
+	reconciled, err := deployment.Reconcile(r.K8sClient(), deployment.New(params), &ems)
+	if err != nil {
+		return reconciled, nil, err
+	}
+
+	autoscalingStatus, err := hpa.Reconcile(r.K8sClient(), hpa.Params{
+		Owner:      &ems,
+		Spec:       ems.Spec.Autoscaling,
+		Deployment: params.Name,
+		Labels:     params.Labels,
+	})
+	if err != nil {
+		return reconciled, nil, err
+	}
+
+	err = pdb.Reconcile(r.K8sClient(), pdb.Params{
+		Owner:    &ems,
+		Template: ems.Spec.PodDisruptionBudget,
+		Name:     PodDisruptionBudget(ems.Name),
+		Selector: params.Selector,
+		Replicas: hpa.MaxReplicas(ems.Spec.Autoscaling, ems.Spec.Count),
+	})
+	if err != nil {
+		return reconciled, nil, err
+	}
+
+	return reconciled, autoscalingStatus, networkpolicy.Reconcile(r.K8sClient(), networkpolicy.Params{
+		Owner:       &ems,
+		Enabled:     r.NetworkPoliciesEnabled(ems.GetNetworkPolicySettings()),
+		Name:        networkpolicy.Name(EMSNamer, ems.Name),
+		Labels:      params.Selector,
+		PodSelector: params.Selector,
+		Ingress:     []networkingv1.NetworkPolicyIngressRule{networkpolicy.FromOperator(r.OperatorNamespace, HTTPPort)},
+	})
 }
 
 func (r *ReconcileMapsServer) deploymentParams(ems emsv1alpha1.ElasticMapsServer, configHash string) deployment.Params {
@@ -372,10 +436,15 @@ func (r *ReconcileMapsServer) deploymentParams(ems emsv1alpha1.ElasticMapsServer
 		Labels:          deploymentLabels,
 		PodTemplateSpec: podSpec,
 		Strategy:        appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType},
+		Autoscaled:      ems.Spec.Autoscaling != nil,
 	}
 }
 
-func (r *ReconcileMapsServer) updateStatus(ems emsv1alpha1.ElasticMapsServer, deploy appsv1.Deployment) error {
+func (r *ReconcileMapsServer) updateStatus(
+	ems emsv1alpha1.ElasticMapsServer,
+	deploy appsv1.Deployment,
+	autoscalingStatus *commonv1.AutoscalingStatus,
+) error {
 	pods, err := k8s.PodsMatchingLabels(r.K8sClient(), ems.Namespace, map[string]string{NameLabelName: ems.Name})
 	if err != nil {
 		return err
@@ -384,6 +453,7 @@ func (r *ReconcileMapsServer) updateStatus(ems emsv1alpha1.ElasticMapsServer, de
 	if err != nil {
 		return err
 	}
+	deploymentStatus.Autoscaling = autoscalingStatus
 	newStatus := emsv1alpha1.MapsStatus{
 		DeploymentStatus:  deploymentStatus,
 		AssociationStatus: ems.Status.AssociationStatus,
@@ -9,6 +9,7 @@ import "github.com/elastic/cloud-on-k8s/pkg/controller/common/name"
 const (
 	httpServiceSuffix = "http"
 	configSuffix      = "config"
+	pdbSuffix         = "default"
 )
 
 // EMSNamer is a Namer that is configured with the defaults for resources related to an Elastic Maps Server resource.
@@ -25,3 +26,7 @@ func Deployment(emsName string) string {
 func Config(emasName string) string {
 	return EMSNamer.Suffix(emasName, configSuffix)
 }
+
+func PodDisruptionBudget(emsName string) string {
+	return EMSNamer.Suffix(emsName, pdbSuffix)
+}
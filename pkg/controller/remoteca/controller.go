@@ -120,6 +120,7 @@ func deleteAllRemoteCa(ctx context.Context, r *ReconcileRemoteCa, es types.Names
 			results.WithError(err)
 		}
 	}
+	results.WithError(deleteExternalCertificateAuthorities(r, es, nil))
 	return results.Aggregate()
 }
 
@@ -135,11 +136,13 @@ func doReconcile(
 		return reconcile.Result{}, err
 	}
 
+	expectedExternalRemoteClusters := getExpectedExternalRemoteClusters(*localEs)
+
 	enabled, err := r.licenseChecker.EnterpriseFeaturesEnabled()
 	if err != nil {
 		return defaultRequeue, err
 	}
-	if !enabled && len(expectedRemoteClusters) > 0 {
+	if !enabled && len(expectedRemoteClusters)+len(expectedExternalRemoteClusters) > 0 {
 		log.V(1).Info(
 			"Remote cluster controller is an enterprise feature. Enterprise features are disabled",
 			"namespace", localEs.Namespace, "es_name", localEs.Name,
@@ -182,6 +185,9 @@ func doReconcile(
 		}
 	}
 
+	// Copy the CA of the external remote clusters
+	results.WithResults(reconcileExternalCertificateAuthorities(ctx, r, localEs, expectedExternalRemoteClusters))
+
 	// Delete existing but not expected remote CA
 	for toDelete := range remoteClustersInvolved {
 		log.V(1).Info("Deleting remote CA",
@@ -268,6 +274,10 @@ func remoteClustersInvolvedWith(
 		return nil, err
 	}
 	for _, remoteCA := range remoteCAList.Items {
+		if _, external := remoteCA.Labels[ExternalRemoteClusterNameLabelName]; external {
+			// CA of a remote cluster running outside of the k8s cluster, managed separately
+			continue
+		}
 		remoteNs := remoteCA.Labels[RemoteClusterNamespaceLabelName]
 		remoteEs := remoteCA.Labels[RemoteClusterNameLabelName]
 		currentRemoteClusters[types.NamespacedName{
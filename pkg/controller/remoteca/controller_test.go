@@ -467,3 +467,60 @@ func TestReconcileRemoteCa_Reconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileRemoteCa_ExternalRemoteClusters(t *testing.T) {
+	es := newClusteBuilder("ns1", "es1").build()
+	es.Spec.RemoteClusters = []esv1.RemoteCluster{
+		{
+			Name:     "eu-west",
+			External: &esv1.ExternalRemoteCluster{Addresses: []string{"10.0.0.1:9300"}, CASecretName: "eu-west-ca"},
+		},
+	}
+	userCA := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{Namespace: "ns1", Name: "eu-west-ca"},
+		Data:       map[string][]byte{certificates.CAFileName: []byte("eu-west")},
+	}
+	r := &ReconcileRemoteCa{
+		Client:         k8s.NewFakeClient(es, fakePublicCa("ns1", "es1"), userCA),
+		accessReviewer: &fakeAccessReviewer{allowed: true},
+		watches:        watches.NewDynamicWatches(),
+		licenseChecker: &fakeLicenseChecker{enterpriseFeaturesEnabled: true},
+		recorder:       record.NewFakeRecorder(10),
+	}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns1", Name: "es1"}}
+	copyKey := types.NamespacedName{Namespace: "ns1", Name: externalRemoteCAObjectMeta(es, "eu-west").Name}
+
+	// the CA provided by the user is copied
+	_, err := r.Reconcile(context.Background(), request)
+	assert.NoError(t, err)
+	var copied corev1.Secret
+	assert.NoError(t, r.Client.Get(context.Background(), copyKey, &copied))
+	assert.Equal(t, []byte("eu-west"), copied.Data[certificates.CAFileName])
+	assert.Equal(t, map[string]string{
+		"common.k8s.elastic.co/type":                                "remote-ca",
+		"elasticsearch.k8s.elastic.co/cluster-name":                 "es1",
+		"elasticsearch.k8s.elastic.co/external-remote-cluster-name": "eu-west",
+	}, copied.Labels)
+	assert.Contains(t, r.watches.Secrets.Registrations(), externalWatchName(request.NamespacedName, "eu-west"))
+	// the copy is not mistaken for the CA of a remote cluster in the k8s cluster
+	involved, err := remoteClustersInvolvedWith(context.Background(), r.Client, request.NamespacedName)
+	assert.NoError(t, err)
+	assert.Empty(t, involved)
+
+	// the copy is updated along with the CA provided by the user
+	userCA.Data[certificates.CAFileName] = []byte("eu-west-rotated")
+	assert.NoError(t, r.Client.Update(context.Background(), userCA))
+	_, err = r.Reconcile(context.Background(), request)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.Background(), copyKey, &copied))
+	assert.Equal(t, []byte("eu-west-rotated"), copied.Data[certificates.CAFileName])
+
+	// the copy is deleted along with the remote cluster
+	assert.NoError(t, r.Client.Get(context.Background(), request.NamespacedName, es))
+	es.Spec.RemoteClusters = nil
+	assert.NoError(t, r.Client.Update(context.Background(), es))
+	_, err = r.Reconcile(context.Background(), request)
+	assert.NoError(t, err)
+	assert.True(t, apierrors.IsNotFound(r.Client.Get(context.Background(), copyKey, &copied)))
+	assert.NotContains(t, r.watches.Secrets.Registrations(), externalWatchName(request.NamespacedName, "eu-west"))
+}
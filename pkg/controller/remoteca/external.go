@@ -0,0 +1,155 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package remoteca
+
+import (
+	"context"
+	"fmt"
+
+	"go.elastic.co/apm"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/certificates"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/hash"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/reconciler"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/watches"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/elasticsearch/certificates/remoteca"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/maps"
+)
+
+const (
+	// ExternalRemoteClusterNameLabelName holds the name of the external remote cluster whose CA is copied in a Secret.
+	ExternalRemoteClusterNameLabelName = "elasticsearch.k8s.elastic.co/external-remote-cluster-name"
+	// externalRemoteCASecretSuffix is the suffix of the Secrets holding the CA of an external remote cluster.
+	externalRemoteCASecretSuffix = "external-remote-ca"
+)
+
+// getExpectedExternalRemoteClusters returns the external remote clusters declared in the spec of the given cluster
+// along with a CA, by name.
+func getExpectedExternalRemoteClusters(es esv1.Elasticsearch) map[string]esv1.ExternalRemoteCluster {
+	expected := make(map[string]esv1.ExternalRemoteCluster)
+	for _, remoteCluster := range es.Spec.RemoteClusters {
+		if remoteCluster.External == nil || remoteCluster.External.CASecretName == "" {
+			continue
+		}
+		expected[remoteCluster.Name] = *remoteCluster.External
+	}
+	return expected
+}
+
+// reconcileExternalCertificateAuthorities copies the CAs provided by the user for the external remote clusters of the
+// given cluster, so that they are trusted by its transport layer. The copies of the CAs of the external remote clusters
+// which are not expected anymore are deleted.
+func reconcileExternalCertificateAuthorities(
+	ctx context.Context,
+	r *ReconcileRemoteCa,
+	es *esv1.Elasticsearch,
+	expected map[string]esv1.ExternalRemoteCluster,
+) *reconciler.Results {
+	span, _ := apm.StartSpan(ctx, "reconcile_external_remote_ca", tracing.SpanTypeApp)
+	defer span.End()
+	results := &reconciler.Results{}
+	esKey := k8s.ExtractNamespacedName(es)
+
+	for remoteName, remoteCluster := range expected {
+		// Watch the Secret provided by the user to update the copy
+		if err := r.watches.Secrets.AddHandler(watches.NamedWatch{
+			Name:    externalWatchName(esKey, remoteName),
+			Watched: []types.NamespacedName{{Namespace: es.Namespace, Name: remoteCluster.CASecretName}},
+			Watcher: esKey,
+		}); err != nil {
+			return results.WithError(err)
+		}
+
+		var userCA corev1.Secret
+		err := r.Client.Get(context.Background(), types.NamespacedName{Namespace: es.Namespace, Name: remoteCluster.CASecretName}, &userCA)
+		if err != nil && !errors.IsNotFound(err) {
+			return results.WithError(err)
+		}
+		if errors.IsNotFound(err) || len(userCA.Data[certificates.CAFileName]) == 0 {
+			log.Info(
+				"Cannot find CA cert of external remote cluster",
+				"namespace", es.Namespace,
+				"es_name", es.Name,
+				"remote_name", remoteName,
+				"secret_name", remoteCluster.CASecretName,
+			)
+			r.recorder.Event(es, corev1.EventTypeWarning, EventReasonClusterCaCertNotFound,
+				fmt.Sprintf("Cannot find CA certificate of external remote cluster %s in secret %s/%s under %s",
+					remoteName, es.Namespace, remoteCluster.CASecretName, certificates.CAFileName))
+			// CA secrets are watched, we don't need to requeue.
+			continue
+		}
+
+		expectedCopy := corev1.Secret{
+			ObjectMeta: externalRemoteCAObjectMeta(es, remoteName),
+			Data: map[string][]byte{
+				certificates.CAFileName: userCA.Data[certificates.CAFileName],
+			},
+		}
+		if _, err := reconciler.ReconcileSecret(r.Client, expectedCopy, es); err != nil {
+			return results.WithError(err)
+		}
+	}
+
+	return results.WithError(deleteExternalCertificateAuthorities(r, esKey, expected))
+}
+
+// deleteExternalCertificateAuthorities deletes the copies of the CAs of the external remote clusters of the given
+// cluster which are not expected, and removes the corresponding watches.
+func deleteExternalCertificateAuthorities(
+	r *ReconcileRemoteCa,
+	es types.NamespacedName,
+	expected map[string]esv1.ExternalRemoteCluster,
+) error {
+	var externalCAs corev1.SecretList
+	if err := r.Client.List(context.Background(),
+		&externalCAs,
+		client.InNamespace(es.Namespace),
+		remoteca.Labels(es.Name),
+		client.HasLabels{ExternalRemoteClusterNameLabelName},
+	); err != nil {
+		return err
+	}
+	for i, externalCA := range externalCAs.Items {
+		remoteName := externalCA.Labels[ExternalRemoteClusterNameLabelName]
+		if _, exists := expected[remoteName]; exists {
+			continue
+		}
+		log.V(1).Info("Deleting external remote CA",
+			"namespace", es.Namespace,
+			"es_name", es.Name,
+			"remote_name", remoteName,
+		)
+		if err := r.Client.Delete(context.Background(), &externalCAs.Items[i]); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		r.watches.Secrets.RemoveHandlerForKey(externalWatchName(es, remoteName))
+	}
+	return nil
+}
+
+func externalWatchName(es types.NamespacedName, remoteName string) string {
+	return fmt.Sprintf("%s-%s-external-%s", es.Namespace, es.Name, remoteName)
+}
+
+func externalRemoteCAObjectMeta(owner *esv1.Elasticsearch, remoteName string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		// the name of the remote cluster may not be a valid Secret name
+		Name:      esv1.ESNamer.Suffix(owner.Name, hash.HashObject(remoteName), externalRemoteCASecretSuffix),
+		Namespace: owner.Namespace,
+		Labels: maps.Merge(
+			map[string]string{ExternalRemoteClusterNameLabelName: remoteName},
+			remoteca.Labels(owner.Name),
+		),
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package stackconfigpolicy
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
+	policyv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/stackconfigpolicy/v1alpha1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/events"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/operator"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/tracing"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+	ulog "github.com/elastic/cloud-on-k8s/pkg/utils/log"
+)
+
+const (
+	controllerName = "stackconfigpolicy-controller"
+)
+
+var log = ulog.Log.WithName(controllerName)
+
+// Add creates a new StackConfigPolicy Controller and adds it to the Manager with default RBAC. The Manager will set
+// fields on the Controller and Start it when the Manager is Started.
+// The settings of the policies are enforced by the Elasticsearch and Kibana controllers, this controller only reports
+// the resources selected by each policy and the conflicts of their settings.
+func Add(mgr manager.Manager, params operator.Parameters) error {
+	r := newReconciler(mgr, params)
+	c, err := common.NewController(mgr, controllerName, r, params)
+	if err != nil {
+		return err
+	}
+	return addWatches(c, r)
+}
+
+// newReconciler returns a new reconcile.Reconciler.
+func newReconciler(mgr manager.Manager, params operator.Parameters) *ReconcileStackConfigPolicy {
+	return &ReconcileStackConfigPolicy{
+		Client:     mgr.GetClient(),
+		recorder:   mgr.GetEventRecorderFor(controllerName),
+		Parameters: params,
+	}
+}
+
+// addWatches adds watches for all resources this controller cares about
+func addWatches(c controller.Controller, r *ReconcileStackConfigPolicy) error {
+	// Watch for changes to StackConfigPolicy
+	if err := c.Watch(&source.Kind{Type: &policyv1alpha1.StackConfigPolicy{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Watch for changes to the specification or the labels of the resources which may be selected by the policies
+	selectionChanged := predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{})
+	if err := c.Watch(
+		&source.Kind{Type: &esv1.Elasticsearch{}},
+		handler.EnqueueRequestsFromMapFunc(allPolicies(r.Client, r.OperatorNamespace)),
+		selectionChanged,
+	); err != nil {
+		return err
+	}
+	return c.Watch(
+		&source.Kind{Type: &kbv1.Kibana{}},
+		handler.EnqueueRequestsFromMapFunc(allPolicies(r.Client, r.OperatorNamespace)),
+		selectionChanged,
+	)
+}
+
+var _ reconcile.Reconciler = &ReconcileStackConfigPolicy{}
+
+// ReconcileStackConfigPolicy reconciles a StackConfigPolicy object
+type ReconcileStackConfigPolicy struct {
+	k8s.Client
+	recorder record.EventRecorder
+	operator.Parameters
+	// iteration is the number of times this controller has run its Reconcile method
+	iteration uint64
+}
+
+// Reconcile computes the status of a StackConfigPolicy: the resources it selects, and the conflicts of its settings
+// with the specification of these resources or with the other policies.
+func (r *ReconcileStackConfigPolicy) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	ctx = common.NewReconciliationContext(ctx, &r.iteration, r.Tracer, controllerName, "policy_name", request)
+	defer common.LogReconciliationRunNoSideEffects(ulog.FromContext(ctx))()
+	defer tracing.EndContextTransaction(ctx)
+
+	var policy policyv1alpha1.StackConfigPolicy
+	if err := r.Client.Get(ctx, request.NamespacedName, &policy); err != nil {
+		if apierrors.IsNotFound(err) {
+			// the resources selected by the policy are reconciled by their own controller
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, tracing.CaptureError(ctx, err)
+	}
+
+	if common.IsUnmanaged(&policy) {
+		ulog.FromContext(ctx).Info("Object is currently not managed by this controller. Skipping reconciliation")
+		return reconcile.Result{}, nil
+	}
+
+	if !policy.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	status, err := r.status(policy)
+	if err != nil {
+		return reconcile.Result{}, tracing.CaptureError(ctx, err)
+	}
+	status.ObservedGeneration = policy.Generation
+	if reflect.DeepEqual(status, policy.Status) {
+		return reconcile.Result{}, nil
+	}
+	policy.Status = status
+	return reconcile.Result{}, tracing.CaptureError(ctx, common.UpdateStatus(r.Client, &policy))
+}
+
+// status returns the status of the given policy.
+func (r *ReconcileStackConfigPolicy) status(policy policyv1alpha1.StackConfigPolicy) (policyv1alpha1.StackConfigPolicyStatus, error) {
+	if policy.Namespace != r.OperatorNamespace {
+		r.recorder.Eventf(&policy, corev1.EventTypeWarning, events.EventReasonValidation,
+			"StackConfigPolicy ignored: policies must be created in the operator namespace %s", r.OperatorNamespace)
+		return policyv1alpha1.StackConfigPolicyStatus{Phase: policyv1alpha1.InvalidPhase}, nil
+	}
+	if _, err := metav1.LabelSelectorAsSelector(&policy.Spec.ResourceSelector); err != nil {
+		r.recorder.Eventf(&policy, corev1.EventTypeWarning, events.EventReasonValidation, "Invalid resource selector: %v", err)
+		return policyv1alpha1.StackConfigPolicyStatus{Phase: policyv1alpha1.InvalidPhase}, nil
+	}
+
+	status := policyv1alpha1.StackConfigPolicyStatus{Phase: policyv1alpha1.ReadyPhase}
+
+	var esList esv1.ElasticsearchList
+	if err := r.Client.List(context.Background(), &esList); err != nil {
+		return status, err
+	}
+	for i := range esList.Items {
+		es := esList.Items[i]
+		if !selects(policy, &es) {
+			continue
+		}
+		s, err := ForElasticsearch(r.Client, r.OperatorNamespace, es)
+		if err != nil {
+			return status, fmt.Errorf("while computing the settings of Elasticsearch %s/%s: %w", es.Namespace, es.Name, err)
+		}
+		addResource(&status, esv1.Kind, es.ObjectMeta, s.ConflictsOf(policy.Name))
+	}
+
+	var kbList kbv1.KibanaList
+	if err := r.Client.List(context.Background(), &kbList); err != nil {
+		return status, err
+	}
+	for i := range kbList.Items {
+		kb := kbList.Items[i]
+		if !selects(policy, &kb) {
+			continue
+		}
+		s, err := ForKibana(r.Client, r.OperatorNamespace, kb)
+		if err != nil {
+			return status, fmt.Errorf("while computing the settings of Kibana %s/%s: %w", kb.Namespace, kb.Name, err)
+		}
+		addResource(&status, kbv1.Kind, kb.ObjectMeta, s.ConflictsOf(policy.Name))
+	}
+	return status, nil
+}
+
+// addResource records a resource selected by the policy in the given status, along with the conflicts of the policy
+// settings on this resource.
+func addResource(status *policyv1alpha1.StackConfigPolicyStatus, kind string, meta metav1.ObjectMeta, conflicts []commonv1.PolicyConflict) {
+	status.Resources++
+	for _, c := range conflicts {
+		status.Phase = policyv1alpha1.ConflictPhase
+		status.Conflicts = append(status.Conflicts, policyv1alpha1.ResourcePolicyConflict{
+			Kind:           kind,
+			Namespace:      meta.Namespace,
+			Name:           meta.Name,
+			PolicyConflict: c,
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package stackconfigpolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
+	policyv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/stackconfigpolicy/v1alpha1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/operator"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+func TestReconcileStackConfigPolicy_Reconcile(t *testing.T) {
+	es := prodES(map[string]interface{}{"action.destructive_requires_name": false})
+	devES := prodES(nil)
+	devES.Name = "dev"
+	devES.Labels = map[string]string{"env": "dev"}
+	kb := kbv1.Kibana{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kb", Labels: map[string]string{"env": "prod"}}}
+
+	tests := []struct {
+		name       string
+		policy     *policyv1alpha1.StackConfigPolicy
+		others     []runtime.Object
+		wantStatus policyv1alpha1.StackConfigPolicyStatus
+		wantEvent  bool
+	}{
+		{
+			name:       "policy outside the operator namespace is invalid",
+			policy:     policy("ns", "policy", prodSelector, nil),
+			wantStatus: policyv1alpha1.StackConfigPolicyStatus{Phase: policyv1alpha1.InvalidPhase, ObservedGeneration: 1},
+			wantEvent:  true,
+		},
+		{
+			name: "policy with an invalid selector is invalid",
+			policy: policy(operatorNamespace, "policy", metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "env", Operator: "Unknown"},
+			}}, nil),
+			wantStatus: policyv1alpha1.StackConfigPolicyStatus{Phase: policyv1alpha1.InvalidPhase, ObservedGeneration: 1},
+			wantEvent:  true,
+		},
+		{
+			name:       "selected resources are counted",
+			policy:     policy(operatorNamespace, "policy", prodSelector, map[string]interface{}{"a": "b"}),
+			others:     []runtime.Object{&devES, &kb},
+			wantStatus: policyv1alpha1.StackConfigPolicyStatus{Phase: policyv1alpha1.ReadyPhase, Resources: 1, ObservedGeneration: 1},
+		},
+		{
+			name:   "conflicts are reported",
+			policy: policy(operatorNamespace, "policy", prodSelector, map[string]interface{}{"action.destructive_requires_name": true}),
+			others: []runtime.Object{&es, &devES, &kb},
+			wantStatus: policyv1alpha1.StackConfigPolicyStatus{
+				Phase:     policyv1alpha1.ConflictPhase,
+				Resources: 2,
+				Conflicts: []policyv1alpha1.ResourcePolicyConflict{{
+					Kind:      esv1.Kind,
+					Namespace: "ns",
+					Name:      "es",
+					PolicyConflict: commonv1.PolicyConflict{
+						Policy:  "policy",
+						Setting: "action.destructive_requires_name",
+						Message: "Overrides the value set in the configuration of node set default",
+					},
+				}},
+				ObservedGeneration: 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.policy.Generation = 1
+			recorder := record.NewFakeRecorder(10)
+			r := &ReconcileStackConfigPolicy{
+				Client:     k8s.NewFakeClient(append(tt.others, tt.policy)...),
+				recorder:   recorder,
+				Parameters: operator.Parameters{OperatorNamespace: operatorNamespace},
+			}
+			nsn := types.NamespacedName{Namespace: tt.policy.Namespace, Name: tt.policy.Name}
+			_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: nsn})
+			require.NoError(t, err)
+
+			var updated policyv1alpha1.StackConfigPolicy
+			require.NoError(t, r.Client.Get(context.Background(), nsn, &updated))
+			require.Equal(t, tt.wantStatus, updated.Status)
+			require.Equal(t, tt.wantEvent, len(recorder.Events) > 0)
+		})
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package stackconfigpolicy
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
+	policyv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/stackconfigpolicy/v1alpha1"
+	"github.com/elastic/cloud-on-k8s/pkg/controller/common/settings"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+// Settings holds the settings enforced on a resource by the StackConfigPolicies which select it.
+type Settings struct {
+	// Config holds the configuration settings of all the policies, merged in order of precedence.
+	// It is nil if no policy applies to the resource.
+	Config *settings.CanonicalConfig
+	// RoleMappings holds the Elasticsearch role mappings of all the policies, sorted by name.
+	RoleMappings []policyv1alpha1.RoleMapping
+	// Applied lists the names of the policies which select the resource, in order of precedence.
+	Applied []string
+	// Conflicts lists the settings which conflict with the resource specification or with another policy.
+	Conflicts []commonv1.PolicyConflict
+}
+
+// Status returns the status reported in the resource the settings are enforced on, or nil if no policy applies.
+func (s Settings) Status() *commonv1.PoliciesStatus {
+	if len(s.Applied) == 0 {
+		return nil
+	}
+	return &commonv1.PoliciesStatus{Applied: s.Applied, Conflicts: s.Conflicts}
+}
+
+// ConflictsOf returns the conflicts of the given policy.
+func (s Settings) ConflictsOf(policyName string) []commonv1.PolicyConflict {
+	var conflicts []commonv1.PolicyConflict
+	for _, c := range s.Conflicts {
+		if c.Policy == policyName {
+			conflicts = append(conflicts, c)
+		}
+	}
+	return conflicts
+}
+
+// ForElasticsearch returns the settings enforced on the given Elasticsearch cluster by the policies of the operator
+// namespace. The configuration of the policies takes precedence over the configuration of the node sets: conflicting
+// settings of the node sets are reported as conflicts.
+func ForElasticsearch(c k8s.Client, operatorNamespace string, es esv1.Elasticsearch) (Settings, error) {
+	policies, err := selectingPolicies(c, operatorNamespace, &es)
+	if err != nil {
+		return Settings{}, err
+	}
+	s, configs, err := mergeConfigs(policies, func(p policyv1alpha1.StackConfigPolicy) *commonv1.Config {
+		return p.Spec.Elasticsearch.Config
+	})
+	if err != nil {
+		return Settings{}, err
+	}
+	s.mergeRoleMappings(policies)
+
+	for _, nodeSet := range es.Spec.NodeSets {
+		if nodeSet.Config == nil {
+			continue
+		}
+		nodeSetCfg, err := settings.NewCanonicalConfigFrom(nodeSet.Config.Data)
+		if err != nil {
+			return Settings{}, err
+		}
+		if err := s.addSpecConflicts(configs, nodeSetCfg, fmt.Sprintf("Overrides the value set in the configuration of node set %s", nodeSet.Name)); err != nil {
+			return Settings{}, err
+		}
+	}
+	return s, nil
+}
+
+// ForKibana returns the settings enforced on the given Kibana instance by the policies of the operator namespace.
+// The configuration of the policies takes precedence over the configuration of the Kibana resource: conflicting
+// settings are reported as conflicts.
+func ForKibana(c k8s.Client, operatorNamespace string, kb kbv1.Kibana) (Settings, error) {
+	policies, err := selectingPolicies(c, operatorNamespace, &kb)
+	if err != nil {
+		return Settings{}, err
+	}
+	s, configs, err := mergeConfigs(policies, func(p policyv1alpha1.StackConfigPolicy) *commonv1.Config {
+		return p.Spec.Kibana.Config
+	})
+	if err != nil {
+		return Settings{}, err
+	}
+
+	if kb.Spec.Config != nil {
+		kbCfg, err := settings.NewCanonicalConfigFrom(kb.Spec.Config.Data)
+		if err != nil {
+			return Settings{}, err
+		}
+		if err := s.addSpecConflicts(configs, kbCfg, "Overrides the value set in the Kibana specification"); err != nil {
+			return Settings{}, err
+		}
+	}
+	return s, nil
+}
+
+// selectingPolicies returns the policies of the operator namespace which select the given resource, sorted by name.
+// Policies with an invalid selector are ignored, they are reported as invalid in their own status.
+func selectingPolicies(c k8s.Client, operatorNamespace string, obj metav1.Object) ([]policyv1alpha1.StackConfigPolicy, error) {
+	var policies policyv1alpha1.StackConfigPolicyList
+	if err := c.List(context.Background(), &policies, client.InNamespace(operatorNamespace)); err != nil {
+		return nil, err
+	}
+	var selecting []policyv1alpha1.StackConfigPolicy
+	for _, p := range policies.Items {
+		if selects(p, obj) {
+			selecting = append(selecting, p)
+		}
+	}
+	sort.Slice(selecting, func(i, j int) bool {
+		return selecting[i].Name < selecting[j].Name
+	})
+	return selecting, nil
+}
+
+// selects returns true if the resource selector of the given policy matches the labels of the given resource.
+func selects(p policyv1alpha1.StackConfigPolicy, obj metav1.Object) bool {
+	selector, err := metav1.LabelSelectorAsSelector(&p.Spec.ResourceSelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(obj.GetLabels()))
+}
+
+// policyConfig is the parsed configuration of a policy.
+type policyConfig struct {
+	policy string
+	config *settings.CanonicalConfig
+}
+
+// mergeConfigs merges the configurations of the given policies, in order. A setting set by a policy takes precedence
+// over the same setting set to a different value by the next policies, which is reported as a conflict.
+// The parsed configuration of each policy is returned along with the merged settings.
+func mergeConfigs(
+	policies []policyv1alpha1.StackConfigPolicy,
+	configOf func(policyv1alpha1.StackConfigPolicy) *commonv1.Config,
+) (Settings, []policyConfig, error) {
+	var s Settings
+	var configs []policyConfig
+	for _, p := range policies {
+		s.Applied = append(s.Applied, p.Name)
+		if s.Config == nil {
+			s.Config = settings.NewCanonicalConfig()
+		}
+		config := configOf(p)
+		if config == nil {
+			continue
+		}
+		cfg, err := settings.NewCanonicalConfigFrom(config.Data)
+		if err != nil {
+			return Settings{}, nil, err
+		}
+		for _, previous := range configs {
+			conflicting, err := previous.config.ConflictingKeys(cfg)
+			if err != nil {
+				return Settings{}, nil, err
+			}
+			for _, key := range conflicting {
+				if s.isIgnored(p.Name, key) {
+					continue
+				}
+				s.Conflicts = append(s.Conflicts, commonv1.PolicyConflict{
+					Policy:  p.Name,
+					Setting: key,
+					Message: fmt.Sprintf("Ignored: set to a different value by policy %s, which takes precedence", previous.policy),
+				})
+			}
+		}
+		configs = append(configs, policyConfig{policy: p.Name, config: cfg})
+
+		// the settings of the previous policies take precedence, work on a copy to keep the parsed config intact
+		merged, err := settings.NewCanonicalConfigFrom(config.Data)
+		if err != nil {
+			return Settings{}, nil, err
+		}
+		if err := merged.OverrideWith(s.Config); err != nil {
+			return Settings{}, nil, err
+		}
+		s.Config = merged
+	}
+	return s, configs, nil
+}
+
+// mergeRoleMappings merges the role mappings of the given policies, in order. A role mapping declared by a policy takes
+// precedence over a role mapping with the same name declared with a different definition by the next policies, which
+// is reported as a conflict.
+func (s *Settings) mergeRoleMappings(policies []policyv1alpha1.StackConfigPolicy) {
+	declaredBy := make(map[string]string)
+	for _, p := range policies {
+		for _, roleMapping := range p.Spec.Elasticsearch.RoleMappings {
+			previousPolicy, exists := declaredBy[roleMapping.Name]
+			if !exists {
+				declaredBy[roleMapping.Name] = p.Name
+				s.RoleMappings = append(s.RoleMappings, roleMapping)
+				continue
+			}
+			for _, previous := range s.RoleMappings {
+				if previous.Name == roleMapping.Name && !reflect.DeepEqual(previous.Definition, roleMapping.Definition) {
+					s.Conflicts = append(s.Conflicts, commonv1.PolicyConflict{
+						Policy:  p.Name,
+						Setting: roleMapping.Name,
+						Message: fmt.Sprintf("Ignored: role mapping declared with a different definition by policy %s, which takes precedence", previousPolicy),
+					})
+				}
+			}
+		}
+	}
+	sort.Slice(s.RoleMappings, func(i, j int) bool {
+		return s.RoleMappings[i].Name < s.RoleMappings[j].Name
+	})
+}
+
+// addSpecConflicts reports the settings of the given policies configurations which override a different value set in
+// the specification of the resource.
+func (s *Settings) addSpecConflicts(configs []policyConfig, specCfg *settings.CanonicalConfig, message string) error {
+	for _, pc := range configs {
+		conflicting, err := specCfg.ConflictingKeys(pc.config)
+		if err != nil {
+			return err
+		}
+		for _, key := range conflicting {
+			if s.isIgnored(pc.policy, key) {
+				continue
+			}
+			s.Conflicts = append(s.Conflicts, commonv1.PolicyConflict{Policy: pc.policy, Setting: key, Message: message})
+		}
+	}
+	return nil
+}
+
+// isIgnored returns true if the given setting of the given policy has already been reported as conflicting with a
+// policy which takes precedence.
+func (s *Settings) isIgnored(policy, setting string) bool {
+	for _, c := range s.Conflicts {
+		if c.Policy == policy && c.Setting == setting {
+			return true
+		}
+	}
+	return false
+}
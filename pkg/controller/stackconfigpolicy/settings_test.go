@@ -0,0 +1,177 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package stackconfigpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
+	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
+	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
+	policyv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/stackconfigpolicy/v1alpha1"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+const operatorNamespace = "elastic-system"
+
+var prodSelector = metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}
+
+func policy(namespace, name string, selector metav1.LabelSelector, esConfig map[string]interface{}, roleMappings ...policyv1alpha1.RoleMapping) *policyv1alpha1.StackConfigPolicy {
+	p := &policyv1alpha1.StackConfigPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: policyv1alpha1.StackConfigPolicySpec{
+			ResourceSelector: selector,
+			Elasticsearch:    policyv1alpha1.ElasticsearchConfigPolicySpec{RoleMappings: roleMappings},
+		},
+	}
+	if esConfig != nil {
+		p.Spec.Elasticsearch.Config = &commonv1.Config{Data: esConfig}
+	}
+	return p
+}
+
+func roleMapping(name, role string) policyv1alpha1.RoleMapping {
+	return policyv1alpha1.RoleMapping{
+		Name:       name,
+		Definition: &commonv1.Config{Data: map[string]interface{}{"roles": []interface{}{role}}},
+	}
+}
+
+func prodES(nodeSetConfig map[string]interface{}) esv1.Elasticsearch {
+	es := esv1.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "es", Labels: map[string]string{"env": "prod"}},
+		Spec:       esv1.ElasticsearchSpec{NodeSets: []esv1.NodeSet{{Name: "default"}}},
+	}
+	if nodeSetConfig != nil {
+		es.Spec.NodeSets[0].Config = &commonv1.Config{Data: nodeSetConfig}
+	}
+	return es
+}
+
+func TestForElasticsearch(t *testing.T) {
+	tests := []struct {
+		name             string
+		policies         []runtime.Object
+		es               esv1.Elasticsearch
+		wantConfig       map[string]interface{}
+		wantRoleMappings []string
+		wantStatus       *commonv1.PoliciesStatus
+	}{
+		{
+			name: "no policy",
+			es:   prodES(nil),
+		},
+		{
+			name: "policies outside the operator namespace or not selecting the cluster are ignored",
+			policies: []runtime.Object{
+				policy("ns", "other-namespace", prodSelector, map[string]interface{}{"a": "b"}),
+				policy(operatorNamespace, "dev", metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}}, map[string]interface{}{"a": "b"}),
+			},
+			es: prodES(nil),
+		},
+		{
+			name: "policy without configuration",
+			policies: []runtime.Object{
+				policy(operatorNamespace, "empty", prodSelector, nil),
+			},
+			es:         prodES(nil),
+			wantConfig: map[string]interface{}{},
+			wantStatus: &commonv1.PoliciesStatus{Applied: []string{"empty"}},
+		},
+		{
+			name: "the first policy by name takes precedence",
+			policies: []runtime.Object{
+				policy(operatorNamespace, "b", prodSelector, map[string]interface{}{"action.destructive_requires_name": false, "x": "b"}, roleMapping("admins", "viewer"), roleMapping("viewers", "viewer")),
+				policy(operatorNamespace, "a", prodSelector, map[string]interface{}{"action.destructive_requires_name": true}, roleMapping("admins", "superuser")),
+			},
+			es: prodES(nil),
+			wantConfig: map[string]interface{}{
+				"action": map[string]interface{}{"destructive_requires_name": true},
+				"x":      "b",
+			},
+			wantRoleMappings: []string{"admins", "viewers"},
+			wantStatus: &commonv1.PoliciesStatus{
+				Applied: []string{"a", "b"},
+				Conflicts: []commonv1.PolicyConflict{
+					{Policy: "b", Setting: "action.destructive_requires_name", Message: "Ignored: set to a different value by policy a, which takes precedence"},
+					{Policy: "b", Setting: "admins", Message: "Ignored: role mapping declared with a different definition by policy a, which takes precedence"},
+				},
+			},
+		},
+		{
+			name: "policy settings override the node sets configuration",
+			policies: []runtime.Object{
+				policy(operatorNamespace, "a", prodSelector, map[string]interface{}{"action.destructive_requires_name": true, "x": "y"}),
+			},
+			es: prodES(map[string]interface{}{"action.destructive_requires_name": false, "x": "y"}),
+			wantConfig: map[string]interface{}{
+				"action": map[string]interface{}{"destructive_requires_name": true},
+				"x":      "y",
+			},
+			wantStatus: &commonv1.PoliciesStatus{
+				Applied: []string{"a"},
+				Conflicts: []commonv1.PolicyConflict{
+					{Policy: "a", Setting: "action.destructive_requires_name", Message: "Overrides the value set in the configuration of node set default"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ForElasticsearch(k8s.NewFakeClient(tt.policies...), operatorNamespace, tt.es)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantStatus, s.Status())
+			if tt.wantConfig == nil {
+				require.Nil(t, s.Config)
+			} else {
+				var config map[string]interface{}
+				require.NoError(t, s.Config.Unpack(&config))
+				if config == nil {
+					config = map[string]interface{}{}
+				}
+				require.Equal(t, tt.wantConfig, config)
+			}
+			names := make([]string, 0, len(s.RoleMappings))
+			for _, rm := range s.RoleMappings {
+				names = append(names, rm.Name)
+			}
+			if tt.wantRoleMappings == nil {
+				require.Empty(t, names)
+			} else {
+				require.Equal(t, tt.wantRoleMappings, names)
+			}
+		})
+	}
+}
+
+func TestForKibana(t *testing.T) {
+	p := policy(operatorNamespace, "a", prodSelector, nil)
+	p.Spec.Kibana.Config = &commonv1.Config{Data: map[string]interface{}{"xpack.reporting.enabled": false}}
+	kb := kbv1.Kibana{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "kb", Labels: map[string]string{"env": "prod"}},
+		Spec: kbv1.KibanaSpec{
+			Config: &commonv1.Config{Data: map[string]interface{}{"xpack.reporting.enabled": true}},
+		},
+	}
+
+	s, err := ForKibana(k8s.NewFakeClient(p), operatorNamespace, kb)
+	require.NoError(t, err)
+	require.Equal(t, &commonv1.PoliciesStatus{
+		Applied: []string{"a"},
+		Conflicts: []commonv1.PolicyConflict{
+			{Policy: "a", Setting: "xpack.reporting.enabled", Message: "Overrides the value set in the Kibana specification"},
+		},
+	}, s.Status())
+	require.Equal(t, s.Conflicts, s.ConflictsOf("a"))
+	require.Empty(t, s.ConflictsOf("b"))
+
+	var config map[string]interface{}
+	require.NoError(t, s.Config.Unpack(&config))
+	require.Equal(t, map[string]interface{}{"xpack": map[string]interface{}{"reporting": map[string]interface{}{"enabled": false}}}, config)
+}
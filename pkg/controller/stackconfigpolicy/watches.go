@@ -0,0 +1,80 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package stackconfigpolicy
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	policyv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/stackconfigpolicy/v1alpha1"
+	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
+)
+
+// WatchPolicies watches the specification of the StackConfigPolicies of the operator namespace, to reconcile the
+// resources they select. newList returns an empty list of the type of the resources reconciled by the controller.
+// On update, the resources selected by both the previous and the new version of a policy are reconciled.
+func WatchPolicies(c controller.Controller, k8sClient k8s.Client, operatorNamespace string, newList func() client.ObjectList) error {
+	return c.Watch(
+		&source.Kind{Type: &policyv1alpha1.StackConfigPolicy{}},
+		handler.EnqueueRequestsFromMapFunc(selectedResources(k8sClient, operatorNamespace, newList)),
+		predicate.GenerationChangedPredicate{},
+	)
+}
+
+// selectedResources returns a function which maps a policy to the resources of the given type it selects.
+func selectedResources(k8sClient k8s.Client, operatorNamespace string, newList func() client.ObjectList) handler.MapFunc {
+	return func(object client.Object) []reconcile.Request {
+		policy, ok := object.(*policyv1alpha1.StackConfigPolicy)
+		if !ok || policy.Namespace != operatorNamespace {
+			return nil
+		}
+		list := newList()
+		if err := k8sClient.List(context.Background(), list); err != nil {
+			log.Error(err, "dropping watch event due to error in handler", "namespace", policy.Namespace, "policy_name", policy.Name)
+			return nil
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			log.Error(err, "dropping watch event due to error in handler", "namespace", policy.Namespace, "policy_name", policy.Name)
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, item := range items {
+			obj, err := meta.Accessor(item)
+			if err != nil {
+				continue
+			}
+			if selects(*policy, obj) {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}})
+			}
+		}
+		return requests
+	}
+}
+
+// allPolicies returns a function which maps any resource to all the policies of the operator namespace, so that they
+// report the resources they select.
+func allPolicies(k8sClient k8s.Client, operatorNamespace string) handler.MapFunc {
+	return func(object client.Object) []reconcile.Request {
+		var policies policyv1alpha1.StackConfigPolicyList
+		if err := k8sClient.List(context.Background(), &policies, client.InNamespace(operatorNamespace)); err != nil {
+			log.Error(err, "dropping watch event due to error in handler", "namespace", object.GetNamespace(), "name", object.GetName())
+			return nil
+		}
+		requests := make([]reconcile.Request, 0, len(policies.Items))
+		for _, p := range policies.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: p.Namespace, Name: p.Name}})
+		}
+		return requests
+	}
+}
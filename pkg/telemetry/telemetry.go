@@ -12,10 +12,12 @@ import (
 	"github.com/elastic/cloud-on-k8s/pkg/about"
 	agentv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/agent/v1alpha1"
 	apmv1 "github.com/elastic/cloud-on-k8s/pkg/apis/apm/v1"
+	autoscalingv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/autoscaling/v1alpha1"
 	beatv1beta1 "github.com/elastic/cloud-on-k8s/pkg/apis/beat/v1beta1"
 	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
 	entv1 "github.com/elastic/cloud-on-k8s/pkg/apis/enterprisesearch/v1"
 	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
+	logstashv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/logstash/v1alpha1"
 	mapsv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/maps/v1alpha1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/reconciler"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/stackmon/monitoring"
@@ -109,6 +111,7 @@ func (r *Reporter) getResourceStats() (map[string]interface{}, error) {
 		entStats,
 		agentStats,
 		mapsStats,
+		logstashStats,
 	} {
 		key, statsPart, err := f(r.client, r.managedNamespaces)
 		if err != nil {
@@ -188,17 +191,21 @@ func (r *Reporter) getLicenseInfo() (map[string]string, error) {
 func esStats(k8sClient k8s.Client, managedNamespaces []string) (string, interface{}, error) {
 	stats := map[string]int32{resourceCount: 0, podCount: 0, autoscaledResourceCount: 0}
 
-	var esList esv1.ElasticsearchList
 	for _, ns := range managedNamespaces {
+		var esList esv1.ElasticsearchList
+		var autoscalerList autoscalingv1alpha1.ElasticsearchAutoscalerList
 		if err := k8sClient.List(context.Background(), &esList, client.InNamespace(ns)); err != nil {
 			return "", nil, err
 		}
+		if err := k8sClient.List(context.Background(), &autoscalerList, client.InNamespace(ns)); err != nil {
+			return "", nil, err
+		}
 
 		for _, es := range esList.Items {
 			es := es
 			stats[resourceCount]++
 			stats[podCount] += es.Status.AvailableNodes
-			if es.IsAutoscalingDefined() {
+			if es.IsAutoscalingAnnotationSet() || len(autoscalerList.Managing(es.Name)) > 0 {
 				stats[autoscaledResourceCount]++
 			}
 			if monitoring.IsLogsDefined(&es) {
@@ -332,3 +339,24 @@ func mapsStats(k8sClient k8s.Client, managedNamespaces []string) (string, interf
 	}
 	return "maps", stats, nil
 }
+
+func logstashStats(k8sClient k8s.Client, managedNamespaces []string) (string, interface{}, error) {
+	multipleRefsKey := "multiple_refs"
+	stats := map[string]int32{resourceCount: 0, podCount: 0, multipleRefsKey: 0}
+
+	var logstashList logstashv1alpha1.LogstashList
+	for _, ns := range managedNamespaces {
+		if err := k8sClient.List(context.Background(), &logstashList, client.InNamespace(ns)); err != nil {
+			return "", nil, err
+		}
+
+		for _, ls := range logstashList.Items {
+			stats[resourceCount]++
+			stats[podCount] += ls.Status.AvailableNodes
+			if len(ls.Spec.ElasticsearchRefs) > 1 {
+				stats[multipleRefsKey]++
+			}
+		}
+	}
+	return "logstashes", stats, nil
+}
@@ -12,11 +12,13 @@ import (
 	"github.com/elastic/cloud-on-k8s/pkg/about"
 	agentv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/agent/v1alpha1"
 	apmv1 "github.com/elastic/cloud-on-k8s/pkg/apis/apm/v1"
+	autoscalingv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/autoscaling/v1alpha1"
 	beatv1beta1 "github.com/elastic/cloud-on-k8s/pkg/apis/beat/v1beta1"
 	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
 	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
 	entv1 "github.com/elastic/cloud-on-k8s/pkg/apis/enterprisesearch/v1"
 	kbv1 "github.com/elastic/cloud-on-k8s/pkg/apis/kibana/v1"
+	logstashv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/logstash/v1alpha1"
 	mapsv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/maps/v1alpha1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/kibana"
 	"github.com/elastic/cloud-on-k8s/pkg/utils/k8s"
@@ -146,6 +148,21 @@ func TestNewReporter(t *testing.T) {
 				AvailableNodes: 3,
 			},
 		},
+		&esv1.Elasticsearch{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns2",
+				Name:      "autoscaled-with-autoscaler",
+			},
+		},
+		&autoscalingv1alpha1.ElasticsearchAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns2",
+				Name:      "autoscaler",
+			},
+			Spec: autoscalingv1alpha1.ElasticsearchAutoscalerSpec{
+				ElasticsearchRef: autoscalingv1alpha1.ElasticsearchRef{Name: "autoscaled-with-autoscaler"},
+			},
+		},
 		&esv1.Elasticsearch{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: "ns1",
@@ -283,6 +300,21 @@ func TestNewReporter(t *testing.T) {
 				},
 			},
 		},
+		&logstashv1alpha1.Logstash{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ls1",
+				Namespace: "ns1",
+			},
+			Spec: logstashv1alpha1.LogstashSpec{
+				ElasticsearchRefs: []logstashv1alpha1.ElasticsearchCluster{
+					{ObjectSelector: commonv1.ObjectSelector{Name: "es1"}, ClusterName: "main"},
+					{ObjectSelector: commonv1.ObjectSelector{Name: "es2"}, ClusterName: "backup"},
+				},
+			},
+			Status: logstashv1alpha1.LogstashStatus{
+				AvailableNodes: 3,
+			},
+		},
 		&corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "elastic-licensing",
@@ -335,9 +367,9 @@ func TestNewReporter(t *testing.T) {
       pod_count: 8
       resource_count: 2
     elasticsearches:
-      autoscaled_resource_count: 1
+      autoscaled_resource_count: 2
       pod_count: 10
-      resource_count: 3
+      resource_count: 4
       stack_monitoring_logs_count: 1
       stack_monitoring_metrics_count: 1
     enterprisesearches:
@@ -346,6 +378,10 @@ func TestNewReporter(t *testing.T) {
     kibanas:
       pod_count: 0
       resource_count: 2
+    logstashes:
+      multiple_refs: 1
+      pod_count: 3
+      resource_count: 1
     maps:
       pod_count: 1
       resource_count: 1
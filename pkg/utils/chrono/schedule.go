@@ -0,0 +1,201 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package chrono
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxScheduleLookAhead bounds the search of the next activation of a schedule, for schedules which are never
+// activated, such as the 30th of February.
+const maxScheduleLookAhead = 5 * 365 * 24 * time.Hour
+
+// descriptors are shortcuts for common schedules.
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// field describes the allowed values of a field of a cron expression.
+type field struct {
+	name     string
+	min, max int
+}
+
+var (
+	minuteField     = field{name: "minute", min: 0, max: 59}
+	hourField       = field{name: "hour", min: 0, max: 23}
+	dayOfMonthField = field{name: "day of month", min: 1, max: 31}
+	monthField      = field{name: "month", min: 1, max: 12}
+	// 7 is accepted for Sunday, and normalized to 0
+	dayOfWeekField = field{name: "day of week", min: 0, max: 7}
+)
+
+// Schedule is a parsed cron expression, evaluated in UTC with a precision of one minute.
+type Schedule struct {
+	minutes, hours, daysOfMonth, months, daysOfWeek uint64
+	// anyDayOfMonth and anyDayOfWeek are true if the corresponding field is a wildcard.
+	anyDayOfMonth, anyDayOfWeek bool
+}
+
+// ParseSchedule parses a standard cron expression with 5 fields: minute, hour, day of month, month and day of week.
+// Each field accepts wildcards (*), values, ranges (1-5), steps (*/15, 0-30/10) and lists of them (1,15,30).
+// The descriptors @yearly, @monthly, @weekly, @daily and @hourly are also accepted.
+func ParseSchedule(expr string) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if descriptor, exists := descriptors[expr]; exists {
+		expr = descriptor
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return Schedule{}, fmt.Errorf("invalid schedule %q: expected 5 fields, got %d", expr, len(fields))
+	}
+	var s Schedule
+	var err error
+	if s.minutes, err = parseField(fields[0], minuteField); err != nil {
+		return Schedule{}, err
+	}
+	if s.hours, err = parseField(fields[1], hourField); err != nil {
+		return Schedule{}, err
+	}
+	if s.daysOfMonth, err = parseField(fields[2], dayOfMonthField); err != nil {
+		return Schedule{}, err
+	}
+	if s.months, err = parseField(fields[3], monthField); err != nil {
+		return Schedule{}, err
+	}
+	if s.daysOfWeek, err = parseField(fields[4], dayOfWeekField); err != nil {
+		return Schedule{}, err
+	}
+	// Sunday can be either 0 or 7
+	if s.daysOfWeek&(1<<7) != 0 {
+		s.daysOfWeek |= 1
+	}
+	s.anyDayOfMonth = strings.HasPrefix(fields[2], "*")
+	s.anyDayOfWeek = strings.HasPrefix(fields[4], "*")
+	return s, nil
+}
+
+// parseField parses a field of a cron expression into a bit set of the allowed values.
+func parseField(expr string, f field) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangeExpr = part[:i]
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %s field %q", f.name, part)
+			}
+		}
+		low, high := f.min, f.max
+		switch {
+		case rangeExpr == "*":
+		case strings.Contains(rangeExpr, "-"):
+			bounds := strings.SplitN(rangeExpr, "-", 2)
+			var err error
+			if low, err = parseValue(bounds[0], f); err != nil {
+				return 0, err
+			}
+			if high, err = parseValue(bounds[1], f); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid range in %s field %q", f.name, part)
+			}
+		default:
+			value, err := parseValue(rangeExpr, f)
+			if err != nil {
+				return 0, err
+			}
+			low = value
+			if step == 1 {
+				high = value
+			}
+		}
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseValue(expr string, f field) (int, error) {
+	value, err := strconv.Atoi(expr)
+	if err != nil || value < f.min || value > f.max {
+		return 0, fmt.Errorf("invalid %s %q: must be between %d and %d", f.name, expr, f.min, f.max)
+	}
+	return value, nil
+}
+
+func has(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) != 0
+}
+
+// dayMatches returns true if the day of the given time matches the schedule. As in cron, if both the day of month and
+// the day of week are restricted, a day matching either of them matches the schedule.
+func (s Schedule) dayMatches(t time.Time) bool {
+	dayOfMonth := has(s.daysOfMonth, t.Day())
+	dayOfWeek := has(s.daysOfWeek, int(t.Weekday()))
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// Matches returns true if the minute of the given time matches the schedule.
+func (s Schedule) Matches(t time.Time) bool {
+	t = t.UTC()
+	return has(s.minutes, t.Minute()) && has(s.hours, t.Hour()) && has(s.months, int(t.Month())) && s.dayMatches(t)
+}
+
+// Next returns the first minute strictly after the given time which matches the schedule, or the zero time if the
+// schedule is not activated in the next 5 years.
+func (s Schedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxScheduleLookAhead)
+	for t.Before(limit) {
+		switch {
+		case !has(s.months, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !has(s.hours, t.Hour()):
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case !has(s.minutes, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Window is a recurring period of time, starting at each activation of a schedule.
+type Window struct {
+	Schedule Schedule
+	Duration time.Duration
+}
+
+// IsOpen returns true if the given time is within one of the periods of the window.
+func (w Window) IsOpen(t time.Time) bool {
+	start := w.Schedule.Next(t.Add(-w.Duration))
+	return !start.IsZero() && !start.After(t)
+}
+
+// NextOpening returns the start of the first period of the window after the given time, or the zero time if there is
+// none in the next 5 years.
+func (w Window) NextOpening(t time.Time) time.Time {
+	return w.Schedule.Next(t)
+}
@@ -0,0 +1,138 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License 2.0;
+// you may not use this file except in compliance with the Elastic License 2.0.
+
+package chrono
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	for _, expr := range []string{
+		"* * * * *",
+		"0 2 * * 6,0",
+		"*/15 1-5 1,15 */2 1-5",
+		"30 22 * * 7",
+		"5/10 * * * *",
+		"@daily",
+		" @weekly ",
+	} {
+		_, err := ParseSchedule(expr)
+		require.NoError(t, err, expr)
+	}
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@every 1h",
+	} {
+		_, err := ParseSchedule(expr)
+		require.Error(t, err, expr)
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	date := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2021, month, day, hour, min, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{
+			name: "every minute",
+			expr: "* * * * *",
+			from: date(time.October, 18, 10, 30).Add(20 * time.Second),
+			want: date(time.October, 18, 10, 31),
+		},
+		{
+			name: "strictly after the given time",
+			expr: "30 10 * * *",
+			from: date(time.October, 18, 10, 30),
+			want: date(time.October, 19, 10, 30),
+		},
+		{
+			name: "later today",
+			expr: "0 22 * * *",
+			from: date(time.October, 18, 10, 30),
+			want: date(time.October, 18, 22, 0),
+		},
+		{
+			// October 18th 2021 is a Monday
+			name: "week-end nights",
+			expr: "0 2 * * 6,0",
+			from: date(time.October, 18, 10, 30),
+			want: date(time.October, 23, 2, 0),
+		},
+		{
+			name: "Sunday as 7",
+			expr: "0 2 * * 7",
+			from: date(time.October, 18, 10, 30),
+			want: date(time.October, 24, 2, 0),
+		},
+		{
+			name: "day of month or day of week",
+			expr: "0 0 20 * 6",
+			from: date(time.October, 18, 10, 30),
+			want: date(time.October, 20, 0, 0),
+		},
+		{
+			name: "next year",
+			expr: "15 3 1 1 *",
+			from: date(time.October, 18, 10, 30),
+			want: time.Date(2022, time.January, 1, 3, 15, 0, 0, time.UTC),
+		},
+		{
+			name: "never",
+			expr: "0 0 30 2 *",
+			from: date(time.October, 18, 10, 30),
+			want: time.Time{},
+		},
+		{
+			name: "evaluated in UTC",
+			expr: "0 12 * * *",
+			from: time.Date(2021, time.October, 18, 13, 30, 0, 0, time.FixedZone("UTC+2", 2*60*60)),
+			want: date(time.October, 18, 12, 0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseSchedule(tt.expr)
+			require.NoError(t, err)
+			got := s.Next(tt.from)
+			require.Equal(t, tt.want, got)
+			if !got.IsZero() {
+				require.True(t, s.Matches(got))
+			}
+		})
+	}
+}
+
+func TestWindow_IsOpen(t *testing.T) {
+	s, err := ParseSchedule("0 22 * * *")
+	require.NoError(t, err)
+	w := Window{Schedule: s, Duration: 4 * time.Hour}
+	date := func(day, hour, min int) time.Time {
+		return time.Date(2021, time.October, day, hour, min, 0, 0, time.UTC)
+	}
+
+	require.False(t, w.IsOpen(date(18, 21, 59)))
+	require.True(t, w.IsOpen(date(18, 22, 0)))
+	require.True(t, w.IsOpen(date(19, 1, 59)))
+	require.False(t, w.IsOpen(date(19, 2, 0)))
+	require.Equal(t, date(19, 22, 0), w.NextOpening(date(19, 2, 0)))
+}
@@ -13,13 +13,22 @@ import (
 )
 
 const (
-	namespace          = "elastic"
-	LeaderKey          = "leader"
-	licensingSubsystem = "licensing"
+	namespace              = "elastic"
+	LeaderKey              = "leader"
+	licensingSubsystem     = "licensing"
+	elasticsearchSubsystem = "elasticsearch"
+	associationSubsystem   = "association"
 
 	LicenseLevelLabel      = "license_level"
 	OperatorNamespaceLabel = "operator_namespace"
 	UUIDLabel              = "uuid"
+
+	NamespaceLabel   = "namespace"
+	NameLabel        = "name"
+	HealthLabel      = "health"
+	PredicateLabel   = "predicate"
+	AssociationLabel = "association"
+	StatusLabel      = "status"
 )
 
 var (
@@ -52,18 +61,66 @@ var (
 		Name:      "memory_gigabytes_total",
 		Help:      "Total memory used in GB",
 	}, []string{LicenseLevelLabel}))
+
+	// ElasticsearchHealthGauge reports the last observed health of each Elasticsearch cluster: the series of the
+	// current health is set to 1, the others to 0.
+	ElasticsearchHealthGauge = registerGauge(prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: elasticsearchSubsystem,
+		Name:      "health",
+		Help:      "Health of the Elasticsearch cluster as last observed by the operator",
+	}, []string{NamespaceLabel, NameLabel, HealthLabel}))
+
+	// ElasticsearchUpgradePendingPodsGauge reports the number of Pods to restart to apply a change to each
+	// Elasticsearch cluster.
+	ElasticsearchUpgradePendingPodsGauge = registerGauge(prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: elasticsearchSubsystem,
+		Name:      "upgrade_pending_pods",
+		Help:      "Number of Pods waiting to be restarted by a rolling upgrade",
+	}, []string{NamespaceLabel, NameLabel}))
+
+	// ElasticsearchUpgradeBlockedPodsGauge reports the number of Pods which cannot be restarted because of each
+	// upgrade predicate.
+	ElasticsearchUpgradeBlockedPodsGauge = registerGauge(prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: elasticsearchSubsystem,
+		Name:      "upgrade_blocked_pods",
+		Help:      "Number of Pods which cannot be restarted by a rolling upgrade, by failed predicate",
+	}, []string{NamespaceLabel, NameLabel, PredicateLabel}))
+
+	// ElasticsearchExpectationsUnsatisfiedGauge is set to 1 while the cache of the operator is not up-to-date with
+	// the changes it made to an Elasticsearch cluster, which delays the orchestration of the cluster.
+	ElasticsearchExpectationsUnsatisfiedGauge = registerGauge(prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: elasticsearchSubsystem,
+		Name:      "expectations_unsatisfied",
+		Help:      "Whether the orchestration of the Elasticsearch cluster is waiting for the operator cache to be up-to-date",
+	}, []string{NamespaceLabel, NameLabel}))
+
+	// AssociationStatusGauge reports the number of associations of a resource in each status, by type of association.
+	AssociationStatusGauge = registerGauge(prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: associationSubsystem,
+		Name:      "status",
+		Help:      "Number of associations of the resource in each status",
+	}, []string{NamespaceLabel, NameLabel, AssociationLabel, StatusLabel}))
 )
 
 func registerGauge(gauge *prometheus.GaugeVec) *prometheus.GaugeVec {
-	err := crmetrics.Registry.Register(gauge)
+	return register(gauge).(*prometheus.GaugeVec)
+}
+
+func register(collector prometheus.Collector) prometheus.Collector {
+	err := crmetrics.Registry.Register(collector)
 	if err != nil {
 		existsErr := new(prometheus.AlreadyRegisteredError)
 		if errors.As(err, &existsErr) {
-			return existsErr.ExistingCollector.(*prometheus.GaugeVec)
+			return existsErr.ExistingCollector
 		}
 
-		panic(fmt.Errorf("failed to register gauge: %w", err))
+		panic(fmt.Errorf("failed to register collector: %w", err))
 	}
 
-	return gauge
+	return collector
 }
@@ -38,6 +38,6 @@ func TestWebhook(t *testing.T) {
 	require.Contains(
 		t,
 		err.Error(),
-		`either daemonset or deployment must be specified`,
+		`either daemonset, deployment or statefulset must be specified`,
 	)
 }
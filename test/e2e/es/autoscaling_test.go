@@ -8,9 +8,9 @@ package es
 
 import (
 	"context"
-	"encoding/json"
 	"testing"
 
+	autoscalingv1alpha1 "github.com/elastic/cloud-on-k8s/pkg/apis/autoscaling/v1alpha1"
 	commonv1 "github.com/elastic/cloud-on-k8s/pkg/apis/common/v1"
 	esv1 "github.com/elastic/cloud-on-k8s/pkg/apis/elasticsearch/v1"
 	"github.com/elastic/cloud-on-k8s/pkg/controller/common/version"
@@ -22,8 +22,10 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // TestAutoscaling ensures that the operator is compatible with the autoscaling Elasticsearch API.
@@ -79,7 +81,6 @@ func TestAutoscaling(t *testing.T) {
 		// Add a ml tier, node count is initially set to 0, it will be updated by the autoscaling controller.
 		WithNodeSet(newNodeSet("ml", []string{"ml"}, 0, corev1.ResourceList{}, initialPVC)).
 		WithRestrictedSecurityContext().
-		WithAnnotation(esv1.ElasticsearchAutoscalingSpecAnnotationName, autoscalingSpecBuilder.toJSON()).
 		WithExpectedNodeSets(
 			newNodeSet("master", []string{"master"}, 1, corev1.ResourceList{corev1.ResourceMemory: nodespec.DefaultMemoryLimits}, initialPVC),
 			// Autoscaling controller should eventually update the data node count to its min. value.
@@ -88,33 +89,36 @@ func TestAutoscaling(t *testing.T) {
 			newNodeSet("ml", []string{"ml"}, 0, corev1.ResourceList{}, initialPVC),
 		)
 
+	// The autoscaler is created before the Elasticsearch cluster.
+	autoscaler := autoscalingSpecBuilder.toElasticsearchAutoscaler(ns1, name)
+
 	// scaleUpStorage uses the fixed decider to trigger a scale up of the data tier up to its max memory limit and 3 nodes.
 	expectedDataPVC := newPVC("20Gi", storageClass)
-	scaleUpStorage := esBuilder.DeepCopy().WithAnnotation(
-		esv1.ElasticsearchAutoscalingSpecAnnotationName,
-		// Only request 19gb, the operator adds a capacity margin of 5% to account for reserved fs space, we don't want to exceed 3 nodes of 20Gi in this test.
-		autoscalingSpecBuilder.withFixedDecider("data-ingest", map[string]string{"storage": "19gb", "nodes": "3"}).toJSON(),
-	).WithExpectedNodeSets(
+	// Only request 19gb, the operator adds a capacity margin of 5% to account for reserved fs space, we don't want to exceed 3 nodes of 20Gi in this test.
+	scaleUpStorageAutoscaler := autoscalingSpecBuilder.
+		withFixedDecider("data-ingest", map[string]string{"storage": "19gb", "nodes": "3"}).
+		toElasticsearchAutoscaler(ns1, name)
+	scaleUpStorage := esBuilder.DeepCopy().WithExpectedNodeSets(
 		newNodeSet("master", []string{"master"}, 1, corev1.ResourceList{corev1.ResourceMemory: nodespec.DefaultMemoryLimits}, initialPVC),
 		newNodeSet("data-ingest", []string{"data", "ingest"}, 3, corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")}, expectedDataPVC),
 		newNodeSet("ml", []string{"ml"}, 0, corev1.ResourceList{}, initialPVC),
 	)
 
 	// scaleUpML uses the fixed decider to trigger the creation of a ML node.
-	scaleUpML := esBuilder.DeepCopy().WithAnnotation(
-		esv1.ElasticsearchAutoscalingSpecAnnotationName,
-		autoscalingSpecBuilder.withFixedDecider("ml", map[string]string{"memory": "4gb", "nodes": "1"}).toJSON(),
-	).WithExpectedNodeSets(
+	scaleUpMLAutoscaler := autoscalingSpecBuilder.
+		withFixedDecider("ml", map[string]string{"memory": "4gb", "nodes": "1"}).
+		toElasticsearchAutoscaler(ns1, name)
+	scaleUpML := esBuilder.DeepCopy().WithExpectedNodeSets(
 		newNodeSet("master", []string{"master"}, 1, corev1.ResourceList{corev1.ResourceMemory: nodespec.DefaultMemoryLimits}, initialPVC),
 		newNodeSet("data-ingest", []string{"data", "ingest"}, 3, corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")}, expectedDataPVC),
 		newNodeSet("ml", []string{"ml"}, 1, corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")}, initialPVC),
 	)
 
 	// scaleDownML use the fixed decider to trigger the scale down, and thus the deletion, of the ML node previously created.
-	scaleDownML := esBuilder.DeepCopy().WithAnnotation(
-		esv1.ElasticsearchAutoscalingSpecAnnotationName,
-		autoscalingSpecBuilder.withFixedDecider("ml", map[string]string{"memory": "0gb", "nodes": "0"}).toJSON(),
-	).WithExpectedNodeSets(
+	scaleDownMLAutoscaler := autoscalingSpecBuilder.
+		withFixedDecider("ml", map[string]string{"memory": "0gb", "nodes": "0"}).
+		toElasticsearchAutoscaler(ns1, name)
+	scaleDownML := esBuilder.DeepCopy().WithExpectedNodeSets(
 		newNodeSet("master", []string{"master"}, 1, corev1.ResourceList{corev1.ResourceMemory: nodespec.DefaultMemoryLimits}, initialPVC),
 		newNodeSet("data-ingest", []string{"data", "ingest"}, 3, corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")}, expectedDataPVC),
 		newNodeSet("ml", []string{"ml"}, 0, corev1.ResourceList{}, initialPVC),
@@ -152,27 +156,60 @@ func TestAutoscaling(t *testing.T) {
 		}),
 	}
 
+	before := func(k *test.K8sClient) test.StepList {
+		return test.StepList{}.WithStep(applyAutoscalerStep(k, autoscaler))
+	}
+
 	stepsFn := func(k *test.K8sClient) test.StepList {
 		return test.StepList{}.
 			WithStep(autoscalingCapacityTest).
 			// Scale vertically and horizontally to add some storage capacity
-			WithSteps(scaleUpStorage.UpgradeTestSteps(k)).
+			WithStep(applyAutoscalerStep(k, scaleUpStorageAutoscaler)).
 			WithSteps(scaleUpStorage.CheckK8sTestSteps(k)).
 			WithSteps(scaleUpStorage.CheckStackTestSteps(k)).
 			WithStep(autoscalingCapacityTest).
 			// Scale vertically and horizontally to add some ml capacity
-			WithSteps(scaleUpML.UpgradeTestSteps(k)).
+			WithStep(applyAutoscalerStep(k, scaleUpMLAutoscaler)).
 			WithSteps(scaleUpML.CheckK8sTestSteps(k)).
 			WithSteps(scaleUpML.CheckStackTestSteps(k)).
 			WithStep(autoscalingCapacityTest).
 			// Scale ML tier back to 0 node
-			WithSteps(scaleDownML.UpgradeTestSteps(k)).
+			WithStep(applyAutoscalerStep(k, scaleDownMLAutoscaler)).
 			WithSteps(scaleDownML.CheckK8sTestSteps(k)).
 			WithSteps(scaleDownML.CheckStackTestSteps(k)).
-			WithStep(autoscalingCapacityTest)
+			WithStep(autoscalingCapacityTest).
+			WithStep(test.Step{
+				Name: "Delete the ElasticsearchAutoscaler",
+				Test: test.Eventually(func() error {
+					err := k.Client.Delete(context.Background(), autoscaler.DeepCopy())
+					if err != nil && !apierrors.IsNotFound(err) {
+						return err
+					}
+					return nil
+				}),
+			})
 	}
 
-	test.Sequence(nil, stepsFn, esWithLicense).RunSequential(t)
+	test.Sequence(before, stepsFn, esWithLicense).RunSequential(t)
+}
+
+// applyAutoscalerStep creates the given ElasticsearchAutoscaler, or updates its specification if it already exists.
+func applyAutoscalerStep(k *test.K8sClient, expected autoscalingv1alpha1.ElasticsearchAutoscaler) test.Step {
+	return test.Step{
+		Name: "Apply the autoscaling policies",
+		Test: test.Eventually(func() error {
+			var current autoscalingv1alpha1.ElasticsearchAutoscaler
+			err := k.Client.Get(context.Background(), types.NamespacedName{Namespace: expected.Namespace, Name: expected.Name}, &current)
+			if apierrors.IsNotFound(err) {
+				return k.Client.Create(context.Background(), expected.DeepCopy())
+			}
+			if err != nil {
+				return err
+			}
+			current.Spec = *expected.Spec.DeepCopy()
+			return k.Client.Update(context.Background(), &current)
+		}),
+	}
 }
 
 // -- Test helpers
@@ -264,15 +301,21 @@ func (ab *AutoscalingSpecBuilder) withFixedDecider(policy string, fixedDeciderSe
 	return ab
 }
 
-// toJSON converts the autoscaling policy into JSON.
-func (ab *AutoscalingSpecBuilder) toJSON() string {
-	spec := esv1.AutoscalingSpec{}
-	for _, policySpec := range ab.policies {
-		spec.AutoscalingPolicySpecs = append(spec.AutoscalingPolicySpecs, policySpec)
+// toElasticsearchAutoscaler returns an ElasticsearchAutoscaler, with the current autoscaling policies, which manages
+// the given Elasticsearch cluster.
+func (ab *AutoscalingSpecBuilder) toElasticsearchAutoscaler(namespace, esName string) autoscalingv1alpha1.ElasticsearchAutoscaler {
+	esa := autoscalingv1alpha1.ElasticsearchAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      esName,
+		},
+		Spec: autoscalingv1alpha1.ElasticsearchAutoscalerSpec{
+			ElasticsearchRef: autoscalingv1alpha1.ElasticsearchRef{Name: esName},
+		},
 	}
-	bytes, err := json.Marshal(spec)
-	if err != nil {
-		ab.t.Fatalf("can't serialize autoscaling spec, err: %s", err)
+	for _, policySpec := range ab.policies {
+		// Policies are copied as the deciders may be updated later by the builder.
+		esa.Spec.AutoscalingPolicySpecs = append(esa.Spec.AutoscalingPolicySpecs, *policySpec.DeepCopy())
 	}
-	return string(bytes)
+	return esa
 }
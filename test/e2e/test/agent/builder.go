@@ -63,6 +63,8 @@ func NewBuilderFromAgent(agent *agentv1alpha1.Agent) Builder {
 		podTemplate = &agent.Spec.DaemonSet.PodTemplate
 	} else if agent.Spec.Deployment != nil {
 		podTemplate = &agent.Spec.Deployment.PodTemplate
+	} else if agent.Spec.StatefulSet != nil {
+		podTemplate = &agent.Spec.StatefulSet.PodTemplate
 	}
 
 	return Builder{
@@ -103,11 +105,15 @@ func (b Builder) WithVersion(version string) Builder {
 func (b Builder) WithDaemonSet() Builder {
 	b.Agent.Spec.DaemonSet = &agentv1alpha1.DaemonSetSpec{}
 
-	// if it exists, move PodTemplate from Deployment to DaemonSet
+	// if it exists, move PodTemplate from Deployment or StatefulSet to DaemonSet
 	if b.Agent.Spec.Deployment != nil {
 		b.Agent.Spec.DaemonSet.PodTemplate = b.Agent.Spec.Deployment.PodTemplate
 		b.Agent.Spec.Deployment = nil
 	}
+	if b.Agent.Spec.StatefulSet != nil {
+		b.Agent.Spec.DaemonSet.PodTemplate = b.Agent.Spec.StatefulSet.PodTemplate
+		b.Agent.Spec.StatefulSet = nil
+	}
 
 	b.PodTemplate = &b.Agent.Spec.DaemonSet.PodTemplate
 
@@ -117,16 +123,37 @@ func (b Builder) WithDaemonSet() Builder {
 func (b Builder) WithDeployment() Builder {
 	b.Agent.Spec.Deployment = &agentv1alpha1.DeploymentSpec{}
 
-	// if it exists, move PodTemplate from DaemonSet to Deployment
+	// if it exists, move PodTemplate from DaemonSet or StatefulSet to Deployment
 	if b.Agent.Spec.DaemonSet != nil {
 		b.Agent.Spec.Deployment.PodTemplate = b.Agent.Spec.DaemonSet.PodTemplate
 		b.Agent.Spec.DaemonSet = nil
 	}
+	if b.Agent.Spec.StatefulSet != nil {
+		b.Agent.Spec.Deployment.PodTemplate = b.Agent.Spec.StatefulSet.PodTemplate
+		b.Agent.Spec.StatefulSet = nil
+	}
 	b.PodTemplate = &b.Agent.Spec.Deployment.PodTemplate
 
 	return b
 }
 
+func (b Builder) WithStatefulSet() Builder {
+	b.Agent.Spec.StatefulSet = &agentv1alpha1.StatefulSetSpec{}
+
+	// if it exists, move PodTemplate from DaemonSet or Deployment to StatefulSet
+	if b.Agent.Spec.DaemonSet != nil {
+		b.Agent.Spec.StatefulSet.PodTemplate = b.Agent.Spec.DaemonSet.PodTemplate
+		b.Agent.Spec.DaemonSet = nil
+	}
+	if b.Agent.Spec.Deployment != nil {
+		b.Agent.Spec.StatefulSet.PodTemplate = b.Agent.Spec.Deployment.PodTemplate
+		b.Agent.Spec.Deployment = nil
+	}
+	b.PodTemplate = &b.Agent.Spec.StatefulSet.PodTemplate
+
+	return b
+}
+
 func (b Builder) WithDeploymentStrategy(s appsv1.DeploymentStrategy) Builder {
 	modifiedBuilder := b
 	if b.Agent.Spec.Deployment == nil {
@@ -131,6 +131,11 @@ func CheckStatus(b Builder, k *test.K8sClient) test.Step {
 			if kb.Status.DeploymentStatus != expected.DeploymentStatus {
 				return fmt.Errorf("expected status %+v but got %+v", expected, kb.Status)
 			}
+			// the status of Kibana is retrieved from its API if it is associated with Elasticsearch
+			if b.Kibana.Spec.ElasticsearchRef.IsDefined() &&
+				(kb.Status.ApplicationStatus == nil || kb.Status.ApplicationStatus.Level != kbv1.ApplicationAvailable) {
+				return fmt.Errorf("expected Kibana to be available but got application status %+v", kb.Status.ApplicationStatus)
+			}
 			return nil
 		}),
 	}